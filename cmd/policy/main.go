@@ -2,12 +2,16 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"log"
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
 	"time"
 
 	"github.com/jpillora/ipfilter"
@@ -25,8 +29,11 @@ import (
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/auth"
+	goliberrors "gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/graceful"
 	goahealth "gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/health"
 	goahealthsrv "gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/http/health/server"
@@ -34,22 +41,31 @@ import (
 	goapolicysrv "gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/http/policy/server"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/openapi"
 	goapolicy "gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/policy"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/authz"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/caller"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/clients/cache"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/clients/nats"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/clients/signer"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/clone"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/config"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/gitsync"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/grpcserver"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/grpcserver/pb"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/header"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/localrepo"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/notify"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/regocache"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/regofunc"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/request"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/service"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/service/health"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/service/policy"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/service/policy/policydata"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage/boltdb"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage/memory"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage/mongodb"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/tlsconfig"
 )
 
 var Version = "0.0.0+development"
@@ -72,18 +88,38 @@ func main() {
 
 	httpClient := httpClient()
 
-	oauthClient := httpClient
+	// create clients of the upstream services, which use mutual TLS if configured
+	signerClient, err := upstreamClient(cfg.Signer.CertFile, cfg.Signer.KeyFile, cfg.Signer.CAFile, cfg.TLS.ReloadInterval, logger)
+	if err != nil {
+		logger.Fatal("failed to create signer client", zap.Error(err))
+	}
+	cacheClient, err := upstreamClient(cfg.Cache.CertFile, cfg.Cache.KeyFile, cfg.Cache.CAFile, cfg.TLS.ReloadInterval, logger)
+	if err != nil {
+		logger.Fatal("failed to create cache client", zap.Error(err))
+	}
+	taskClient, err := upstreamClient(cfg.Task.CertFile, cfg.Task.KeyFile, cfg.Task.CAFile, cfg.TLS.ReloadInterval, logger)
+	if err != nil {
+		logger.Fatal("failed to create task client", zap.Error(err))
+	}
+	ocmClient, err := upstreamClient(cfg.OCM.CertFile, cfg.OCM.KeyFile, cfg.OCM.CAFile, cfg.TLS.ReloadInterval, logger)
+	if err != nil {
+		logger.Fatal("failed to create OCM client", zap.Error(err))
+	}
+
+	signerOAuthClient, cacheOAuthClient, taskOAuthClient := signerClient, cacheClient, taskClient
 	if cfg.Auth.Enabled {
-		// Create an HTTP Client which automatically issues and carries an OAuth2 token.
+		// Create HTTP Clients which automatically issue and carry an OAuth2 token.
 		// The token will auto-refresh when its expiration is near.
 		oauthCtx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
-		oauthClient = newOAuth2Client(oauthCtx, cfg.OAuth.ClientID, cfg.OAuth.ClientSecret, cfg.OAuth.TokenURL)
+		signerOAuthClient = newOAuth2Client(oauthCtx, cfg.OAuth.ClientID, cfg.OAuth.ClientSecret, cfg.OAuth.TokenURL, signerClient)
+		cacheOAuthClient = newOAuth2Client(oauthCtx, cfg.OAuth.ClientID, cfg.OAuth.ClientSecret, cfg.OAuth.TokenURL, cacheClient)
+		taskOAuthClient = newOAuth2Client(oauthCtx, cfg.OAuth.ClientID, cfg.OAuth.ClientSecret, cfg.OAuth.TokenURL, taskClient)
 	}
 
-	signer := signer.New(cfg.Signer.Addr, signer.WithHTTPClient(httpClient))
+	signer := signer.New(cfg.Signer.Addr, signer.WithHTTPClient(signerClient))
 
 	// create cache client
-	cache := cache.New(cfg.Cache.Addr, cache.WithHTTPClient(oauthClient))
+	cache := cache.New(cfg.Cache.Addr, cache.WithHTTPClient(cacheOAuthClient))
 
 	// create event client
 	events, err := nats.New(cfg.Nats.Addr, cfg.Nats.Subject)
@@ -99,7 +135,7 @@ func main() {
 	regocache := regocache.New()
 	subscribers = append(subscribers, regocache)
 
-	storage, err := makeStorage(cfg, logger)
+	storage, policySyncer, err := makeStorage(cfg, logger)
 	if err != nil {
 		logger.Fatal("error creating storage", zap.Error(err))
 	}
@@ -109,7 +145,15 @@ func main() {
 	var notifier *notify.Notifier
 	subscriberStorage, ok := storage.(notify.Storage)
 	if ok {
-		notifier = notify.New(events, subscriberStorage, httpClient, logger)
+		notifier = notify.New(
+			events,
+			subscriberStorage,
+			httpClient,
+			cfg.Webhook.MaxAttempts,
+			cfg.Webhook.RetryBackoff,
+			cfg.Webhook.MaxRetryBackoff,
+			logger,
+		)
 		subscribers = append(subscribers, notifier)
 	} else {
 		logger.Info("policy storage does not support policy change notifications")
@@ -132,11 +176,11 @@ func main() {
 
 	// register rego extension functions
 	{
-		cacheFuncs := regofunc.NewCacheFuncs(cfg.Cache.Addr, oauthClient)
+		cacheFuncs := regofunc.NewCacheFuncs(cfg.Cache.Addr, cacheOAuthClient)
 		didResolverFuncs := regofunc.NewDIDResolverFuncs(cfg.DIDResolver.Addr, httpClient)
-		taskFuncs := regofunc.NewTaskFuncs(cfg.Task.Addr, oauthClient)
-		ocmFuncs := regofunc.NewOcmFuncs(cfg.OCM.Addr, httpClient)
-		signerFuncs := regofunc.NewSignerFuncs(cfg.Signer.Addr, oauthClient)
+		taskFuncs := regofunc.NewTaskFuncs(cfg.Task.Addr, taskOAuthClient)
+		ocmFuncs := regofunc.NewOcmFuncs(cfg.OCM.Addr, ocmClient)
+		signerFuncs := regofunc.NewSignerFuncs(cfg.Signer.Addr, signerOAuthClient)
 		didWebFuncs := regofunc.NewDIDWebFuncs()
 		storageFuncs := regofunc.NewStorageFuncs(storage)
 		regofunc.Register("cacheGet", rego.Function3(cacheFuncs.CacheGetFunc()))
@@ -168,7 +212,7 @@ func main() {
 
 	// create services
 	var (
-		policySvc goapolicy.Service
+		policySvc *policy.Service
 		healthSvc goahealth.Service
 	)
 	{
@@ -184,6 +228,13 @@ func main() {
 			httpClient,
 			logger,
 		)
+		// unlock policies when their locks expire
+		go policySvc.StartUnlocker(ctx, cfg.Policy.UnlockInterval)
+		// retry failed webhook deliveries
+		if notifier != nil {
+			go notifier.StartRetrier(ctx, cfg.Webhook.PollInterval)
+		}
+
 		healthSvc = health.New(Version)
 	}
 
@@ -199,6 +250,19 @@ func main() {
 		openapiEndpoints = openapi.NewEndpoints(nil)
 	}
 
+	// Authorize requests with system policies if configured
+	if cfg.Auth.AdminPolicy != "" || cfg.Auth.EvaluationPolicy != "" {
+		if !cfg.Auth.Enabled {
+			logger.Fatal("authorization policies require authentication to be enabled")
+		}
+
+		authorizer, err := authz.New(policySvc, cfg.Auth.AdminPolicy, cfg.Auth.EvaluationPolicy, logger)
+		if err != nil {
+			logger.Fatal("failed to create authorizer", zap.Error(err))
+		}
+		policyEndpoints.Use(authorizer.Middleware())
+	}
+
 	// Provide the transport specific request decoder and response encoder.
 	// The goa http package has built-in support for JSON, XML and gob.
 	// Other encodings can be used by providing the corresponding functions,
@@ -229,6 +293,18 @@ func main() {
 
 	// Apply middlewares on the servers
 	policyServer.Evaluate = header.Middleware()(policyServer.Evaluate)
+	policyServer.Evaluate = request.Middleware()(policyServer.Evaluate)
+	policyServer.Validate = header.Middleware()(policyServer.Validate)
+	policyServer.Validate = request.Middleware()(policyServer.Validate)
+
+	// gRPC interceptors are created from the same HTTP middlewares.
+	// Middlewares applied later on the HTTP server wrap the previous ones,
+	// while chained gRPC interceptors are executed in the given order, so
+	// new interceptors are prepended to keep the same order of execution.
+	grpcInterceptors := []grpc.UnaryServerInterceptor{
+		grpcserver.HTTPMiddleware(header.Middleware(), pb.PolicyService_Evaluate_FullMethodName, pb.PolicyService_Validate_FullMethodName),
+		grpcserver.HTTPMiddleware(request.Middleware(), pb.PolicyService_Evaluate_FullMethodName, pb.PolicyService_Validate_FullMethodName),
+	}
 
 	// Apply IP filter middleware if enabled
 	if cfg.IPFilter.Enabled {
@@ -239,6 +315,7 @@ func main() {
 		})
 
 		policyServer.Use(m.Wrap)
+		grpcInterceptors = append([]grpc.UnaryServerInterceptor{grpcserver.HTTPMiddleware(m.Wrap)}, grpcInterceptors...)
 	}
 
 	// Apply Authentication middleware if enabled
@@ -247,7 +324,14 @@ func main() {
 		if err != nil {
 			logger.Fatal("failed to create authentication middleware", zap.Error(err))
 		}
+
+		// the caller is taken from the token after it's verified
+		policyServer.Use(caller.Middleware())
 		policyServer.Use(m.Handler())
+		grpcInterceptors = append([]grpc.UnaryServerInterceptor{
+			grpcserver.HTTPMiddleware(m.Handler()),
+			grpcserver.HTTPMiddleware(caller.Middleware()),
+		}, grpcInterceptors...)
 	}
 
 	// Configure the mux.
@@ -258,6 +342,22 @@ func main() {
 	// expose metrics
 	go exposeMetrics(cfg.Metrics.Addr, logger)
 
+	// use TLS for the HTTP and gRPC servers if configured
+	var serverTLS *tls.Config
+	if cfg.TLS.CertFile != "" || cfg.TLS.KeyFile != "" || cfg.TLS.ClientCAFile != "" {
+		if cfg.TLS.CertFile == "" || cfg.TLS.KeyFile == "" {
+			logger.Fatal("server certificate and key files must be set for TLS")
+		}
+
+		certs, err := tlsconfig.Load(cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.ClientCAFile, logger)
+		if err != nil {
+			logger.Fatal("failed to load server certificates", zap.Error(err))
+		}
+		go certs.Start(ctx, cfg.TLS.ReloadInterval)
+
+		serverTLS = certs.ServerConfig(cfg.TLS.ClientCertOptional)
+	}
+
 	var handler http.Handler = mux
 	srv := &http.Server{
 		Addr:              cfg.HTTP.Host + ":" + cfg.HTTP.Port,
@@ -266,6 +366,7 @@ func main() {
 		IdleTimeout:       cfg.HTTP.IdleTimeout,
 		ReadTimeout:       cfg.HTTP.ReadTimeout,
 		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		TLSConfig:         serverTLS,
 	}
 
 	g.Go(func() error {
@@ -274,12 +375,29 @@ func main() {
 			return ngrokListenAndServe(ctx, srv, logger)
 		}
 
+		if srv.TLSConfig != nil {
+			return tlsListenAndServe(ctx, srv, 20*time.Second, logger)
+		}
+
 		if err := graceful.Shutdown(ctx, srv, 20*time.Second); err != nil {
 			logger.Error("server shutdown error", zap.Error(err))
 			return err
 		}
 		return errors.New("server stopped successfully")
 	})
+	if cfg.GRPC.Enabled {
+		grpcOpts := []grpc.ServerOption{grpc.ChainUnaryInterceptor(grpcInterceptors...)}
+		if serverTLS != nil {
+			grpcOpts = append(grpcOpts, grpc.Creds(credentials.NewTLS(serverTLS)))
+		}
+
+		grpcSrv := grpc.NewServer(grpcOpts...)
+		pb.RegisterPolicyServiceServer(grpcSrv, grpcserver.New(policyEndpoints))
+
+		g.Go(func() error {
+			return grpcListenAndServe(ctx, grpcSrv, cfg.GRPC.Host+":"+cfg.GRPC.Port, logger)
+		})
+	}
 	g.Go(func() error {
 		if err := storage.ListenPolicyDataChanges(ctx); err != nil {
 			logger.Error("mongo change streams listener stopped", zap.Error(err))
@@ -292,6 +410,11 @@ func main() {
 			return dataRefresher.Start(ctx)
 		})
 	}
+	if policySyncer != nil {
+		g.Go(func() error {
+			return policySyncer.Start(ctx)
+		})
+	}
 
 	if err := g.Wait(); err != nil {
 		logger.Error("run group stopped", zap.Error(err))
@@ -337,14 +460,41 @@ func httpClient() *http.Client {
 	}
 }
 
-func newOAuth2Client(ctx context.Context, cID, cSecret, tokenURL string) *http.Client {
+// newOAuth2Client creates an HTTP Client sending requests with the given
+// client and authenticating them with tokens issued by the HTTP Client
+// from the context.
+func newOAuth2Client(ctx context.Context, cID, cSecret, tokenURL string, client *http.Client) *http.Client {
 	oauthCfg := clientcredentials.Config{
 		ClientID:     cID,
 		ClientSecret: cSecret,
 		TokenURL:     tokenURL,
 	}
 
-	return oauthCfg.Client(ctx)
+	return &http.Client{
+		Transport: &oauth2.Transport{
+			Source: oauthCfg.TokenSource(ctx),
+			Base:   client.Transport,
+		},
+	}
+}
+
+// upstreamClient creates an HTTP Client for an upstream service. If a client
+// certificate or a CA bundle is given, the client uses mutual TLS with
+// certificates which are reloaded when their files are changed.
+func upstreamClient(certFile, keyFile, caFile string, reloadInterval time.Duration, logger *zap.Logger) (*http.Client, error) {
+	client := httpClient()
+	if certFile == "" && keyFile == "" && caFile == "" {
+		return client, nil
+	}
+
+	certs, err := tlsconfig.Load(certFile, keyFile, caFile, logger)
+	if err != nil {
+		return nil, err
+	}
+	go certs.Start(context.Background(), reloadInterval)
+
+	client.Transport.(*http.Transport).TLSClientConfig = certs.ClientConfig()
+	return client, nil
 }
 
 func exposeMetrics(addr string, logger *zap.Logger) {
@@ -387,8 +537,90 @@ func ngrokListenAndServe(ctx context.Context, srv *http.Server, logger *zap.Logg
 	return srv.Serve(ln)
 }
 
-func makeStorage(cfg config.Config, logger *zap.Logger) (policy.Storage, error) {
-	if cfg.Mongo.Addr != "" { // create MongoDB storage
+// tlsListenAndServe starts the HTTP server with TLS and stops it gracefully
+// on receiving a stop signal or context cancellation signal.
+func tlsListenAndServe(ctx context.Context, srv *http.Server, timeout time.Duration, logger *zap.Logger) error {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	done := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		done <- srv.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info(fmt.Sprintf("starting https server on %s", srv.Addr))
+
+	if err := srv.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+		logger.Error("server error", zap.Error(err))
+		return err
+	}
+
+	if err := <-done; err != nil {
+		logger.Error("server shutdown error", zap.Error(err))
+		return err
+	}
+	return errors.New("server stopped successfully")
+}
+
+// grpcListenAndServe starts the gRPC server on the given address
+// and stops it gracefully when the context is cancelled.
+func grpcListenAndServe(ctx context.Context, srv *grpc.Server, addr string, logger *zap.Logger) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("error starting grpc listener: %v", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		srv.GracefulStop()
+	}()
+
+	logger.Info(fmt.Sprintf("starting grpc server on %s", addr))
+
+	if err := srv.Serve(ln); err != nil {
+		logger.Error("grpc server error", zap.Error(err))
+		return err
+	}
+	return errors.New("grpc server stopped successfully")
+}
+
+// policySyncer updates the policies in storage in the background.
+type policySyncer interface {
+	Start(ctx context.Context) error
+}
+
+// makeStorage creates the policy storage. If policies are loaded from
+// a local directory, it also returns a watcher which reloads changed policies.
+// If policies are loaded in memory from a Git repository and a sync interval
+// is configured, it returns a syncer which pulls the repository periodically.
+func makeStorage(cfg config.Config, logger *zap.Logger) (policy.Storage, policySyncer, error) {
+	if cfg.Policy.LocalDir != "" { // create memory storage with policies from local directory
+		dir, err := filepath.Abs(cfg.Policy.LocalDir)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		repository := cfg.Policy.LocalRepository
+		if repository == "" {
+			repository = filepath.Base(dir)
+		}
+
+		policies, err := clone.LoadPolicies(dir, repository)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		logger.Info("policies are loaded from local directory", zap.String("dir", dir), zap.String("repository", repository))
+
+		storage := memory.New(&clone.Cloner{}, policies, logger)
+		watcher := localrepo.New(dir, repository, policies, storage, cfg.Policy.LocalPollInterval, logger)
+
+		return storage, watcher, nil
+	} else if cfg.Mongo.Addr != "" { // create MongoDB storage
 		// connect to mongo db
 		db, err := mongo.Connect(
 			context.Background(),
@@ -399,36 +631,166 @@ func makeStorage(cfg config.Config, logger *zap.Logger) (policy.Storage, error)
 			}),
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		storage, err := mongodb.New(db, cfg.Mongo.DB, cfg.Mongo.Collection, logger)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+
+		return storage, nil, nil
+	} else if cfg.Bolt.Path != "" { // create embedded bbolt storage
+		storage, err := boltdb.New(cfg.Bolt.Path, logger)
+		if err != nil {
+			return nil, nil, err
 		}
 
-		return storage, nil
+		if cfg.Policy.CloneURL != "" {
+			if err := importRepoPolicies(cfg, storage, logger); err != nil {
+				storage.Close(context.Background())
+				return nil, nil, err
+			}
+		}
+
+		return storage, nil, nil
 	} else if cfg.Policy.CloneURL != "" { // create memory storage
 		cloner, err := clone.New()
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		// the clone is kept for the periodic sync, which pulls only the new commits
+		if cfg.Policy.SyncInterval <= 0 {
+			defer cloner.Cleanup() //nolint:errcheck
 		}
-		defer cloner.Cleanup() //nolint:errcheck
 
 		repo, err := cloner.Clone(context.Background(), cfg.Policy.CloneURL, cfg.Policy.User, cfg.Policy.Pass, cfg.Policy.Branch)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		policies, err := cloner.IterateRepo(cfg.Policy.Folder, repo)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+
+		commit, err := cloner.Commit()
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, p := range policies {
+			p.Commit = commit
+		}
+
+		keyring, err := verifyRepoCommit(cfg, cloner, logger)
+		if err != nil {
+			return nil, nil, err
 		}
 
 		storage := memory.New(cloner, policies, logger)
 
-		return storage, nil
+		if cfg.Policy.SyncInterval <= 0 {
+			return storage, nil, nil
+		}
+
+		repository := gitsync.Repository{
+			URL:      cfg.Policy.CloneURL,
+			User:     cfg.Policy.User,
+			Pass:     cfg.Policy.Pass,
+			Branch:   cfg.Policy.Branch,
+			Folder:   cfg.Policy.Folder,
+			Keyring:  keyring,
+			OnRemove: cfg.Policy.OnRemove,
+		}
+		syncer, err := gitsync.New(repository, cloner, storage, cfg.Policy.SyncInterval, logger)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return storage, syncer, nil
 	}
 
-	return nil, errors.New("storage configuration is not provided")
+	return nil, nil, errors.New("storage configuration is not provided")
+}
+
+// importRepoPolicies clones the policy repository and saves in storage
+// the policies which are new or modified. The lock state of policies
+// already in storage is preserved.
+func importRepoPolicies(cfg config.Config, s policy.Storage, logger *zap.Logger) error {
+	cloner, err := clone.New()
+	if err != nil {
+		return err
+	}
+	defer cloner.Cleanup() //nolint:errcheck
+
+	repo, err := cloner.Clone(context.Background(), cfg.Policy.CloneURL, cfg.Policy.User, cfg.Policy.Pass, cfg.Policy.Branch)
+	if err != nil {
+		return err
+	}
+
+	policies, err := cloner.IterateRepo(cfg.Policy.Folder, repo)
+	if err != nil {
+		return err
+	}
+
+	commit, err := cloner.Commit()
+	if err != nil {
+		return err
+	}
+
+	if _, err := verifyRepoCommit(cfg, cloner, logger); err != nil {
+		return err
+	}
+
+	ctx := storage.WithRevisionSource(context.Background(), storage.RevisionSourceSync, "")
+
+	for _, p := range policies {
+		p.Commit = commit
+
+		current, err := s.Policy(ctx, p.Repository, p.Group, p.Name, p.Version)
+		if err != nil && !goliberrors.Is(goliberrors.NotFound, err) {
+			return err
+		}
+
+		if current != nil {
+			if current.Rego == p.Rego &&
+				current.Data == p.Data &&
+				current.DataConfig == p.DataConfig &&
+				current.OutputSchema == p.OutputSchema &&
+				current.ExportConfig == p.ExportConfig &&
+				current.Commit != nil {
+				continue
+			}
+			p.Locked = current.Locked
+			p.Lock = current.Lock
+		}
+
+		if err := s.SavePolicy(ctx, p); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("policies are imported from repository", zap.String("repository", cfg.Policy.CloneURL))
+
+	return nil
+}
+
+// verifyRepoCommit verifies that the cloned commit of the policy repository
+// is signed by a trusted key, if a GPG keyring or SSH allowed signers are
+// configured. It returns the loaded keyring or nil if commits are not verified.
+func verifyRepoCommit(cfg config.Config, cloner *clone.Cloner, logger *zap.Logger) (*clone.Keyring, error) {
+	keyring, err := clone.LoadKeyring(cfg.Policy.GPGKeyring, cfg.Policy.SSHAllowedSigners)
+	if err != nil || keyring == nil {
+		return nil, err
+	}
+
+	signer, err := cloner.VerifyCommit(keyring)
+	if err != nil {
+		logger.Error("policy repository commit is not signed by a trusted key", zap.Error(err))
+		return nil, fmt.Errorf("refusing to load policies: %v", err)
+	}
+
+	logger.Info("policy repository commit is verified", zap.String("signer", signer))
+
+	return keyring, nil
 }
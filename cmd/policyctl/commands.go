@@ -0,0 +1,1240 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/ptr"
+	goapolicy "gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/policy"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/service/policy"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+// coordinates identify a single policy.
+type coordinates struct {
+	repository string
+	group      string
+	name       string
+	version    string
+}
+
+func (c *coordinates) String() string {
+	return strings.Join([]string{c.repository, c.group, c.name, c.version}, "/")
+}
+
+// policyView is the JSON representation of a policy, which
+// uses the same field names as the policy service HTTP API.
+type policyView struct {
+	Repository string      `json:"repository"`
+	PolicyName string      `json:"policyName"`
+	Group      string      `json:"group"`
+	Version    string      `json:"version"`
+	Rego       *string     `json:"rego,omitempty"`
+	Data       *string     `json:"data,omitempty"`
+	DataConfig *string     `json:"dataConfig,omitempty"`
+	Locked     bool        `json:"locked"`
+	LastUpdate int64       `json:"lastUpdate"`
+	Archived   *bool       `json:"archived,omitempty"`
+	Commit     *commitView `json:"commit,omitempty"`
+
+	OutputSchema *string       `json:"outputSchema,omitempty"`
+	ExportConfig *string       `json:"exportConfig,omitempty"`
+	Metadata     *metadataView `json:"metadata,omitempty"`
+	Lock         *lockView     `json:"lock,omitempty"`
+}
+
+// lockView is the JSON representation of the lock of a policy.
+type lockView struct {
+	Reason    *string `json:"reason,omitempty"`
+	LockedBy  *string `json:"lockedBy,omitempty"`
+	LockedAt  int64   `json:"lockedAt"`
+	ExpiresAt *int64  `json:"expiresAt,omitempty"`
+}
+
+// metadataView is the JSON representation of the METADATA annotations of a policy.
+type metadataView struct {
+	Title            *string        `json:"title,omitempty"`
+	Description      *string        `json:"description,omitempty"`
+	Authors          []string       `json:"authors,omitempty"`
+	Organizations    []string       `json:"organizations,omitempty"`
+	RelatedResources []string       `json:"relatedResources,omitempty"`
+	Custom           map[string]any `json:"custom,omitempty"`
+}
+
+// commitView is the JSON representation of the Git commit of a policy.
+type commitView struct {
+	SHA    string  `json:"sha"`
+	Time   int64   `json:"time"`
+	Author string  `json:"author"`
+	Branch *string `json:"branch,omitempty"`
+}
+
+func newPolicyView(p *goapolicy.Policy) *policyView {
+	v := &policyView{
+		Repository: p.Repository,
+		PolicyName: p.PolicyName,
+		Group:      p.Group,
+		Version:    p.Version,
+		Rego:       p.Rego,
+		Data:       p.Data,
+		DataConfig: p.DataConfig,
+		Locked:     p.Locked,
+		LastUpdate: p.LastUpdate,
+		Archived:   p.Archived,
+
+		OutputSchema: p.OutputSchema,
+		ExportConfig: p.ExportConfig,
+	}
+
+	if p.Commit != nil {
+		v.Commit = &commitView{
+			SHA:    p.Commit.Sha,
+			Time:   p.Commit.Time,
+			Author: p.Commit.Author,
+			Branch: p.Commit.Branch,
+		}
+	}
+
+	if p.Metadata != nil {
+		v.Metadata = &metadataView{
+			Title:            p.Metadata.Title,
+			Description:      p.Metadata.Description,
+			Authors:          p.Metadata.Authors,
+			Organizations:    p.Metadata.Organizations,
+			RelatedResources: p.Metadata.RelatedResources,
+			Custom:           p.Metadata.Custom,
+		}
+	}
+
+	if p.Lock != nil {
+		v.Lock = &lockView{
+			Reason:    p.Lock.Reason,
+			LockedBy:  p.Lock.LockedBy,
+			LockedAt:  p.Lock.LockedAt,
+			ExpiresAt: p.Lock.ExpiresAt,
+		}
+	}
+
+	return v
+}
+
+// stringsFlag is a flag which can be given multiple times.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// parseCoordinates parses policy coordinates given in the
+// same order as in the policy URLs: repository/group/name/version
+func parseCoordinates(s string) (*coordinates, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("invalid policy %q: expected format is repository/group/name/version", s)
+	}
+
+	for _, p := range parts {
+		if p == "" {
+			return nil, fmt.Errorf("invalid policy %q: expected format is repository/group/name/version", s)
+		}
+	}
+
+	return &coordinates{repository: parts[0], group: parts[1], name: parts[2], version: parts[3]}, nil
+}
+
+// parseFlags parses command flags and checks the number of positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string, nargs int) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != nargs {
+		return fmt.Errorf("expected %d argument(s), but got %d", nargs, fs.NArg())
+	}
+
+	return nil
+}
+
+func listPolicies(ctx context.Context, ctl *ctl, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	locked := fs.String("locked", "", "Filter locked (true) or unlocked (false) policies.")
+	name := fs.String("name", "", "Filter policies by exact name.")
+	search := fs.String("search", "", "Filter policies containing the text in repository, group or name.")
+	rego := fs.Bool("rego", false, "Include policy source code (JSON output only).")
+	data := fs.Bool("data", false, "Include policy static data (JSON output only).")
+	dataConfig := fs.Bool("dataConfig", false, "Include policy data configuration (JSON output only).")
+	repository := fs.String("repository", "", "Filter policies of a repository.")
+	group := fs.String("group", "", "Filter policies of a group.")
+	version := fs.String("version", "", "Filter policies with a version.")
+	updatedSince := fs.String("updatedSince", "", "Filter policies updated at or after the time in RFC 3339 format.")
+	sortBy := fs.String("sort", "name", "Sort policies by name or lastUpdate.")
+	order := fs.String("order", "asc", "Sort order asc or desc.")
+	var annotations stringsFlag
+	fs.Var(&annotations, "annotation", "Filter policies by custom METADATA annotation, e.g. custom.domain=gaia-x (can be repeated).")
+	if err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+
+	req := &goapolicy.PoliciesRequest{
+		Rego:       rego,
+		Data:       data,
+		DataConfig: dataConfig,
+		Sort:       *sortBy,
+		Order:      *order,
+		Annotation: annotations,
+	}
+	if *repository != "" {
+		req.Repository = repository
+	}
+	if *group != "" {
+		req.Group = group
+	}
+	if *version != "" {
+		req.Version = version
+	}
+	if *updatedSince != "" {
+		req.UpdatedSince = updatedSince
+	}
+	if *locked != "" {
+		l, err := strconv.ParseBool(*locked)
+		if err != nil {
+			return fmt.Errorf("invalid locked value: %q", *locked)
+		}
+		req.Locked = &l
+	}
+	if *name != "" {
+		req.PolicyName = name
+	}
+
+	res, err := ctl.client.ListPolicies(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	// search is not supported by the policy service, so it's done here
+	policies := make([]*policyView, 0, len(res.Policies))
+	for _, p := range res.Policies {
+		if *search != "" &&
+			!strings.Contains(p.Repository, *search) &&
+			!strings.Contains(p.Group, *search) &&
+			!strings.Contains(p.PolicyName, *search) {
+			continue
+		}
+		policies = append(policies, newPolicyView(p))
+	}
+
+	rows := make([][]string, 0, len(policies))
+	for _, p := range policies {
+		var commit string
+		if p.Commit != nil {
+			commit = p.Commit.SHA
+			if len(commit) > 8 {
+				commit = commit[:8]
+			}
+		}
+		rows = append(rows, []string{
+			p.Repository,
+			p.Group,
+			p.PolicyName,
+			p.Version,
+			strconv.FormatBool(p.Locked),
+			strconv.FormatBool(p.Archived != nil && *p.Archived),
+			commit,
+			time.Unix(p.LastUpdate, 0).UTC().Format(time.RFC3339),
+		})
+	}
+
+	return ctl.out.print(policies, []string{"REPOSITORY", "GROUP", "NAME", "VERSION", "LOCKED", "ARCHIVED", "COMMIT", "LAST UPDATE"}, rows)
+}
+
+func showPolicy(ctx context.Context, ctl *ctl, args []string) error {
+	fs := flag.NewFlagSet("show", flag.ContinueOnError)
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	c, err := parseCoordinates(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	res, err := ctl.client.GetPolicy(ctx, &goapolicy.GetPolicyRequest{
+		Repository: c.repository,
+		Group:      c.group,
+		PolicyName: c.name,
+		Version:    c.version,
+	})
+	if err != nil {
+		return err
+	}
+
+	if ctl.out.format == outputJSON {
+		return ctl.out.json(newPolicyView(res))
+	}
+
+	fmt.Fprintf(ctl.out.w, "policy %s (locked: %t, last update: %s)\n", c, res.Locked, time.Unix(res.LastUpdate, 0).UTC().Format(time.RFC3339))
+	if l := res.Lock; l != nil {
+		fmt.Fprintf(ctl.out.w, "locked at: %s\n", time.Unix(l.LockedAt, 0).UTC().Format(time.RFC3339))
+		if l.LockedBy != nil {
+			fmt.Fprintf(ctl.out.w, "locked by: %s\n", *l.LockedBy)
+		}
+		if l.Reason != nil {
+			fmt.Fprintf(ctl.out.w, "lock reason: %s\n", *l.Reason)
+		}
+		if l.ExpiresAt != nil {
+			fmt.Fprintf(ctl.out.w, "lock expires at: %s\n", time.Unix(*l.ExpiresAt, 0).UTC().Format(time.RFC3339))
+		}
+	}
+	if m := res.Metadata; m != nil {
+		if m.Title != nil {
+			fmt.Fprintf(ctl.out.w, "title: %s\n", *m.Title)
+		}
+		if m.Description != nil {
+			fmt.Fprintf(ctl.out.w, "description: %s\n", *m.Description)
+		}
+		if len(m.Authors) > 0 {
+			fmt.Fprintf(ctl.out.w, "authors: %s\n", strings.Join(m.Authors, ", "))
+		}
+		keys := make([]string, 0, len(m.Custom))
+		for key := range m.Custom {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(ctl.out.w, "custom.%s: %v\n", key, m.Custom[key])
+		}
+	}
+
+	for _, field := range []struct {
+		name  string
+		value *string
+	}{
+		{"rego", res.Rego},
+		{"data", res.Data},
+		{"dataConfig", res.DataConfig},
+		{"outputSchema", res.OutputSchema},
+		{"exportConfig", res.ExportConfig},
+	} {
+		if field.value == nil || *field.value == "" {
+			continue
+		}
+		fmt.Fprintf(ctl.out.w, "\n--- %s\n%s\n", field.name, *field.value)
+	}
+
+	return nil
+}
+
+func policyDependencies(ctx context.Context, ctl *ctl, args []string) error {
+	fs := flag.NewFlagSet("dependencies", flag.ContinueOnError)
+	builtin := fs.String("builtin", "", "Show only policies calling the builtin, e.g. did.resolve or ocm.*")
+	repository := fs.String("repository", "", "Show only policies of a repository.")
+	if err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+
+	req := &goapolicy.PolicyDependenciesRequest{}
+	if *builtin != "" {
+		req.Builtin = builtin
+	}
+	if *repository != "" {
+		req.Repository = repository
+	}
+
+	res, err := ctl.client.PolicyDependencies(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(res.Policies))
+	for _, p := range res.Policies {
+		builtins := strings.Join(p.Builtins, ", ")
+		if p.Error != nil {
+			builtins = "parse error: " + *p.Error
+		}
+		rows = append(rows, []string{
+			fmt.Sprintf("%s/%s/%s/%s", p.Repository, p.Group, p.PolicyName, p.Version),
+			builtins,
+			strings.Join(p.DataPaths, ", "),
+		})
+	}
+
+	return ctl.out.print(res.Policies, []string{"POLICY", "BUILTINS", "DATA PATHS"}, rows)
+}
+
+func lockPolicy(ctx context.Context, ctl *ctl, args []string) error {
+	fs := flag.NewFlagSet("lock", flag.ContinueOnError)
+	reason := fs.String("reason", "", "Reason for locking the policy.")
+	expiresAt := fs.String("expiresAt", "", "Time in RFC 3339 format when the policy is unlocked automatically (optional).")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	c, err := parseCoordinates(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	req := &goapolicy.LockRequest{
+		Repository: c.repository,
+		Group:      c.group,
+		PolicyName: c.name,
+		Version:    c.version,
+	}
+	if *reason != "" {
+		req.Reason = reason
+	}
+	if *expiresAt != "" {
+		req.ExpiresAt = expiresAt
+	}
+
+	if err := ctl.client.Lock(ctx, req); err != nil {
+		return err
+	}
+
+	return ctl.out.message(fmt.Sprintf("policy %s is locked", c), map[string]interface{}{
+		"policy": c.String(),
+		"locked": true,
+	})
+}
+
+func unlockPolicy(ctx context.Context, ctl *ctl, args []string) error {
+	fs := flag.NewFlagSet("unlock", flag.ContinueOnError)
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	c, err := parseCoordinates(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	err = ctl.client.Unlock(ctx, &goapolicy.UnlockRequest{
+		Repository: c.repository,
+		Group:      c.group,
+		PolicyName: c.name,
+		Version:    c.version,
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctl.out.message(fmt.Sprintf("policy %s is unlocked", c), map[string]interface{}{
+		"policy": c.String(),
+		"locked": false,
+	})
+}
+
+func bulk(ctx context.Context, ctl *ctl, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("expected subcommand: lock or unlock")
+	}
+
+	switch args[0] {
+	case "lock":
+		return bulkLock(ctx, ctl, args[1:])
+	case "unlock":
+		return bulkUnlock(ctx, ctl, args[1:])
+	default:
+		return fmt.Errorf("unknown subcommand: %q", args[0])
+	}
+}
+
+// bulkLock locks the policies matching the flags or listed as arguments.
+func bulkLock(ctx context.Context, ctl *ctl, args []string) error {
+	fs := flag.NewFlagSet("bulk lock", flag.ContinueOnError)
+	repository := fs.String("repository", "", "Lock policies of a repository.")
+	group := fs.String("group", "", "Lock policies of a group.")
+	name := fs.String("name", "", "Lock policies with names matching a shell pattern, e.g. did*.")
+	version := fs.String("version", "", "Lock policies with a version.")
+	reason := fs.String("reason", "", "Reason for locking the policies.")
+	expiresAt := fs.String("expiresAt", "", "Time in RFC 3339 format when the policies are unlocked automatically (optional).")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	policies, err := policyRefs(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	req := &goapolicy.BulkLockRequest{Policies: policies}
+	if *repository != "" {
+		req.Repository = repository
+	}
+	if *group != "" {
+		req.Group = group
+	}
+	if *name != "" {
+		req.PolicyName = name
+	}
+	if *version != "" {
+		req.Version = version
+	}
+	if *reason != "" {
+		req.Reason = reason
+	}
+	if *expiresAt != "" {
+		req.ExpiresAt = expiresAt
+	}
+
+	res, err := ctl.client.BulkLock(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return printPolicyRefs(ctl, res.Policies)
+}
+
+// bulkUnlock unlocks the policies matching the flags or listed as arguments.
+func bulkUnlock(ctx context.Context, ctl *ctl, args []string) error {
+	fs := flag.NewFlagSet("bulk unlock", flag.ContinueOnError)
+	repository := fs.String("repository", "", "Unlock policies of a repository.")
+	group := fs.String("group", "", "Unlock policies of a group.")
+	name := fs.String("name", "", "Unlock policies with names matching a shell pattern, e.g. did*.")
+	version := fs.String("version", "", "Unlock policies with a version.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	policies, err := policyRefs(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	req := &goapolicy.BulkUnlockRequest{Policies: policies}
+	if *repository != "" {
+		req.Repository = repository
+	}
+	if *group != "" {
+		req.Group = group
+	}
+	if *name != "" {
+		req.PolicyName = name
+	}
+	if *version != "" {
+		req.Version = version
+	}
+
+	res, err := ctl.client.BulkUnlock(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return printPolicyRefs(ctl, res.Policies)
+}
+
+func policyRefs(args []string) ([]*goapolicy.PolicyRef, error) {
+	var refs []*goapolicy.PolicyRef
+	for _, arg := range args {
+		c, err := parseCoordinates(arg)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, &goapolicy.PolicyRef{
+			Repository: c.repository,
+			Group:      c.group,
+			PolicyName: c.name,
+			Version:    c.version,
+		})
+	}
+
+	return refs, nil
+}
+
+func printPolicyRefs(ctl *ctl, policies []*goapolicy.PolicyRef) error {
+	rows := make([][]string, 0, len(policies))
+	for _, p := range policies {
+		rows = append(rows, []string{p.Repository, p.Group, p.PolicyName, p.Version})
+	}
+
+	return ctl.out.print(policies, []string{"REPOSITORY", "GROUP", "NAME", "VERSION"}, rows)
+}
+
+func createPolicy(ctx context.Context, ctl *ctl, args []string) error {
+	return savePolicy(ctx, ctl, "create", args)
+}
+
+func updatePolicy(ctx context.Context, ctl *ctl, args []string) error {
+	return savePolicy(ctx, ctl, "update", args)
+}
+
+// savePolicy creates or updates a policy with the contents of the given files.
+func savePolicy(ctx context.Context, ctl *ctl, name string, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	regoFile := fs.String("rego", "", "File containing the policy source code.")
+	dataFile := fs.String("data", "", "JSON file containing the policy static data (optional).")
+	dataConfigFile := fs.String("dataConfig", "", "JSON file containing the policy static data configuration (optional).")
+	outputSchemaFile := fs.String("outputSchema", "", "JSON schema file for validation of the policy output (optional).")
+	exportConfigFile := fs.String("exportConfig", "", "JSON file containing the policy export configuration (optional).")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	if *regoFile == "" {
+		return fmt.Errorf("policy source code file is required")
+	}
+
+	c, err := parseCoordinates(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	source, err := os.ReadFile(*regoFile)
+	if err != nil {
+		return err
+	}
+
+	req := &goapolicy.PolicyRequest{
+		Repository: c.repository,
+		Group:      c.group,
+		PolicyName: c.name,
+		Version:    c.version,
+		Rego:       string(source),
+	}
+
+	if req.Data, err = readOptionalFile(*dataFile); err != nil {
+		return err
+	}
+	if req.DataConfig, err = readOptionalFile(*dataConfigFile); err != nil {
+		return err
+	}
+	if req.OutputSchema, err = readOptionalFile(*outputSchemaFile); err != nil {
+		return err
+	}
+	if req.ExportConfig, err = readOptionalFile(*exportConfigFile); err != nil {
+		return err
+	}
+
+	var res any
+	if name == "create" {
+		res, err = ctl.client.CreatePolicy(ctx, req)
+	} else {
+		res, err = ctl.client.UpdatePolicy(ctx, req)
+	}
+	if err != nil {
+		return err
+	}
+
+	saved, _ := res.(map[string]interface{})
+	return ctl.out.message(fmt.Sprintf("policy %s is %sd", c, name), saved)
+}
+
+// readOptionalFile returns the content of a file given with an optional
+// flag, or nil if the flag is not set.
+func readOptionalFile(filename string) (*string, error) {
+	if filename == "" {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return ptr.String(string(content)), nil
+}
+
+func deletePolicy(ctx context.Context, ctl *ctl, args []string) error {
+	fs := flag.NewFlagSet("delete", flag.ContinueOnError)
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	c, err := parseCoordinates(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	err = ctl.client.DeletePolicy(ctx, &goapolicy.DeletePolicyRequest{
+		Repository: c.repository,
+		Group:      c.group,
+		PolicyName: c.name,
+		Version:    c.version,
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctl.out.message(fmt.Sprintf("policy %s is deleted", c), map[string]interface{}{
+		"policy":  c.String(),
+		"deleted": true,
+	})
+}
+
+func revisions(ctx context.Context, ctl *ctl, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("expected subcommand: list, show, diff or rollback")
+	}
+
+	switch args[0] {
+	case "list":
+		return revisionsList(ctx, ctl, args[1:])
+	case "show":
+		return revisionsShow(ctx, ctl, args[1:])
+	case "diff":
+		return revisionsDiff(ctx, ctl, args[1:])
+	case "rollback":
+		return revisionsRollback(ctx, ctl, args[1:])
+	default:
+		return fmt.Errorf("unknown subcommand: %q", args[0])
+	}
+}
+
+func revisionsList(ctx context.Context, ctl *ctl, args []string) error {
+	fs := flag.NewFlagSet("revisions list", flag.ContinueOnError)
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	c, err := parseCoordinates(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	res, err := ctl.client.PolicyRevisions(ctx, &goapolicy.PolicyRevisionsRequest{
+		Repository: c.repository,
+		Group:      c.group,
+		PolicyName: c.name,
+		Version:    c.version,
+	})
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(res.Revisions))
+	for _, r := range res.Revisions {
+		var commit string
+		if r.Commit != nil {
+			commit = *r.Commit
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(r.Revision),
+			time.Unix(r.CreatedAt, 0).UTC().Format(time.RFC3339),
+			r.Source,
+			commit,
+			r.Hash,
+		})
+	}
+
+	return ctl.out.print(res.Revisions, []string{"REVISION", "CREATED", "SOURCE", "COMMIT", "HASH"}, rows)
+}
+
+func revisionsShow(ctx context.Context, ctl *ctl, args []string) error {
+	fs := flag.NewFlagSet("revisions show", flag.ContinueOnError)
+	revision := fs.Int("r", 0, "Revision number.")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	c, err := parseCoordinates(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	res, err := ctl.client.PolicyRevision(ctx, &goapolicy.PolicyRevisionRequest{
+		Repository: c.repository,
+		Group:      c.group,
+		PolicyName: c.name,
+		Version:    c.version,
+		Revision:   *revision,
+	})
+	if err != nil {
+		return err
+	}
+
+	if ctl.out.format == outputJSON {
+		return ctl.out.json(res)
+	}
+
+	fmt.Fprintf(ctl.out.w, "revision %d (%s, %s)\n", res.Revision, res.Source, time.Unix(res.CreatedAt, 0).UTC().Format(time.RFC3339))
+	for _, field := range []struct {
+		name  string
+		value *string
+	}{
+		{"rego", res.Rego},
+		{"data", res.Data},
+		{"dataConfig", res.DataConfig},
+		{"outputSchema", res.OutputSchema},
+		{"exportConfig", res.ExportConfig},
+	} {
+		if field.value == nil || *field.value == "" {
+			continue
+		}
+		fmt.Fprintf(ctl.out.w, "\n--- %s\n%s\n", field.name, *field.value)
+	}
+
+	return nil
+}
+
+func revisionsDiff(ctx context.Context, ctl *ctl, args []string) error {
+	fs := flag.NewFlagSet("revisions diff", flag.ContinueOnError)
+	from := fs.Int("from", 0, "Revision to compare from.")
+	to := fs.Int("to", 0, "Revision to compare to.")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	c, err := parseCoordinates(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	res, err := ctl.client.DiffPolicyRevisions(ctx, &goapolicy.DiffPolicyRevisionsRequest{
+		Repository: c.repository,
+		Group:      c.group,
+		PolicyName: c.name,
+		Version:    c.version,
+		From:       *from,
+		To:         *to,
+	})
+	if err != nil {
+		return err
+	}
+
+	if ctl.out.format == outputJSON {
+		return ctl.out.json(res)
+	}
+
+	if len(res.Changes) == 0 {
+		return ctl.out.message(fmt.Sprintf("revisions %d and %d are equal", res.From, res.To), nil)
+	}
+	for _, change := range res.Changes {
+		fmt.Fprint(ctl.out.w, change.Diff)
+	}
+
+	return nil
+}
+
+func revisionsRollback(ctx context.Context, ctl *ctl, args []string) error {
+	fs := flag.NewFlagSet("revisions rollback", flag.ContinueOnError)
+	revision := fs.Int("r", 0, "Revision number to restore.")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	c, err := parseCoordinates(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	res, err := ctl.client.RollbackPolicy(ctx, &goapolicy.RollbackPolicyRequest{
+		Repository: c.repository,
+		Group:      c.group,
+		PolicyName: c.name,
+		Version:    c.version,
+		Revision:   *revision,
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctl.out.message(fmt.Sprintf("policy %s is rolled back to revision %d as revision %d", c, *revision, res.Revision), map[string]interface{}{
+		"policy":   c.String(),
+		"restored": *revision,
+		"revision": res.Revision,
+	})
+}
+
+func exportBundle(ctx context.Context, ctl *ctl, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	wasm := fs.Bool("wasm", false, "Export the policy compiled to WebAssembly.")
+	file := fs.String("f", "", "Output file. Defaults to the bundle filename returned by the policy service.")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	c, err := parseCoordinates(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	target := "rego"
+	if *wasm {
+		target = "wasm"
+	}
+
+	res, body, err := ctl.client.ExportBundle(ctx, &goapolicy.ExportBundleRequest{
+		Repository: c.repository,
+		Group:      c.group,
+		PolicyName: c.name,
+		Version:    c.version,
+		Target:     target,
+	})
+	if err != nil {
+		return err
+	}
+	defer body.Close() //nolint:errcheck
+
+	filename := *file
+	if filename == "" {
+		_, params, err := mime.ParseMediaType(res.ContentDisposition)
+		if err != nil || params["filename"] == "" {
+			return fmt.Errorf("cannot get bundle filename, specify output file with -f")
+		}
+		filename = filepath.Base(params["filename"])
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close() //nolint:errcheck
+
+	n, err := io.Copy(f, body)
+	if err != nil {
+		return fmt.Errorf("error writing bundle file: %v", err)
+	}
+
+	return ctl.out.message(fmt.Sprintf("policy %s is exported to %s", c, filename), map[string]interface{}{
+		"policy": c.String(),
+		"file":   filename,
+		"size":   n,
+	})
+}
+
+func importBundle(ctx context.Context, ctl *ctl, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	bundle, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	res, err := ctl.client.ImportBundle(ctx, &goapolicy.ImportBundlePayload{Length: ptr.Int(len(bundle))}, io.NopCloser(bytes.NewReader(bundle)))
+	if err != nil {
+		return err
+	}
+
+	imported, _ := res.(map[string]interface{})
+	return ctl.out.message(
+		fmt.Sprintf("policy %v/%v/%v/%v is imported", imported["repository"], imported["group"], imported["name"], imported["version"]),
+		imported,
+	)
+}
+
+// verifyBundle verifies a policy bundle offline with a public key
+// given as JWK file. The policy service is not called.
+func verifyBundle(_ context.Context, ctl *ctl, args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	keyFile := fs.String("key", "", "File containing the verification public key as JWK or JWK set.")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	if *keyFile == "" {
+		return fmt.Errorf("verification key file is required")
+	}
+
+	key, err := readKey(*keyFile)
+	if err != nil {
+		return err
+	}
+
+	bundle, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	metadata, err := policy.VerifyBundle(bundle, key)
+	if err != nil {
+		return fmt.Errorf("bundle verification failed: %v", err)
+	}
+
+	p := metadata.Policy
+	return ctl.out.message(
+		fmt.Sprintf("bundle signature is valid for policy %s/%s/%s/%s", p.Repository, p.Group, p.Name, p.Version),
+		map[string]interface{}{
+			"valid":    true,
+			"metadata": metadata,
+		},
+	)
+}
+
+// readKey reads a public key from a file containing a single JWK or
+// JWK set. If the file contains a JWK set, the first key is used.
+func readKey(filename string) (jwk.Key, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	set, err := jwk.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid verification key: %v", err)
+	}
+
+	key, ok := set.Key(0)
+	if !ok {
+		return nil, fmt.Errorf("verification key file contains no keys")
+	}
+
+	return key, nil
+}
+
+func evaluatePolicy(ctx context.Context, ctl *ctl, args []string) error {
+	fs := flag.NewFlagSet("evaluate", flag.ContinueOnError)
+	inputFile := fs.String("input", "", "JSON file containing the policy input. Use - to read from stdin.")
+	validate := fs.Bool("validate", false, "Validate the policy output against its output schema.")
+	evaluationID := fs.String("evaluationID", "", "Evaluation ID under which the result is stored in the cache. This flag is optional.")
+	ttl := fs.Int("ttl", 0, "Cache TTL of the result in seconds. This flag is optional.")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	c, err := parseCoordinates(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	req := &goapolicy.EvaluateRequest{
+		Repository: c.repository,
+		Group:      c.group,
+		PolicyName: c.name,
+		Version:    c.version,
+	}
+	if *evaluationID != "" {
+		req.EvaluationID = evaluationID
+	}
+	if *ttl > 0 {
+		req.TTL = ttl
+	}
+
+	if *inputFile != "" {
+		var data []byte
+		if *inputFile == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(*inputFile)
+		}
+		if err != nil {
+			return fmt.Errorf("error reading input: %v", err)
+		}
+
+		if err := json.Unmarshal(data, &req.Input); err != nil {
+			return fmt.Errorf("input is not valid JSON: %v", err)
+		}
+	}
+
+	var res *goapolicy.EvaluateResult
+	if *validate {
+		res, err = ctl.client.Validate(ctx, req)
+	} else {
+		res, err = ctl.client.Evaluate(ctx, req)
+	}
+	if err != nil {
+		return err
+	}
+
+	// policy results have arbitrary structure,
+	// so they are always printed as JSON
+	if ctl.out.format == outputJSON {
+		return ctl.out.json(map[string]interface{}{
+			"result": res.Result,
+			"etag":   res.ETag,
+		})
+	}
+
+	return ctl.out.json(res.Result)
+}
+
+func autoImport(ctx context.Context, ctl *ctl, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("expected subcommand: list, set or delete")
+	}
+
+	switch args[0] {
+	case "list":
+		return autoImportList(ctx, ctl, args[1:])
+	case "set":
+		return autoImportSet(ctx, ctl, args[1:])
+	case "delete":
+		return autoImportDelete(ctx, ctl, args[1:])
+	default:
+		return fmt.Errorf("unknown subcommand: %q", args[0])
+	}
+}
+
+func autoImportList(ctx context.Context, ctl *ctl, args []string) error {
+	fs := flag.NewFlagSet("autoimport list", flag.ContinueOnError)
+	if err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+
+	res, err := ctl.client.PolicyAutoImport(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the result is not typed in the Goa design, so it's decoded
+	// into the storage type which is returned by the policy service
+	data, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	var result struct {
+		Configs []*storage.PolicyAutoImport `json:"autoimport"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("invalid auto import configurations: %v", err)
+	}
+
+	rows := make([][]string, 0, len(result.Configs))
+	for _, cfg := range result.Configs {
+		rows = append(rows, []string{
+			cfg.PolicyURL,
+			cfg.Interval.String(),
+			cfg.NextImport.UTC().Format(time.RFC3339),
+		})
+	}
+
+	return ctl.out.print(result.Configs, []string{"POLICY URL", "INTERVAL", "NEXT IMPORT"}, rows)
+}
+
+func autoImportSet(ctx context.Context, ctl *ctl, args []string) error {
+	fs := flag.NewFlagSet("autoimport set", flag.ContinueOnError)
+	policyURL := fs.String("url", "", "URL of the policy bundle export.")
+	interval := fs.String("interval", "", "Import interval given as time duration string, e.g. 1h.")
+	if err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+
+	if *policyURL == "" || *interval == "" {
+		return fmt.Errorf("url and interval are required")
+	}
+
+	res, err := ctl.client.SetPolicyAutoImport(ctx, &goapolicy.SetPolicyAutoImportRequest{
+		PolicyURL: *policyURL,
+		Interval:  *interval,
+	})
+	if err != nil {
+		return err
+	}
+
+	fields, _ := res.(map[string]interface{})
+	return ctl.out.message(fmt.Sprintf("policy bundle %s is imported every %s", *policyURL, *interval), fields)
+}
+
+func autoImportDelete(ctx context.Context, ctl *ctl, args []string) error {
+	fs := flag.NewFlagSet("autoimport delete", flag.ContinueOnError)
+	policyURL := fs.String("url", "", "URL of the policy bundle export.")
+	if err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+
+	if *policyURL == "" {
+		return fmt.Errorf("url is required")
+	}
+
+	res, err := ctl.client.DeletePolicyAutoImport(ctx, &goapolicy.DeletePolicyAutoImportRequest{
+		PolicyURL: *policyURL,
+	})
+	if err != nil {
+		return err
+	}
+
+	fields, _ := res.(map[string]interface{})
+	return ctl.out.message(fmt.Sprintf("automatic import of %s is deleted", *policyURL), fields)
+}
+
+func subscribe(ctx context.Context, ctl *ctl, args []string) error {
+	fs := flag.NewFlagSet("subscribe", flag.ContinueOnError)
+	name := fs.String("name", "", "Name of the subscriber.")
+	webhookURL := fs.String("url", "", "Webhook URL called on policy changes.")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	if *name == "" || *webhookURL == "" {
+		return fmt.Errorf("name and url are required")
+	}
+
+	c, err := parseCoordinates(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	res, err := ctl.client.SubscribeForPolicyChange(ctx, &goapolicy.SubscribeRequest{
+		WebhookURL: *webhookURL,
+		Subscriber: *name,
+		Repository: c.repository,
+		Group:      c.group,
+		PolicyName: c.name,
+		Version:    c.version,
+	})
+	if err != nil {
+		return err
+	}
+
+	fields, _ := res.(map[string]interface{})
+	return ctl.out.message(fmt.Sprintf("subscriber %s is subscribed for changes of policy %s", *name, c), fields)
+}
+
+func deliveries(ctx context.Context, ctl *ctl, args []string) error {
+	fs := flag.NewFlagSet("deliveries", flag.ContinueOnError)
+	name := fs.String("name", "", "Name of the subscriber.")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	if *name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	c, err := parseCoordinates(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	res, err := ctl.client.SubscriberDeliveries(ctx, &goapolicy.SubscriberDeliveriesRequest{
+		Repository: c.repository,
+		Group:      c.group,
+		PolicyName: c.name,
+		Version:    c.version,
+		Subscriber: *name,
+	})
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(res.Deliveries))
+	for _, d := range res.Deliveries {
+		var nextAttempt, lastError string
+		if d.NextAttempt != nil {
+			nextAttempt = time.Unix(*d.NextAttempt, 0).UTC().Format(time.RFC3339)
+		}
+		if d.LastError != nil {
+			lastError = *d.LastError
+		}
+		rows = append(rows, []string{
+			d.ID,
+			time.Unix(d.CreatedAt, 0).UTC().Format(time.RFC3339),
+			d.State,
+			strconv.Itoa(d.Attempts),
+			nextAttempt,
+			lastError,
+		})
+	}
+
+	return ctl.out.print(res.Deliveries, []string{"ID", "CREATED", "STATE", "ATTEMPTS", "NEXT ATTEMPT", "LAST ERROR"}, rows)
+}
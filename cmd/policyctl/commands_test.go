@@ -0,0 +1,411 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/ptr"
+	goapolicy "gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/policy"
+)
+
+// fakeClient records the method and the request of the last call.
+// Methods which are not used by the tests panic, because the
+// embedded Client is nil.
+type fakeClient struct {
+	Client
+
+	method string
+	req    interface{}
+	res    interface{}
+}
+
+func (f *fakeClient) call(method string, req interface{}) {
+	f.method = method
+	f.req = req
+}
+
+func (f *fakeClient) Evaluate(_ context.Context, p *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error) {
+	f.call("Evaluate", p)
+	return &goapolicy.EvaluateResult{Result: map[string]interface{}{}}, nil
+}
+
+func (f *fakeClient) Validate(_ context.Context, p *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error) {
+	f.call("Validate", p)
+	return &goapolicy.EvaluateResult{Result: map[string]interface{}{}}, nil
+}
+
+func (f *fakeClient) Lock(_ context.Context, p *goapolicy.LockRequest) error {
+	f.call("Lock", p)
+	return nil
+}
+
+func (f *fakeClient) BulkLock(_ context.Context, p *goapolicy.BulkLockRequest) (*goapolicy.BulkLockResult, error) {
+	f.call("BulkLock", p)
+	return &goapolicy.BulkLockResult{}, nil
+}
+
+func (f *fakeClient) CreatePolicy(_ context.Context, p *goapolicy.PolicyRequest) (any, error) {
+	f.call("CreatePolicy", p)
+	return map[string]interface{}{}, nil
+}
+
+func (f *fakeClient) UpdatePolicy(_ context.Context, p *goapolicy.PolicyRequest) (any, error) {
+	f.call("UpdatePolicy", p)
+	return map[string]interface{}{}, nil
+}
+
+func (f *fakeClient) ListPolicies(_ context.Context, p *goapolicy.PoliciesRequest) (*goapolicy.PoliciesResult, error) {
+	f.call("ListPolicies", p)
+	return &goapolicy.PoliciesResult{}, nil
+}
+
+func (f *fakeClient) PolicyAutoImport(_ context.Context) (any, error) {
+	f.call("PolicyAutoImport", nil)
+	return f.res, nil
+}
+
+func (f *fakeClient) SubscribeForPolicyChange(_ context.Context, p *goapolicy.SubscribeRequest) (any, error) {
+	f.call("SubscribeForPolicyChange", p)
+	return map[string]interface{}{}, nil
+}
+
+func (f *fakeClient) SubscriberDeliveries(_ context.Context, p *goapolicy.SubscriberDeliveriesRequest) (*goapolicy.SubscriberDeliveriesResult, error) {
+	f.call("SubscriberDeliveries", p)
+	return &goapolicy.SubscriberDeliveriesResult{}, nil
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	filename := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(filename, []byte(content), 0600))
+	return filename
+}
+
+func TestParseCoordinates(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+
+		res     *coordinates
+		errtext string
+	}{
+		{
+			name:    "too few parts",
+			policy:  "policies/example/1.0",
+			errtext: "expected format is repository/group/name/version",
+		},
+		{
+			name:    "empty part",
+			policy:  "policies//example/1.0",
+			errtext: "expected format is repository/group/name/version",
+		},
+		{
+			name:   "valid coordinates",
+			policy: "policies/xfsc.auth/example/1.0",
+			res:    &coordinates{repository: "policies", group: "xfsc.auth", name: "example", version: "1.0"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := parseCoordinates(test.policy)
+			if test.errtext != "" {
+				assert.ErrorContains(t, err, test.errtext)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.res, res)
+			assert.Equal(t, test.policy, res.String())
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("POLICY_ADDR", "http://policy:8080")
+
+	cfg, args, err := loadConfig([]string{"-o", "json", "lock", "policies/example/test/1.0"}, usage)
+	require.NoError(t, err)
+	assert.Equal(t, "http://policy:8080", cfg.Addr)
+	assert.Equal(t, outputJSON, cfg.Output)
+	assert.Equal(t, []string{"lock", "policies/example/test/1.0"}, args)
+
+	cfg, _, err = loadConfig([]string{"-addr", "http://localhost:9000", "list"}, usage)
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:9000", cfg.Addr)
+
+	_, _, err = loadConfig([]string{"-o", "yaml", "list"}, usage)
+	assert.ErrorContains(t, err, `invalid output format: "yaml"`)
+}
+
+func TestCommands(t *testing.T) {
+	input := writeFile(t, "input.json", `{"msg":"hello"}`)
+
+	tests := []struct {
+		name string
+		run  func(ctx context.Context, ctl *ctl, args []string) error
+		args []string
+
+		method  string
+		req     interface{}
+		errtext string
+	}{
+		{
+			name:   "list with filters",
+			run:    listPolicies,
+			args:   []string{"-locked", "true", "-name", "example", "-repository", "policies", "-sort", "lastUpdate", "-order", "desc", "-annotation", "custom.domain=gaia-x", "-annotation", "custom.level=2", "-rego"},
+			method: "ListPolicies",
+			req: &goapolicy.PoliciesRequest{
+				Locked:     ptr.Bool(true),
+				PolicyName: ptr.String("example"),
+				Repository: ptr.String("policies"),
+				Rego:       ptr.Bool(true),
+				Data:       ptr.Bool(false),
+				DataConfig: ptr.Bool(false),
+				Sort:       "lastUpdate",
+				Order:      "desc",
+				Annotation: []string{"custom.domain=gaia-x", "custom.level=2"},
+			},
+		},
+		{
+			name:    "list with invalid locked value",
+			run:     listPolicies,
+			args:    []string{"-locked", "maybe"},
+			errtext: `invalid locked value: "maybe"`,
+		},
+		{
+			name:    "list with unexpected argument",
+			run:     listPolicies,
+			args:    []string{"policies/example/test/1.0"},
+			errtext: "expected 0 argument(s), but got 1",
+		},
+		{
+			name:   "lock with reason and expiration",
+			run:    lockPolicy,
+			args:   []string{"-reason", "incident 42", "-expiresAt", "2030-01-01T00:00:00Z", "policies/example/test/1.0"},
+			method: "Lock",
+			req: &goapolicy.LockRequest{
+				Repository: "policies",
+				Group:      "example",
+				PolicyName: "test",
+				Version:    "1.0",
+				Reason:     ptr.String("incident 42"),
+				ExpiresAt:  ptr.String("2030-01-01T00:00:00Z"),
+			},
+		},
+		{
+			name:    "lock with invalid policy",
+			run:     lockPolicy,
+			args:    []string{"policies/test"},
+			errtext: `invalid policy "policies/test"`,
+		},
+		{
+			name:   "bulk lock with filters and policies",
+			run:    bulk,
+			args:   []string{"lock", "-repository", "policies", "-name", "did*", "-reason", "compromised", "policies/example/test/1.0"},
+			method: "BulkLock",
+			req: &goapolicy.BulkLockRequest{
+				Repository: ptr.String("policies"),
+				PolicyName: ptr.String("did*"),
+				Reason:     ptr.String("compromised"),
+				Policies: []*goapolicy.PolicyRef{
+					{Repository: "policies", Group: "example", PolicyName: "test", Version: "1.0"},
+				},
+			},
+		},
+		{
+			name:    "bulk with unknown subcommand",
+			run:     bulk,
+			args:    []string{"delete"},
+			errtext: `unknown subcommand: "delete"`,
+		},
+		{
+			name:   "evaluate with input file",
+			run:    evaluatePolicy,
+			args:   []string{"-input", input, "-evaluationID", "id", "-ttl", "60", "policies/example/test/1.0"},
+			method: "Evaluate",
+			req: &goapolicy.EvaluateRequest{
+				Repository:   "policies",
+				Group:        "example",
+				PolicyName:   "test",
+				Version:      "1.0",
+				Input:        map[string]interface{}{"msg": "hello"},
+				EvaluationID: ptr.String("id"),
+				TTL:          ptr.Int(60),
+			},
+		},
+		{
+			name:   "evaluate with validation",
+			run:    evaluatePolicy,
+			args:   []string{"-validate", "policies/example/test/1.0"},
+			method: "Validate",
+			req: &goapolicy.EvaluateRequest{
+				Repository: "policies",
+				Group:      "example",
+				PolicyName: "test",
+				Version:    "1.0",
+			},
+		},
+		{
+			name:    "evaluate with invalid input",
+			run:     evaluatePolicy,
+			args:    []string{"-input", writeFile(t, "invalid.json", "{"), "policies/example/test/1.0"},
+			errtext: "input is not valid JSON",
+		},
+		{
+			name:   "subscribe",
+			run:    subscribe,
+			args:   []string{"-name", "subscriber", "-url", "https://example.com/hook", "policies/example/test/1.0"},
+			method: "SubscribeForPolicyChange",
+			req: &goapolicy.SubscribeRequest{
+				WebhookURL: "https://example.com/hook",
+				Subscriber: "subscriber",
+				Repository: "policies",
+				Group:      "example",
+				PolicyName: "test",
+				Version:    "1.0",
+			},
+		},
+		{
+			name:    "subscribe without url",
+			run:     subscribe,
+			args:    []string{"-name", "subscriber", "policies/example/test/1.0"},
+			errtext: "name and url are required",
+		},
+		{
+			name:   "subscriber deliveries",
+			run:    deliveries,
+			args:   []string{"-name", "subscriber", "policies/example/test/1.0"},
+			method: "SubscriberDeliveries",
+			req: &goapolicy.SubscriberDeliveriesRequest{
+				Repository: "policies",
+				Group:      "example",
+				PolicyName: "test",
+				Version:    "1.0",
+				Subscriber: "subscriber",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &fakeClient{}
+			c := &ctl{client: client, out: &output{format: outputJSON, w: &bytes.Buffer{}}}
+
+			err := test.run(context.Background(), c, test.args)
+			if test.errtext != "" {
+				assert.ErrorContains(t, err, test.errtext)
+				assert.Empty(t, client.method)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.method, client.method)
+			assert.Equal(t, test.req, client.req)
+		})
+	}
+}
+
+func TestSavePolicy(t *testing.T) {
+	rego := writeFile(t, "policy.rego", "package example.test")
+	data := writeFile(t, "data.json", `{"hello":"world"}`)
+	schema := writeFile(t, "schema.json", `{"type":"object"}`)
+
+	tests := []struct {
+		name string
+		run  func(ctx context.Context, ctl *ctl, args []string) error
+		args []string
+
+		method  string
+		req     *goapolicy.PolicyRequest
+		errtext string
+	}{
+		{
+			name:    "source code file is missing",
+			run:     createPolicy,
+			args:    []string{"policies/example/test/1.0"},
+			errtext: "policy source code file is required",
+		},
+		{
+			name:    "data file doesn't exist",
+			run:     createPolicy,
+			args:    []string{"-rego", rego, "-data", "missing.json", "policies/example/test/1.0"},
+			errtext: "no such file or directory",
+		},
+		{
+			name:   "policy is created",
+			run:    createPolicy,
+			args:   []string{"-rego", rego, "-data", data, "-outputSchema", schema, "policies/example/test/1.0"},
+			method: "CreatePolicy",
+			req: &goapolicy.PolicyRequest{
+				Repository:   "policies",
+				Group:        "example",
+				PolicyName:   "test",
+				Version:      "1.0",
+				Rego:         "package example.test",
+				Data:         ptr.String(`{"hello":"world"}`),
+				OutputSchema: ptr.String(`{"type":"object"}`),
+			},
+		},
+		{
+			name:   "same file is given for two flags",
+			run:    updatePolicy,
+			args:   []string{"-rego", rego, "-data", data, "-dataConfig", data, "policies/example/test/1.0"},
+			method: "UpdatePolicy",
+			req: &goapolicy.PolicyRequest{
+				Repository: "policies",
+				Group:      "example",
+				PolicyName: "test",
+				Version:    "1.0",
+				Rego:       "package example.test",
+				Data:       ptr.String(`{"hello":"world"}`),
+				DataConfig: ptr.String(`{"hello":"world"}`),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &fakeClient{}
+			c := &ctl{client: client, out: &output{format: outputTable, w: &bytes.Buffer{}}}
+
+			err := test.run(context.Background(), c, test.args)
+			if test.errtext != "" {
+				assert.ErrorContains(t, err, test.errtext)
+				assert.Empty(t, client.method)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.method, client.method)
+			assert.Equal(t, test.req, client.req)
+		})
+	}
+}
+
+func TestAutoImportList(t *testing.T) {
+	// the Goa client decodes the untyped result as generic JSON
+	var res interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"autoimport":[{
+		"MongoID": "000000000000000000000000",
+		"PolicyURL": "https://example.com/policy/export",
+		"Interval": 3600000000000,
+		"NextImport": "2030-01-01T10:00:00Z"
+	}]}`), &res))
+
+	var out bytes.Buffer
+	c := &ctl{client: &fakeClient{res: res}, out: &output{format: outputTable, w: &out}}
+
+	require.NoError(t, autoImport(context.Background(), c, []string{"list"}))
+	assert.Equal(t,
+		"POLICY URL                         INTERVAL  NEXT IMPORT\n"+
+			"https://example.com/policy/export  1h0m0s    2030-01-01T10:00:00Z\n",
+		out.String(),
+	)
+}
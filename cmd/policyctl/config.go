@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// Config defines the global options of the policyctl tool.
+// Configuration is loaded from environment and can be overridden
+// by the global command-line flags given before the command name.
+type Config struct {
+	// Addr specifies the address of the policy service.
+	Addr string `envconfig:"POLICY_ADDR" default:"http://localhost:8081"`
+
+	// Output specifies the output format of the commands - table or json.
+	Output string `envconfig:"POLICYCTL_OUTPUT" default:"table"`
+
+	OAuth oauthConfig
+}
+
+// OAuth client configuration. If ClientID is not empty, requests
+// to the policy service are sent with an OAuth2 access token obtained
+// with the client credentials flow.
+type oauthConfig struct {
+	ClientID     string `envconfig:"OAUTH_CLIENT_ID"`
+	ClientSecret string `envconfig:"OAUTH_CLIENT_SECRET"`
+	TokenURL     string `envconfig:"OAUTH_TOKEN_URL"`
+}
+
+// loadConfig loads the configuration from environment and global
+// command-line flags and returns the remaining command-line arguments.
+func loadConfig(args []string, usage func(w io.Writer)) (*Config, []string, error) {
+	cfg := Config{}
+	if err := envconfig.Process("", &cfg); err != nil {
+		return nil, nil, err
+	}
+
+	fs := flag.NewFlagSet("policyctl", flag.ContinueOnError)
+	fs.Usage = func() { usage(fs.Output()) }
+	fs.StringVar(&cfg.Addr, "addr", cfg.Addr, "Policy service address.")
+	fs.StringVar(&cfg.Output, "o", cfg.Output, "Output format: table or json.")
+	fs.StringVar(&cfg.OAuth.ClientID, "clientID", cfg.OAuth.ClientID, "OAuth client ID. This flag is optional.")
+	fs.StringVar(&cfg.OAuth.ClientSecret, "clientSecret", cfg.OAuth.ClientSecret, "OAuth client secret. This flag is optional.")
+	fs.StringVar(&cfg.OAuth.TokenURL, "tokenURL", cfg.OAuth.TokenURL, "OAuth token URL. This flag is optional.")
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	if cfg.Output != outputTable && cfg.Output != outputJSON {
+		return nil, nil, fmt.Errorf("invalid output format: %q", cfg.Output)
+	}
+
+	return &cfg, fs.Args(), nil
+}
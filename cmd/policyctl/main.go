@@ -0,0 +1,212 @@
+// Package main provides policyctl - a command-line tool
+// for administration of the policy service.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	goahttp "goa.design/goa/v3/http"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	goapolicycli "gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/http/policy/client"
+	goapolicy "gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/policy"
+)
+
+// command is a policyctl subcommand.
+type command struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, ctl *ctl, args []string) error
+}
+
+var commands = []*command{
+	{name: "list", usage: "list [-locked true|false] [-name NAME] [-repository REPO] [-group GROUP] [-version VERSION] [-updatedSince TIME] [-sort name|lastUpdate] [-order asc|desc] [-annotation custom.KEY=VALUE] [-search TEXT] [-rego] [-data] [-dataConfig]", run: listPolicies},
+	{name: "show", usage: "show REPOSITORY/GROUP/NAME/VERSION", run: showPolicy},
+	{name: "dependencies", usage: "dependencies [-builtin NAME] [-repository REPO]", run: policyDependencies},
+	{name: "lock", usage: "lock [-reason TEXT] [-expiresAt TIME] REPOSITORY/GROUP/NAME/VERSION", run: lockPolicy},
+	{name: "unlock", usage: "unlock REPOSITORY/GROUP/NAME/VERSION", run: unlockPolicy},
+	{name: "bulk", usage: "bulk lock|unlock [-repository REPO] [-group GROUP] [-name PATTERN] [-version VERSION] [-reason TEXT] [-expiresAt TIME] [POLICY...]", run: bulk},
+	{name: "create", usage: "create -rego FILE [-data FILE] [-dataConfig FILE] [-outputSchema FILE] [-exportConfig FILE] REPOSITORY/GROUP/NAME/VERSION", run: createPolicy},
+	{name: "update", usage: "update -rego FILE [-data FILE] [-dataConfig FILE] [-outputSchema FILE] [-exportConfig FILE] REPOSITORY/GROUP/NAME/VERSION", run: updatePolicy},
+	{name: "delete", usage: "delete REPOSITORY/GROUP/NAME/VERSION", run: deletePolicy},
+	{name: "revisions", usage: "revisions list POLICY | show -r REVISION POLICY | diff -from REVISION -to REVISION POLICY | rollback -r REVISION POLICY", run: revisions},
+	{name: "export", usage: "export [-wasm] [-f FILE] REPOSITORY/GROUP/NAME/VERSION", run: exportBundle},
+	{name: "import", usage: "import FILE", run: importBundle},
+	{name: "verify", usage: "verify -key JWK_FILE FILE", run: verifyBundle},
+	{name: "evaluate", usage: "evaluate [-input FILE] [-validate] [-evaluationID ID] [-ttl SECONDS] REPOSITORY/GROUP/NAME/VERSION", run: evaluatePolicy},
+	{name: "autoimport", usage: "autoimport list | set -url URL -interval DURATION | delete -url URL", run: autoImport},
+	{name: "subscribe", usage: "subscribe -name NAME -url WEBHOOK_URL REPOSITORY/GROUP/NAME/VERSION", run: subscribe},
+	{name: "deliveries", usage: "deliveries -name NAME REPOSITORY/GROUP/NAME/VERSION", run: deliveries},
+}
+
+// Client is the part of the Goa generated policy service
+// client which is used by the commands.
+type Client interface {
+	Evaluate(ctx context.Context, p *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error)
+	Validate(ctx context.Context, p *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error)
+	Lock(ctx context.Context, p *goapolicy.LockRequest) error
+	Unlock(ctx context.Context, p *goapolicy.UnlockRequest) error
+	BulkLock(ctx context.Context, p *goapolicy.BulkLockRequest) (*goapolicy.BulkLockResult, error)
+	BulkUnlock(ctx context.Context, p *goapolicy.BulkUnlockRequest) (*goapolicy.BulkLockResult, error)
+	CreatePolicy(ctx context.Context, p *goapolicy.PolicyRequest) (any, error)
+	UpdatePolicy(ctx context.Context, p *goapolicy.PolicyRequest) (any, error)
+	DeletePolicy(ctx context.Context, p *goapolicy.DeletePolicyRequest) error
+	PolicyRevisions(ctx context.Context, p *goapolicy.PolicyRevisionsRequest) (*goapolicy.PolicyRevisionsResult, error)
+	PolicyRevision(ctx context.Context, p *goapolicy.PolicyRevisionRequest) (*goapolicy.PolicyRevisionResult, error)
+	DiffPolicyRevisions(ctx context.Context, p *goapolicy.DiffPolicyRevisionsRequest) (*goapolicy.DiffPolicyRevisionsResult, error)
+	RollbackPolicy(ctx context.Context, p *goapolicy.RollbackPolicyRequest) (*goapolicy.PolicyRevisionResult, error)
+	ExportBundle(ctx context.Context, p *goapolicy.ExportBundleRequest) (*goapolicy.ExportBundleResult, io.ReadCloser, error)
+	ImportBundle(ctx context.Context, p *goapolicy.ImportBundlePayload, req io.ReadCloser) (any, error)
+	ListPolicies(ctx context.Context, p *goapolicy.PoliciesRequest) (*goapolicy.PoliciesResult, error)
+	PolicyDependencies(ctx context.Context, p *goapolicy.PolicyDependenciesRequest) (*goapolicy.PolicyDependenciesResult, error)
+	GetPolicy(ctx context.Context, p *goapolicy.GetPolicyRequest) (*goapolicy.Policy, error)
+	SetPolicyAutoImport(ctx context.Context, p *goapolicy.SetPolicyAutoImportRequest) (any, error)
+	PolicyAutoImport(ctx context.Context) (any, error)
+	DeletePolicyAutoImport(ctx context.Context, p *goapolicy.DeletePolicyAutoImportRequest) (any, error)
+	SubscribeForPolicyChange(ctx context.Context, p *goapolicy.SubscribeRequest) (any, error)
+	SubscriberDeliveries(ctx context.Context, p *goapolicy.SubscriberDeliveriesRequest) (*goapolicy.SubscriberDeliveriesResult, error)
+}
+
+// ctl holds the policy service client and output settings
+// shared by all commands.
+type ctl struct {
+	client Client
+	out    *output
+}
+
+func main() {
+	cfg, args, err := loadConfig(os.Args[1:], usage)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		log.Fatalln("failed to load configuration: ", err)
+	}
+
+	if len(args) == 0 {
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+
+	var cmd *command
+	for _, c := range commands {
+		if c.name == args[0] {
+			cmd = c
+		}
+	}
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "unknown command: %q\n\n", args[0])
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+
+	client, err := newClient(cfg)
+	if err != nil {
+		log.Fatalln("failed to create policy client: ", err)
+	}
+
+	c := &ctl{
+		client: client,
+		out:    &output{format: cfg.Output, w: os.Stdout},
+	}
+
+	if err := cmd.run(context.Background(), c, args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Fprintf(os.Stderr, "%s: %v\n", cmd.name, err)
+		os.Exit(1)
+	}
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: policyctl [-addr ADDR] [-o table|json] [-clientID ID -clientSecret SECRET -tokenURL URL] COMMAND [ARGS]\n\n")
+	fmt.Fprintf(w, "Commands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %s\n", c.usage)
+	}
+}
+
+// newClient creates a policy service client using the Goa generated HTTP client.
+func newClient(cfg *Config) (*goapolicy.Client, error) {
+	u, err := url.Parse(cfg.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy service address: %v", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid policy service address: %q", cfg.Addr)
+	}
+
+	client := httpClient()
+	if cfg.OAuth.ClientID != "" {
+		// Create an HTTP Client which automatically issues and carries an OAuth2 token.
+		// The token will auto-refresh when its expiration is near.
+		oauthCtx := context.WithValue(context.Background(), oauth2.HTTPClient, client)
+		client = newOAuth2Client(oauthCtx, cfg.OAuth.ClientID, cfg.OAuth.ClientSecret, cfg.OAuth.TokenURL)
+	}
+
+	c := goapolicycli.NewClient(u.Scheme, u.Host, client, goahttp.RequestEncoder, goahttp.ResponseDecoder, false)
+
+	return goapolicy.NewClient(
+		c.Evaluate(),
+		c.Validate(),
+		c.Lock(),
+		c.Unlock(),
+		c.BulkLock(),
+		c.BulkUnlock(),
+		c.CreatePolicy(),
+		c.UpdatePolicy(),
+		c.DeletePolicy(),
+		c.PolicyRevisions(),
+		c.PolicyRevision(),
+		c.DiffPolicyRevisions(),
+		c.RollbackPolicy(),
+		c.ExportBundle(),
+		c.ExportWasmBundle(),
+		c.PolicyPublicKey(),
+		c.ImportBundle(),
+		c.ListPolicies(),
+		c.PolicyDependencies(),
+		c.GetPolicy(),
+		c.SetPolicyAutoImport(),
+		c.PolicyAutoImport(),
+		c.DeletePolicyAutoImport(),
+		c.SubscribeForPolicyChange(),
+		c.SubscriberDeliveries(),
+	), nil
+}
+
+func httpClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout: 30 * time.Second,
+			}).DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+			IdleConnTimeout:     60 * time.Second,
+		},
+		Timeout: 60 * time.Second,
+	}
+}
+
+func newOAuth2Client(ctx context.Context, cID, cSecret, tokenURL string) *http.Client {
+	oauthCfg := clientcredentials.Config{
+		ClientID:     cID,
+		ClientSecret: cSecret,
+		TokenURL:     tokenURL,
+	}
+
+	return oauthCfg.Client(ctx)
+}
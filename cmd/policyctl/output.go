@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// output prints command results either as table or as JSON.
+type output struct {
+	format string
+	w      io.Writer
+}
+
+// print writes v as indented JSON if the output format is json,
+// otherwise it writes the given header and rows as a table.
+func (o *output) print(v interface{}, header []string, rows [][]string) error {
+	if o.format == outputJSON {
+		return o.json(v)
+	}
+
+	tw := tabwriter.NewWriter(o.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// message writes a status message for commands which don't return data.
+// With json output format the fields are written as JSON object.
+func (o *output) message(msg string, fields map[string]interface{}) error {
+	if o.format == outputJSON {
+		return o.json(fields)
+	}
+
+	_, err := fmt.Fprintln(o.w, msg)
+	return err
+}
+
+func (o *output) json(v interface{}) error {
+	enc := json.NewEncoder(o.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
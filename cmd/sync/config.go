@@ -7,6 +7,9 @@ import (
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
+	"gopkg.in/yaml.v3"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/clone"
 )
 
 // Config defines the options for syncing policies.
@@ -25,16 +28,78 @@ type Config struct {
 	// running as a service. This is the case when KeepAlive is true.
 	SyncInterval time.Duration `envconfig:"SYNC_INTERVAL" default:"120s"`
 
-	Repo repoConfig
-	DB   mongoConfig
+	// OnRemove defines what happens with policies in MongoDB, which
+	// are removed from the Git repository: keep, delete, lock or archive.
+	OnRemove string `envconfig:"ON_REMOVE" default:"keep"`
+
+	// FullSync disables incremental sync, so that the repositories are
+	// cloned again and all policies are compared on every sync.
+	FullSync bool `envconfig:"FULL_SYNC" default:"false"`
+
+	// DryRun prints the plan of the changes which a sync would make in
+	// MongoDB, without making them. The program exits after a single run
+	// with exit code 2 if there are changes, 0 if there are no changes
+	// and 1 on errors. PlanFormat is the format of the plan: text or json.
+	DryRun     bool   `envconfig:"DRY_RUN" default:"false"`
+	PlanFormat string `envconfig:"PLAN_FORMAT" default:"text"`
+
+	// ReposFile is the path of a YAML file listing the policy repositories
+	// to sync. If it's empty, a single repository is configured by the
+	// POLICY_REPO environment variables or command-line flags.
+	ReposFile string `envconfig:"POLICY_REPOS_FILE"`
+
+	// Repos contains the repositories to sync, which are loaded
+	// from ReposFile or is the single configured Repo.
+	Repos []repoConfig `ignored:"true"`
+
+	Repo    repoConfig
+	DB      mongoConfig
+	Webhook webhookConfig
 }
 
 type repoConfig struct {
-	URL    string `envconfig:"POLICY_REPO" required:"true"`
-	User   string `envconfig:"POLICY_REPO_USER"`
-	Pass   string `envconfig:"POLICY_REPO_PASS"`
-	Branch string `envconfig:"POLICY_REPO_BRANCH"`
-	Folder string `envconfig:"POLICY_REPO_FOLDER"`
+	URL    string `envconfig:"POLICY_REPO" yaml:"url"`
+	User   string `envconfig:"POLICY_REPO_USER" yaml:"user"`
+	Pass   string `envconfig:"POLICY_REPO_PASS" yaml:"pass"`
+	Branch string `envconfig:"POLICY_REPO_BRANCH" yaml:"branch"`
+	Folder string `envconfig:"POLICY_REPO_FOLDER" yaml:"folder"`
+
+	// Token is an access token used instead of a password.
+	Token string `envconfig:"POLICY_REPO_TOKEN" yaml:"token"`
+
+	// SSHKey is the path of the private key file used to clone the repository
+	// over SSH, and KnownHosts is the path of the known_hosts file used to
+	// verify the host key of the Git server.
+	SSHKey           string `envconfig:"POLICY_REPO_SSH_KEY" yaml:"sshKey"`
+	SSHKeyPassphrase string `envconfig:"POLICY_REPO_SSH_KEY_PASSPHRASE" yaml:"sshKeyPassphrase"`
+	KnownHosts       string `envconfig:"POLICY_REPO_KNOWN_HOSTS" yaml:"knownHosts"`
+
+	// GPGKeyring is the path of an ASCII armored keyring and SSHAllowedSigners
+	// is the path of a file with SSH public keys of the maintainers trusted to
+	// sign commits. If any of them is set, policies are synced only from
+	// commits signed by a trusted key.
+	GPGKeyring        string `envconfig:"POLICY_REPO_GPG_KEYRING" yaml:"gpgKeyring"`
+	SSHAllowedSigners string `envconfig:"POLICY_REPO_SSH_ALLOWED_SIGNERS" yaml:"sshAllowedSigners"`
+
+	// SyncInterval defines how often the repository is synced. If it's
+	// not set, the SyncInterval of the Config is used.
+	SyncInterval time.Duration `ignored:"true" yaml:"syncInterval"`
+}
+
+// reposFile is the content of the repositories configuration file.
+type reposFile struct {
+	Repositories []repoConfig `yaml:"repositories"`
+}
+
+func (r *repoConfig) auth() *clone.Auth {
+	return &clone.Auth{
+		User:             r.User,
+		Pass:             r.Pass,
+		Token:            r.Token,
+		SSHKey:           r.SSHKey,
+		SSHKeyPassphrase: r.SSHKeyPassphrase,
+		KnownHosts:       r.KnownHosts,
+	}
 }
 
 type mongoConfig struct {
@@ -44,6 +109,19 @@ type mongoConfig struct {
 	Name string `envconfig:"DB_NAME" default:"policy"`
 }
 
+type webhookConfig struct {
+	// Addr is the address of the listener for push webhooks from GitLab,
+	// GitHub or Gitea, e.g. ":8080". A push to the synced branch triggers
+	// a sync right away. The listener is disabled if Addr is empty.
+	Addr string `envconfig:"WEBHOOK_ADDR"`
+	// Secret is the secret token or signing key configured for the webhook
+	// in the Git server. It's required if the listener is enabled.
+	Secret string `envconfig:"WEBHOOK_SECRET"`
+	// Debounce is the time to wait for more pushes after a sync is
+	// triggered, so that multiple pushes are synced at once.
+	Debounce time.Duration `envconfig:"WEBHOOK_DEBOUNCE" default:"5s"`
+}
+
 func loadConfig() (*Config, error) {
 	cfg := Config{}
 
@@ -54,14 +132,28 @@ func loadConfig() (*Config, error) {
 		flag.StringVar(&cfg.Repo.Pass, "repoPass", "", "Git repo password. This flag is optional.")
 		flag.StringVar(&cfg.Repo.Branch, "branch", "", "Git branch for explicit checkout. This flag is optional.")
 		flag.StringVar(&cfg.Repo.Folder, "repoFolder", "", "Folder to search for Policies within Repo. This flag is optional.")
+		flag.StringVar(&cfg.Repo.Token, "repoToken", "", "Git repo access token. This flag is optional.")
+		flag.StringVar(&cfg.Repo.SSHKey, "sshKey", "", "Path of the SSH private key for cloning the repo over SSH. This flag is optional.")
+		flag.StringVar(&cfg.Repo.SSHKeyPassphrase, "sshKeyPassphrase", "", "Passphrase of the SSH private key. This flag is optional.")
+		flag.StringVar(&cfg.Repo.KnownHosts, "knownHosts", "", "Path of the known_hosts file for verifying the SSH host key. This flag is optional.")
+		flag.StringVar(&cfg.Repo.GPGKeyring, "gpgKeyring", "", "Path of the armored GPG keyring with keys trusted to sign commits. This flag is optional.")
+		flag.StringVar(&cfg.Repo.SSHAllowedSigners, "sshAllowedSigners", "", "Path of the file with SSH public keys trusted to sign commits. This flag is optional.")
+		flag.StringVar(&cfg.ReposFile, "reposFile", "", "Path of a YAML file listing the policy repositories to sync, used instead of repoURL.")
 		flag.StringVar(&cfg.DB.Addr, "dbAddr", "", "Mongo DB connection string.")
 		flag.StringVar(&cfg.DB.User, "dbUser", "", "Mongo DB username.")
 		flag.StringVar(&cfg.DB.Pass, "dbPass", "", "Mongo DB password.")
 		flag.StringVar(&cfg.DB.Name, "dbName", "policy", "Mongo DB name.")
 		flag.BoolVar(&cfg.KeepAlive, "keepAlive", false, "If true, the sync process behaves like a service and is continuously executing sync on syncInterval period.")
 		flag.DurationVar(&cfg.SyncInterval, "syncInterval", 120*time.Second, "Sync interval given as time duration string, e.g. 120s.")
+		flag.StringVar(&cfg.OnRemove, "onRemove", onRemoveKeep, "Action for policies removed from the Git repo: keep, delete, lock or archive.")
+		flag.BoolVar(&cfg.FullSync, "fullSync", false, "If true, the repos are cloned again and all policies are compared on every sync.")
+		flag.BoolVar(&cfg.DryRun, "dryRun", false, "If true, the plan of the changes is printed without changing policies in Mongo DB.")
+		flag.StringVar(&cfg.PlanFormat, "planFormat", planFormatText, "Format of the dry run plan: text or json.")
+		flag.StringVar(&cfg.Webhook.Addr, "webhookAddr", "", "Address of the listener for Git push webhooks, e.g. :8080. This flag is optional.")
+		flag.StringVar(&cfg.Webhook.Secret, "webhookSecret", "", "Secret token or signing key of the Git push webhooks.")
+		flag.DurationVar(&cfg.Webhook.Debounce, "webhookDebounce", 5*time.Second, "Time to wait for more pushes before a triggered sync, e.g. 5s.")
 		flag.Parse()
-		if cfg.Repo.URL == "" || cfg.DB.Addr == "" {
+		if (cfg.Repo.URL == "" && cfg.ReposFile == "") || cfg.DB.Addr == "" {
 			return nil, fmt.Errorf("required command-line flag values are missing")
 		}
 		// load from environment if no command-line flags are given
@@ -69,5 +161,71 @@ func loadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	switch cfg.OnRemove {
+	case onRemoveKeep, onRemoveDelete, onRemoveLock, onRemoveArchive:
+	default:
+		return nil, fmt.Errorf("invalid action for removed policies: %q", cfg.OnRemove)
+	}
+
+	switch cfg.PlanFormat {
+	case planFormatText, planFormatJSON:
+	default:
+		return nil, fmt.Errorf("invalid plan format: %q", cfg.PlanFormat)
+	}
+
+	if cfg.Webhook.Addr != "" && cfg.Webhook.Secret == "" {
+		return nil, fmt.Errorf("webhook secret is required when the webhook listener is enabled")
+	}
+
+	if err := loadRepos(&cfg); err != nil {
+		return nil, err
+	}
+
 	return &cfg, nil
 }
+
+// loadRepos sets the repositories to sync from the repositories file
+// or from the single configured repository. Environment variables
+// in the file like ${GIT_TOKEN} are replaced with their values,
+// so that credentials don't have to be stored in the file.
+func loadRepos(cfg *Config) error {
+	if cfg.ReposFile == "" {
+		cfg.Repos = []repoConfig{cfg.Repo}
+	} else {
+		content, err := os.ReadFile(cfg.ReposFile)
+		if err != nil {
+			return fmt.Errorf("error reading repositories file: %v", err)
+		}
+
+		var file reposFile
+		if err := yaml.Unmarshal([]byte(os.ExpandEnv(string(content))), &file); err != nil {
+			return fmt.Errorf("error parsing repositories file: %v", err)
+		}
+		cfg.Repos = file.Repositories
+	}
+
+	if len(cfg.Repos) == 0 {
+		return fmt.Errorf("no policy repositories are configured")
+	}
+
+	names := make(map[string]bool, len(cfg.Repos))
+	for i := range cfg.Repos {
+		repo := &cfg.Repos[i]
+		if repo.URL == "" {
+			return fmt.Errorf("policy repository URL is missing")
+		}
+
+		// policies are stored by repository name, which must be unique
+		name := clone.RepoName(repo.URL)
+		if names[name] {
+			return fmt.Errorf("duplicate policy repository name: %q", name)
+		}
+		names[name] = true
+
+		if repo.SyncInterval <= 0 {
+			repo.SyncInterval = cfg.SyncInterval
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRepos(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "ghp_token")
+	t.Setenv("REPO_BRANCH", "release")
+
+	tests := []struct {
+		name    string
+		file    string
+		repo    repoConfig
+		repos   []repoConfig
+		errtext string
+	}{
+		{
+			name:  "single configured repository",
+			repo:  repoConfig{URL: "https://gitlab.example.com/policies.git", Branch: "main"},
+			repos: []repoConfig{{URL: "https://gitlab.example.com/policies.git", Branch: "main", SyncInterval: 2 * time.Minute}},
+		},
+		{
+			name: "repositories file with env expansion and per-repo defaults",
+			file: `
+repositories:
+  - url: git@gitlab.example.com:xfsc/policies.git
+    branch: main
+    folder: policies
+    sshKey: /secrets/id_ed25519
+    knownHosts: /secrets/known_hosts
+    gpgKeyring: /secrets/maintainers.asc
+  - url: https://github.com/example/rules.git
+    token: ${GITHUB_TOKEN}
+    branch: $REPO_BRANCH
+    sshAllowedSigners: /secrets/allowed_signers
+    syncInterval: 10m
+  - url: https://gitea.example.com/example/extra.git
+    user: sync
+    pass: ${UNDEFINED_PASSWORD}
+`,
+			repo: repoConfig{URL: "https://gitlab.example.com/ignored.git"},
+			repos: []repoConfig{
+				{
+					URL:          "git@gitlab.example.com:xfsc/policies.git",
+					Branch:       "main",
+					Folder:       "policies",
+					SSHKey:       "/secrets/id_ed25519",
+					KnownHosts:   "/secrets/known_hosts",
+					GPGKeyring:   "/secrets/maintainers.asc",
+					SyncInterval: 2 * time.Minute,
+				},
+				{
+					URL:               "https://github.com/example/rules.git",
+					Token:             "ghp_token",
+					Branch:            "release",
+					SSHAllowedSigners: "/secrets/allowed_signers",
+					SyncInterval:      10 * time.Minute,
+				},
+				{
+					URL:          "https://gitea.example.com/example/extra.git",
+					User:         "sync",
+					SyncInterval: 2 * time.Minute,
+				},
+			},
+		},
+		{
+			name:    "single repository without URL",
+			errtext: "policy repository URL is missing",
+		},
+		{
+			name:    "repositories file without repositories",
+			file:    "repositories: []\n",
+			errtext: "no policy repositories are configured",
+		},
+		{
+			name:    "repository without URL",
+			file:    "repositories:\n  - url: https://github.com/example/rules.git\n  - branch: main\n",
+			errtext: "policy repository URL is missing",
+		},
+		{
+			name:    "repositories with the same name",
+			file:    "repositories:\n  - url: https://github.com/example/policies.git\n  - url: git@gitlab.example.com:xfsc/policies.git\n",
+			errtext: `duplicate policy repository name: "policies"`,
+		},
+		{
+			name:    "invalid sync interval",
+			file:    "repositories:\n  - url: https://github.com/example/policies.git\n    syncInterval: often\n",
+			errtext: "error parsing repositories file",
+		},
+		{
+			name:    "invalid YAML",
+			file:    "repositories:\n  - url: [\n",
+			errtext: "error parsing repositories file",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := &Config{SyncInterval: 2 * time.Minute, Repo: test.repo}
+			if test.file != "" {
+				cfg.ReposFile = filepath.Join(t.TempDir(), "repos.yaml")
+				require.NoError(t, os.WriteFile(cfg.ReposFile, []byte(test.file), 0600))
+			}
+
+			err := loadRepos(cfg)
+			if test.errtext != "" {
+				assert.ErrorContains(t, err, test.errtext)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.repos, cfg.Repos)
+		})
+	}
+
+	t.Run("missing repositories file", func(t *testing.T) {
+		err := loadRepos(&Config{ReposFile: filepath.Join(t.TempDir(), "missing.yaml")})
+		assert.ErrorContains(t, err, "error reading repositories file")
+	})
+}
+
+func TestLoadConfig(t *testing.T) {
+	// the configuration is loaded from the environment without arguments
+	args := os.Args
+	os.Args = []string{"sync"}
+	t.Cleanup(func() { os.Args = args })
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		errtext string
+	}{
+		{
+			name: "valid configuration",
+			env:  map[string]string{"DB_ADDR": "mongodb://localhost:27017", "POLICY_REPO": "https://github.com/example/policies.git"},
+		},
+		{
+			name:    "missing database address",
+			env:     map[string]string{"POLICY_REPO": "https://github.com/example/policies.git"},
+			errtext: "required key DB_ADDR missing value",
+		},
+		{
+			name:    "invalid action for removed policies",
+			env:     map[string]string{"DB_ADDR": "mongodb://localhost:27017", "POLICY_REPO": "https://github.com/example/policies.git", "ON_REMOVE": "purge"},
+			errtext: `invalid action for removed policies: "purge"`,
+		},
+		{
+			name:    "invalid plan format",
+			env:     map[string]string{"DB_ADDR": "mongodb://localhost:27017", "POLICY_REPO": "https://github.com/example/policies.git", "PLAN_FORMAT": "yaml"},
+			errtext: `invalid plan format: "yaml"`,
+		},
+		{
+			name:    "webhook listener without secret",
+			env:     map[string]string{"DB_ADDR": "mongodb://localhost:27017", "POLICY_REPO": "https://github.com/example/policies.git", "WEBHOOK_ADDR": ":8080"},
+			errtext: "webhook secret is required",
+		},
+		{
+			name:    "missing repository",
+			env:     map[string]string{"DB_ADDR": "mongodb://localhost:27017"},
+			errtext: "policy repository URL is missing",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, key := range []string{"DB_ADDR", "POLICY_REPO", "ON_REMOVE", "PLAN_FORMAT", "WEBHOOK_ADDR", "WEBHOOK_SECRET", "POLICY_REPOS_FILE", "SYNC_INTERVAL"} {
+				t.Setenv(key, test.env[key])
+				if _, ok := test.env[key]; !ok {
+					os.Unsetenv(key) //nolint:errcheck
+				}
+			}
+
+			cfg, err := loadConfig()
+			if test.errtext != "" {
+				assert.ErrorContains(t, err, test.errtext)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, onRemoveKeep, cfg.OnRemove)
+			assert.Equal(t, planFormatText, cfg.PlanFormat)
+			assert.Equal(t, "policy", cfg.DB.Name)
+			assert.Equal(t, []repoConfig{{URL: "https://github.com/example/policies.git", SyncInterval: 120 * time.Second}}, cfg.Repos)
+		})
+	}
+}
@@ -1,20 +1,22 @@
-// Package main provides a script to clone a repository containing Rego policies
+// Package main provides a script to clone repositories containing Rego policies
 // and add them to a Mongo DB collection
 package main
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"os"
+	"sync"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/clone"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage/mongodb"
 )
 
 const (
@@ -22,6 +24,14 @@ const (
 	policyCollection = "policies"
 )
 
+// Actions for policies which are removed from the Git repository.
+const (
+	onRemoveKeep    = "keep"
+	onRemoveDelete  = "delete"
+	onRemoveLock    = "lock"
+	onRemoveArchive = "archive"
+)
+
 func main() {
 	cfg, err := loadConfig()
 	if err != nil {
@@ -45,92 +55,106 @@ func main() {
 	}
 	defer db.Disconnect(context.Background()) //nolint:errcheck
 
-	for {
-		if err := sync(cfg, db); err != nil {
-			log.Println(err)
-		}
-
-		if cfg.KeepAlive {
-			// TODO catch SIGTERM/INTERRUPT here instead of hanging the program
-
-			time.Sleep(cfg.SyncInterval)
-			continue
+	syncers := make([]*repoSyncer, 0, len(cfg.Repos))
+	for _, repo := range cfg.Repos {
+		syncer, err := newRepoSyncer(repo)
+		if err != nil {
+			log.Fatalln("error creating repository syncer: ", err)
 		}
-
-		break // quit sync
+		syncers = append(syncers, syncer)
 	}
-}
 
-func sync(cfg *Config, db *mongo.Client) error {
-	// delete policy repository local folder in case the script failed last time it was executed
-	if err := os.RemoveAll(cloneFolder); err != nil {
-		return fmt.Errorf("failed to remove clone folder: %v", err)
+	if cfg.DryRun {
+		code := dryRun(cfg, db.Database(cfg.DB.Name).Collection(policyCollection), syncers, os.Stdout)
+		db.Disconnect(context.Background()) //nolint:errcheck
+		os.Exit(code)
 	}
 
-	// clone policy repository
-	cloner, err := clone.New()
-	if err != nil {
-		return err
+	// revisions of policies changed concurrently by the policy service must have unique numbers
+	revisions := db.Database(cfg.DB.Name).Collection(mongodb.RevisionCollection)
+	if err := mongodb.CreateRevisionIndex(context.Background(), revisions); err != nil {
+		log.Fatalln(err)
 	}
 
-	log.Println("Cloning repository...")
-	repo, err := cloner.Clone(context.Background(), cfg.Repo.URL, cfg.Repo.User, cfg.Repo.Pass, cfg.Repo.Branch)
-	if err != nil {
-		return fmt.Errorf("error cloning repo: %v", err)
+	if cfg.Webhook.Addr != "" {
+		go listenWebhooks(cfg, syncers)
 	}
 
-	log.Println("Repository is cloned successfully.")
-
-	// get all policies from the repository and the given directory
-
-	log.Println("Getting policies from the cloned repository...")
-
-	policies, err := cloner.IterateRepo(cfg.Repo.Folder, repo)
-	if err != nil {
-		return fmt.Errorf("error iterating repo: %v", err)
-	}
-
-	log.Println("Policies are extracted successfully.")
-
-	// insert or update policies in Mongo DB
-	if err := upsertPolicies(context.Background(), db, policies, cfg.DB.Name, cloner); err != nil {
-		return fmt.Errorf("error updating policies: %v", err)
+	// every repository is synced independently
+	var wg sync.WaitGroup
+	for _, syncer := range syncers {
+		wg.Add(1)
+		go func(syncer *repoSyncer) {
+			defer wg.Done()
+			syncer.run(cfg, db)
+		}(syncer)
 	}
-
-	// delete policy repository folder
-	if err := os.RemoveAll(cloneFolder); err != nil {
-		return fmt.Errorf("error deleting policy repo folder: %v", err)
-	}
-
-	log.Println("Policies are updated successfully.")
-
-	return nil
+	wg.Wait()
 }
 
 // upsertPolicies compares policies from Git repository and MongoDB
 // and then updates the modified policies and inserts new ones.
-func upsertPolicies(ctx context.Context, db *mongo.Client, repoPolicies map[string]*storage.Policy, policyDatabase string, cloner *clone.Cloner) error {
-	log.Println("Updating policies in Database...")
+// A revision with the Git commit is stored for every changed policy.
+// Policies of the repository which are removed from Git are
+// deleted, locked or archived depending on the onRemove action.
+//
+// If filenames are given, only the policies with these filenames are
+// compared, i.e. repoPolicies contains only the changed policies of
+// the repository. Otherwise, all policies are compared.
+func upsertPolicies(ctx context.Context, db *mongo.Client, repoPolicies map[string]*storage.Policy, filenames []string, repository, policyDatabase, onRemove string, cloner *clone.Cloner, logger *log.Logger) error {
+	logger.Println("Updating policies in Database...")
 	collection := db.Database(policyDatabase).Collection(policyCollection)
+	revisions := db.Database(policyDatabase).Collection(mongodb.RevisionCollection)
+
+	filter := bson.M{}
+	if filenames != nil {
+		filter = bson.M{"repository": repository, "filename": bson.M{"$in": filenames}}
+	}
 
-	currPolicies, err := fetchCurrPolicies(ctx, collection, cloner)
+	currPolicies, err := fetchCurrPolicies(ctx, collection, filter, cloner)
 	if err != nil {
 		return err
 	}
 
 	forUpsert := compare(currPolicies, repoPolicies)
 	if len(forUpsert) > 0 {
-		return upsert(ctx, forUpsert, collection)
+		if err := upsert(ctx, forUpsert, collection); err != nil {
+			return err
+		}
+	}
+	for _, policy := range forUpsert {
+		if err := mongodb.SaveRevision(ctx, revisions, policy, storage.RevisionSourceSync, ""); err != nil {
+			return err
+		}
 	}
+	logger.Printf("%d policies are inserted or updated.\n", len(forUpsert))
+
+	if onRemove == onRemoveKeep {
+		return nil
+	}
+
+	forRemove := removed(currPolicies, repoPolicies, repository, onRemove)
+	if len(forRemove) > 0 {
+		if err := remove(ctx, forRemove, onRemove, collection); err != nil {
+			return err
+		}
+	}
+	report(forRemove, onRemove, logger)
 
 	return nil
 }
 
-// fetchCurrPolicies fetches all policies currently stored in MongoDB
-// and returns a map with keys constructed out of the "group", "name" and
-// "version" fields of a Policy and value - a reference to the Policy
-func fetchCurrPolicies(ctx context.Context, db *mongo.Collection, cloner *clone.Cloner) (map[string]*storage.Policy, error) {
-	results, err := db.Find(ctx, bson.D{})
+// policyFinder finds the policies stored in the MongoDB policy collection.
+// It's implemented by *mongo.Collection.
+type policyFinder interface {
+	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error)
+}
+
+// fetchCurrPolicies fetches the policies currently stored in MongoDB, which
+// match the filter, and returns a map with keys constructed out of the "group",
+// "name" and "version" fields of a Policy and value - a reference to the Policy
+func fetchCurrPolicies(ctx context.Context, db policyFinder, filter bson.M, cloner *clone.Cloner) (map[string]*storage.Policy, error) {
+	results, err := db.Find(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -159,8 +183,10 @@ func compare(currPolicies map[string]*storage.Policy, repoPolicies map[string]*s
 	for k, rPolicy := range repoPolicies {
 		// check if the policy from GIT (by key) exists in MongoDB
 		if cPolicy, ok := currPolicies[k]; ok {
-			// if GIT policy exists in MongoDB, check if it is modified
-			if !equal(cPolicy, rPolicy) {
+			// if GIT policy exists in MongoDB, check if it is modified,
+			// it was archived and is added to the repository again or
+			// its commit is not recorded yet
+			if !equal(cPolicy, rPolicy) || cPolicy.Archived || cPolicy.Commit == nil {
 				// if GIT policy is modified, save the 'lock' state before updating in MongoDB
 				rPolicy.Locked = cPolicy.Locked
 				forUpsert = append(forUpsert, rPolicy)
@@ -196,6 +222,9 @@ func upsert(ctx context.Context, policies []*storage.Policy, db *mongo.Collectio
 				"dataConfig":          policy.DataConfig,
 				"outputSchema":        policy.OutputSchema,
 				"exportConfig":        policy.ExportConfig,
+				"archived":            false,
+				"commit":              policy.Commit,
+				"metadata":            storage.ParsePolicyMetadata(policy.Rego),
 				"lastUpdate":          time.Now(),
 				"nextDataRefreshTime": nextDataRefreshTime(policy),
 			},
@@ -212,6 +241,70 @@ func upsert(ctx context.Context, policies []*storage.Policy, db *mongo.Collectio
 	return nil
 }
 
+// removed returns the policies of the given repository which are stored
+// in MongoDB, but are removed from the Git repository. Policies which are
+// already locked or archived are skipped for the lock and archive actions.
+func removed(currPolicies map[string]*storage.Policy, repoPolicies map[string]*storage.Policy, repository, onRemove string) []*storage.Policy {
+	var forRemove []*storage.Policy
+	for k, cPolicy := range currPolicies {
+		if cPolicy.Repository != repository {
+			continue
+		}
+
+		if _, ok := repoPolicies[k]; ok {
+			continue
+		}
+
+		if (onRemove == onRemoveLock && cPolicy.Locked) || (onRemove == onRemoveArchive && cPolicy.Archived) {
+			continue
+		}
+
+		forRemove = append(forRemove, cPolicy)
+	}
+
+	return forRemove
+}
+
+// remove deletes, locks or archives the given policies in MongoDB collection.
+//
+// Deleting policies emits change events with a deleted type to the policy
+// change subscribers of the policy service.
+func remove(ctx context.Context, policies []*storage.Policy, onRemove string, db *mongo.Collection) error {
+	ids := make([]primitive.ObjectID, 0, len(policies))
+	for _, policy := range policies {
+		ids = append(ids, policy.MongoID)
+	}
+	filter := bson.M{"_id": bson.M{"$in": ids}}
+
+	var err error
+	switch onRemove {
+	case onRemoveDelete:
+		_, err = db.DeleteMany(ctx, filter)
+	case onRemoveLock:
+		lock := &storage.PolicyLock{Reason: "policy is removed from the repository", Actor: "sync", LockedAt: time.Now()}
+		_, err = db.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"locked": true, "lock": lock, "lastUpdate": time.Now()}})
+	case onRemoveArchive:
+		_, err = db.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"archived": true, "lastUpdate": time.Now()}})
+	}
+
+	return err
+}
+
+// report logs the policies removed from the Git repository and
+// what happened with them.
+func report(policies []*storage.Policy, onRemove string, logger *log.Logger) {
+	past := map[string]string{
+		onRemoveDelete:  "deleted",
+		onRemoveLock:    "locked",
+		onRemoveArchive: "archived",
+	}[onRemove]
+
+	for _, p := range policies {
+		logger.Printf("Policy %s/%s/%s/%s is removed from the repository and is %s.\n", p.Repository, p.Group, p.Name, p.Version, past)
+	}
+	logger.Printf("%d removed policies are %s.\n", len(policies), past)
+}
+
 func nextDataRefreshTime(p *storage.Policy) time.Time {
 	if p.DataConfig != "" {
 		return time.Now()
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+// Formats of the dry run plan.
+const (
+	planFormatText = "text"
+	planFormatJSON = "json"
+)
+
+// Exit codes of a dry run, which tell CI whether a sync would change policies.
+const (
+	exitNoChanges = 0
+	exitError     = 1
+	exitChanges   = 2
+)
+
+// plan lists the changes which a sync would make in MongoDB.
+type plan struct {
+	Repositories []*repoPlan `json:"repositories"`
+}
+
+// repoPlan lists the changes of the policies of a single repository.
+type repoPlan struct {
+	Repository string        `json:"repository"`
+	Commit     string        `json:"commit,omitempty"`
+	New        []*planPolicy `json:"new"`
+	Modified   []*planPolicy `json:"modified"`
+	Removed    []*planPolicy `json:"removed"`
+	Error      string        `json:"error,omitempty"`
+}
+
+type planPolicy struct {
+	Group    string `json:"group"`
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Filename string `json:"filename"`
+
+	// Changes contains unified diffs of the modified policy fields.
+	Changes []*fieldDiff `json:"changes,omitempty"`
+	// Restored is true if an archived policy is added to the repository again.
+	Restored bool `json:"restored,omitempty"`
+	// Action is the onRemove action for a removed policy.
+	Action string `json:"action,omitempty"`
+}
+
+type fieldDiff struct {
+	Field string `json:"field"`
+	Diff  string `json:"diff"`
+}
+
+// dryRun writes the plan of the changes, which a sync would make in MongoDB,
+// without changing anything in MongoDB and returns the exit code of the program.
+func dryRun(cfg *Config, policies policyFinder, syncers []*repoSyncer, w io.Writer) int {
+	var (
+		p       plan
+		code    = exitNoChanges
+		changes bool
+	)
+
+	for _, s := range syncers {
+		rp, err := s.plan(cfg, policies)
+		if err != nil {
+			s.logger.Println(err)
+			rp.Error = err.Error()
+			code = exitError
+		}
+		changes = changes || rp.hasChanges()
+		p.Repositories = append(p.Repositories, rp)
+	}
+
+	var err error
+	if cfg.PlanFormat == planFormatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(p)
+	} else {
+		err = p.print(w)
+	}
+	if err != nil {
+		log.Println("error printing plan: ", err)
+		return exitError
+	}
+
+	if code == exitNoChanges && changes {
+		code = exitChanges
+	}
+
+	return code
+}
+
+// plan pulls the repository and compares its policies with the policies
+// in MongoDB in the same way as a full sync, but without applying the changes.
+func (s *repoSyncer) plan(cfg *Config, policies policyFinder) (*repoPlan, error) {
+	rp := &repoPlan{
+		Repository: s.name,
+		New:        []*planPolicy{},
+		Modified:   []*planPolicy{},
+		Removed:    []*planPolicy{},
+	}
+
+	repo, err := s.cloner.Pull(context.Background(), s.repo.URL, s.repo.Branch, s.repo.auth())
+	if err != nil {
+		s.cloner.Cleanup() //nolint:errcheck
+		return rp, fmt.Errorf("error pulling repo: %v", err)
+	}
+
+	commit, err := s.cloner.Commit()
+	if err != nil {
+		return rp, fmt.Errorf("error getting repo commit: %v", err)
+	}
+	rp.Commit = commit.SHA
+
+	if s.keyring != nil {
+		if _, err := s.cloner.VerifyCommit(s.keyring); err != nil {
+			return rp, fmt.Errorf("refusing to sync policies: %v", err)
+		}
+	}
+
+	repoPolicies, err := s.cloner.IterateRepo(s.repo.Folder, repo)
+	if err != nil {
+		return rp, fmt.Errorf("error iterating repo: %v", err)
+	}
+
+	currPolicies, err := fetchCurrPolicies(context.Background(), policies, bson.M{}, s.cloner)
+	if err != nil {
+		return rp, fmt.Errorf("error getting policies: %v", err)
+	}
+
+	for _, rPolicy := range compare(currPolicies, repoPolicies) {
+		key := s.cloner.ConstructKey(rPolicy.Repository, rPolicy.Group, rPolicy.Name, rPolicy.Version)
+		cPolicy, ok := currPolicies[key]
+		if !ok {
+			rp.New = append(rp.New, newPlanPolicy(rPolicy))
+			continue
+		}
+
+		pp := newPlanPolicy(rPolicy)
+		pp.Restored = cPolicy.Archived
+		if pp.Changes, err = diffPolicies(cPolicy, rPolicy, commit.SHA); err != nil {
+			return rp, fmt.Errorf("error comparing policies: %v", err)
+		}
+		rp.Modified = append(rp.Modified, pp)
+	}
+
+	for _, cPolicy := range removed(currPolicies, repoPolicies, repo, cfg.OnRemove) {
+		pp := newPlanPolicy(cPolicy)
+		pp.Action = cfg.OnRemove
+		rp.Removed = append(rp.Removed, pp)
+	}
+
+	for _, policies := range [][]*planPolicy{rp.New, rp.Modified, rp.Removed} {
+		sort.Slice(policies, func(i, j int) bool {
+			return policies[i].Filename < policies[j].Filename
+		})
+	}
+
+	return rp, nil
+}
+
+func newPlanPolicy(p *storage.Policy) *planPolicy {
+	return &planPolicy{
+		Group:    p.Group,
+		Name:     p.Name,
+		Version:  p.Version,
+		Filename: p.Filename,
+	}
+}
+
+// diffPolicies returns unified diffs of the changed fields
+// of a policy in MongoDB and in the Git repository.
+func diffPolicies(cPolicy, rPolicy *storage.Policy, commit string) ([]*fieldDiff, error) {
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{name: "rego", from: cPolicy.Rego, to: rPolicy.Rego},
+		{name: "data", from: cPolicy.Data, to: rPolicy.Data},
+		{name: "dataConfig", from: cPolicy.DataConfig, to: rPolicy.DataConfig},
+		{name: "outputSchema", from: cPolicy.OutputSchema, to: rPolicy.OutputSchema},
+		{name: "exportConfig", from: cPolicy.ExportConfig, to: rPolicy.ExportConfig},
+	}
+
+	var changes []*fieldDiff
+	for _, field := range fields {
+		if field.from == field.to {
+			continue
+		}
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(field.from),
+			B:        difflib.SplitLines(field.to),
+			FromFile: field.name + "@mongodb",
+			ToFile:   field.name + "@" + shortSHA(commit),
+			Context:  3,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, &fieldDiff{Field: field.name, Diff: diff})
+	}
+
+	return changes, nil
+}
+
+// hasChanges reports whether a sync would change policies of the repository.
+// Removed policies are kept in MongoDB with the keep action.
+func (rp *repoPlan) hasChanges() bool {
+	if len(rp.New) > 0 || len(rp.Modified) > 0 {
+		return true
+	}
+
+	for _, p := range rp.Removed {
+		if p.Action != onRemoveKeep {
+			return true
+		}
+	}
+
+	return false
+}
+
+// print writes the plan in a human-readable form.
+func (p *plan) print(w io.Writer) error {
+	var b strings.Builder
+	var added, modified, removed int
+
+	for _, rp := range p.Repositories {
+		fmt.Fprintf(&b, "Repository %s", rp.Repository)
+		if rp.Commit != "" {
+			fmt.Fprintf(&b, " (commit %s)", shortSHA(rp.Commit))
+		}
+		b.WriteString(":\n")
+
+		if rp.Error != "" {
+			fmt.Fprintf(&b, "  ! %s\n\n", rp.Error)
+			continue
+		}
+
+		if !rp.hasChanges() && len(rp.Removed) == 0 {
+			b.WriteString("  no changes\n")
+		}
+
+		for _, pp := range rp.New {
+			fmt.Fprintf(&b, "  + %s (new)\n", pp.path())
+		}
+		for _, pp := range rp.Modified {
+			switch {
+			case pp.Restored:
+				fmt.Fprintf(&b, "  ~ %s (restored)\n", pp.path())
+			case len(pp.Changes) == 0:
+				fmt.Fprintf(&b, "  ~ %s (commit is recorded)\n", pp.path())
+			default:
+				fmt.Fprintf(&b, "  ~ %s (modified)\n", pp.path())
+			}
+			for _, change := range pp.Changes {
+				for _, line := range strings.Split(strings.TrimSuffix(change.Diff, "\n"), "\n") {
+					fmt.Fprintf(&b, "      %s\n", line)
+				}
+			}
+		}
+		for _, pp := range rp.Removed {
+			fmt.Fprintf(&b, "  - %s (removed, %s)\n", pp.path(), pp.Action)
+			if pp.Action != onRemoveKeep {
+				removed++
+			}
+		}
+		b.WriteString("\n")
+
+		added += len(rp.New)
+		modified += len(rp.Modified)
+	}
+
+	fmt.Fprintf(&b, "Plan: %d to add, %d to modify, %d to remove.\n", added, modified, removed)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (pp *planPolicy) path() string {
+	return pp.Group + "/" + pp.Name + "/" + pp.Version
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
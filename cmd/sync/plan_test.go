@@ -0,0 +1,260 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/clone"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+// fakePolicies returns the stored policies for any filter.
+type fakePolicies struct {
+	policies []*storage.Policy
+	err      error
+}
+
+func (f *fakePolicies) Find(_ context.Context, _ interface{}, _ ...*options.FindOptions) (*mongo.Cursor, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	docs := make([]interface{}, 0, len(f.policies))
+	for _, p := range f.policies {
+		docs = append(docs, p)
+	}
+	return mongo.NewCursorFromDocuments(docs, nil, nil)
+}
+
+// fixtureRepo commits the files to a new Git repository named policies
+// and returns its URL.
+func fixtureRepo(t *testing.T, files map[string]string) string {
+	dir := filepath.Join(t.TempDir(), "policies")
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+		_, err = worktree.Add(name)
+		require.NoError(t, err)
+	}
+
+	_, err = worktree.Commit("add policies", &git.CommitOptions{
+		Author: &object.Signature{Name: "alice", Email: "alice@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	return dir
+}
+
+func storedPolicy(name, rego string) *storage.Policy {
+	return &storage.Policy{
+		MongoID:    primitive.NewObjectID(),
+		Repository: "policies",
+		Group:      "xfsc",
+		Name:       name,
+		Version:    "1.0",
+		Filename:   "xfsc/" + name + "/1.0/policy.rego",
+		Rego:       rego,
+		Commit:     &storage.Commit{SHA: "8f4ae88d0c5d"},
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	url := fixtureRepo(t, map[string]string{
+		"xfsc/added/1.0/policy.rego":     "package xfsc.added\n",
+		"xfsc/changed/1.0/policy.rego":   "package xfsc.changed\n\nallow := true\n",
+		"xfsc/unchanged/1.0/policy.rego": "package xfsc.unchanged\n",
+		"xfsc/locked/1.0/policy.rego":    "package xfsc.locked\n\nallow := true\n",
+		"xfsc/archived/1.0/policy.rego":  "package xfsc.archived\n",
+	})
+
+	locked := func(p *storage.Policy) *storage.Policy {
+		p.Locked = true
+		return p
+	}
+	archived := storedPolicy("archived", "package xfsc.archived\n")
+	archived.Archived = true
+	other := storedPolicy("removed", "package xfsc.removed\n")
+	other.Repository = "rules"
+
+	current := []*storage.Policy{
+		storedPolicy("changed", "package xfsc.changed\n\nallow := false\n"),
+		storedPolicy("unchanged", "package xfsc.unchanged\n"),
+		locked(storedPolicy("locked", "package xfsc.locked\n\nallow := false\n")),
+		archived,
+		storedPolicy("removed", "package xfsc.removed\n"),
+		locked(storedPolicy("removedlocked", "package xfsc.removedlocked\n")),
+		other,
+	}
+
+	tests := []struct {
+		name     string
+		url      string
+		onRemove string
+		stored   *fakePolicies
+
+		added    []string
+		modified []string
+		restored []string
+		removed  []string
+		summary  string
+		errtext  string
+		code     int
+	}{
+		{
+			name:     "added, changed and removed policies are kept",
+			onRemove: onRemoveKeep,
+			stored:   &fakePolicies{policies: current},
+			added:    []string{"xfsc/added/1.0"},
+			modified: []string{"xfsc/archived/1.0", "xfsc/changed/1.0", "xfsc/locked/1.0"},
+			restored: []string{"xfsc/archived/1.0"},
+			removed:  []string{"xfsc/removed/1.0", "xfsc/removedlocked/1.0"},
+			summary:  "Plan: 1 to add, 3 to modify, 0 to remove.",
+			code:     exitChanges,
+		},
+		{
+			name:     "removed policies are locked unless they are locked already",
+			onRemove: onRemoveLock,
+			stored:   &fakePolicies{policies: current},
+			added:    []string{"xfsc/added/1.0"},
+			modified: []string{"xfsc/archived/1.0", "xfsc/changed/1.0", "xfsc/locked/1.0"},
+			restored: []string{"xfsc/archived/1.0"},
+			removed:  []string{"xfsc/removed/1.0"},
+			summary:  "Plan: 1 to add, 3 to modify, 1 to remove.",
+			code:     exitChanges,
+		},
+		{
+			name:     "removed policies are deleted",
+			onRemove: onRemoveDelete,
+			stored: &fakePolicies{policies: []*storage.Policy{
+				storedPolicy("added", "package xfsc.added\n"),
+				storedPolicy("changed", "package xfsc.changed\n\nallow := true\n"),
+				storedPolicy("unchanged", "package xfsc.unchanged\n"),
+				storedPolicy("locked", "package xfsc.locked\n\nallow := true\n"),
+				storedPolicy("archived", "package xfsc.archived\n"),
+				locked(storedPolicy("removedlocked", "package xfsc.removedlocked\n")),
+			}},
+			removed: []string{"xfsc/removedlocked/1.0"},
+			summary: "Plan: 0 to add, 0 to modify, 1 to remove.",
+			code:    exitChanges,
+		},
+		{
+			name:     "removed policies which are kept are no changes",
+			onRemove: onRemoveKeep,
+			stored: &fakePolicies{policies: []*storage.Policy{
+				storedPolicy("added", "package xfsc.added\n"),
+				storedPolicy("changed", "package xfsc.changed\n\nallow := true\n"),
+				storedPolicy("unchanged", "package xfsc.unchanged\n"),
+				locked(storedPolicy("locked", "package xfsc.locked\n\nallow := true\n")),
+				storedPolicy("archived", "package xfsc.archived\n"),
+				storedPolicy("removed", "package xfsc.removed\n"),
+			}},
+			removed: []string{"xfsc/removed/1.0"},
+			summary: "Plan: 0 to add, 0 to modify, 0 to remove.",
+			code:    exitNoChanges,
+		},
+		{
+			name:     "error getting stored policies",
+			onRemove: onRemoveKeep,
+			stored:   &fakePolicies{err: errors.New("connection refused")},
+			errtext:  "error getting policies: connection refused",
+			code:     exitError,
+		},
+		{
+			name:     "error pulling the repository",
+			url:      filepath.Join(t.TempDir(), "missing"),
+			onRemove: onRemoveKeep,
+			stored:   &fakePolicies{policies: current},
+			errtext:  "error pulling repo",
+			code:     exitError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repoURL := url
+			if test.url != "" {
+				repoURL = test.url
+			}
+			cloner, err := clone.NewInFolder(filepath.Join(t.TempDir(), "clone"))
+			require.NoError(t, err)
+			syncer := &repoSyncer{
+				repo:   repoConfig{URL: repoURL},
+				name:   clone.RepoName(repoURL),
+				cloner: cloner,
+				logger: log.New(io.Discard, "", 0),
+			}
+
+			cfg := &Config{OnRemove: test.onRemove, PlanFormat: planFormatJSON}
+			var out bytes.Buffer
+			code := dryRun(cfg, test.stored, []*repoSyncer{syncer}, &out)
+			assert.Equal(t, test.code, code)
+
+			var p plan
+			require.NoError(t, json.Unmarshal(out.Bytes(), &p))
+			require.Len(t, p.Repositories, 1)
+			rp := p.Repositories[0]
+
+			if test.errtext != "" {
+				assert.Contains(t, rp.Error, test.errtext)
+				return
+			}
+
+			assert.Empty(t, rp.Error)
+			assert.NotEmpty(t, rp.Commit)
+			assert.Equal(t, test.added, paths(rp.New))
+			assert.Equal(t, test.modified, paths(rp.Modified))
+			assert.Equal(t, test.removed, paths(rp.Removed))
+
+			var restored []string
+			for _, pp := range rp.Modified {
+				if pp.Restored {
+					restored = append(restored, pp.path())
+				}
+				if !pp.Restored {
+					require.Len(t, pp.Changes, 1)
+					assert.Equal(t, "rego", pp.Changes[0].Field)
+					assert.Contains(t, pp.Changes[0].Diff, "+allow := true")
+				}
+			}
+			assert.Equal(t, test.restored, restored)
+			for _, pp := range rp.Removed {
+				assert.Equal(t, test.onRemove, pp.Action)
+			}
+
+			// the text plan summarizes the same changes
+			cfg.PlanFormat = planFormatText
+			out.Reset()
+			assert.Equal(t, test.code, dryRun(cfg, test.stored, []*repoSyncer{syncer}, &out))
+			assert.Contains(t, out.String(), test.summary)
+		})
+	}
+}
+
+func paths(policies []*planPolicy) []string {
+	var paths []string
+	for _, pp := range policies {
+		paths = append(paths, pp.path())
+	}
+	return paths
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/clone"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+// repoSyncer syncs the policies of a single repository independently
+// of the other repositories, with its own interval, clone folder,
+// log prefix and status.
+type repoSyncer struct {
+	repo   repoConfig
+	name   string
+	cloner *clone.Cloner
+	logger *log.Logger
+
+	// keyring contains the keys trusted to sign the synced commits.
+	// If it's nil, commit signatures are not verified.
+	keyring *clone.Keyring
+
+	// trigger receives the syncs triggered by push webhooks. Pushes
+	// received while a sync is running trigger a single sync afterwards.
+	trigger chan struct{}
+
+	mu     sync.Mutex
+	status repoStatus
+}
+
+// repoStatus is the status of the last sync of a repository.
+type repoStatus struct {
+	Repository  string     `json:"repository"`
+	URL         string     `json:"url"`
+	Branch      string     `json:"branch,omitempty"`
+	LastSync    *time.Time `json:"lastSync,omitempty"`
+	LastSuccess *time.Time `json:"lastSuccess,omitempty"`
+	Commit      string     `json:"commit,omitempty"`
+	Signer      string     `json:"signer,omitempty"`
+	Failures    int        `json:"failures"`
+	Error       string     `json:"error,omitempty"`
+}
+
+func newRepoSyncer(repo repoConfig) (*repoSyncer, error) {
+	name := clone.RepoName(repo.URL)
+
+	cloner, err := clone.NewInFolder(filepath.Join(cloneFolder, name))
+	if err != nil {
+		return nil, err
+	}
+
+	keyring, err := clone.LoadKeyring(repo.GPGKeyring, repo.SSHAllowedSigners)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repoSyncer{
+		repo:    repo,
+		name:    name,
+		cloner:  cloner,
+		keyring: keyring,
+		logger:  log.New(os.Stderr, "["+name+"] ", log.LstdFlags|log.Lmsgprefix),
+		trigger: make(chan struct{}, 1),
+		status: repoStatus{
+			Repository: name,
+			URL:        repo.URL,
+			Branch:     repo.Branch,
+		},
+	}, nil
+}
+
+// run syncs the repository once, or continuously when the program is
+// running as a service or is listening for push webhooks.
+func (s *repoSyncer) run(cfg *Config, db *mongo.Client) {
+	for {
+		commit, signer, err := s.sync(cfg, db)
+		s.setStatus(commit, signer, err)
+		if err != nil {
+			s.logger.Println(err)
+		}
+
+		if cfg.KeepAlive || cfg.Webhook.Addr != "" {
+			// TODO catch SIGTERM/INTERRUPT here instead of hanging the program
+
+			s.wait(cfg)
+			continue
+		}
+
+		break // quit sync
+	}
+}
+
+// wait blocks until the next sync: on every SyncInterval when the program
+// is running as a service, or when a push webhook triggers a sync.
+func (s *repoSyncer) wait(cfg *Config) {
+	var interval <-chan time.Time
+	if cfg.KeepAlive {
+		interval = time.After(s.repo.SyncInterval)
+	}
+
+	select {
+	case <-interval:
+	case <-s.trigger:
+		// wait for more pushes, so that they are synced at once
+		time.Sleep(cfg.Webhook.Debounce)
+		select {
+		case <-s.trigger:
+		default:
+		}
+	}
+}
+
+// triggerSync triggers a sync unless a sync is already pending.
+func (s *repoSyncer) triggerSync() {
+	select {
+	case s.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// sync pulls the repository, updates its policies in MongoDB
+// and returns the synced commit and the identity of its signer.
+//
+// The clone of the repository is kept between syncs. If the commit synced
+// last time is available in the clone, only the policies in the folders
+// changed since that commit are updated. Otherwise, all policies of the
+// repository are compared with the policies in MongoDB.
+func (s *repoSyncer) sync(cfg *Config, db *mongo.Client) (string, string, error) {
+	ctx := context.Background()
+	states := db.Database(cfg.DB.Name).Collection(syncStateCollection)
+
+	if cfg.FullSync {
+		// delete policy repository local folder in case the script failed last time it was executed
+		if err := s.cloner.Cleanup(); err != nil {
+			return "", "", fmt.Errorf("failed to remove clone folder: %v", err)
+		}
+	}
+
+	s.logger.Println("Pulling repository...")
+	repo, err := s.cloner.Pull(ctx, s.repo.URL, s.repo.Branch, s.repo.auth())
+	if err != nil {
+		// the repository is cloned again on the next sync
+		s.cloner.Cleanup() //nolint:errcheck
+		return "", "", fmt.Errorf("error pulling repo: %v", err)
+	}
+
+	s.logger.Println("Repository is pulled successfully.")
+
+	commit, err := s.cloner.Commit()
+	if err != nil {
+		return "", "", fmt.Errorf("error getting repo commit: %v", err)
+	}
+
+	// refuse policies from commits which are not signed by a trusted key
+	var signer string
+	if s.keyring != nil {
+		signer, err = s.cloner.VerifyCommit(s.keyring)
+		if err != nil {
+			return "", "", fmt.Errorf("refusing to sync policies: %v", err)
+		}
+		s.logger.Printf("Commit %s is signed by %s.\n", commit.SHA, signer)
+	}
+
+	state, err := lastSyncState(ctx, states, repo)
+	if err != nil {
+		return "", "", fmt.Errorf("error getting last sync state: %v", err)
+	}
+
+	incremental := !cfg.FullSync && state.applies(s.repo)
+	if incremental && state.Commit == commit.SHA {
+		s.logger.Printf("Repository is not changed since the last synced commit %s.\n", commit.SHA)
+		return commit.SHA, signer, nil
+	}
+
+	var (
+		policies  map[string]*storage.Policy
+		filenames []string
+	)
+	if incremental {
+		s.logger.Printf("Getting policies changed since commit %s...\n", state.Commit)
+		policies, filenames, err = s.cloner.IterateChanges(s.repo.Folder, repo, state.Commit)
+		if err != nil {
+			s.logger.Printf("Falling back to a full sync: %v\n", err)
+			incremental = false
+		}
+	}
+	if !incremental {
+		// get all policies from the repository and the given directory
+		s.logger.Println("Getting policies from the cloned repository...")
+		policies, err = s.cloner.IterateRepo(s.repo.Folder, repo)
+		if err != nil {
+			return "", "", fmt.Errorf("error iterating repo: %v", err)
+		}
+		filenames = nil
+	}
+
+	s.logger.Println("Policies are extracted successfully.")
+
+	// record the commit from which policies are synced
+	for _, p := range policies {
+		p.Commit = commit
+	}
+
+	// insert or update policies in Mongo DB
+	if err := upsertPolicies(ctx, db, policies, filenames, repo, cfg.DB.Name, cfg.OnRemove, s.cloner, s.logger); err != nil {
+		return "", "", fmt.Errorf("error updating policies: %v", err)
+	}
+
+	err = saveSyncState(ctx, states, &syncState{
+		Repository: repo,
+		URL:        s.repo.URL,
+		Branch:     s.repo.Branch,
+		Folder:     s.repo.Folder,
+		Commit:     commit.SHA,
+		SyncedAt:   time.Now(),
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("error saving sync state: %v", err)
+	}
+
+	s.logger.Println("Policies are updated successfully.")
+
+	return commit.SHA, signer, nil
+}
+
+func (s *repoSyncer) setStatus(commit, signer string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.status.LastSync = &now
+
+	if err != nil {
+		s.status.Failures++
+		s.status.Error = err.Error()
+		return
+	}
+
+	s.status.LastSuccess = &now
+	s.status.Commit = commit
+	s.status.Signer = signer
+	s.status.Failures = 0
+	s.status.Error = ""
+}
+
+func (s *repoSyncer) getStatus() repoStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.status
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const syncStateCollection = "sync_state"
+
+// syncState is the last commit synced from a repository. It's stored in
+// MongoDB, so that the next sync applies only the changes since the commit.
+type syncState struct {
+	Repository string    `bson:"repository"`
+	URL        string    `bson:"url"`
+	Branch     string    `bson:"branch"`
+	Folder     string    `bson:"folder"`
+	Commit     string    `bson:"commit"`
+	SyncedAt   time.Time `bson:"syncedAt"`
+}
+
+// lastSyncState returns the last sync state of a repository
+// or nil if the repository is not synced yet.
+func lastSyncState(ctx context.Context, db *mongo.Collection, repository string) (*syncState, error) {
+	var state syncState
+	err := db.FindOne(ctx, bson.M{"repository": repository}).Decode(&state)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+func saveSyncState(ctx context.Context, db *mongo.Collection, state *syncState) error {
+	_, err := db.ReplaceOne(
+		ctx,
+		bson.M{"repository": state.Repository},
+		state,
+		options.Replace().SetUpsert(true),
+	)
+
+	return err
+}
+
+// applies reports whether the changes since the synced commit can be
+// applied incrementally, i.e. the same branch and folder are synced.
+func (s *syncState) applies(repo repoConfig) bool {
+	return s != nil && s.Commit != "" && s.Branch == repo.Branch && s.Folder == repo.Folder
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	webhookPath        = "/webhook"
+	statusPath         = "/status"
+	maxWebhookBodySize = 10 << 20
+)
+
+// pushEvent contains the fields of GitLab, GitHub and Gitea push
+// webhook payloads, which identify the pushed repository and branch.
+type pushEvent struct {
+	Ref string `json:"ref"`
+
+	// repository of GitHub and Gitea push events
+	Repository struct {
+		CloneURL string `json:"clone_url"`
+		SSHURL   string `json:"ssh_url"`
+		HTMLURL  string `json:"html_url"`
+	} `json:"repository"`
+
+	// project of GitLab push events
+	Project struct {
+		GitHTTPURL string `json:"git_http_url"`
+		GitSSHURL  string `json:"git_ssh_url"`
+		WebURL     string `json:"web_url"`
+	} `json:"project"`
+}
+
+// webhookHandler accepts push webhooks from GitLab, GitHub and Gitea
+// and triggers a sync of the repositories to which the push is made,
+// if the push is to the synced branch.
+type webhookHandler struct {
+	secret string
+	repos  []*repoSyncer
+}
+
+// listenWebhooks starts an HTTP server receiving push webhooks on the given
+// address. The server also exposes the sync status of the repositories to
+// requests authenticated with the webhook secret.
+func listenWebhooks(cfg *Config, repos []*repoSyncer) {
+	mux := http.NewServeMux()
+	mux.Handle(webhookPath, &webhookHandler{
+		secret: cfg.Webhook.Secret,
+		repos:  repos,
+	})
+	mux.HandleFunc(statusPath, statusHandler(cfg.Webhook.Secret, repos))
+
+	srv := &http.Server{
+		Addr:              cfg.Webhook.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+	}
+
+	log.Printf("Listening for push webhooks on %s%s\n", cfg.Webhook.Addr, webhookPath)
+	if err := srv.ListenAndServe(); err != nil {
+		log.Fatalln("webhook listener stopped: ", err)
+	}
+}
+
+func (h *webhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		http.Error(w, "error reading request body", http.StatusBadRequest)
+		return
+	}
+
+	event, ok := h.verify(r.Header, body)
+	if !ok {
+		log.Println("Webhook request with invalid secret or signature is rejected.")
+		http.Error(w, "invalid webhook secret or signature", http.StatusUnauthorized)
+		return
+	}
+
+	if !isPushEvent(event) {
+		// e.g. the ping event sent when the webhook is created
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var push pushEvent
+	if err := json.Unmarshal(body, &push); err != nil {
+		http.Error(w, "invalid push event payload", http.StatusBadRequest)
+		return
+	}
+
+	var triggered bool
+	for _, repo := range h.repos {
+		if !push.matches(repo, len(h.repos) == 1) {
+			continue
+		}
+
+		// a sync which is already pending will also include the pushed changes
+		repo.triggerSync()
+		repo.logger.Printf("Push to %s triggered a sync.\n", push.Ref)
+		triggered = true
+	}
+
+	if !triggered {
+		log.Printf("Push to %s is ignored.\n", push.Ref)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// matches reports whether the push is made to the synced branch of
+// the repository. The URL of the repository is not compared if it's
+// the only synced repository, as it may be cloned from another address.
+func (e *pushEvent) matches(repo *repoSyncer, single bool) bool {
+	if repo.repo.Branch != "" && e.Ref != "refs/heads/"+repo.repo.Branch {
+		return false
+	}
+
+	if single {
+		return true
+	}
+
+	for _, url := range []string{
+		e.Repository.CloneURL,
+		e.Repository.SSHURL,
+		e.Repository.HTMLURL,
+		e.Project.GitHTTPURL,
+		e.Project.GitSSHURL,
+		e.Project.WebURL,
+	} {
+		if url != "" && normalizeURL(url) == normalizeURL(repo.repo.URL) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeURL(url string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git"))
+}
+
+// statusHandler returns the sync status of the repositories. As the status
+// contains the repository URLs and sync errors, the webhook secret must be
+// sent as a bearer token in the Authorization header.
+func statusHandler(secret string, repos []*repoSyncer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+			http.Error(w, "invalid webhook secret", http.StatusUnauthorized)
+			return
+		}
+
+		statuses := make([]repoStatus, 0, len(repos))
+		for _, repo := range repos {
+			statuses = append(statuses, repo.getStatus())
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(statuses); err != nil {
+			log.Println("error encoding sync status: ", err)
+		}
+	}
+}
+
+// verify checks the shared secret or signature of a webhook request
+// and returns the event name sent by the Git server.
+//
+// GitLab sends the secret token in the X-Gitlab-Token header, while
+// Gitea and GitHub send an HMAC-SHA256 signature of the request body
+// in the X-Gitea-Signature and X-Hub-Signature-256 headers.
+func (h *webhookHandler) verify(header http.Header, body []byte) (string, bool) {
+	if token := header.Get("X-Gitlab-Token"); token != "" {
+		ok := subtle.ConstantTimeCompare([]byte(token), []byte(h.secret)) == 1
+		return header.Get("X-Gitlab-Event"), ok
+	}
+
+	if signature := header.Get("X-Gitea-Signature"); signature != "" {
+		return header.Get("X-Gitea-Event"), h.validSignature(signature, body)
+	}
+
+	if signature := header.Get("X-Hub-Signature-256"); signature != "" {
+		signature, found := strings.CutPrefix(signature, "sha256=")
+		return header.Get("X-GitHub-Event"), found && h.validSignature(signature, body)
+	}
+
+	return "", false
+}
+
+// validSignature checks a hex encoded HMAC-SHA256 signature of the body.
+func (h *webhookHandler) validSignature(signature string, body []byte) bool {
+	sig, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(h.secret))
+	mac.Write(body)
+
+	return hmac.Equal(sig, mac.Sum(nil))
+}
+
+func isPushEvent(event string) bool {
+	return event == "push" || event == "Push Hook"
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "s3cr3t"
+
+func testSyncer(url, branch string) *repoSyncer {
+	return &repoSyncer{
+		repo:    repoConfig{URL: url, Branch: branch},
+		logger:  log.New(io.Discard, "", 0),
+		trigger: make(chan struct{}, 1),
+		status:  repoStatus{Repository: "policies", URL: url, Branch: branch},
+	}
+}
+
+func sign(body string) string {
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestWebhookHandler(t *testing.T) {
+	gitlabPush := `{"ref":"refs/heads/main","project":{"git_http_url":"https://gitlab.example.com/group/policies.git"}}`
+	githubPush := `{"ref":"refs/heads/main","repository":{"clone_url":"https://github.com/example/policies.git"}}`
+	giteaPush := `{"ref":"refs/heads/main","repository":{"ssh_url":"git@gitea.example.com:example/rules.git"}}`
+
+	tests := []struct {
+		name    string
+		method  string
+		header  map[string]string
+		body    string
+		repos   []*repoSyncer
+		status  int
+		trigger []bool
+	}{
+		{
+			name:    "gitlab push with valid token",
+			header:  map[string]string{"X-Gitlab-Token": testSecret, "X-Gitlab-Event": "Push Hook"},
+			body:    gitlabPush,
+			repos:   []*repoSyncer{testSyncer("https://gitlab.example.com/group/policies", "main")},
+			status:  http.StatusAccepted,
+			trigger: []bool{true},
+		},
+		{
+			name:    "gitlab push with invalid token",
+			header:  map[string]string{"X-Gitlab-Token": "wrong", "X-Gitlab-Event": "Push Hook"},
+			body:    gitlabPush,
+			repos:   []*repoSyncer{testSyncer("https://gitlab.example.com/group/policies", "main")},
+			status:  http.StatusUnauthorized,
+			trigger: []bool{false},
+		},
+		{
+			name:    "github push with valid signature",
+			header:  map[string]string{"X-Hub-Signature-256": "sha256=" + sign(githubPush), "X-GitHub-Event": "push"},
+			body:    githubPush,
+			repos:   []*repoSyncer{testSyncer("https://github.com/example/policies.git", "")},
+			status:  http.StatusAccepted,
+			trigger: []bool{true},
+		},
+		{
+			name:    "github push with invalid signature",
+			header:  map[string]string{"X-Hub-Signature-256": "sha256=" + sign("another body"), "X-GitHub-Event": "push"},
+			body:    githubPush,
+			repos:   []*repoSyncer{testSyncer("https://github.com/example/policies.git", "")},
+			status:  http.StatusUnauthorized,
+			trigger: []bool{false},
+		},
+		{
+			name:    "github push with signature without algorithm",
+			header:  map[string]string{"X-Hub-Signature-256": sign(githubPush), "X-GitHub-Event": "push"},
+			body:    githubPush,
+			repos:   []*repoSyncer{testSyncer("https://github.com/example/policies.git", "")},
+			status:  http.StatusUnauthorized,
+			trigger: []bool{false},
+		},
+		{
+			name:    "gitea push with valid signature triggers the pushed repository",
+			header:  map[string]string{"X-Gitea-Signature": sign(giteaPush), "X-Gitea-Event": "push"},
+			body:    giteaPush,
+			repos:   []*repoSyncer{testSyncer("https://gitea.example.com/example/policies", "main"), testSyncer("git@gitea.example.com:example/rules.git", "main")},
+			status:  http.StatusAccepted,
+			trigger: []bool{false, true},
+		},
+		{
+			name:    "gitea push with invalid signature",
+			header:  map[string]string{"X-Gitea-Signature": "not hex", "X-Gitea-Event": "push"},
+			body:    giteaPush,
+			repos:   []*repoSyncer{testSyncer("git@gitea.example.com:example/rules.git", "main")},
+			status:  http.StatusUnauthorized,
+			trigger: []bool{false},
+		},
+		{
+			name:    "push without secret or signature",
+			header:  map[string]string{"X-GitHub-Event": "push"},
+			body:    githubPush,
+			repos:   []*repoSyncer{testSyncer("https://github.com/example/policies.git", "")},
+			status:  http.StatusUnauthorized,
+			trigger: []bool{false},
+		},
+		{
+			name:    "push to another branch is ignored",
+			header:  map[string]string{"X-Gitlab-Token": testSecret, "X-Gitlab-Event": "Push Hook"},
+			body:    gitlabPush,
+			repos:   []*repoSyncer{testSyncer("https://gitlab.example.com/group/policies", "release")},
+			status:  http.StatusNoContent,
+			trigger: []bool{false},
+		},
+		{
+			name:    "push to another repository is ignored",
+			header:  map[string]string{"X-Hub-Signature-256": "sha256=" + sign(githubPush), "X-GitHub-Event": "push"},
+			body:    githubPush,
+			repos:   []*repoSyncer{testSyncer("https://github.com/example/rules.git", ""), testSyncer("https://github.com/example/other.git", "")},
+			status:  http.StatusNoContent,
+			trigger: []bool{false, false},
+		},
+		{
+			name:    "ping event does not trigger a sync",
+			header:  map[string]string{"X-Hub-Signature-256": "sha256=" + sign(`{"zen":"ping"}`), "X-GitHub-Event": "ping"},
+			body:    `{"zen":"ping"}`,
+			repos:   []*repoSyncer{testSyncer("https://github.com/example/policies.git", "")},
+			status:  http.StatusNoContent,
+			trigger: []bool{false},
+		},
+		{
+			name:    "invalid push payload",
+			header:  map[string]string{"X-Gitlab-Token": testSecret, "X-Gitlab-Event": "Push Hook"},
+			body:    `{"ref":`,
+			repos:   []*repoSyncer{testSyncer("https://gitlab.example.com/group/policies", "main")},
+			status:  http.StatusBadRequest,
+			trigger: []bool{false},
+		},
+		{
+			name:    "get request is not allowed",
+			method:  http.MethodGet,
+			header:  map[string]string{"X-Gitlab-Token": testSecret, "X-Gitlab-Event": "Push Hook"},
+			repos:   []*repoSyncer{testSyncer("https://gitlab.example.com/group/policies", "main")},
+			status:  http.StatusMethodNotAllowed,
+			trigger: []bool{false},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			method := test.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			req := httptest.NewRequest(method, webhookPath, strings.NewReader(test.body))
+			for k, v := range test.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+
+			h := &webhookHandler{secret: testSecret, repos: test.repos}
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, test.status, rec.Code)
+			for i, repo := range test.repos {
+				assert.Equal(t, test.trigger[i], len(repo.trigger) == 1, "trigger of repository %d", i)
+			}
+		})
+	}
+}
+
+func TestStatusHandler(t *testing.T) {
+	repo := testSyncer("https://github.com/example/policies.git", "main")
+	repo.setStatus("", "", errors.New("error cloning repository"))
+
+	tests := []struct {
+		name   string
+		method string
+		auth   string
+		status int
+	}{
+		{name: "valid secret", method: http.MethodGet, auth: "Bearer " + testSecret, status: http.StatusOK},
+		{name: "invalid secret", method: http.MethodGet, auth: "Bearer wrong", status: http.StatusUnauthorized},
+		{name: "secret without bearer scheme", method: http.MethodGet, auth: testSecret, status: http.StatusUnauthorized},
+		{name: "missing secret", method: http.MethodGet, status: http.StatusUnauthorized},
+		{name: "post request is not allowed", method: http.MethodPost, auth: "Bearer " + testSecret, status: http.StatusMethodNotAllowed},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(test.method, statusPath, nil)
+			if test.auth != "" {
+				req.Header.Set("Authorization", test.auth)
+			}
+			rec := httptest.NewRecorder()
+
+			statusHandler(testSecret, []*repoSyncer{repo})(rec, req)
+
+			require.Equal(t, test.status, rec.Code)
+			if test.status != http.StatusOK {
+				assert.NotContains(t, rec.Body.String(), repo.repo.URL)
+				return
+			}
+
+			var statuses []repoStatus
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &statuses))
+			require.Len(t, statuses, 1)
+			assert.Equal(t, "https://github.com/example/policies.git", statuses[0].URL)
+			assert.Equal(t, "error cloning repository", statuses[0].Error)
+			assert.Equal(t, 1, statuses[0].Failures)
+		})
+	}
+}
@@ -84,12 +84,105 @@ var _ = Service("policy", func() {
 		})
 	})
 
+	Method("BulkLock", func() {
+		Description("Lock all unlocked policies matching the request, so that they cannot be evaluated.")
+		Payload(BulkLockRequest)
+		Result(BulkLockResult)
+		HTTP(func() {
+			POST("/v1/policies/lock")
+			Response(StatusOK)
+		})
+	})
+
+	Method("BulkUnlock", func() {
+		Description("Unlock all locked policies matching the request, so they can be evaluated again.")
+		Payload(BulkUnlockRequest)
+		Result(BulkLockResult)
+		HTTP(func() {
+			POST("/v1/policies/unlock")
+			Response(StatusOK)
+		})
+	})
+
+	Method("CreatePolicy", func() {
+		Description("Create a new policy in storage.")
+		Payload(PolicyRequest)
+		Result(Any)
+		HTTP(func() {
+			POST("/policy/{repository}/{group}/{policyName}/{version}")
+			Response(StatusOK)
+		})
+	})
+
+	Method("UpdatePolicy", func() {
+		Description("Update the source code, data and configuration of an existing policy.")
+		Payload(PolicyRequest)
+		Result(Any)
+		HTTP(func() {
+			PUT("/policy/{repository}/{group}/{policyName}/{version}")
+			Response(StatusOK)
+		})
+	})
+
+	Method("DeletePolicy", func() {
+		Description("Delete a policy from storage.")
+		Payload(DeletePolicyRequest)
+		Result(Empty)
+		HTTP(func() {
+			DELETE("/policy/{repository}/{group}/{policyName}/{version}")
+			Response(StatusOK)
+		})
+	})
+
+	Method("PolicyRevisions", func() {
+		Description("List the revisions of a policy.")
+		Payload(PolicyRevisionsRequest)
+		Result(PolicyRevisionsResult)
+		HTTP(func() {
+			GET("/policy/{repository}/{group}/{policyName}/{version}/revisions")
+			Response(StatusOK)
+		})
+	})
+
+	Method("PolicyRevision", func() {
+		Description("Show the source code, data and configuration of a policy revision.")
+		Payload(PolicyRevisionRequest)
+		Result(PolicyRevisionResult)
+		HTTP(func() {
+			GET("/policy/{repository}/{group}/{policyName}/{version}/revisions/{revision}")
+			Response(StatusOK)
+		})
+	})
+
+	Method("DiffPolicyRevisions", func() {
+		Description("Show the differences between two revisions of a policy.")
+		Payload(DiffPolicyRevisionsRequest)
+		Result(DiffPolicyRevisionsResult)
+		HTTP(func() {
+			GET("/policy/{repository}/{group}/{policyName}/{version}/revisions/diff")
+			Param("from", Int, "Revision to compare from.")
+			Param("to", Int, "Revision to compare to.")
+			Response(StatusOK)
+		})
+	})
+
+	Method("RollbackPolicy", func() {
+		Description("Restore a policy revision as the current policy and notify subscribers.")
+		Payload(RollbackPolicyRequest)
+		Result(PolicyRevisionResult)
+		HTTP(func() {
+			POST("/policy/{repository}/{group}/{policyName}/{version}/revisions/{revision}/rollback")
+			Response(StatusOK)
+		})
+	})
+
 	Method("ExportBundle", func() {
 		Description("Export a signed policy bundle.")
 		Payload(ExportBundleRequest)
 		Result(ExportBundleResult)
 		HTTP(func() {
 			GET("/policy/{repository}/{group}/{policyName}/{version}/export")
+			Param("target", String, "Export target format: 'rego' (default) or 'wasm' (optional).")
 
 			// bypass response body encoder code generation, so that
 			// a zip bytes buffer (io.ReadCloser) can be returned to the client
@@ -105,6 +198,23 @@ var _ = Service("policy", func() {
 		})
 	})
 
+	Method("ExportWasmBundle", func() {
+		Description("Export a signed policy bundle with the policy compiled to WebAssembly.")
+		Payload(ExportWasmBundleRequest)
+		Result(ExportBundleResult)
+		HTTP(func() {
+			GET("/policy/{repository}/{group}/{policyName}/{version}/export/wasm")
+
+			SkipResponseBodyEncodeDecode()
+
+			Response(StatusOK, func() {
+				Header("content-type")
+				Header("content-length")
+				Header("content-disposition")
+			})
+		})
+	})
+
 	Method("PolicyPublicKey", func() {
 		Description("PolicyPublicKey returns the public key in JWK format which must be used to verify a signed policy bundle.")
 		Payload(PolicyPublicKeyRequest)
@@ -145,11 +255,44 @@ var _ = Service("policy", func() {
 				Param("rego", Boolean, "Include policy source code in results (optional).")
 				Param("data", Boolean, "Include policy static data in results (optional). ")
 				Param("dataConfig", Boolean, "Include static data config (optional).")
+				Param("repository", String, "Filter to return policies of a repository (optional).")
+				Param("group", String, "Filter to return policies of a group (optional).")
+				Param("version", String, "Filter to return policies with a version (optional).")
+				Param("updatedSince", String, "Filter to return policies updated at or after the given time in RFC 3339 format (optional).")
+				Param("sort", String, "Sort policies by name or lastUpdate (optional).")
+				Param("order", String, "Sort order asc or desc (optional).")
+				Param("cursor", String, "Cursor returned as nextCursor with the previous page of policies (optional).")
+				Param("limit", Int, "Maximum number of returned policies, all policies are returned if it's not set (optional).")
+				Param("annotation", ArrayOf(String), "Filter to return policies with custom METADATA annotation values, e.g. custom.domain=gaia-x (optional).")
+			})
+			Response(StatusOK)
+		})
+	})
+
+	Method("PolicyDependencies", func() {
+		Description("Report the builtin functions, imported packages and data paths used by policies.")
+		Payload(PolicyDependenciesRequest)
+		Result(PolicyDependenciesResult)
+		HTTP(func() {
+			GET("/v1/policies/dependencies")
+			Params(func() {
+				Param("builtin", String, "Return only policies calling the builtin function, e.g. did.resolve or ocm.* (optional).")
+				Param("repository", String, "Filter to return policies of a repository (optional).")
 			})
 			Response(StatusOK)
 		})
 	})
 
+	Method("GetPolicy", func() {
+		Description("Get the source code, data, configuration and METADATA annotations of a policy.")
+		Payload(GetPolicyRequest)
+		Result(Policy)
+		HTTP(func() {
+			GET("/v1/policies/{repository}/{group}/{policyName}/{version}")
+			Response(StatusOK)
+		})
+	})
+
 	Method("SetPolicyAutoImport", func() {
 		Description("SetPolicyAutoImport enables automatic import of policy bundle on a given time interval.")
 		Payload(SetPolicyAutoImportRequest)
@@ -189,6 +332,16 @@ var _ = Service("policy", func() {
 			Response(StatusOK)
 		})
 	})
+
+	Method("SubscriberDeliveries", func() {
+		Description("List the webhook deliveries to a policy change subscriber.")
+		Payload(SubscriberDeliveriesRequest)
+		Result(SubscriberDeliveriesResult)
+		HTTP(func() {
+			GET("/policy/{repository}/{group}/{policyName}/{version}/notifychange/{subscriber}/deliveries")
+			Response(StatusOK)
+		})
+	})
 })
 
 var _ = Service("health", func() {
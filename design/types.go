@@ -35,6 +35,12 @@ var LockRequest = Type("LockRequest", func() {
 	Field(2, "group", String, "Policy group.")
 	Field(3, "policyName", String, "Policy name.")
 	Field(4, "version", String, "Policy version.")
+	Field(5, "reason", String, "Reason for locking the policy.", func() {
+		Example("incident 42: unexpected evaluation results")
+	})
+	Field(6, "expiresAt", String, "Time in RFC 3339 format when the policy is unlocked automatically (optional).", func() {
+		Format(FormatDateTime)
+	})
 	Required("repository", "group", "policyName", "version")
 })
 
@@ -46,7 +52,170 @@ var UnlockRequest = Type("UnlockRequest", func() {
 	Required("repository", "group", "policyName", "version")
 })
 
+var BulkLockRequest = Type("BulkLockRequest", func() {
+	Field(1, "repository", String, "Lock policies of a repository.")
+	Field(2, "group", String, "Lock policies of a group.")
+	Field(3, "policyName", String, "Lock policies with names matching a shell pattern, e.g. did*.")
+	Field(4, "version", String, "Lock policies with a version.")
+	Field(5, "policies", ArrayOf(PolicyRef), "Lock only the listed policies.")
+	Field(6, "reason", String, "Reason for locking the policies.", func() {
+		Example("compromised trust anchor")
+	})
+	Field(7, "expiresAt", String, "Time in RFC 3339 format when the policies are unlocked automatically (optional).", func() {
+		Format(FormatDateTime)
+	})
+})
+
+var BulkUnlockRequest = Type("BulkUnlockRequest", func() {
+	Field(1, "repository", String, "Unlock policies of a repository.")
+	Field(2, "group", String, "Unlock policies of a group.")
+	Field(3, "policyName", String, "Unlock policies with names matching a shell pattern, e.g. did*.")
+	Field(4, "version", String, "Unlock policies with a version.")
+	Field(5, "policies", ArrayOf(PolicyRef), "Unlock only the listed policies.")
+})
+
+var BulkLockResult = Type("BulkLockResult", func() {
+	Field(1, "policies", ArrayOf(PolicyRef), "Locked or unlocked policies.")
+	Required("policies")
+})
+
+var PolicyRef = Type("PolicyRef", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Field(4, "version", String, "Policy version.")
+	Required("repository", "group", "policyName", "version")
+})
+
+var PolicyRequest = Type("PolicyRequest", func() {
+	Field(1, "repository", String, "Policy repository.", func() {
+		Example("policies")
+	})
+	Field(2, "group", String, "Policy group.", func() {
+		Example("example")
+	})
+	Field(3, "policyName", String, "Policy name.", func() {
+		Example("example")
+	})
+	Field(4, "version", String, "Policy version.", func() {
+		Example("1.0")
+	})
+	Field(5, "rego", String, "Policy rego source code. The package declaration must be 'group.policyName'.", func() {
+		MinLength(1)
+		Example("package example.example\n\nallow := true")
+	})
+	Field(6, "data", String, "Policy static data as JSON object (optional).")
+	Field(7, "dataConfig", String, "Policy static data configuration as JSON (optional).")
+	Field(8, "outputSchema", String, "JSON schema for validation of the policy output (optional).")
+	Field(9, "exportConfig", String, "Policy bundle export configuration as JSON (optional).")
+	Required("repository", "group", "policyName", "version", "rego")
+})
+
+var DeletePolicyRequest = Type("DeletePolicyRequest", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Field(4, "version", String, "Policy version.")
+	Required("repository", "group", "policyName", "version")
+})
+
+var PolicyRevisionsRequest = Type("PolicyRevisionsRequest", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Field(4, "version", String, "Policy version.")
+	Required("repository", "group", "policyName", "version")
+})
+
+var PolicyRevisionRequest = Type("PolicyRevisionRequest", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Field(4, "version", String, "Policy version.")
+	Field(5, "revision", Int, "Policy revision number.", func() {
+		Minimum(1)
+	})
+	Required("repository", "group", "policyName", "version", "revision")
+})
+
+var DiffPolicyRevisionsRequest = Type("DiffPolicyRevisionsRequest", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Field(4, "version", String, "Policy version.")
+	Field(5, "from", Int, "Revision to compare from.", func() {
+		Minimum(1)
+	})
+	Field(6, "to", Int, "Revision to compare to.", func() {
+		Minimum(1)
+	})
+	Required("repository", "group", "policyName", "version", "from", "to")
+})
+
+var RollbackPolicyRequest = Type("RollbackPolicyRequest", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Field(4, "version", String, "Policy version.")
+	Field(5, "revision", Int, "Policy revision to restore.", func() {
+		Minimum(1)
+	})
+	Required("repository", "group", "policyName", "version", "revision")
+})
+
+var PolicyRevisionResult = Type("PolicyRevisionResult", func() {
+	Field(1, "revision", Int, "Revision number.")
+	Field(2, "hash", String, "SHA-256 hash of the policy source code, data and configuration.")
+	Field(3, "source", String, "Source of the revision: sync, import, api, data refresh or rollback.")
+	Field(4, "commit", String, "Git commit of the revision, if known.")
+	Field(5, "createdAt", Int64, "Creation time of the revision (Unix timestamp).")
+	Field(6, "rego", String, "Policy rego source code.")
+	Field(7, "data", String, "Policy static data.")
+	Field(8, "dataConfig", String, "Policy static data optional configuration.")
+	Field(9, "outputSchema", String, "JSON schema for validation of the policy output.")
+	Field(10, "exportConfig", String, "Policy bundle export configuration.")
+	Required("revision", "hash", "source", "createdAt")
+})
+
+var PolicyRevisionsResult = Type("PolicyRevisionsResult", func() {
+	Field(1, "revisions", ArrayOf(PolicyRevisionResult), "JSON array of policy revisions without their content.")
+	Required("revisions")
+})
+
+var PolicyRevisionDiff = Type("PolicyRevisionDiff", func() {
+	Field(1, "field", String, "Changed policy field: rego, data, dataConfig, outputSchema or exportConfig.")
+	Field(2, "diff", String, "Unified diff of the field.")
+	Required("field", "diff")
+})
+
+var DiffPolicyRevisionsResult = Type("DiffPolicyRevisionsResult", func() {
+	Field(1, "from", Int, "Revision compared from.")
+	Field(2, "to", Int, "Revision compared to.")
+	Field(3, "changes", ArrayOf(PolicyRevisionDiff), "Differences of the changed policy fields.")
+	Required("from", "to", "changes")
+})
+
 var ExportBundleRequest = Type("ExportBundleRequest", func() {
+	Field(1, "repository", String, "Policy repository.", func() {
+		Example("policies")
+	})
+	Field(2, "group", String, "Policy group.", func() {
+		Example("example")
+	})
+	Field(3, "policyName", String, "Policy name.", func() {
+		Example("returnDID")
+	})
+	Field(4, "version", String, "Policy version.", func() {
+		Example("1.0")
+	})
+	Field(5, "target", String, "Export target format.", func() {
+		Enum("rego", "wasm")
+		Default("rego")
+	})
+	Required("repository", "group", "policyName", "version")
+})
+
+var ExportWasmBundleRequest = Type("ExportWasmBundleRequest", func() {
 	Field(1, "repository", String, "Policy repository.", func() {
 		Example("policies")
 	})
@@ -95,19 +264,104 @@ var Policy = Type("Policy", func() {
 	Field(7, "dataConfig", String, "Policy static data optional configuration.")
 	Field(8, "locked", Boolean, "Locked specifies if the policy is locked or allowed to execute.")
 	Field(9, "lastUpdate", Int64, "Last update (Unix timestamp).")
+	Field(10, "archived", Boolean, "Archived specifies if the policy is removed from its repository and cannot be evaluated.")
+	Field(11, "commit", PolicyCommit, "Git commit from which the policy is synchronized.")
+	Field(12, "outputSchema", String, "Policy output JSON schema.")
+	Field(13, "exportConfig", String, "Policy export configuration.")
+	Field(14, "metadata", PolicyMetadata, "METADATA annotations of the policy package.")
+	Field(15, "lock", PolicyLock, "Details of the policy lock.")
 	Required("repository", "group", "policyName", "version", "locked", "lastUpdate")
 })
 
+var PolicyLock = Type("PolicyLock", func() {
+	Field(1, "reason", String, "Reason for locking the policy.")
+	Field(2, "lockedBy", String, "Authenticated caller who locked the policy.")
+	Field(3, "lockedAt", Int64, "Lock time (Unix timestamp).")
+	Field(4, "expiresAt", Int64, "Time when the policy is unlocked automatically (Unix timestamp).")
+	Required("lockedAt")
+})
+
+var PolicyMetadata = Type("PolicyMetadata", func() {
+	Field(1, "title", String, "Policy title.")
+	Field(2, "description", String, "Policy description.")
+	Field(3, "authors", ArrayOf(String), "Policy authors.")
+	Field(4, "organizations", ArrayOf(String), "Organizations of the policy authors.")
+	Field(5, "relatedResources", ArrayOf(String), "URLs of resources related to the policy.")
+	Field(6, "custom", MapOf(String, Any), "Custom annotations.")
+})
+
+var PolicyDependenciesRequest = Type("PolicyDependenciesRequest", func() {
+	Field(1, "builtin", String, func() { Example("did.resolve") })
+	Field(2, "repository", String, func() { Example("policies") })
+})
+
+var PolicyDependenciesResult = Type("PolicyDependenciesResult", func() {
+	Field(1, "policies", ArrayOf(DependencyReport), "Dependencies of the policies.")
+	Required("policies")
+})
+
+var DependencyReport = Type("DependencyReport", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Field(4, "version", String, "Policy version.")
+	Field(5, "builtins", ArrayOf(String), "Builtin and extension functions called by the policy.")
+	Field(6, "imports", ArrayOf(String), "Packages imported by the policy.")
+	Field(7, "dataPaths", ArrayOf(String), "Data paths referenced by the policy.")
+	Field(8, "error", String, "Error parsing the policy source code.")
+	Required("repository", "group", "policyName", "version", "builtins", "imports", "dataPaths")
+})
+
+var GetPolicyRequest = Type("GetPolicyRequest", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Field(4, "version", String, "Policy version.")
+	Required("repository", "group", "policyName", "version")
+})
+
+var PolicyCommit = Type("PolicyCommit", func() {
+	Field(1, "sha", String, "Commit SHA.")
+	Field(2, "time", Int64, "Commit time (Unix timestamp).")
+	Field(3, "author", String, "Commit author.")
+	Field(4, "branch", String, "Git branch from which the commit is synchronized.")
+	Required("sha", "time", "author")
+})
+
 var PoliciesRequest = Type("PoliciesRequest", func() {
 	Field(1, "locked", Boolean)
 	Field(2, "policyName", String, func() { Example("example") })
 	Field(3, "rego", Boolean)
 	Field(4, "data", Boolean)
 	Field(5, "dataConfig", Boolean)
+	Field(6, "repository", String, func() { Example("policies") })
+	Field(7, "group", String, func() { Example("example") })
+	Field(8, "version", String, func() { Example("1.0") })
+	Field(9, "updatedSince", String, func() {
+		Format(FormatDateTime)
+		Example("2024-01-02T15:04:05Z")
+	})
+	Field(10, "sort", String, func() {
+		Enum("name", "lastUpdate")
+		Default("name")
+	})
+	Field(11, "order", String, func() {
+		Enum("asc", "desc")
+		Default("asc")
+	})
+	Field(12, "cursor", String)
+	Field(13, "limit", Int, func() {
+		Minimum(1)
+		Maximum(1000)
+	})
+	Field(14, "annotation", ArrayOf(String), func() {
+		Example([]string{"custom.domain=gaia-x"})
+	})
 })
 
 var PoliciesResult = Type("PoliciesResult", func() {
 	Field(1, "policies", ArrayOf(Policy), "JSON array of policies.")
+	Field(2, "nextCursor", String, "Cursor for the next page of policies, which is missing on the last page.")
 	Required("policies")
 })
 
@@ -126,6 +380,32 @@ var SubscribeRequest = Type("SubscribeRequest", func() {
 	Required("webhook_url", "subscriber", "repository", "policyName", "group", "version")
 })
 
+var SubscriberDeliveriesRequest = Type("SubscriberDeliveriesRequest", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Field(4, "version", String, "Policy version.")
+	Field(5, "subscriber", String, "Name of the subscriber for policy.")
+	Required("repository", "group", "policyName", "version", "subscriber")
+})
+
+var WebhookDelivery = Type("WebhookDelivery", func() {
+	Field(1, "id", String, "Delivery ID, which is sent in the X-Policy-Delivery-Id header.")
+	Field(2, "event", Any, "Policy change event sent to the webhook.")
+	Field(3, "state", String, "Delivery state: pending, delivered or dead.")
+	Field(4, "attempts", Int, "Number of delivery attempts.")
+	Field(5, "lastError", String, "Error of the last failed attempt.")
+	Field(6, "nextAttempt", Int64, "Time of the next attempt of a pending delivery (Unix timestamp).")
+	Field(7, "createdAt", Int64, "Creation time of the delivery (Unix timestamp).")
+	Field(8, "updatedAt", Int64, "Time of the last delivery update (Unix timestamp).")
+	Required("id", "event", "state", "attempts", "createdAt", "updatedAt")
+})
+
+var SubscriberDeliveriesResult = Type("SubscriberDeliveriesResult", func() {
+	Field(1, "deliveries", ArrayOf(WebhookDelivery), "JSON array of webhook deliveries, oldest first.")
+	Required("deliveries")
+})
+
 var SetPolicyAutoImportRequest = Type("SetPolicyAutoImportRequest", func() {
 	Field(1, "policyURL", String, "PolicyURL defines the address from where a policy bundle will be taken.", func() {
 		Format(FormatURI)
@@ -23,14 +23,14 @@ import (
 //
 //	command (subcommand1|subcommand2|...)
 func UsageCommands() string {
-	return `policy (evaluate|validate|lock|unlock|export-bundle|policy-public-key|import-bundle|list-policies|set-policy-auto-import|policy-auto-import|delete-policy-auto-import|subscribe-for-policy-change)
+	return `policy (evaluate|validate|lock|unlock|bulk-lock|bulk-unlock|create-policy|update-policy|delete-policy|policy-revisions|policy-revision|diff-policy-revisions|rollback-policy|export-bundle|export-wasm-bundle|policy-public-key|import-bundle|list-policies|policy-dependencies|get-policy|set-policy-auto-import|policy-auto-import|delete-policy-auto-import|subscribe-for-policy-change|subscriber-deliveries)
 health (liveness|readiness)
 `
 }
 
 // UsageExamples produces an example of a valid invocation of the CLI tool.
 func UsageExamples() string {
-	return os.Args[0] + ` policy evaluate --body "Ipsa ad voluptatum maxime ut." --repository "policies" --group "example" --policy-name "example" --version "1.0" --evaluation-id "Et dolores." --ttl 2774440846631434065` + "\n" +
+	return os.Args[0] + ` policy evaluate --body "Perferendis omnis id distinctio perspiciatis." --repository "policies" --group "example" --policy-name "example" --version "1.0" --evaluation-id "Necessitatibus qui." --ttl 1525435340009316053` + "\n" +
 		os.Args[0] + ` health liveness` + "\n" +
 		""
 }
@@ -66,6 +66,7 @@ func ParseEndpoint(
 		policyValidateTTLFlag          = policyValidateFlags.String("ttl", "", "")
 
 		policyLockFlags          = flag.NewFlagSet("lock", flag.ExitOnError)
+		policyLockBodyFlag       = policyLockFlags.String("body", "REQUIRED", "")
 		policyLockRepositoryFlag = policyLockFlags.String("repository", "REQUIRED", "Policy repository.")
 		policyLockGroupFlag      = policyLockFlags.String("group", "REQUIRED", "Policy group.")
 		policyLockPolicyNameFlag = policyLockFlags.String("policy-name", "REQUIRED", "Policy name.")
@@ -77,11 +78,72 @@ func ParseEndpoint(
 		policyUnlockPolicyNameFlag = policyUnlockFlags.String("policy-name", "REQUIRED", "Policy name.")
 		policyUnlockVersionFlag    = policyUnlockFlags.String("version", "REQUIRED", "Policy version.")
 
+		policyBulkLockFlags    = flag.NewFlagSet("bulk-lock", flag.ExitOnError)
+		policyBulkLockBodyFlag = policyBulkLockFlags.String("body", "REQUIRED", "")
+
+		policyBulkUnlockFlags    = flag.NewFlagSet("bulk-unlock", flag.ExitOnError)
+		policyBulkUnlockBodyFlag = policyBulkUnlockFlags.String("body", "REQUIRED", "")
+
+		policyCreatePolicyFlags          = flag.NewFlagSet("create-policy", flag.ExitOnError)
+		policyCreatePolicyBodyFlag       = policyCreatePolicyFlags.String("body", "REQUIRED", "")
+		policyCreatePolicyRepositoryFlag = policyCreatePolicyFlags.String("repository", "REQUIRED", "Policy repository.")
+		policyCreatePolicyGroupFlag      = policyCreatePolicyFlags.String("group", "REQUIRED", "Policy group.")
+		policyCreatePolicyPolicyNameFlag = policyCreatePolicyFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policyCreatePolicyVersionFlag    = policyCreatePolicyFlags.String("version", "REQUIRED", "Policy version.")
+
+		policyUpdatePolicyFlags          = flag.NewFlagSet("update-policy", flag.ExitOnError)
+		policyUpdatePolicyBodyFlag       = policyUpdatePolicyFlags.String("body", "REQUIRED", "")
+		policyUpdatePolicyRepositoryFlag = policyUpdatePolicyFlags.String("repository", "REQUIRED", "Policy repository.")
+		policyUpdatePolicyGroupFlag      = policyUpdatePolicyFlags.String("group", "REQUIRED", "Policy group.")
+		policyUpdatePolicyPolicyNameFlag = policyUpdatePolicyFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policyUpdatePolicyVersionFlag    = policyUpdatePolicyFlags.String("version", "REQUIRED", "Policy version.")
+
+		policyDeletePolicyFlags          = flag.NewFlagSet("delete-policy", flag.ExitOnError)
+		policyDeletePolicyRepositoryFlag = policyDeletePolicyFlags.String("repository", "REQUIRED", "Policy repository.")
+		policyDeletePolicyGroupFlag      = policyDeletePolicyFlags.String("group", "REQUIRED", "Policy group.")
+		policyDeletePolicyPolicyNameFlag = policyDeletePolicyFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policyDeletePolicyVersionFlag    = policyDeletePolicyFlags.String("version", "REQUIRED", "Policy version.")
+
+		policyPolicyRevisionsFlags          = flag.NewFlagSet("policy-revisions", flag.ExitOnError)
+		policyPolicyRevisionsRepositoryFlag = policyPolicyRevisionsFlags.String("repository", "REQUIRED", "Policy repository.")
+		policyPolicyRevisionsGroupFlag      = policyPolicyRevisionsFlags.String("group", "REQUIRED", "Policy group.")
+		policyPolicyRevisionsPolicyNameFlag = policyPolicyRevisionsFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policyPolicyRevisionsVersionFlag    = policyPolicyRevisionsFlags.String("version", "REQUIRED", "Policy version.")
+
+		policyPolicyRevisionFlags          = flag.NewFlagSet("policy-revision", flag.ExitOnError)
+		policyPolicyRevisionRepositoryFlag = policyPolicyRevisionFlags.String("repository", "REQUIRED", "Policy repository.")
+		policyPolicyRevisionGroupFlag      = policyPolicyRevisionFlags.String("group", "REQUIRED", "Policy group.")
+		policyPolicyRevisionPolicyNameFlag = policyPolicyRevisionFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policyPolicyRevisionVersionFlag    = policyPolicyRevisionFlags.String("version", "REQUIRED", "Policy version.")
+		policyPolicyRevisionRevisionFlag   = policyPolicyRevisionFlags.String("revision", "REQUIRED", "Policy revision number.")
+
+		policyDiffPolicyRevisionsFlags          = flag.NewFlagSet("diff-policy-revisions", flag.ExitOnError)
+		policyDiffPolicyRevisionsRepositoryFlag = policyDiffPolicyRevisionsFlags.String("repository", "REQUIRED", "Policy repository.")
+		policyDiffPolicyRevisionsGroupFlag      = policyDiffPolicyRevisionsFlags.String("group", "REQUIRED", "Policy group.")
+		policyDiffPolicyRevisionsPolicyNameFlag = policyDiffPolicyRevisionsFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policyDiffPolicyRevisionsVersionFlag    = policyDiffPolicyRevisionsFlags.String("version", "REQUIRED", "Policy version.")
+		policyDiffPolicyRevisionsFromFlag       = policyDiffPolicyRevisionsFlags.String("from", "REQUIRED", "")
+		policyDiffPolicyRevisionsToFlag         = policyDiffPolicyRevisionsFlags.String("to", "REQUIRED", "")
+
+		policyRollbackPolicyFlags          = flag.NewFlagSet("rollback-policy", flag.ExitOnError)
+		policyRollbackPolicyRepositoryFlag = policyRollbackPolicyFlags.String("repository", "REQUIRED", "Policy repository.")
+		policyRollbackPolicyGroupFlag      = policyRollbackPolicyFlags.String("group", "REQUIRED", "Policy group.")
+		policyRollbackPolicyPolicyNameFlag = policyRollbackPolicyFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policyRollbackPolicyVersionFlag    = policyRollbackPolicyFlags.String("version", "REQUIRED", "Policy version.")
+		policyRollbackPolicyRevisionFlag   = policyRollbackPolicyFlags.String("revision", "REQUIRED", "Policy revision to restore.")
+
 		policyExportBundleFlags          = flag.NewFlagSet("export-bundle", flag.ExitOnError)
 		policyExportBundleRepositoryFlag = policyExportBundleFlags.String("repository", "REQUIRED", "Policy repository.")
 		policyExportBundleGroupFlag      = policyExportBundleFlags.String("group", "REQUIRED", "Policy group.")
 		policyExportBundlePolicyNameFlag = policyExportBundleFlags.String("policy-name", "REQUIRED", "Policy name.")
 		policyExportBundleVersionFlag    = policyExportBundleFlags.String("version", "REQUIRED", "Policy version.")
+		policyExportBundleTargetFlag     = policyExportBundleFlags.String("target", "rego", "")
+
+		policyExportWasmBundleFlags          = flag.NewFlagSet("export-wasm-bundle", flag.ExitOnError)
+		policyExportWasmBundleRepositoryFlag = policyExportWasmBundleFlags.String("repository", "REQUIRED", "Policy repository.")
+		policyExportWasmBundleGroupFlag      = policyExportWasmBundleFlags.String("group", "REQUIRED", "Policy group.")
+		policyExportWasmBundlePolicyNameFlag = policyExportWasmBundleFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policyExportWasmBundleVersionFlag    = policyExportWasmBundleFlags.String("version", "REQUIRED", "Policy version.")
 
 		policyPolicyPublicKeyFlags          = flag.NewFlagSet("policy-public-key", flag.ExitOnError)
 		policyPolicyPublicKeyRepositoryFlag = policyPolicyPublicKeyFlags.String("repository", "REQUIRED", "Policy repository.")
@@ -93,12 +155,31 @@ func ParseEndpoint(
 		policyImportBundleLengthFlag = policyImportBundleFlags.String("length", "", "")
 		policyImportBundleStreamFlag = policyImportBundleFlags.String("stream", "REQUIRED", "path to file containing the streamed request body")
 
-		policyListPoliciesFlags          = flag.NewFlagSet("list-policies", flag.ExitOnError)
-		policyListPoliciesLockedFlag     = policyListPoliciesFlags.String("locked", "", "")
-		policyListPoliciesPolicyNameFlag = policyListPoliciesFlags.String("policy-name", "", "")
-		policyListPoliciesRegoFlag       = policyListPoliciesFlags.String("rego", "", "")
-		policyListPoliciesDataFlag       = policyListPoliciesFlags.String("data", "", "")
-		policyListPoliciesDataConfigFlag = policyListPoliciesFlags.String("data-config", "", "")
+		policyListPoliciesFlags            = flag.NewFlagSet("list-policies", flag.ExitOnError)
+		policyListPoliciesLockedFlag       = policyListPoliciesFlags.String("locked", "", "")
+		policyListPoliciesPolicyNameFlag   = policyListPoliciesFlags.String("policy-name", "", "")
+		policyListPoliciesRegoFlag         = policyListPoliciesFlags.String("rego", "", "")
+		policyListPoliciesDataFlag         = policyListPoliciesFlags.String("data", "", "")
+		policyListPoliciesDataConfigFlag   = policyListPoliciesFlags.String("data-config", "", "")
+		policyListPoliciesRepositoryFlag   = policyListPoliciesFlags.String("repository", "", "")
+		policyListPoliciesGroupFlag        = policyListPoliciesFlags.String("group", "", "")
+		policyListPoliciesVersionFlag      = policyListPoliciesFlags.String("version", "", "")
+		policyListPoliciesUpdatedSinceFlag = policyListPoliciesFlags.String("updated-since", "", "")
+		policyListPoliciesSortFlag         = policyListPoliciesFlags.String("sort", "name", "")
+		policyListPoliciesOrderFlag        = policyListPoliciesFlags.String("order", "asc", "")
+		policyListPoliciesCursorFlag       = policyListPoliciesFlags.String("cursor", "", "")
+		policyListPoliciesLimitFlag        = policyListPoliciesFlags.String("limit", "", "")
+		policyListPoliciesAnnotationFlag   = policyListPoliciesFlags.String("annotation", "", "")
+
+		policyPolicyDependenciesFlags          = flag.NewFlagSet("policy-dependencies", flag.ExitOnError)
+		policyPolicyDependenciesBuiltinFlag    = policyPolicyDependenciesFlags.String("builtin", "", "")
+		policyPolicyDependenciesRepositoryFlag = policyPolicyDependenciesFlags.String("repository", "", "")
+
+		policyGetPolicyFlags          = flag.NewFlagSet("get-policy", flag.ExitOnError)
+		policyGetPolicyRepositoryFlag = policyGetPolicyFlags.String("repository", "REQUIRED", "Policy repository.")
+		policyGetPolicyGroupFlag      = policyGetPolicyFlags.String("group", "REQUIRED", "Policy group.")
+		policyGetPolicyPolicyNameFlag = policyGetPolicyFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policyGetPolicyVersionFlag    = policyGetPolicyFlags.String("version", "REQUIRED", "Policy version.")
 
 		policySetPolicyAutoImportFlags    = flag.NewFlagSet("set-policy-auto-import", flag.ExitOnError)
 		policySetPolicyAutoImportBodyFlag = policySetPolicyAutoImportFlags.String("body", "REQUIRED", "")
@@ -115,6 +196,13 @@ func ParseEndpoint(
 		policySubscribeForPolicyChangePolicyNameFlag = policySubscribeForPolicyChangeFlags.String("policy-name", "REQUIRED", "Policy name.")
 		policySubscribeForPolicyChangeVersionFlag    = policySubscribeForPolicyChangeFlags.String("version", "REQUIRED", "Policy version.")
 
+		policySubscriberDeliveriesFlags          = flag.NewFlagSet("subscriber-deliveries", flag.ExitOnError)
+		policySubscriberDeliveriesRepositoryFlag = policySubscriberDeliveriesFlags.String("repository", "REQUIRED", "Policy repository.")
+		policySubscriberDeliveriesGroupFlag      = policySubscriberDeliveriesFlags.String("group", "REQUIRED", "Policy group.")
+		policySubscriberDeliveriesPolicyNameFlag = policySubscriberDeliveriesFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policySubscriberDeliveriesVersionFlag    = policySubscriberDeliveriesFlags.String("version", "REQUIRED", "Policy version.")
+		policySubscriberDeliveriesSubscriberFlag = policySubscriberDeliveriesFlags.String("subscriber", "REQUIRED", "Name of the subscriber for policy.")
+
 		healthFlags = flag.NewFlagSet("health", flag.ContinueOnError)
 
 		healthLivenessFlags = flag.NewFlagSet("liveness", flag.ExitOnError)
@@ -126,14 +214,27 @@ func ParseEndpoint(
 	policyValidateFlags.Usage = policyValidateUsage
 	policyLockFlags.Usage = policyLockUsage
 	policyUnlockFlags.Usage = policyUnlockUsage
+	policyBulkLockFlags.Usage = policyBulkLockUsage
+	policyBulkUnlockFlags.Usage = policyBulkUnlockUsage
+	policyCreatePolicyFlags.Usage = policyCreatePolicyUsage
+	policyUpdatePolicyFlags.Usage = policyUpdatePolicyUsage
+	policyDeletePolicyFlags.Usage = policyDeletePolicyUsage
+	policyPolicyRevisionsFlags.Usage = policyPolicyRevisionsUsage
+	policyPolicyRevisionFlags.Usage = policyPolicyRevisionUsage
+	policyDiffPolicyRevisionsFlags.Usage = policyDiffPolicyRevisionsUsage
+	policyRollbackPolicyFlags.Usage = policyRollbackPolicyUsage
 	policyExportBundleFlags.Usage = policyExportBundleUsage
+	policyExportWasmBundleFlags.Usage = policyExportWasmBundleUsage
 	policyPolicyPublicKeyFlags.Usage = policyPolicyPublicKeyUsage
 	policyImportBundleFlags.Usage = policyImportBundleUsage
 	policyListPoliciesFlags.Usage = policyListPoliciesUsage
+	policyPolicyDependenciesFlags.Usage = policyPolicyDependenciesUsage
+	policyGetPolicyFlags.Usage = policyGetPolicyUsage
 	policySetPolicyAutoImportFlags.Usage = policySetPolicyAutoImportUsage
 	policyPolicyAutoImportFlags.Usage = policyPolicyAutoImportUsage
 	policyDeletePolicyAutoImportFlags.Usage = policyDeletePolicyAutoImportUsage
 	policySubscribeForPolicyChangeFlags.Usage = policySubscribeForPolicyChangeUsage
+	policySubscriberDeliveriesFlags.Usage = policySubscriberDeliveriesUsage
 
 	healthFlags.Usage = healthUsage
 	healthLivenessFlags.Usage = healthLivenessUsage
@@ -187,9 +288,39 @@ func ParseEndpoint(
 			case "unlock":
 				epf = policyUnlockFlags
 
+			case "bulk-lock":
+				epf = policyBulkLockFlags
+
+			case "bulk-unlock":
+				epf = policyBulkUnlockFlags
+
+			case "create-policy":
+				epf = policyCreatePolicyFlags
+
+			case "update-policy":
+				epf = policyUpdatePolicyFlags
+
+			case "delete-policy":
+				epf = policyDeletePolicyFlags
+
+			case "policy-revisions":
+				epf = policyPolicyRevisionsFlags
+
+			case "policy-revision":
+				epf = policyPolicyRevisionFlags
+
+			case "diff-policy-revisions":
+				epf = policyDiffPolicyRevisionsFlags
+
+			case "rollback-policy":
+				epf = policyRollbackPolicyFlags
+
 			case "export-bundle":
 				epf = policyExportBundleFlags
 
+			case "export-wasm-bundle":
+				epf = policyExportWasmBundleFlags
+
 			case "policy-public-key":
 				epf = policyPolicyPublicKeyFlags
 
@@ -199,6 +330,12 @@ func ParseEndpoint(
 			case "list-policies":
 				epf = policyListPoliciesFlags
 
+			case "policy-dependencies":
+				epf = policyPolicyDependenciesFlags
+
+			case "get-policy":
+				epf = policyGetPolicyFlags
+
 			case "set-policy-auto-import":
 				epf = policySetPolicyAutoImportFlags
 
@@ -211,6 +348,9 @@ func ParseEndpoint(
 			case "subscribe-for-policy-change":
 				epf = policySubscribeForPolicyChangeFlags
 
+			case "subscriber-deliveries":
+				epf = policySubscriberDeliveriesFlags
+
 			}
 
 		case "health":
@@ -254,13 +394,43 @@ func ParseEndpoint(
 				data, err = policyc.BuildValidatePayload(*policyValidateBodyFlag, *policyValidateRepositoryFlag, *policyValidateGroupFlag, *policyValidatePolicyNameFlag, *policyValidateVersionFlag, *policyValidateEvaluationIDFlag, *policyValidateTTLFlag)
 			case "lock":
 				endpoint = c.Lock()
-				data, err = policyc.BuildLockPayload(*policyLockRepositoryFlag, *policyLockGroupFlag, *policyLockPolicyNameFlag, *policyLockVersionFlag)
+				data, err = policyc.BuildLockPayload(*policyLockBodyFlag, *policyLockRepositoryFlag, *policyLockGroupFlag, *policyLockPolicyNameFlag, *policyLockVersionFlag)
 			case "unlock":
 				endpoint = c.Unlock()
 				data, err = policyc.BuildUnlockPayload(*policyUnlockRepositoryFlag, *policyUnlockGroupFlag, *policyUnlockPolicyNameFlag, *policyUnlockVersionFlag)
+			case "bulk-lock":
+				endpoint = c.BulkLock()
+				data, err = policyc.BuildBulkLockPayload(*policyBulkLockBodyFlag)
+			case "bulk-unlock":
+				endpoint = c.BulkUnlock()
+				data, err = policyc.BuildBulkUnlockPayload(*policyBulkUnlockBodyFlag)
+			case "create-policy":
+				endpoint = c.CreatePolicy()
+				data, err = policyc.BuildCreatePolicyPayload(*policyCreatePolicyBodyFlag, *policyCreatePolicyRepositoryFlag, *policyCreatePolicyGroupFlag, *policyCreatePolicyPolicyNameFlag, *policyCreatePolicyVersionFlag)
+			case "update-policy":
+				endpoint = c.UpdatePolicy()
+				data, err = policyc.BuildUpdatePolicyPayload(*policyUpdatePolicyBodyFlag, *policyUpdatePolicyRepositoryFlag, *policyUpdatePolicyGroupFlag, *policyUpdatePolicyPolicyNameFlag, *policyUpdatePolicyVersionFlag)
+			case "delete-policy":
+				endpoint = c.DeletePolicy()
+				data, err = policyc.BuildDeletePolicyPayload(*policyDeletePolicyRepositoryFlag, *policyDeletePolicyGroupFlag, *policyDeletePolicyPolicyNameFlag, *policyDeletePolicyVersionFlag)
+			case "policy-revisions":
+				endpoint = c.PolicyRevisions()
+				data, err = policyc.BuildPolicyRevisionsPayload(*policyPolicyRevisionsRepositoryFlag, *policyPolicyRevisionsGroupFlag, *policyPolicyRevisionsPolicyNameFlag, *policyPolicyRevisionsVersionFlag)
+			case "policy-revision":
+				endpoint = c.PolicyRevision()
+				data, err = policyc.BuildPolicyRevisionPayload(*policyPolicyRevisionRepositoryFlag, *policyPolicyRevisionGroupFlag, *policyPolicyRevisionPolicyNameFlag, *policyPolicyRevisionVersionFlag, *policyPolicyRevisionRevisionFlag)
+			case "diff-policy-revisions":
+				endpoint = c.DiffPolicyRevisions()
+				data, err = policyc.BuildDiffPolicyRevisionsPayload(*policyDiffPolicyRevisionsRepositoryFlag, *policyDiffPolicyRevisionsGroupFlag, *policyDiffPolicyRevisionsPolicyNameFlag, *policyDiffPolicyRevisionsVersionFlag, *policyDiffPolicyRevisionsFromFlag, *policyDiffPolicyRevisionsToFlag)
+			case "rollback-policy":
+				endpoint = c.RollbackPolicy()
+				data, err = policyc.BuildRollbackPolicyPayload(*policyRollbackPolicyRepositoryFlag, *policyRollbackPolicyGroupFlag, *policyRollbackPolicyPolicyNameFlag, *policyRollbackPolicyVersionFlag, *policyRollbackPolicyRevisionFlag)
 			case "export-bundle":
 				endpoint = c.ExportBundle()
-				data, err = policyc.BuildExportBundlePayload(*policyExportBundleRepositoryFlag, *policyExportBundleGroupFlag, *policyExportBundlePolicyNameFlag, *policyExportBundleVersionFlag)
+				data, err = policyc.BuildExportBundlePayload(*policyExportBundleRepositoryFlag, *policyExportBundleGroupFlag, *policyExportBundlePolicyNameFlag, *policyExportBundleVersionFlag, *policyExportBundleTargetFlag)
+			case "export-wasm-bundle":
+				endpoint = c.ExportWasmBundle()
+				data, err = policyc.BuildExportWasmBundlePayload(*policyExportWasmBundleRepositoryFlag, *policyExportWasmBundleGroupFlag, *policyExportWasmBundlePolicyNameFlag, *policyExportWasmBundleVersionFlag)
 			case "policy-public-key":
 				endpoint = c.PolicyPublicKey()
 				data, err = policyc.BuildPolicyPublicKeyPayload(*policyPolicyPublicKeyRepositoryFlag, *policyPolicyPublicKeyGroupFlag, *policyPolicyPublicKeyPolicyNameFlag, *policyPolicyPublicKeyVersionFlag)
@@ -272,7 +442,13 @@ func ParseEndpoint(
 				}
 			case "list-policies":
 				endpoint = c.ListPolicies()
-				data, err = policyc.BuildListPoliciesPayload(*policyListPoliciesLockedFlag, *policyListPoliciesPolicyNameFlag, *policyListPoliciesRegoFlag, *policyListPoliciesDataFlag, *policyListPoliciesDataConfigFlag)
+				data, err = policyc.BuildListPoliciesPayload(*policyListPoliciesLockedFlag, *policyListPoliciesPolicyNameFlag, *policyListPoliciesRegoFlag, *policyListPoliciesDataFlag, *policyListPoliciesDataConfigFlag, *policyListPoliciesRepositoryFlag, *policyListPoliciesGroupFlag, *policyListPoliciesVersionFlag, *policyListPoliciesUpdatedSinceFlag, *policyListPoliciesSortFlag, *policyListPoliciesOrderFlag, *policyListPoliciesCursorFlag, *policyListPoliciesLimitFlag, *policyListPoliciesAnnotationFlag)
+			case "policy-dependencies":
+				endpoint = c.PolicyDependencies()
+				data, err = policyc.BuildPolicyDependenciesPayload(*policyPolicyDependenciesBuiltinFlag, *policyPolicyDependenciesRepositoryFlag)
+			case "get-policy":
+				endpoint = c.GetPolicy()
+				data, err = policyc.BuildGetPolicyPayload(*policyGetPolicyRepositoryFlag, *policyGetPolicyGroupFlag, *policyGetPolicyPolicyNameFlag, *policyGetPolicyVersionFlag)
 			case "set-policy-auto-import":
 				endpoint = c.SetPolicyAutoImport()
 				data, err = policyc.BuildSetPolicyAutoImportPayload(*policySetPolicyAutoImportBodyFlag)
@@ -285,6 +461,9 @@ func ParseEndpoint(
 			case "subscribe-for-policy-change":
 				endpoint = c.SubscribeForPolicyChange()
 				data, err = policyc.BuildSubscribeForPolicyChangePayload(*policySubscribeForPolicyChangeBodyFlag, *policySubscribeForPolicyChangeRepositoryFlag, *policySubscribeForPolicyChangeGroupFlag, *policySubscribeForPolicyChangePolicyNameFlag, *policySubscribeForPolicyChangeVersionFlag)
+			case "subscriber-deliveries":
+				endpoint = c.SubscriberDeliveries()
+				data, err = policyc.BuildSubscriberDeliveriesPayload(*policySubscriberDeliveriesRepositoryFlag, *policySubscriberDeliveriesGroupFlag, *policySubscriberDeliveriesPolicyNameFlag, *policySubscriberDeliveriesVersionFlag, *policySubscriberDeliveriesSubscriberFlag)
 			}
 		case "health":
 			c := healthc.NewClient(scheme, host, doer, enc, dec, restore)
@@ -316,14 +495,27 @@ COMMAND:
     validate: Validate executes a policy with the given 'data' as input and validates the output schema.
     lock: Lock a policy so that it cannot be evaluated.
     unlock: Unlock a policy so it can be evaluated again.
+    bulk-lock: Lock all unlocked policies matching the request, so that they cannot be evaluated.
+    bulk-unlock: Unlock all locked policies matching the request, so they can be evaluated again.
+    create-policy: Create a new policy in storage.
+    update-policy: Update the source code, data and configuration of an existing policy.
+    delete-policy: Delete a policy from storage.
+    policy-revisions: List the revisions of a policy.
+    policy-revision: Show the source code, data and configuration of a policy revision.
+    diff-policy-revisions: Show the differences between two revisions of a policy.
+    rollback-policy: Restore a policy revision as the current policy and notify subscribers.
     export-bundle: Export a signed policy bundle.
+    export-wasm-bundle: Export a signed policy bundle with the policy compiled to WebAssembly.
     policy-public-key: PolicyPublicKey returns the public key in JWK format which must be used to verify a signed policy bundle.
     import-bundle: Import a signed policy bundle.
     list-policies: List policies from storage with optional filters.
+    policy-dependencies: Report the builtin functions, imported packages and data paths used by policies.
+    get-policy: Get the source code, data, configuration and METADATA annotations of a policy.
     set-policy-auto-import: SetPolicyAutoImport enables automatic import of policy bundle on a given time interval.
     policy-auto-import: PolicyAutoImport returns all automatic import configurations.
     delete-policy-auto-import: DeletePolicyAutoImport removes a single automatic import configuration.
     subscribe-for-policy-change: Subscribe for policy change notifications by registering webhook callbacks which the policy service will call.
+    subscriber-deliveries: List the webhook deliveries to a policy change subscriber.
 
 Additional help:
     %[1]s policy COMMAND --help
@@ -342,7 +534,7 @@ Evaluate executes a policy with the given 'data' as input.
     -ttl INT: 
 
 Example:
-    %[1]s policy evaluate --body "Ipsa ad voluptatum maxime ut." --repository "policies" --group "example" --policy-name "example" --version "1.0" --evaluation-id "Et dolores." --ttl 2774440846631434065
+    %[1]s policy evaluate --body "Perferendis omnis id distinctio perspiciatis." --repository "policies" --group "example" --policy-name "example" --version "1.0" --evaluation-id "Necessitatibus qui." --ttl 1525435340009316053
 `, os.Args[0])
 }
 
@@ -359,21 +551,25 @@ Validate executes a policy with the given 'data' as input and validates the outp
     -ttl INT: 
 
 Example:
-    %[1]s policy validate --body "At ut dolore." --repository "policies" --group "example" --policy-name "example" --version "1.0" --evaluation-id "Similique architecto." --ttl 873309878343572558
+    %[1]s policy validate --body "Neque est dolore." --repository "policies" --group "example" --policy-name "example" --version "1.0" --evaluation-id "Mollitia repellendus consequuntur." --ttl 8491237598760923914
 `, os.Args[0])
 }
 
 func policyLockUsage() {
-	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy lock -repository STRING -group STRING -policy-name STRING -version STRING
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy lock -body JSON -repository STRING -group STRING -policy-name STRING -version STRING
 
 Lock a policy so that it cannot be evaluated.
+    -body JSON: 
     -repository STRING: Policy repository.
     -group STRING: Policy group.
     -policy-name STRING: Policy name.
     -version STRING: Policy version.
 
 Example:
-    %[1]s policy lock --repository "Vel non quo." --group "Molestias rerum sunt eaque." --policy-name "Dolores totam voluptatem." --version "Sapiente architecto et enim omnis."
+    %[1]s policy lock --body '{
+      "expiresAt": "2007-09-24T10:16:45Z",
+      "reason": "incident 42: unexpected evaluation results"
+   }' --repository "Sed quibusdam." --group "Eum est et dolores unde incidunt nobis." --policy-name "Voluptas eius cupiditate ut ipsam ipsa quod." --version "Velit voluptatem eligendi."
 `, os.Args[0])
 }
 
@@ -387,21 +583,238 @@ Unlock a policy so it can be evaluated again.
     -version STRING: Policy version.
 
 Example:
-    %[1]s policy unlock --repository "Tenetur rerum necessitatibus fugit." --group "Ut velit aut nobis repellendus." --policy-name "Rem et occaecati quam." --version "Laborum harum voluptate et ut similique doloremque."
+    %[1]s policy unlock --repository "Earum esse." --group "Fugit non incidunt ut quidem doloremque." --policy-name "Nam voluptate placeat fuga ex vero corporis." --version "Dolore voluptatem."
+`, os.Args[0])
+}
+
+func policyBulkLockUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy bulk-lock -body JSON
+
+Lock all unlocked policies matching the request, so that they cannot be evaluated.
+    -body JSON: 
+
+Example:
+    %[1]s policy bulk-lock --body '{
+      "expiresAt": "2011-05-16T22:51:28Z",
+      "group": "Porro earum error quia provident non.",
+      "policies": [
+         {
+            "group": "Quia sed et quis fugit ipsam tempora.",
+            "policyName": "Nobis officiis natus illo ex in.",
+            "repository": "Ut perferendis.",
+            "version": "In ab sed excepturi."
+         },
+         {
+            "group": "Quia sed et quis fugit ipsam tempora.",
+            "policyName": "Nobis officiis natus illo ex in.",
+            "repository": "Ut perferendis.",
+            "version": "In ab sed excepturi."
+         },
+         {
+            "group": "Quia sed et quis fugit ipsam tempora.",
+            "policyName": "Nobis officiis natus illo ex in.",
+            "repository": "Ut perferendis.",
+            "version": "In ab sed excepturi."
+         },
+         {
+            "group": "Quia sed et quis fugit ipsam tempora.",
+            "policyName": "Nobis officiis natus illo ex in.",
+            "repository": "Ut perferendis.",
+            "version": "In ab sed excepturi."
+         }
+      ],
+      "policyName": "Molestiae maxime.",
+      "reason": "compromised trust anchor",
+      "repository": "Cupiditate excepturi illum porro mollitia ducimus assumenda.",
+      "version": "Ut non molestiae veniam aut."
+   }'
+`, os.Args[0])
+}
+
+func policyBulkUnlockUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy bulk-unlock -body JSON
+
+Unlock all locked policies matching the request, so they can be evaluated again.
+    -body JSON: 
+
+Example:
+    %[1]s policy bulk-unlock --body '{
+      "group": "Quia impedit.",
+      "policies": [
+         {
+            "group": "Quia sed et quis fugit ipsam tempora.",
+            "policyName": "Nobis officiis natus illo ex in.",
+            "repository": "Ut perferendis.",
+            "version": "In ab sed excepturi."
+         },
+         {
+            "group": "Quia sed et quis fugit ipsam tempora.",
+            "policyName": "Nobis officiis natus illo ex in.",
+            "repository": "Ut perferendis.",
+            "version": "In ab sed excepturi."
+         },
+         {
+            "group": "Quia sed et quis fugit ipsam tempora.",
+            "policyName": "Nobis officiis natus illo ex in.",
+            "repository": "Ut perferendis.",
+            "version": "In ab sed excepturi."
+         },
+         {
+            "group": "Quia sed et quis fugit ipsam tempora.",
+            "policyName": "Nobis officiis natus illo ex in.",
+            "repository": "Ut perferendis.",
+            "version": "In ab sed excepturi."
+         }
+      ],
+      "policyName": "In voluptatem provident deleniti repellendus officia ut.",
+      "repository": "Aliquid pariatur et quo error.",
+      "version": "Illum ab mollitia impedit."
+   }'
+`, os.Args[0])
+}
+
+func policyCreatePolicyUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy create-policy -body JSON -repository STRING -group STRING -policy-name STRING -version STRING
+
+Create a new policy in storage.
+    -body JSON: 
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -version STRING: Policy version.
+
+Example:
+    %[1]s policy create-policy --body '{
+      "data": "Consequatur fuga laborum enim iusto.",
+      "dataConfig": "Dolores sunt dolorem.",
+      "exportConfig": "Et culpa eaque.",
+      "outputSchema": "Sit repellat aut reiciendis fugiat.",
+      "rego": "package example.example\n\nallow := true"
+   }' --repository "policies" --group "example" --policy-name "example" --version "1.0"
+`, os.Args[0])
+}
+
+func policyUpdatePolicyUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy update-policy -body JSON -repository STRING -group STRING -policy-name STRING -version STRING
+
+Update the source code, data and configuration of an existing policy.
+    -body JSON: 
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -version STRING: Policy version.
+
+Example:
+    %[1]s policy update-policy --body '{
+      "data": "Aperiam ratione enim qui omnis nihil dolorem.",
+      "dataConfig": "Non consequatur ad dolores cum.",
+      "exportConfig": "Vero rerum ipsum.",
+      "outputSchema": "Tempore alias neque.",
+      "rego": "package example.example\n\nallow := true"
+   }' --repository "policies" --group "example" --policy-name "example" --version "1.0"
+`, os.Args[0])
+}
+
+func policyDeletePolicyUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy delete-policy -repository STRING -group STRING -policy-name STRING -version STRING
+
+Delete a policy from storage.
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -version STRING: Policy version.
+
+Example:
+    %[1]s policy delete-policy --repository "Ut at molestiae." --group "Magni est est voluptate hic." --policy-name "Cupiditate ut id ea neque ab." --version "Aspernatur facilis a recusandae nihil quis inventore."
+`, os.Args[0])
+}
+
+func policyPolicyRevisionsUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy policy-revisions -repository STRING -group STRING -policy-name STRING -version STRING
+
+List the revisions of a policy.
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -version STRING: Policy version.
+
+Example:
+    %[1]s policy policy-revisions --repository "Quia et porro adipisci expedita delectus quo." --group "Laudantium voluptatem libero ipsum sequi aliquid." --policy-name "Nostrum ullam ut consequatur occaecati exercitationem voluptates." --version "Animi earum voluptatibus aut aut molestiae."
+`, os.Args[0])
+}
+
+func policyPolicyRevisionUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy policy-revision -repository STRING -group STRING -policy-name STRING -version STRING -revision INT
+
+Show the source code, data and configuration of a policy revision.
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -version STRING: Policy version.
+    -revision INT: Policy revision number.
+
+Example:
+    %[1]s policy policy-revision --repository "Provident animi." --group "Illum voluptatibus quia sapiente placeat." --policy-name "Numquam minima blanditiis." --version "Ea illo quisquam adipisci quo." --revision 8970863267046682152
+`, os.Args[0])
+}
+
+func policyDiffPolicyRevisionsUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy diff-policy-revisions -repository STRING -group STRING -policy-name STRING -version STRING -from INT -to INT
+
+Show the differences between two revisions of a policy.
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -version STRING: Policy version.
+    -from INT: 
+    -to INT: 
+
+Example:
+    %[1]s policy diff-policy-revisions --repository "Magnam natus similique autem aut." --group "Eaque itaque laboriosam." --policy-name "Consequatur modi doloribus vel." --version "Non nihil quod rerum aliquam." --from 6352619902900331632 --to 8783770466608021377
+`, os.Args[0])
+}
+
+func policyRollbackPolicyUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy rollback-policy -repository STRING -group STRING -policy-name STRING -version STRING -revision INT
+
+Restore a policy revision as the current policy and notify subscribers.
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -version STRING: Policy version.
+    -revision INT: Policy revision to restore.
+
+Example:
+    %[1]s policy rollback-policy --repository "Et tenetur eum." --group "Voluptatem consectetur cum porro optio saepe." --policy-name "Assumenda voluptatum adipisci nisi quam." --version "Ut ad accusamus." --revision 7524282137817452627
 `, os.Args[0])
 }
 
 func policyExportBundleUsage() {
-	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy export-bundle -repository STRING -group STRING -policy-name STRING -version STRING
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy export-bundle -repository STRING -group STRING -policy-name STRING -version STRING -target STRING
 
 Export a signed policy bundle.
     -repository STRING: Policy repository.
     -group STRING: Policy group.
     -policy-name STRING: Policy name.
     -version STRING: Policy version.
+    -target STRING: 
+
+Example:
+    %[1]s policy export-bundle --repository "policies" --group "example" --policy-name "returnDID" --version "1.0" --target "wasm"
+`, os.Args[0])
+}
+
+func policyExportWasmBundleUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy export-wasm-bundle -repository STRING -group STRING -policy-name STRING -version STRING
+
+Export a signed policy bundle with the policy compiled to WebAssembly.
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -version STRING: Policy version.
 
 Example:
-    %[1]s policy export-bundle --repository "policies" --group "example" --policy-name "returnDID" --version "1.0"
+    %[1]s policy export-wasm-bundle --repository "policies" --group "example" --policy-name "returnDID" --version "1.0"
 `, os.Args[0])
 }
 
@@ -427,12 +840,12 @@ Import a signed policy bundle.
     -stream STRING: path to file containing the streamed request body
 
 Example:
-    %[1]s policy import-bundle --length 1580298023389806623 --stream "goa.png"
+    %[1]s policy import-bundle --length 3472087794822676692 --stream "goa.png"
 `, os.Args[0])
 }
 
 func policyListPoliciesUsage() {
-	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy list-policies -locked BOOL -policy-name STRING -rego BOOL -data BOOL -data-config BOOL
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy list-policies -locked BOOL -policy-name STRING -rego BOOL -data BOOL -data-config BOOL -repository STRING -group STRING -version STRING -updated-since STRING -sort STRING -order STRING -cursor STRING -limit INT -annotation JSON
 
 List policies from storage with optional filters.
     -locked BOOL: 
@@ -440,9 +853,46 @@ List policies from storage with optional filters.
     -rego BOOL: 
     -data BOOL: 
     -data-config BOOL: 
+    -repository STRING: 
+    -group STRING: 
+    -version STRING: 
+    -updated-since STRING: 
+    -sort STRING: 
+    -order STRING: 
+    -cursor STRING: 
+    -limit INT: 
+    -annotation JSON: 
 
 Example:
-    %[1]s policy list-policies --locked false --policy-name "example" --rego true --data false --data-config false
+    %[1]s policy list-policies --locked true --policy-name "example" --rego true --data false --data-config false --repository "policies" --group "example" --version "1.0" --updated-since "2024-01-02T15:04:05Z" --sort "name" --order "desc" --cursor "Eum et temporibus possimus mollitia eum." --limit 21 --annotation '[
+      "custom.domain=gaia-x"
+   ]'
+`, os.Args[0])
+}
+
+func policyPolicyDependenciesUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy policy-dependencies -builtin STRING -repository STRING
+
+Report the builtin functions, imported packages and data paths used by policies.
+    -builtin STRING: 
+    -repository STRING: 
+
+Example:
+    %[1]s policy policy-dependencies --builtin "did.resolve" --repository "policies"
+`, os.Args[0])
+}
+
+func policyGetPolicyUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy get-policy -repository STRING -group STRING -policy-name STRING -version STRING
+
+Get the source code, data, configuration and METADATA annotations of a policy.
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -version STRING: Policy version.
+
+Example:
+    %[1]s policy get-policy --repository "Beatae qui blanditiis unde." --group "Laborum aut et voluptatibus quos." --policy-name "Sit explicabo dolores quia quia." --version "Voluptatem repellendus pariatur aperiam maxime eum."
 `, os.Args[0])
 }
 
@@ -455,7 +905,7 @@ SetPolicyAutoImport enables automatic import of policy bundle on a given time in
 Example:
     %[1]s policy set-policy-auto-import --body '{
       "interval": "1h30m",
-      "policyURL": "http://altenwerthstrosin.info/ressie"
+      "policyURL": "http://turcotte.org/mayra"
    }'
 `, os.Args[0])
 }
@@ -478,7 +928,7 @@ DeletePolicyAutoImport removes a single automatic import configuration.
 
 Example:
     %[1]s policy delete-policy-auto-import --body '{
-      "policyURL": "http://harvey.info/mossie_wilkinson"
+      "policyURL": "http://stiedemanncarroll.net/isaac.toy"
    }'
 `, os.Args[0])
 }
@@ -495,9 +945,24 @@ Subscribe for policy change notifications by registering webhook callbacks which
 
 Example:
     %[1]s policy subscribe-for-policy-change --body '{
-      "subscriber": "bsm",
-      "webhook_url": "http://yundt.org/bart"
-   }' --repository "Quis eius voluptas est ipsum." --group "Rerum exercitationem odit tempora ab in aliquid." --policy-name "Deleniti odit dolor et et." --version "Libero sed a at."
+      "subscriber": "v8v",
+      "webhook_url": "http://russel.name/horacio.stehr"
+   }' --repository "Ut vitae." --group "Illum cum incidunt." --policy-name "Sequi saepe praesentium reiciendis neque fugit ut." --version "Omnis aliquam eligendi iste."
+`, os.Args[0])
+}
+
+func policySubscriberDeliveriesUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy subscriber-deliveries -repository STRING -group STRING -policy-name STRING -version STRING -subscriber STRING
+
+List the webhook deliveries to a policy change subscriber.
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -version STRING: Policy version.
+    -subscriber STRING: Name of the subscriber for policy.
+
+Example:
+    %[1]s policy subscriber-deliveries --repository "Repellat commodi." --group "Voluptate delectus asperiores quasi quaerat quam." --policy-name "Vero ut." --version "Maxime et aliquam." --subscriber "Commodi blanditiis."
 `, os.Args[0])
 }
 
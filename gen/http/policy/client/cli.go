@@ -25,7 +25,7 @@ func BuildEvaluatePayload(policyEvaluateBody string, policyEvaluateRepository st
 	{
 		err = json.Unmarshal([]byte(policyEvaluateBody), &body)
 		if err != nil {
-			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "\"Ipsa ad voluptatum maxime ut.\"")
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "\"Perferendis omnis id distinctio perspiciatis.\"")
 		}
 	}
 	var repository string
@@ -84,7 +84,7 @@ func BuildValidatePayload(policyValidateBody string, policyValidateRepository st
 	{
 		err = json.Unmarshal([]byte(policyValidateBody), &body)
 		if err != nil {
-			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "\"At ut dolore.\"")
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "\"Neque est dolore.\"")
 		}
 	}
 	var repository string
@@ -137,7 +137,21 @@ func BuildValidatePayload(policyValidateBody string, policyValidateRepository st
 
 // BuildLockPayload builds the payload for the policy Lock endpoint from CLI
 // flags.
-func BuildLockPayload(policyLockRepository string, policyLockGroup string, policyLockPolicyName string, policyLockVersion string) (*policy.LockRequest, error) {
+func BuildLockPayload(policyLockBody string, policyLockRepository string, policyLockGroup string, policyLockPolicyName string, policyLockVersion string) (*policy.LockRequest, error) {
+	var err error
+	var body LockRequestBody
+	{
+		err = json.Unmarshal([]byte(policyLockBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"expiresAt\": \"2007-09-24T10:16:45Z\",\n      \"reason\": \"incident 42: unexpected evaluation results\"\n   }'")
+		}
+		if body.ExpiresAt != nil {
+			err = goa.MergeErrors(err, goa.ValidateFormat("body.expiresAt", *body.ExpiresAt, goa.FormatDateTime))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
 	var repository string
 	{
 		repository = policyLockRepository
@@ -154,7 +168,10 @@ func BuildLockPayload(policyLockRepository string, policyLockGroup string, polic
 	{
 		version = policyLockVersion
 	}
-	v := &policy.LockRequest{}
+	v := &policy.LockRequest{
+		Reason:    body.Reason,
+		ExpiresAt: body.ExpiresAt,
+	}
 	v.Repository = repository
 	v.Group = group
 	v.PolicyName = policyName
@@ -191,9 +208,369 @@ func BuildUnlockPayload(policyUnlockRepository string, policyUnlockGroup string,
 	return v, nil
 }
 
+// BuildBulkLockPayload builds the payload for the policy BulkLock endpoint
+// from CLI flags.
+func BuildBulkLockPayload(policyBulkLockBody string) (*policy.BulkLockRequest, error) {
+	var err error
+	var body BulkLockRequestBody
+	{
+		err = json.Unmarshal([]byte(policyBulkLockBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"expiresAt\": \"2011-05-16T22:51:28Z\",\n      \"group\": \"Porro earum error quia provident non.\",\n      \"policies\": [\n         {\n            \"group\": \"Quia sed et quis fugit ipsam tempora.\",\n            \"policyName\": \"Nobis officiis natus illo ex in.\",\n            \"repository\": \"Ut perferendis.\",\n            \"version\": \"In ab sed excepturi.\"\n         },\n         {\n            \"group\": \"Quia sed et quis fugit ipsam tempora.\",\n            \"policyName\": \"Nobis officiis natus illo ex in.\",\n            \"repository\": \"Ut perferendis.\",\n            \"version\": \"In ab sed excepturi.\"\n         },\n         {\n            \"group\": \"Quia sed et quis fugit ipsam tempora.\",\n            \"policyName\": \"Nobis officiis natus illo ex in.\",\n            \"repository\": \"Ut perferendis.\",\n            \"version\": \"In ab sed excepturi.\"\n         },\n         {\n            \"group\": \"Quia sed et quis fugit ipsam tempora.\",\n            \"policyName\": \"Nobis officiis natus illo ex in.\",\n            \"repository\": \"Ut perferendis.\",\n            \"version\": \"In ab sed excepturi.\"\n         }\n      ],\n      \"policyName\": \"Molestiae maxime.\",\n      \"reason\": \"compromised trust anchor\",\n      \"repository\": \"Cupiditate excepturi illum porro mollitia ducimus assumenda.\",\n      \"version\": \"Ut non molestiae veniam aut.\"\n   }'")
+		}
+	}
+	v := &policy.BulkLockRequest{
+		Repository: body.Repository,
+		Group:      body.Group,
+		PolicyName: body.PolicyName,
+		Version:    body.Version,
+		Reason:     body.Reason,
+		ExpiresAt:  body.ExpiresAt,
+	}
+	if body.Policies != nil {
+		v.Policies = make([]*policy.PolicyRef, len(body.Policies))
+		for i, val := range body.Policies {
+			v.Policies[i] = marshalPolicyRefRequestBodyToPolicyPolicyRef(val)
+		}
+	}
+
+	return v, nil
+}
+
+// BuildBulkUnlockPayload builds the payload for the policy BulkUnlock endpoint
+// from CLI flags.
+func BuildBulkUnlockPayload(policyBulkUnlockBody string) (*policy.BulkUnlockRequest, error) {
+	var err error
+	var body BulkUnlockRequestBody
+	{
+		err = json.Unmarshal([]byte(policyBulkUnlockBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"group\": \"Quia impedit.\",\n      \"policies\": [\n         {\n            \"group\": \"Quia sed et quis fugit ipsam tempora.\",\n            \"policyName\": \"Nobis officiis natus illo ex in.\",\n            \"repository\": \"Ut perferendis.\",\n            \"version\": \"In ab sed excepturi.\"\n         },\n         {\n            \"group\": \"Quia sed et quis fugit ipsam tempora.\",\n            \"policyName\": \"Nobis officiis natus illo ex in.\",\n            \"repository\": \"Ut perferendis.\",\n            \"version\": \"In ab sed excepturi.\"\n         },\n         {\n            \"group\": \"Quia sed et quis fugit ipsam tempora.\",\n            \"policyName\": \"Nobis officiis natus illo ex in.\",\n            \"repository\": \"Ut perferendis.\",\n            \"version\": \"In ab sed excepturi.\"\n         },\n         {\n            \"group\": \"Quia sed et quis fugit ipsam tempora.\",\n            \"policyName\": \"Nobis officiis natus illo ex in.\",\n            \"repository\": \"Ut perferendis.\",\n            \"version\": \"In ab sed excepturi.\"\n         }\n      ],\n      \"policyName\": \"In voluptatem provident deleniti repellendus officia ut.\",\n      \"repository\": \"Aliquid pariatur et quo error.\",\n      \"version\": \"Illum ab mollitia impedit.\"\n   }'")
+		}
+	}
+	v := &policy.BulkUnlockRequest{
+		Repository: body.Repository,
+		Group:      body.Group,
+		PolicyName: body.PolicyName,
+		Version:    body.Version,
+	}
+	if body.Policies != nil {
+		v.Policies = make([]*policy.PolicyRef, len(body.Policies))
+		for i, val := range body.Policies {
+			v.Policies[i] = marshalPolicyRefRequestBodyToPolicyPolicyRef(val)
+		}
+	}
+
+	return v, nil
+}
+
+// BuildCreatePolicyPayload builds the payload for the policy CreatePolicy
+// endpoint from CLI flags.
+func BuildCreatePolicyPayload(policyCreatePolicyBody string, policyCreatePolicyRepository string, policyCreatePolicyGroup string, policyCreatePolicyPolicyName string, policyCreatePolicyVersion string) (*policy.PolicyRequest, error) {
+	var err error
+	var body CreatePolicyRequestBody
+	{
+		err = json.Unmarshal([]byte(policyCreatePolicyBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"data\": \"Consequatur fuga laborum enim iusto.\",\n      \"dataConfig\": \"Dolores sunt dolorem.\",\n      \"exportConfig\": \"Et culpa eaque.\",\n      \"outputSchema\": \"Sit repellat aut reiciendis fugiat.\",\n      \"rego\": \"package example.example\\n\\nallow := true\"\n   }'")
+		}
+		if utf8.RuneCountInString(body.Rego) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("body.rego", body.Rego, utf8.RuneCountInString(body.Rego), 1, true))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	var repository string
+	{
+		repository = policyCreatePolicyRepository
+	}
+	var group string
+	{
+		group = policyCreatePolicyGroup
+	}
+	var policyName string
+	{
+		policyName = policyCreatePolicyPolicyName
+	}
+	var version string
+	{
+		version = policyCreatePolicyVersion
+	}
+	v := &policy.PolicyRequest{
+		Rego:         body.Rego,
+		Data:         body.Data,
+		DataConfig:   body.DataConfig,
+		OutputSchema: body.OutputSchema,
+		ExportConfig: body.ExportConfig,
+	}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v, nil
+}
+
+// BuildUpdatePolicyPayload builds the payload for the policy UpdatePolicy
+// endpoint from CLI flags.
+func BuildUpdatePolicyPayload(policyUpdatePolicyBody string, policyUpdatePolicyRepository string, policyUpdatePolicyGroup string, policyUpdatePolicyPolicyName string, policyUpdatePolicyVersion string) (*policy.PolicyRequest, error) {
+	var err error
+	var body UpdatePolicyRequestBody
+	{
+		err = json.Unmarshal([]byte(policyUpdatePolicyBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"data\": \"Aperiam ratione enim qui omnis nihil dolorem.\",\n      \"dataConfig\": \"Non consequatur ad dolores cum.\",\n      \"exportConfig\": \"Vero rerum ipsum.\",\n      \"outputSchema\": \"Tempore alias neque.\",\n      \"rego\": \"package example.example\\n\\nallow := true\"\n   }'")
+		}
+		if utf8.RuneCountInString(body.Rego) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("body.rego", body.Rego, utf8.RuneCountInString(body.Rego), 1, true))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	var repository string
+	{
+		repository = policyUpdatePolicyRepository
+	}
+	var group string
+	{
+		group = policyUpdatePolicyGroup
+	}
+	var policyName string
+	{
+		policyName = policyUpdatePolicyPolicyName
+	}
+	var version string
+	{
+		version = policyUpdatePolicyVersion
+	}
+	v := &policy.PolicyRequest{
+		Rego:         body.Rego,
+		Data:         body.Data,
+		DataConfig:   body.DataConfig,
+		OutputSchema: body.OutputSchema,
+		ExportConfig: body.ExportConfig,
+	}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v, nil
+}
+
+// BuildDeletePolicyPayload builds the payload for the policy DeletePolicy
+// endpoint from CLI flags.
+func BuildDeletePolicyPayload(policyDeletePolicyRepository string, policyDeletePolicyGroup string, policyDeletePolicyPolicyName string, policyDeletePolicyVersion string) (*policy.DeletePolicyRequest, error) {
+	var repository string
+	{
+		repository = policyDeletePolicyRepository
+	}
+	var group string
+	{
+		group = policyDeletePolicyGroup
+	}
+	var policyName string
+	{
+		policyName = policyDeletePolicyPolicyName
+	}
+	var version string
+	{
+		version = policyDeletePolicyVersion
+	}
+	v := &policy.DeletePolicyRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v, nil
+}
+
+// BuildPolicyRevisionsPayload builds the payload for the policy
+// PolicyRevisions endpoint from CLI flags.
+func BuildPolicyRevisionsPayload(policyPolicyRevisionsRepository string, policyPolicyRevisionsGroup string, policyPolicyRevisionsPolicyName string, policyPolicyRevisionsVersion string) (*policy.PolicyRevisionsRequest, error) {
+	var repository string
+	{
+		repository = policyPolicyRevisionsRepository
+	}
+	var group string
+	{
+		group = policyPolicyRevisionsGroup
+	}
+	var policyName string
+	{
+		policyName = policyPolicyRevisionsPolicyName
+	}
+	var version string
+	{
+		version = policyPolicyRevisionsVersion
+	}
+	v := &policy.PolicyRevisionsRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v, nil
+}
+
+// BuildPolicyRevisionPayload builds the payload for the policy PolicyRevision
+// endpoint from CLI flags.
+func BuildPolicyRevisionPayload(policyPolicyRevisionRepository string, policyPolicyRevisionGroup string, policyPolicyRevisionPolicyName string, policyPolicyRevisionVersion string, policyPolicyRevisionRevision string) (*policy.PolicyRevisionRequest, error) {
+	var err error
+	var repository string
+	{
+		repository = policyPolicyRevisionRepository
+	}
+	var group string
+	{
+		group = policyPolicyRevisionGroup
+	}
+	var policyName string
+	{
+		policyName = policyPolicyRevisionPolicyName
+	}
+	var version string
+	{
+		version = policyPolicyRevisionVersion
+	}
+	var revision int
+	{
+		var v int64
+		v, err = strconv.ParseInt(policyPolicyRevisionRevision, 10, strconv.IntSize)
+		revision = int(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for revision, must be INT")
+		}
+		if revision < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("revision", revision, 1, true))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	v := &policy.PolicyRevisionRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+	v.Revision = revision
+
+	return v, nil
+}
+
+// BuildDiffPolicyRevisionsPayload builds the payload for the policy
+// DiffPolicyRevisions endpoint from CLI flags.
+func BuildDiffPolicyRevisionsPayload(policyDiffPolicyRevisionsRepository string, policyDiffPolicyRevisionsGroup string, policyDiffPolicyRevisionsPolicyName string, policyDiffPolicyRevisionsVersion string, policyDiffPolicyRevisionsFrom string, policyDiffPolicyRevisionsTo string) (*policy.DiffPolicyRevisionsRequest, error) {
+	var err error
+	var repository string
+	{
+		repository = policyDiffPolicyRevisionsRepository
+	}
+	var group string
+	{
+		group = policyDiffPolicyRevisionsGroup
+	}
+	var policyName string
+	{
+		policyName = policyDiffPolicyRevisionsPolicyName
+	}
+	var version string
+	{
+		version = policyDiffPolicyRevisionsVersion
+	}
+	var from int
+	{
+		var v int64
+		v, err = strconv.ParseInt(policyDiffPolicyRevisionsFrom, 10, strconv.IntSize)
+		from = int(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for from, must be INT")
+		}
+		if from < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("from", from, 1, true))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	var to int
+	{
+		var v int64
+		v, err = strconv.ParseInt(policyDiffPolicyRevisionsTo, 10, strconv.IntSize)
+		to = int(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for to, must be INT")
+		}
+		if to < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("to", to, 1, true))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	v := &policy.DiffPolicyRevisionsRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+	v.From = from
+	v.To = to
+
+	return v, nil
+}
+
+// BuildRollbackPolicyPayload builds the payload for the policy RollbackPolicy
+// endpoint from CLI flags.
+func BuildRollbackPolicyPayload(policyRollbackPolicyRepository string, policyRollbackPolicyGroup string, policyRollbackPolicyPolicyName string, policyRollbackPolicyVersion string, policyRollbackPolicyRevision string) (*policy.RollbackPolicyRequest, error) {
+	var err error
+	var repository string
+	{
+		repository = policyRollbackPolicyRepository
+	}
+	var group string
+	{
+		group = policyRollbackPolicyGroup
+	}
+	var policyName string
+	{
+		policyName = policyRollbackPolicyPolicyName
+	}
+	var version string
+	{
+		version = policyRollbackPolicyVersion
+	}
+	var revision int
+	{
+		var v int64
+		v, err = strconv.ParseInt(policyRollbackPolicyRevision, 10, strconv.IntSize)
+		revision = int(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for revision, must be INT")
+		}
+		if revision < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("revision", revision, 1, true))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	v := &policy.RollbackPolicyRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+	v.Revision = revision
+
+	return v, nil
+}
+
 // BuildExportBundlePayload builds the payload for the policy ExportBundle
 // endpoint from CLI flags.
-func BuildExportBundlePayload(policyExportBundleRepository string, policyExportBundleGroup string, policyExportBundlePolicyName string, policyExportBundleVersion string) (*policy.ExportBundleRequest, error) {
+func BuildExportBundlePayload(policyExportBundleRepository string, policyExportBundleGroup string, policyExportBundlePolicyName string, policyExportBundleVersion string, policyExportBundleTarget string) (*policy.ExportBundleRequest, error) {
+	var err error
 	var repository string
 	{
 		repository = policyExportBundleRepository
@@ -210,11 +587,52 @@ func BuildExportBundlePayload(policyExportBundleRepository string, policyExportB
 	{
 		version = policyExportBundleVersion
 	}
+	var target string
+	{
+		if policyExportBundleTarget != "" {
+			target = policyExportBundleTarget
+			if !(target == "rego" || target == "wasm") {
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("target", target, []any{"rego", "wasm"}))
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
 	v := &policy.ExportBundleRequest{}
 	v.Repository = repository
 	v.Group = group
 	v.PolicyName = policyName
 	v.Version = version
+	v.Target = target
+
+	return v, nil
+}
+
+// BuildExportWasmBundlePayload builds the payload for the policy
+// ExportWasmBundle endpoint from CLI flags.
+func BuildExportWasmBundlePayload(policyExportWasmBundleRepository string, policyExportWasmBundleGroup string, policyExportWasmBundlePolicyName string, policyExportWasmBundleVersion string) (*policy.ExportWasmBundleRequest, error) {
+	var repository string
+	{
+		repository = policyExportWasmBundleRepository
+	}
+	var group string
+	{
+		group = policyExportWasmBundleGroup
+	}
+	var policyName string
+	{
+		policyName = policyExportWasmBundlePolicyName
+	}
+	var version string
+	{
+		version = policyExportWasmBundleVersion
+	}
+	v := &policy.ExportWasmBundleRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
 
 	return v, nil
 }
@@ -271,7 +689,7 @@ func BuildImportBundlePayload(policyImportBundleLength string) (*policy.ImportBu
 
 // BuildListPoliciesPayload builds the payload for the policy ListPolicies
 // endpoint from CLI flags.
-func BuildListPoliciesPayload(policyListPoliciesLocked string, policyListPoliciesPolicyName string, policyListPoliciesRego string, policyListPoliciesData string, policyListPoliciesDataConfig string) (*policy.PoliciesRequest, error) {
+func BuildListPoliciesPayload(policyListPoliciesLocked string, policyListPoliciesPolicyName string, policyListPoliciesRego string, policyListPoliciesData string, policyListPoliciesDataConfig string, policyListPoliciesRepository string, policyListPoliciesGroup string, policyListPoliciesVersion string, policyListPoliciesUpdatedSince string, policyListPoliciesSort string, policyListPoliciesOrder string, policyListPoliciesCursor string, policyListPoliciesLimit string, policyListPoliciesAnnotation string) (*policy.PoliciesRequest, error) {
 	var err error
 	var locked *bool
 	{
@@ -323,12 +741,159 @@ func BuildListPoliciesPayload(policyListPoliciesLocked string, policyListPolicie
 			}
 		}
 	}
+	var repository *string
+	{
+		if policyListPoliciesRepository != "" {
+			repository = &policyListPoliciesRepository
+		}
+	}
+	var group *string
+	{
+		if policyListPoliciesGroup != "" {
+			group = &policyListPoliciesGroup
+		}
+	}
+	var version *string
+	{
+		if policyListPoliciesVersion != "" {
+			version = &policyListPoliciesVersion
+		}
+	}
+	var updatedSince *string
+	{
+		if policyListPoliciesUpdatedSince != "" {
+			updatedSince = &policyListPoliciesUpdatedSince
+			err = goa.MergeErrors(err, goa.ValidateFormat("updatedSince", *updatedSince, goa.FormatDateTime))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	var sort string
+	{
+		if policyListPoliciesSort != "" {
+			sort = policyListPoliciesSort
+			if !(sort == "name" || sort == "lastUpdate") {
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("sort", sort, []any{"name", "lastUpdate"}))
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	var order string
+	{
+		if policyListPoliciesOrder != "" {
+			order = policyListPoliciesOrder
+			if !(order == "asc" || order == "desc") {
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("order", order, []any{"asc", "desc"}))
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	var cursor *string
+	{
+		if policyListPoliciesCursor != "" {
+			cursor = &policyListPoliciesCursor
+		}
+	}
+	var limit *int
+	{
+		if policyListPoliciesLimit != "" {
+			var v int64
+			v, err = strconv.ParseInt(policyListPoliciesLimit, 10, strconv.IntSize)
+			val := int(v)
+			limit = &val
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for limit, must be INT")
+			}
+			if *limit < 1 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("limit", *limit, 1, true))
+			}
+			if *limit > 1000 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("limit", *limit, 1000, false))
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	var annotation []string
+	{
+		if policyListPoliciesAnnotation != "" {
+			err = json.Unmarshal([]byte(policyListPoliciesAnnotation), &annotation)
+			if err != nil {
+				return nil, fmt.Errorf("invalid JSON for annotation, \nerror: %s, \nexample of valid JSON:\n%s", err, "'[\n      \"custom.domain=gaia-x\"\n   ]'")
+			}
+		}
+	}
 	v := &policy.PoliciesRequest{}
 	v.Locked = locked
 	v.PolicyName = policyName
 	v.Rego = rego
 	v.Data = data
 	v.DataConfig = dataConfig
+	v.Repository = repository
+	v.Group = group
+	v.Version = version
+	v.UpdatedSince = updatedSince
+	v.Sort = sort
+	v.Order = order
+	v.Cursor = cursor
+	v.Limit = limit
+	v.Annotation = annotation
+
+	return v, nil
+}
+
+// BuildPolicyDependenciesPayload builds the payload for the policy
+// PolicyDependencies endpoint from CLI flags.
+func BuildPolicyDependenciesPayload(policyPolicyDependenciesBuiltin string, policyPolicyDependenciesRepository string) (*policy.PolicyDependenciesRequest, error) {
+	var builtin *string
+	{
+		if policyPolicyDependenciesBuiltin != "" {
+			builtin = &policyPolicyDependenciesBuiltin
+		}
+	}
+	var repository *string
+	{
+		if policyPolicyDependenciesRepository != "" {
+			repository = &policyPolicyDependenciesRepository
+		}
+	}
+	v := &policy.PolicyDependenciesRequest{}
+	v.Builtin = builtin
+	v.Repository = repository
+
+	return v, nil
+}
+
+// BuildGetPolicyPayload builds the payload for the policy GetPolicy endpoint
+// from CLI flags.
+func BuildGetPolicyPayload(policyGetPolicyRepository string, policyGetPolicyGroup string, policyGetPolicyPolicyName string, policyGetPolicyVersion string) (*policy.GetPolicyRequest, error) {
+	var repository string
+	{
+		repository = policyGetPolicyRepository
+	}
+	var group string
+	{
+		group = policyGetPolicyGroup
+	}
+	var policyName string
+	{
+		policyName = policyGetPolicyPolicyName
+	}
+	var version string
+	{
+		version = policyGetPolicyVersion
+	}
+	v := &policy.GetPolicyRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
 
 	return v, nil
 }
@@ -341,7 +906,7 @@ func BuildSetPolicyAutoImportPayload(policySetPolicyAutoImportBody string) (*pol
 	{
 		err = json.Unmarshal([]byte(policySetPolicyAutoImportBody), &body)
 		if err != nil {
-			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"interval\": \"1h30m\",\n      \"policyURL\": \"http://altenwerthstrosin.info/ressie\"\n   }'")
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"interval\": \"1h30m\",\n      \"policyURL\": \"http://turcotte.org/mayra\"\n   }'")
 		}
 		err = goa.MergeErrors(err, goa.ValidateFormat("body.policyURL", body.PolicyURL, goa.FormatURI))
 		if utf8.RuneCountInString(body.Interval) < 2 {
@@ -367,7 +932,7 @@ func BuildDeletePolicyAutoImportPayload(policyDeletePolicyAutoImportBody string)
 	{
 		err = json.Unmarshal([]byte(policyDeletePolicyAutoImportBody), &body)
 		if err != nil {
-			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"policyURL\": \"http://harvey.info/mossie_wilkinson\"\n   }'")
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"policyURL\": \"http://stiedemanncarroll.net/isaac.toy\"\n   }'")
 		}
 		err = goa.MergeErrors(err, goa.ValidateFormat("body.policyURL", body.PolicyURL, goa.FormatURI))
 		if err != nil {
@@ -389,7 +954,7 @@ func BuildSubscribeForPolicyChangePayload(policySubscribeForPolicyChangeBody str
 	{
 		err = json.Unmarshal([]byte(policySubscribeForPolicyChangeBody), &body)
 		if err != nil {
-			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"subscriber\": \"bsm\",\n      \"webhook_url\": \"http://yundt.org/bart\"\n   }'")
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"subscriber\": \"v8v\",\n      \"webhook_url\": \"http://russel.name/horacio.stehr\"\n   }'")
 		}
 		err = goa.MergeErrors(err, goa.ValidateFormat("body.webhook_url", body.WebhookURL, goa.FormatURI))
 		if utf8.RuneCountInString(body.Subscriber) < 3 {
@@ -429,3 +994,36 @@ func BuildSubscribeForPolicyChangePayload(policySubscribeForPolicyChangeBody str
 
 	return v, nil
 }
+
+// BuildSubscriberDeliveriesPayload builds the payload for the policy
+// SubscriberDeliveries endpoint from CLI flags.
+func BuildSubscriberDeliveriesPayload(policySubscriberDeliveriesRepository string, policySubscriberDeliveriesGroup string, policySubscriberDeliveriesPolicyName string, policySubscriberDeliveriesVersion string, policySubscriberDeliveriesSubscriber string) (*policy.SubscriberDeliveriesRequest, error) {
+	var repository string
+	{
+		repository = policySubscriberDeliveriesRepository
+	}
+	var group string
+	{
+		group = policySubscriberDeliveriesGroup
+	}
+	var policyName string
+	{
+		policyName = policySubscriberDeliveriesPolicyName
+	}
+	var version string
+	{
+		version = policySubscriberDeliveriesVersion
+	}
+	var subscriber string
+	{
+		subscriber = policySubscriberDeliveriesSubscriber
+	}
+	v := &policy.SubscriberDeliveriesRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+	v.Subscriber = subscriber
+
+	return v, nil
+}
@@ -32,10 +32,50 @@ type Client struct {
 	// Unlock Doer is the HTTP client used to make requests to the Unlock endpoint.
 	UnlockDoer goahttp.Doer
 
+	// BulkLock Doer is the HTTP client used to make requests to the BulkLock
+	// endpoint.
+	BulkLockDoer goahttp.Doer
+
+	// BulkUnlock Doer is the HTTP client used to make requests to the BulkUnlock
+	// endpoint.
+	BulkUnlockDoer goahttp.Doer
+
+	// CreatePolicy Doer is the HTTP client used to make requests to the
+	// CreatePolicy endpoint.
+	CreatePolicyDoer goahttp.Doer
+
+	// UpdatePolicy Doer is the HTTP client used to make requests to the
+	// UpdatePolicy endpoint.
+	UpdatePolicyDoer goahttp.Doer
+
+	// DeletePolicy Doer is the HTTP client used to make requests to the
+	// DeletePolicy endpoint.
+	DeletePolicyDoer goahttp.Doer
+
+	// PolicyRevisions Doer is the HTTP client used to make requests to the
+	// PolicyRevisions endpoint.
+	PolicyRevisionsDoer goahttp.Doer
+
+	// PolicyRevision Doer is the HTTP client used to make requests to the
+	// PolicyRevision endpoint.
+	PolicyRevisionDoer goahttp.Doer
+
+	// DiffPolicyRevisions Doer is the HTTP client used to make requests to the
+	// DiffPolicyRevisions endpoint.
+	DiffPolicyRevisionsDoer goahttp.Doer
+
+	// RollbackPolicy Doer is the HTTP client used to make requests to the
+	// RollbackPolicy endpoint.
+	RollbackPolicyDoer goahttp.Doer
+
 	// ExportBundle Doer is the HTTP client used to make requests to the
 	// ExportBundle endpoint.
 	ExportBundleDoer goahttp.Doer
 
+	// ExportWasmBundle Doer is the HTTP client used to make requests to the
+	// ExportWasmBundle endpoint.
+	ExportWasmBundleDoer goahttp.Doer
+
 	// PolicyPublicKey Doer is the HTTP client used to make requests to the
 	// PolicyPublicKey endpoint.
 	PolicyPublicKeyDoer goahttp.Doer
@@ -48,6 +88,14 @@ type Client struct {
 	// ListPolicies endpoint.
 	ListPoliciesDoer goahttp.Doer
 
+	// PolicyDependencies Doer is the HTTP client used to make requests to the
+	// PolicyDependencies endpoint.
+	PolicyDependenciesDoer goahttp.Doer
+
+	// GetPolicy Doer is the HTTP client used to make requests to the GetPolicy
+	// endpoint.
+	GetPolicyDoer goahttp.Doer
+
 	// SetPolicyAutoImport Doer is the HTTP client used to make requests to the
 	// SetPolicyAutoImport endpoint.
 	SetPolicyAutoImportDoer goahttp.Doer
@@ -64,6 +112,10 @@ type Client struct {
 	// the SubscribeForPolicyChange endpoint.
 	SubscribeForPolicyChangeDoer goahttp.Doer
 
+	// SubscriberDeliveries Doer is the HTTP client used to make requests to the
+	// SubscriberDeliveries endpoint.
+	SubscriberDeliveriesDoer goahttp.Doer
+
 	// RestoreResponseBody controls whether the response bodies are reset after
 	// decoding so they can be read again.
 	RestoreResponseBody bool
@@ -88,14 +140,27 @@ func NewClient(
 		ValidateDoer:                 doer,
 		LockDoer:                     doer,
 		UnlockDoer:                   doer,
+		BulkLockDoer:                 doer,
+		BulkUnlockDoer:               doer,
+		CreatePolicyDoer:             doer,
+		UpdatePolicyDoer:             doer,
+		DeletePolicyDoer:             doer,
+		PolicyRevisionsDoer:          doer,
+		PolicyRevisionDoer:           doer,
+		DiffPolicyRevisionsDoer:      doer,
+		RollbackPolicyDoer:           doer,
 		ExportBundleDoer:             doer,
+		ExportWasmBundleDoer:         doer,
 		PolicyPublicKeyDoer:          doer,
 		ImportBundleDoer:             doer,
 		ListPoliciesDoer:             doer,
+		PolicyDependenciesDoer:       doer,
+		GetPolicyDoer:                doer,
 		SetPolicyAutoImportDoer:      doer,
 		PolicyAutoImportDoer:         doer,
 		DeletePolicyAutoImportDoer:   doer,
 		SubscribeForPolicyChangeDoer: doer,
+		SubscriberDeliveriesDoer:     doer,
 		RestoreResponseBody:          restoreBody,
 		scheme:                       scheme,
 		host:                         host,
@@ -156,6 +221,7 @@ func (c *Client) Validate() goa.Endpoint {
 // server.
 func (c *Client) Lock() goa.Endpoint {
 	var (
+		encodeRequest  = EncodeLockRequest(c.encoder)
 		decodeResponse = DecodeLockResponse(c.decoder, c.RestoreResponseBody)
 	)
 	return func(ctx context.Context, v any) (any, error) {
@@ -163,6 +229,10 @@ func (c *Client) Lock() goa.Endpoint {
 		if err != nil {
 			return nil, err
 		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
 		resp, err := c.LockDoer.Do(req)
 		if err != nil {
 			return nil, goahttp.ErrRequestError("policy", "Lock", err)
@@ -190,10 +260,207 @@ func (c *Client) Unlock() goa.Endpoint {
 	}
 }
 
+// BulkLock returns an endpoint that makes HTTP requests to the policy service
+// BulkLock server.
+func (c *Client) BulkLock() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeBulkLockRequest(c.encoder)
+		decodeResponse = DecodeBulkLockResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildBulkLockRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.BulkLockDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "BulkLock", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// BulkUnlock returns an endpoint that makes HTTP requests to the policy
+// service BulkUnlock server.
+func (c *Client) BulkUnlock() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeBulkUnlockRequest(c.encoder)
+		decodeResponse = DecodeBulkUnlockResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildBulkUnlockRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.BulkUnlockDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "BulkUnlock", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// CreatePolicy returns an endpoint that makes HTTP requests to the policy
+// service CreatePolicy server.
+func (c *Client) CreatePolicy() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeCreatePolicyRequest(c.encoder)
+		decodeResponse = DecodeCreatePolicyResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildCreatePolicyRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.CreatePolicyDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "CreatePolicy", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// UpdatePolicy returns an endpoint that makes HTTP requests to the policy
+// service UpdatePolicy server.
+func (c *Client) UpdatePolicy() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeUpdatePolicyRequest(c.encoder)
+		decodeResponse = DecodeUpdatePolicyResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildUpdatePolicyRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.UpdatePolicyDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "UpdatePolicy", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// DeletePolicy returns an endpoint that makes HTTP requests to the policy
+// service DeletePolicy server.
+func (c *Client) DeletePolicy() goa.Endpoint {
+	var (
+		decodeResponse = DecodeDeletePolicyResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildDeletePolicyRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.DeletePolicyDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "DeletePolicy", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// PolicyRevisions returns an endpoint that makes HTTP requests to the policy
+// service PolicyRevisions server.
+func (c *Client) PolicyRevisions() goa.Endpoint {
+	var (
+		decodeResponse = DecodePolicyRevisionsResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildPolicyRevisionsRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.PolicyRevisionsDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "PolicyRevisions", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// PolicyRevision returns an endpoint that makes HTTP requests to the policy
+// service PolicyRevision server.
+func (c *Client) PolicyRevision() goa.Endpoint {
+	var (
+		decodeResponse = DecodePolicyRevisionResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildPolicyRevisionRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.PolicyRevisionDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "PolicyRevision", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// DiffPolicyRevisions returns an endpoint that makes HTTP requests to the
+// policy service DiffPolicyRevisions server.
+func (c *Client) DiffPolicyRevisions() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeDiffPolicyRevisionsRequest(c.encoder)
+		decodeResponse = DecodeDiffPolicyRevisionsResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildDiffPolicyRevisionsRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.DiffPolicyRevisionsDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "DiffPolicyRevisions", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// RollbackPolicy returns an endpoint that makes HTTP requests to the policy
+// service RollbackPolicy server.
+func (c *Client) RollbackPolicy() goa.Endpoint {
+	var (
+		decodeResponse = DecodeRollbackPolicyResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildRollbackPolicyRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.RollbackPolicyDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "RollbackPolicy", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
 // ExportBundle returns an endpoint that makes HTTP requests to the policy
 // service ExportBundle server.
 func (c *Client) ExportBundle() goa.Endpoint {
 	var (
+		encodeRequest  = EncodeExportBundleRequest(c.encoder)
 		decodeResponse = DecodeExportBundleResponse(c.decoder, c.RestoreResponseBody)
 	)
 	return func(ctx context.Context, v any) (any, error) {
@@ -201,6 +468,10 @@ func (c *Client) ExportBundle() goa.Endpoint {
 		if err != nil {
 			return nil, err
 		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
 		resp, err := c.ExportBundleDoer.Do(req)
 		if err != nil {
 			return nil, goahttp.ErrRequestError("policy", "ExportBundle", err)
@@ -214,6 +485,30 @@ func (c *Client) ExportBundle() goa.Endpoint {
 	}
 }
 
+// ExportWasmBundle returns an endpoint that makes HTTP requests to the policy
+// service ExportWasmBundle server.
+func (c *Client) ExportWasmBundle() goa.Endpoint {
+	var (
+		decodeResponse = DecodeExportWasmBundleResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildExportWasmBundleRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.ExportWasmBundleDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "ExportWasmBundle", err)
+		}
+		res, err := decodeResponse(resp)
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		return &policy.ExportWasmBundleResponseData{Result: res.(*policy.ExportBundleResult), Body: resp.Body}, nil
+	}
+}
+
 // PolicyPublicKey returns an endpoint that makes HTTP requests to the policy
 // service PolicyPublicKey server.
 func (c *Client) PolicyPublicKey() goa.Endpoint {
@@ -281,6 +576,49 @@ func (c *Client) ListPolicies() goa.Endpoint {
 	}
 }
 
+// PolicyDependencies returns an endpoint that makes HTTP requests to the
+// policy service PolicyDependencies server.
+func (c *Client) PolicyDependencies() goa.Endpoint {
+	var (
+		encodeRequest  = EncodePolicyDependenciesRequest(c.encoder)
+		decodeResponse = DecodePolicyDependenciesResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildPolicyDependenciesRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.PolicyDependenciesDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "PolicyDependencies", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// GetPolicy returns an endpoint that makes HTTP requests to the policy service
+// GetPolicy server.
+func (c *Client) GetPolicy() goa.Endpoint {
+	var (
+		decodeResponse = DecodeGetPolicyResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildGetPolicyRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.GetPolicyDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "GetPolicy", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
 // SetPolicyAutoImport returns an endpoint that makes HTTP requests to the
 // policy service SetPolicyAutoImport server.
 func (c *Client) SetPolicyAutoImport() goa.Endpoint {
@@ -371,3 +709,22 @@ func (c *Client) SubscribeForPolicyChange() goa.Endpoint {
 		return decodeResponse(resp)
 	}
 }
+
+// SubscriberDeliveries returns an endpoint that makes HTTP requests to the
+// policy service SubscriberDeliveries server.
+func (c *Client) SubscriberDeliveries() goa.Endpoint {
+	var (
+		decodeResponse = DecodeSubscriberDeliveriesResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildSubscriberDeliveriesRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.SubscriberDeliveriesDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "SubscriberDeliveries", err)
+		}
+		return decodeResponse(resp)
+	}
+}
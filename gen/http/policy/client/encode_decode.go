@@ -259,6 +259,22 @@ func (c *Client) BuildLockRequest(ctx context.Context, v any) (*http.Request, er
 	return req, nil
 }
 
+// EncodeLockRequest returns an encoder for requests sent to the policy Lock
+// server.
+func EncodeLockRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*policy.LockRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("policy", "Lock", "*policy.LockRequest", v)
+		}
+		body := NewLockRequestBody(p)
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("policy", "Lock", err)
+		}
+		return nil
+	}
+}
+
 // DecodeLockResponse returns a decoder for responses returned by the policy
 // Lock endpoint. restoreBody controls whether the response body should be
 // restored after having been read.
@@ -344,9 +360,151 @@ func DecodeUnlockResponse(decoder func(*http.Response) goahttp.Decoder, restoreB
 	}
 }
 
-// BuildExportBundleRequest instantiates a HTTP request object with method and
-// path set to call the "policy" service "ExportBundle" endpoint
-func (c *Client) BuildExportBundleRequest(ctx context.Context, v any) (*http.Request, error) {
+// BuildBulkLockRequest instantiates a HTTP request object with method and path
+// set to call the "policy" service "BulkLock" endpoint
+func (c *Client) BuildBulkLockRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: BulkLockPolicyPath()}
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "BulkLock", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeBulkLockRequest returns an encoder for requests sent to the policy
+// BulkLock server.
+func EncodeBulkLockRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*policy.BulkLockRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("policy", "BulkLock", "*policy.BulkLockRequest", v)
+		}
+		body := NewBulkLockRequestBody(p)
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("policy", "BulkLock", err)
+		}
+		return nil
+	}
+}
+
+// DecodeBulkLockResponse returns a decoder for responses returned by the
+// policy BulkLock endpoint. restoreBody controls whether the response body
+// should be restored after having been read.
+func DecodeBulkLockResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body BulkLockResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "BulkLock", err)
+			}
+			err = ValidateBulkLockResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "BulkLock", err)
+			}
+			res := NewBulkLockResultOK(&body)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "BulkLock", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildBulkUnlockRequest instantiates a HTTP request object with method and
+// path set to call the "policy" service "BulkUnlock" endpoint
+func (c *Client) BuildBulkUnlockRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: BulkUnlockPolicyPath()}
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "BulkUnlock", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeBulkUnlockRequest returns an encoder for requests sent to the policy
+// BulkUnlock server.
+func EncodeBulkUnlockRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*policy.BulkUnlockRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("policy", "BulkUnlock", "*policy.BulkUnlockRequest", v)
+		}
+		body := NewBulkUnlockRequestBody(p)
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("policy", "BulkUnlock", err)
+		}
+		return nil
+	}
+}
+
+// DecodeBulkUnlockResponse returns a decoder for responses returned by the
+// policy BulkUnlock endpoint. restoreBody controls whether the response body
+// should be restored after having been read.
+func DecodeBulkUnlockResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body BulkUnlockResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "BulkUnlock", err)
+			}
+			err = ValidateBulkUnlockResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "BulkUnlock", err)
+			}
+			res := NewBulkUnlockBulkLockResultOK(&body)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "BulkUnlock", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildCreatePolicyRequest instantiates a HTTP request object with method and
+// path set to call the "policy" service "CreatePolicy" endpoint
+func (c *Client) BuildCreatePolicyRequest(ctx context.Context, v any) (*http.Request, error) {
 	var (
 		repository string
 		group      string
@@ -354,19 +512,19 @@ func (c *Client) BuildExportBundleRequest(ctx context.Context, v any) (*http.Req
 		version    string
 	)
 	{
-		p, ok := v.(*policy.ExportBundleRequest)
+		p, ok := v.(*policy.PolicyRequest)
 		if !ok {
-			return nil, goahttp.ErrInvalidType("policy", "ExportBundle", "*policy.ExportBundleRequest", v)
+			return nil, goahttp.ErrInvalidType("policy", "CreatePolicy", "*policy.PolicyRequest", v)
 		}
 		repository = p.Repository
 		group = p.Group
 		policyName = p.PolicyName
 		version = p.Version
 	}
-	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: ExportBundlePolicyPath(repository, group, policyName, version)}
-	req, err := http.NewRequest("GET", u.String(), nil)
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: CreatePolicyPolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("POST", u.String(), nil)
 	if err != nil {
-		return nil, goahttp.ErrInvalidURL("policy", "ExportBundle", u.String(), err)
+		return nil, goahttp.ErrInvalidURL("policy", "CreatePolicy", u.String(), err)
 	}
 	if ctx != nil {
 		req = req.WithContext(ctx)
@@ -375,10 +533,639 @@ func (c *Client) BuildExportBundleRequest(ctx context.Context, v any) (*http.Req
 	return req, nil
 }
 
-// DecodeExportBundleResponse returns a decoder for responses returned by the
-// policy ExportBundle endpoint. restoreBody controls whether the response body
+// EncodeCreatePolicyRequest returns an encoder for requests sent to the policy
+// CreatePolicy server.
+func EncodeCreatePolicyRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*policy.PolicyRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("policy", "CreatePolicy", "*policy.PolicyRequest", v)
+		}
+		body := NewCreatePolicyRequestBody(p)
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("policy", "CreatePolicy", err)
+		}
+		return nil
+	}
+}
+
+// DecodeCreatePolicyResponse returns a decoder for responses returned by the
+// policy CreatePolicy endpoint. restoreBody controls whether the response body
 // should be restored after having been read.
-func DecodeExportBundleResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+func DecodeCreatePolicyResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body any
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "CreatePolicy", err)
+			}
+			return body, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "CreatePolicy", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildUpdatePolicyRequest instantiates a HTTP request object with method and
+// path set to call the "policy" service "UpdatePolicy" endpoint
+func (c *Client) BuildUpdatePolicyRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+	)
+	{
+		p, ok := v.(*policy.PolicyRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "UpdatePolicy", "*policy.PolicyRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: UpdatePolicyPolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("PUT", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "UpdatePolicy", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeUpdatePolicyRequest returns an encoder for requests sent to the policy
+// UpdatePolicy server.
+func EncodeUpdatePolicyRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*policy.PolicyRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("policy", "UpdatePolicy", "*policy.PolicyRequest", v)
+		}
+		body := NewUpdatePolicyRequestBody(p)
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("policy", "UpdatePolicy", err)
+		}
+		return nil
+	}
+}
+
+// DecodeUpdatePolicyResponse returns a decoder for responses returned by the
+// policy UpdatePolicy endpoint. restoreBody controls whether the response body
+// should be restored after having been read.
+func DecodeUpdatePolicyResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body any
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "UpdatePolicy", err)
+			}
+			return body, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "UpdatePolicy", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildDeletePolicyRequest instantiates a HTTP request object with method and
+// path set to call the "policy" service "DeletePolicy" endpoint
+func (c *Client) BuildDeletePolicyRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+	)
+	{
+		p, ok := v.(*policy.DeletePolicyRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "DeletePolicy", "*policy.DeletePolicyRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: DeletePolicyPolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "DeletePolicy", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// DecodeDeletePolicyResponse returns a decoder for responses returned by the
+// policy DeletePolicy endpoint. restoreBody controls whether the response body
+// should be restored after having been read.
+func DecodeDeletePolicyResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			return nil, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "DeletePolicy", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildPolicyRevisionsRequest instantiates a HTTP request object with method
+// and path set to call the "policy" service "PolicyRevisions" endpoint
+func (c *Client) BuildPolicyRevisionsRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+	)
+	{
+		p, ok := v.(*policy.PolicyRevisionsRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "PolicyRevisions", "*policy.PolicyRevisionsRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: PolicyRevisionsPolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "PolicyRevisions", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// DecodePolicyRevisionsResponse returns a decoder for responses returned by
+// the policy PolicyRevisions endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+func DecodePolicyRevisionsResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body PolicyRevisionsResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "PolicyRevisions", err)
+			}
+			err = ValidatePolicyRevisionsResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "PolicyRevisions", err)
+			}
+			res := NewPolicyRevisionsResultOK(&body)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "PolicyRevisions", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildPolicyRevisionRequest instantiates a HTTP request object with method
+// and path set to call the "policy" service "PolicyRevision" endpoint
+func (c *Client) BuildPolicyRevisionRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+		revision   int
+	)
+	{
+		p, ok := v.(*policy.PolicyRevisionRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "PolicyRevision", "*policy.PolicyRevisionRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+		revision = p.Revision
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: PolicyRevisionPolicyPath(repository, group, policyName, version, revision)}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "PolicyRevision", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// DecodePolicyRevisionResponse returns a decoder for responses returned by the
+// policy PolicyRevision endpoint. restoreBody controls whether the response
+// body should be restored after having been read.
+func DecodePolicyRevisionResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body PolicyRevisionResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "PolicyRevision", err)
+			}
+			err = ValidatePolicyRevisionResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "PolicyRevision", err)
+			}
+			res := NewPolicyRevisionResultOK(&body)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "PolicyRevision", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildDiffPolicyRevisionsRequest instantiates a HTTP request object with
+// method and path set to call the "policy" service "DiffPolicyRevisions"
+// endpoint
+func (c *Client) BuildDiffPolicyRevisionsRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+	)
+	{
+		p, ok := v.(*policy.DiffPolicyRevisionsRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "DiffPolicyRevisions", "*policy.DiffPolicyRevisionsRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: DiffPolicyRevisionsPolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "DiffPolicyRevisions", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeDiffPolicyRevisionsRequest returns an encoder for requests sent to the
+// policy DiffPolicyRevisions server.
+func EncodeDiffPolicyRevisionsRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*policy.DiffPolicyRevisionsRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("policy", "DiffPolicyRevisions", "*policy.DiffPolicyRevisionsRequest", v)
+		}
+		values := req.URL.Query()
+		values.Add("from", fmt.Sprintf("%v", p.From))
+		values.Add("to", fmt.Sprintf("%v", p.To))
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+
+// DecodeDiffPolicyRevisionsResponse returns a decoder for responses returned
+// by the policy DiffPolicyRevisions endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+func DecodeDiffPolicyRevisionsResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body DiffPolicyRevisionsResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "DiffPolicyRevisions", err)
+			}
+			err = ValidateDiffPolicyRevisionsResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "DiffPolicyRevisions", err)
+			}
+			res := NewDiffPolicyRevisionsResultOK(&body)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "DiffPolicyRevisions", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildRollbackPolicyRequest instantiates a HTTP request object with method
+// and path set to call the "policy" service "RollbackPolicy" endpoint
+func (c *Client) BuildRollbackPolicyRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+		revision   int
+	)
+	{
+		p, ok := v.(*policy.RollbackPolicyRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "RollbackPolicy", "*policy.RollbackPolicyRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+		revision = p.Revision
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: RollbackPolicyPolicyPath(repository, group, policyName, version, revision)}
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "RollbackPolicy", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// DecodeRollbackPolicyResponse returns a decoder for responses returned by the
+// policy RollbackPolicy endpoint. restoreBody controls whether the response
+// body should be restored after having been read.
+func DecodeRollbackPolicyResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body RollbackPolicyResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "RollbackPolicy", err)
+			}
+			err = ValidateRollbackPolicyResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "RollbackPolicy", err)
+			}
+			res := NewRollbackPolicyPolicyRevisionResultOK(&body)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "RollbackPolicy", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildExportBundleRequest instantiates a HTTP request object with method and
+// path set to call the "policy" service "ExportBundle" endpoint
+func (c *Client) BuildExportBundleRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+	)
+	{
+		p, ok := v.(*policy.ExportBundleRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "ExportBundle", "*policy.ExportBundleRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: ExportBundlePolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "ExportBundle", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeExportBundleRequest returns an encoder for requests sent to the policy
+// ExportBundle server.
+func EncodeExportBundleRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*policy.ExportBundleRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("policy", "ExportBundle", "*policy.ExportBundleRequest", v)
+		}
+		values := req.URL.Query()
+		values.Add("target", p.Target)
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+
+// DecodeExportBundleResponse returns a decoder for responses returned by the
+// policy ExportBundle endpoint. restoreBody controls whether the response body
+// should be restored after having been read.
+func DecodeExportBundleResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				contentType        string
+				contentLength      int
+				contentDisposition string
+				err                error
+			)
+			contentTypeRaw := resp.Header.Get("Content-Type")
+			if contentTypeRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("content-type", "header"))
+			}
+			contentType = contentTypeRaw
+			{
+				contentLengthRaw := resp.Header.Get("Content-Length")
+				if contentLengthRaw == "" {
+					return nil, goahttp.ErrValidationError("policy", "ExportBundle", goa.MissingFieldError("content-length", "header"))
+				}
+				v, err2 := strconv.ParseInt(contentLengthRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("content-length", contentLengthRaw, "integer"))
+				}
+				contentLength = int(v)
+			}
+			contentDispositionRaw := resp.Header.Get("Content-Disposition")
+			if contentDispositionRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("content-disposition", "header"))
+			}
+			contentDisposition = contentDispositionRaw
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "ExportBundle", err)
+			}
+			res := NewExportBundleResultOK(contentType, contentLength, contentDisposition)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "ExportBundle", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildExportWasmBundleRequest instantiates a HTTP request object with method
+// and path set to call the "policy" service "ExportWasmBundle" endpoint
+func (c *Client) BuildExportWasmBundleRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+	)
+	{
+		p, ok := v.(*policy.ExportWasmBundleRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "ExportWasmBundle", "*policy.ExportWasmBundleRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: ExportWasmBundlePolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "ExportWasmBundle", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// DecodeExportWasmBundleResponse returns a decoder for responses returned by
+// the policy ExportWasmBundle endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+func DecodeExportWasmBundleResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
 	return func(resp *http.Response) (any, error) {
 		if restoreBody {
 			b, err := io.ReadAll(resp.Body)
@@ -406,7 +1193,7 @@ func DecodeExportBundleResponse(decoder func(*http.Response) goahttp.Decoder, re
 			{
 				contentLengthRaw := resp.Header.Get("Content-Length")
 				if contentLengthRaw == "" {
-					return nil, goahttp.ErrValidationError("policy", "ExportBundle", goa.MissingFieldError("content-length", "header"))
+					return nil, goahttp.ErrValidationError("policy", "ExportWasmBundle", goa.MissingFieldError("content-length", "header"))
 				}
 				v, err2 := strconv.ParseInt(contentLengthRaw, 10, strconv.IntSize)
 				if err2 != nil {
@@ -420,13 +1207,13 @@ func DecodeExportBundleResponse(decoder func(*http.Response) goahttp.Decoder, re
 			}
 			contentDisposition = contentDispositionRaw
 			if err != nil {
-				return nil, goahttp.ErrValidationError("policy", "ExportBundle", err)
+				return nil, goahttp.ErrValidationError("policy", "ExportWasmBundle", err)
 			}
-			res := NewExportBundleResultOK(contentType, contentLength, contentDisposition)
+			res := NewExportWasmBundleExportBundleResultOK(contentType, contentLength, contentDisposition)
 			return res, nil
 		default:
 			body, _ := io.ReadAll(resp.Body)
-			return nil, goahttp.ErrInvalidResponse("policy", "ExportBundle", resp.StatusCode, string(body))
+			return nil, goahttp.ErrInvalidResponse("policy", "ExportWasmBundle", resp.StatusCode, string(body))
 		}
 	}
 }
@@ -625,6 +1412,29 @@ func EncodeListPoliciesRequest(encoder func(*http.Request) goahttp.Encoder) func
 		if p.DataConfig != nil {
 			values.Add("dataConfig", fmt.Sprintf("%v", *p.DataConfig))
 		}
+		if p.Repository != nil {
+			values.Add("repository", *p.Repository)
+		}
+		if p.Group != nil {
+			values.Add("group", *p.Group)
+		}
+		if p.Version != nil {
+			values.Add("version", *p.Version)
+		}
+		if p.UpdatedSince != nil {
+			values.Add("updatedSince", *p.UpdatedSince)
+		}
+		values.Add("sort", p.Sort)
+		values.Add("order", p.Order)
+		if p.Cursor != nil {
+			values.Add("cursor", *p.Cursor)
+		}
+		if p.Limit != nil {
+			values.Add("limit", fmt.Sprintf("%v", *p.Limit))
+		}
+		for _, value := range p.Annotation {
+			values.Add("annotation", value)
+		}
 		req.URL.RawQuery = values.Encode()
 		return nil
 	}
@@ -670,6 +1480,153 @@ func DecodeListPoliciesResponse(decoder func(*http.Response) goahttp.Decoder, re
 	}
 }
 
+// BuildPolicyDependenciesRequest instantiates a HTTP request object with
+// method and path set to call the "policy" service "PolicyDependencies"
+// endpoint
+func (c *Client) BuildPolicyDependenciesRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: PolicyDependenciesPolicyPath()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "PolicyDependencies", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodePolicyDependenciesRequest returns an encoder for requests sent to the
+// policy PolicyDependencies server.
+func EncodePolicyDependenciesRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*policy.PolicyDependenciesRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("policy", "PolicyDependencies", "*policy.PolicyDependenciesRequest", v)
+		}
+		values := req.URL.Query()
+		if p.Builtin != nil {
+			values.Add("builtin", *p.Builtin)
+		}
+		if p.Repository != nil {
+			values.Add("repository", *p.Repository)
+		}
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+
+// DecodePolicyDependenciesResponse returns a decoder for responses returned by
+// the policy PolicyDependencies endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+func DecodePolicyDependenciesResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body PolicyDependenciesResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "PolicyDependencies", err)
+			}
+			err = ValidatePolicyDependenciesResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "PolicyDependencies", err)
+			}
+			res := NewPolicyDependenciesResultOK(&body)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "PolicyDependencies", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildGetPolicyRequest instantiates a HTTP request object with method and
+// path set to call the "policy" service "GetPolicy" endpoint
+func (c *Client) BuildGetPolicyRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+	)
+	{
+		p, ok := v.(*policy.GetPolicyRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "GetPolicy", "*policy.GetPolicyRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: GetPolicyPolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "GetPolicy", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// DecodeGetPolicyResponse returns a decoder for responses returned by the
+// policy GetPolicy endpoint. restoreBody controls whether the response body
+// should be restored after having been read.
+func DecodeGetPolicyResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body GetPolicyResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "GetPolicy", err)
+			}
+			err = ValidateGetPolicyResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "GetPolicy", err)
+			}
+			res := NewGetPolicyPolicyOK(&body)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "GetPolicy", resp.StatusCode, string(body))
+		}
+	}
+}
+
 // BuildSetPolicyAutoImportRequest instantiates a HTTP request object with
 // method and path set to call the "policy" service "SetPolicyAutoImport"
 // endpoint
@@ -937,19 +1894,299 @@ func DecodeSubscribeForPolicyChangeResponse(decoder func(*http.Response) goahttp
 	}
 }
 
+// BuildSubscriberDeliveriesRequest instantiates a HTTP request object with
+// method and path set to call the "policy" service "SubscriberDeliveries"
+// endpoint
+func (c *Client) BuildSubscriberDeliveriesRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+		subscriber string
+	)
+	{
+		p, ok := v.(*policy.SubscriberDeliveriesRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "SubscriberDeliveries", "*policy.SubscriberDeliveriesRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+		subscriber = p.Subscriber
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: SubscriberDeliveriesPolicyPath(repository, group, policyName, version, subscriber)}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "SubscriberDeliveries", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// DecodeSubscriberDeliveriesResponse returns a decoder for responses returned
+// by the policy SubscriberDeliveries endpoint. restoreBody controls whether
+// the response body should be restored after having been read.
+func DecodeSubscriberDeliveriesResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body SubscriberDeliveriesResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "SubscriberDeliveries", err)
+			}
+			err = ValidateSubscriberDeliveriesResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "SubscriberDeliveries", err)
+			}
+			res := NewSubscriberDeliveriesResultOK(&body)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "SubscriberDeliveries", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// marshalPolicyPolicyRefToPolicyRefRequestBody builds a value of type
+// *PolicyRefRequestBody from a value of type *policy.PolicyRef.
+func marshalPolicyPolicyRefToPolicyRefRequestBody(v *policy.PolicyRef) *PolicyRefRequestBody {
+	if v == nil {
+		return nil
+	}
+	res := &PolicyRefRequestBody{
+		Repository: v.Repository,
+		Group:      v.Group,
+		PolicyName: v.PolicyName,
+		Version:    v.Version,
+	}
+
+	return res
+}
+
+// marshalPolicyRefRequestBodyToPolicyPolicyRef builds a value of type
+// *policy.PolicyRef from a value of type *PolicyRefRequestBody.
+func marshalPolicyRefRequestBodyToPolicyPolicyRef(v *PolicyRefRequestBody) *policy.PolicyRef {
+	if v == nil {
+		return nil
+	}
+	res := &policy.PolicyRef{
+		Repository: v.Repository,
+		Group:      v.Group,
+		PolicyName: v.PolicyName,
+		Version:    v.Version,
+	}
+
+	return res
+}
+
+// unmarshalPolicyRefResponseBodyToPolicyPolicyRef builds a value of type
+// *policy.PolicyRef from a value of type *PolicyRefResponseBody.
+func unmarshalPolicyRefResponseBodyToPolicyPolicyRef(v *PolicyRefResponseBody) *policy.PolicyRef {
+	res := &policy.PolicyRef{
+		Repository: *v.Repository,
+		Group:      *v.Group,
+		PolicyName: *v.PolicyName,
+		Version:    *v.Version,
+	}
+
+	return res
+}
+
+// unmarshalPolicyRevisionResultResponseBodyToPolicyPolicyRevisionResult builds
+// a value of type *policy.PolicyRevisionResult from a value of type
+// *PolicyRevisionResultResponseBody.
+func unmarshalPolicyRevisionResultResponseBodyToPolicyPolicyRevisionResult(v *PolicyRevisionResultResponseBody) *policy.PolicyRevisionResult {
+	res := &policy.PolicyRevisionResult{
+		Revision:     *v.Revision,
+		Hash:         *v.Hash,
+		Source:       *v.Source,
+		Commit:       v.Commit,
+		CreatedAt:    *v.CreatedAt,
+		Rego:         v.Rego,
+		Data:         v.Data,
+		DataConfig:   v.DataConfig,
+		OutputSchema: v.OutputSchema,
+		ExportConfig: v.ExportConfig,
+	}
+
+	return res
+}
+
+// unmarshalPolicyRevisionDiffResponseBodyToPolicyPolicyRevisionDiff builds a
+// value of type *policy.PolicyRevisionDiff from a value of type
+// *PolicyRevisionDiffResponseBody.
+func unmarshalPolicyRevisionDiffResponseBodyToPolicyPolicyRevisionDiff(v *PolicyRevisionDiffResponseBody) *policy.PolicyRevisionDiff {
+	res := &policy.PolicyRevisionDiff{
+		Field: *v.Field,
+		Diff:  *v.Diff,
+	}
+
+	return res
+}
+
 // unmarshalPolicyResponseBodyToPolicyPolicy builds a value of type
 // *policy.Policy from a value of type *PolicyResponseBody.
 func unmarshalPolicyResponseBodyToPolicyPolicy(v *PolicyResponseBody) *policy.Policy {
 	res := &policy.Policy{
+		Repository:   *v.Repository,
+		PolicyName:   *v.PolicyName,
+		Group:        *v.Group,
+		Version:      *v.Version,
+		Rego:         v.Rego,
+		Data:         v.Data,
+		DataConfig:   v.DataConfig,
+		Locked:       *v.Locked,
+		LastUpdate:   *v.LastUpdate,
+		Archived:     v.Archived,
+		OutputSchema: v.OutputSchema,
+		ExportConfig: v.ExportConfig,
+	}
+	if v.Commit != nil {
+		res.Commit = unmarshalPolicyCommitResponseBodyToPolicyPolicyCommit(v.Commit)
+	}
+	if v.Metadata != nil {
+		res.Metadata = unmarshalPolicyMetadataResponseBodyToPolicyPolicyMetadata(v.Metadata)
+	}
+	if v.Lock != nil {
+		res.Lock = unmarshalPolicyLockResponseBodyToPolicyPolicyLock(v.Lock)
+	}
+
+	return res
+}
+
+// unmarshalPolicyCommitResponseBodyToPolicyPolicyCommit builds a value of type
+// *policy.PolicyCommit from a value of type *PolicyCommitResponseBody.
+func unmarshalPolicyCommitResponseBodyToPolicyPolicyCommit(v *PolicyCommitResponseBody) *policy.PolicyCommit {
+	if v == nil {
+		return nil
+	}
+	res := &policy.PolicyCommit{
+		Sha:    *v.Sha,
+		Time:   *v.Time,
+		Author: *v.Author,
+		Branch: v.Branch,
+	}
+
+	return res
+}
+
+// unmarshalPolicyMetadataResponseBodyToPolicyPolicyMetadata builds a value of
+// type *policy.PolicyMetadata from a value of type *PolicyMetadataResponseBody.
+func unmarshalPolicyMetadataResponseBodyToPolicyPolicyMetadata(v *PolicyMetadataResponseBody) *policy.PolicyMetadata {
+	if v == nil {
+		return nil
+	}
+	res := &policy.PolicyMetadata{
+		Title:       v.Title,
+		Description: v.Description,
+	}
+	if v.Authors != nil {
+		res.Authors = make([]string, len(v.Authors))
+		for i, val := range v.Authors {
+			res.Authors[i] = val
+		}
+	}
+	if v.Organizations != nil {
+		res.Organizations = make([]string, len(v.Organizations))
+		for i, val := range v.Organizations {
+			res.Organizations[i] = val
+		}
+	}
+	if v.RelatedResources != nil {
+		res.RelatedResources = make([]string, len(v.RelatedResources))
+		for i, val := range v.RelatedResources {
+			res.RelatedResources[i] = val
+		}
+	}
+	if v.Custom != nil {
+		res.Custom = make(map[string]any, len(v.Custom))
+		for key, val := range v.Custom {
+			tk := key
+			tv := val
+			res.Custom[tk] = tv
+		}
+	}
+
+	return res
+}
+
+// unmarshalPolicyLockResponseBodyToPolicyPolicyLock builds a value of type
+// *policy.PolicyLock from a value of type *PolicyLockResponseBody.
+func unmarshalPolicyLockResponseBodyToPolicyPolicyLock(v *PolicyLockResponseBody) *policy.PolicyLock {
+	if v == nil {
+		return nil
+	}
+	res := &policy.PolicyLock{
+		Reason:    v.Reason,
+		LockedBy:  v.LockedBy,
+		LockedAt:  *v.LockedAt,
+		ExpiresAt: v.ExpiresAt,
+	}
+
+	return res
+}
+
+// unmarshalDependencyReportResponseBodyToPolicyDependencyReport builds a value
+// of type *policy.DependencyReport from a value of type
+// *DependencyReportResponseBody.
+func unmarshalDependencyReportResponseBodyToPolicyDependencyReport(v *DependencyReportResponseBody) *policy.DependencyReport {
+	res := &policy.DependencyReport{
 		Repository: *v.Repository,
-		PolicyName: *v.PolicyName,
 		Group:      *v.Group,
+		PolicyName: *v.PolicyName,
 		Version:    *v.Version,
-		Rego:       v.Rego,
-		Data:       v.Data,
-		DataConfig: v.DataConfig,
-		Locked:     *v.Locked,
-		LastUpdate: *v.LastUpdate,
+		Error:      v.Error,
+	}
+	res.Builtins = make([]string, len(v.Builtins))
+	for i, val := range v.Builtins {
+		res.Builtins[i] = val
+	}
+	res.Imports = make([]string, len(v.Imports))
+	for i, val := range v.Imports {
+		res.Imports[i] = val
+	}
+	res.DataPaths = make([]string, len(v.DataPaths))
+	for i, val := range v.DataPaths {
+		res.DataPaths[i] = val
+	}
+
+	return res
+}
+
+// unmarshalWebhookDeliveryResponseBodyToPolicyWebhookDelivery builds a value
+// of type *policy.WebhookDelivery from a value of type
+// *WebhookDeliveryResponseBody.
+func unmarshalWebhookDeliveryResponseBodyToPolicyWebhookDelivery(v *WebhookDeliveryResponseBody) *policy.WebhookDelivery {
+	res := &policy.WebhookDelivery{
+		ID:          *v.ID,
+		Event:       v.Event,
+		State:       *v.State,
+		Attempts:    *v.Attempts,
+		LastError:   v.LastError,
+		NextAttempt: v.NextAttempt,
+		CreatedAt:   *v.CreatedAt,
+		UpdatedAt:   *v.UpdatedAt,
 	}
 
 	return res
@@ -12,6 +12,80 @@ import (
 	goa "goa.design/goa/v3/pkg"
 )
 
+// LockRequestBody is the type of the "policy" service "Lock" endpoint HTTP
+// request body.
+type LockRequestBody struct {
+	// Reason for locking the policy.
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty" xml:"reason,omitempty"`
+	// Time in RFC 3339 format when the policy is unlocked automatically (optional).
+	ExpiresAt *string `form:"expiresAt,omitempty" json:"expiresAt,omitempty" xml:"expiresAt,omitempty"`
+}
+
+// BulkLockRequestBody is the type of the "policy" service "BulkLock" endpoint
+// HTTP request body.
+type BulkLockRequestBody struct {
+	// Lock policies of a repository.
+	Repository *string `form:"repository,omitempty" json:"repository,omitempty" xml:"repository,omitempty"`
+	// Lock policies of a group.
+	Group *string `form:"group,omitempty" json:"group,omitempty" xml:"group,omitempty"`
+	// Lock policies with names matching a shell pattern, e.g. did*.
+	PolicyName *string `form:"policyName,omitempty" json:"policyName,omitempty" xml:"policyName,omitempty"`
+	// Lock policies with a version.
+	Version *string `form:"version,omitempty" json:"version,omitempty" xml:"version,omitempty"`
+	// Lock only the listed policies.
+	Policies []*PolicyRefRequestBody `form:"policies,omitempty" json:"policies,omitempty" xml:"policies,omitempty"`
+	// Reason for locking the policies.
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty" xml:"reason,omitempty"`
+	// Time in RFC 3339 format when the policies are unlocked automatically
+	// (optional).
+	ExpiresAt *string `form:"expiresAt,omitempty" json:"expiresAt,omitempty" xml:"expiresAt,omitempty"`
+}
+
+// BulkUnlockRequestBody is the type of the "policy" service "BulkUnlock"
+// endpoint HTTP request body.
+type BulkUnlockRequestBody struct {
+	// Unlock policies of a repository.
+	Repository *string `form:"repository,omitempty" json:"repository,omitempty" xml:"repository,omitempty"`
+	// Unlock policies of a group.
+	Group *string `form:"group,omitempty" json:"group,omitempty" xml:"group,omitempty"`
+	// Unlock policies with names matching a shell pattern, e.g. did*.
+	PolicyName *string `form:"policyName,omitempty" json:"policyName,omitempty" xml:"policyName,omitempty"`
+	// Unlock policies with a version.
+	Version *string `form:"version,omitempty" json:"version,omitempty" xml:"version,omitempty"`
+	// Unlock only the listed policies.
+	Policies []*PolicyRefRequestBody `form:"policies,omitempty" json:"policies,omitempty" xml:"policies,omitempty"`
+}
+
+// CreatePolicyRequestBody is the type of the "policy" service "CreatePolicy"
+// endpoint HTTP request body.
+type CreatePolicyRequestBody struct {
+	// Policy rego source code. The package declaration must be 'group.policyName'.
+	Rego string `form:"rego" json:"rego" xml:"rego"`
+	// Policy static data as JSON object (optional).
+	Data *string `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Policy static data configuration as JSON (optional).
+	DataConfig *string `form:"dataConfig,omitempty" json:"dataConfig,omitempty" xml:"dataConfig,omitempty"`
+	// JSON schema for validation of the policy output (optional).
+	OutputSchema *string `form:"outputSchema,omitempty" json:"outputSchema,omitempty" xml:"outputSchema,omitempty"`
+	// Policy bundle export configuration as JSON (optional).
+	ExportConfig *string `form:"exportConfig,omitempty" json:"exportConfig,omitempty" xml:"exportConfig,omitempty"`
+}
+
+// UpdatePolicyRequestBody is the type of the "policy" service "UpdatePolicy"
+// endpoint HTTP request body.
+type UpdatePolicyRequestBody struct {
+	// Policy rego source code. The package declaration must be 'group.policyName'.
+	Rego string `form:"rego" json:"rego" xml:"rego"`
+	// Policy static data as JSON object (optional).
+	Data *string `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Policy static data configuration as JSON (optional).
+	DataConfig *string `form:"dataConfig,omitempty" json:"dataConfig,omitempty" xml:"dataConfig,omitempty"`
+	// JSON schema for validation of the policy output (optional).
+	OutputSchema *string `form:"outputSchema,omitempty" json:"outputSchema,omitempty" xml:"outputSchema,omitempty"`
+	// Policy bundle export configuration as JSON (optional).
+	ExportConfig *string `form:"exportConfig,omitempty" json:"exportConfig,omitempty" xml:"exportConfig,omitempty"`
+}
+
 // SetPolicyAutoImportRequestBody is the type of the "policy" service
 // "SetPolicyAutoImport" endpoint HTTP request body.
 type SetPolicyAutoImportRequestBody struct {
@@ -37,11 +111,203 @@ type SubscribeForPolicyChangeRequestBody struct {
 	Subscriber string `form:"subscriber" json:"subscriber" xml:"subscriber"`
 }
 
+// BulkLockResponseBody is the type of the "policy" service "BulkLock" endpoint
+// HTTP response body.
+type BulkLockResponseBody struct {
+	// Locked or unlocked policies.
+	Policies []*PolicyRefResponseBody `form:"policies,omitempty" json:"policies,omitempty" xml:"policies,omitempty"`
+}
+
+// BulkUnlockResponseBody is the type of the "policy" service "BulkUnlock"
+// endpoint HTTP response body.
+type BulkUnlockResponseBody struct {
+	// Locked or unlocked policies.
+	Policies []*PolicyRefResponseBody `form:"policies,omitempty" json:"policies,omitempty" xml:"policies,omitempty"`
+}
+
+// PolicyRevisionsResponseBody is the type of the "policy" service
+// "PolicyRevisions" endpoint HTTP response body.
+type PolicyRevisionsResponseBody struct {
+	// JSON array of policy revisions without their content.
+	Revisions []*PolicyRevisionResultResponseBody `form:"revisions,omitempty" json:"revisions,omitempty" xml:"revisions,omitempty"`
+}
+
+// PolicyRevisionResponseBody is the type of the "policy" service
+// "PolicyRevision" endpoint HTTP response body.
+type PolicyRevisionResponseBody struct {
+	// Revision number.
+	Revision *int `form:"revision,omitempty" json:"revision,omitempty" xml:"revision,omitempty"`
+	// SHA-256 hash of the policy source code, data and configuration.
+	Hash *string `form:"hash,omitempty" json:"hash,omitempty" xml:"hash,omitempty"`
+	// Source of the revision: sync, import, api, data refresh or rollback.
+	Source *string `form:"source,omitempty" json:"source,omitempty" xml:"source,omitempty"`
+	// Git commit of the revision, if known.
+	Commit *string `form:"commit,omitempty" json:"commit,omitempty" xml:"commit,omitempty"`
+	// Creation time of the revision (Unix timestamp).
+	CreatedAt *int64 `form:"createdAt,omitempty" json:"createdAt,omitempty" xml:"createdAt,omitempty"`
+	// Policy rego source code.
+	Rego *string `form:"rego,omitempty" json:"rego,omitempty" xml:"rego,omitempty"`
+	// Policy static data.
+	Data *string `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Policy static data optional configuration.
+	DataConfig *string `form:"dataConfig,omitempty" json:"dataConfig,omitempty" xml:"dataConfig,omitempty"`
+	// JSON schema for validation of the policy output.
+	OutputSchema *string `form:"outputSchema,omitempty" json:"outputSchema,omitempty" xml:"outputSchema,omitempty"`
+	// Policy bundle export configuration.
+	ExportConfig *string `form:"exportConfig,omitempty" json:"exportConfig,omitempty" xml:"exportConfig,omitempty"`
+}
+
+// DiffPolicyRevisionsResponseBody is the type of the "policy" service
+// "DiffPolicyRevisions" endpoint HTTP response body.
+type DiffPolicyRevisionsResponseBody struct {
+	// Revision compared from.
+	From *int `form:"from,omitempty" json:"from,omitempty" xml:"from,omitempty"`
+	// Revision compared to.
+	To *int `form:"to,omitempty" json:"to,omitempty" xml:"to,omitempty"`
+	// Differences of the changed policy fields.
+	Changes []*PolicyRevisionDiffResponseBody `form:"changes,omitempty" json:"changes,omitempty" xml:"changes,omitempty"`
+}
+
+// RollbackPolicyResponseBody is the type of the "policy" service
+// "RollbackPolicy" endpoint HTTP response body.
+type RollbackPolicyResponseBody struct {
+	// Revision number.
+	Revision *int `form:"revision,omitempty" json:"revision,omitempty" xml:"revision,omitempty"`
+	// SHA-256 hash of the policy source code, data and configuration.
+	Hash *string `form:"hash,omitempty" json:"hash,omitempty" xml:"hash,omitempty"`
+	// Source of the revision: sync, import, api, data refresh or rollback.
+	Source *string `form:"source,omitempty" json:"source,omitempty" xml:"source,omitempty"`
+	// Git commit of the revision, if known.
+	Commit *string `form:"commit,omitempty" json:"commit,omitempty" xml:"commit,omitempty"`
+	// Creation time of the revision (Unix timestamp).
+	CreatedAt *int64 `form:"createdAt,omitempty" json:"createdAt,omitempty" xml:"createdAt,omitempty"`
+	// Policy rego source code.
+	Rego *string `form:"rego,omitempty" json:"rego,omitempty" xml:"rego,omitempty"`
+	// Policy static data.
+	Data *string `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Policy static data optional configuration.
+	DataConfig *string `form:"dataConfig,omitempty" json:"dataConfig,omitempty" xml:"dataConfig,omitempty"`
+	// JSON schema for validation of the policy output.
+	OutputSchema *string `form:"outputSchema,omitempty" json:"outputSchema,omitempty" xml:"outputSchema,omitempty"`
+	// Policy bundle export configuration.
+	ExportConfig *string `form:"exportConfig,omitempty" json:"exportConfig,omitempty" xml:"exportConfig,omitempty"`
+}
+
 // ListPoliciesResponseBody is the type of the "policy" service "ListPolicies"
 // endpoint HTTP response body.
 type ListPoliciesResponseBody struct {
 	// JSON array of policies.
 	Policies []*PolicyResponseBody `form:"policies,omitempty" json:"policies,omitempty" xml:"policies,omitempty"`
+	// Cursor for the next page of policies, which is missing on the last page.
+	NextCursor *string `form:"nextCursor,omitempty" json:"nextCursor,omitempty" xml:"nextCursor,omitempty"`
+}
+
+// PolicyDependenciesResponseBody is the type of the "policy" service
+// "PolicyDependencies" endpoint HTTP response body.
+type PolicyDependenciesResponseBody struct {
+	// Dependencies of the policies.
+	Policies []*DependencyReportResponseBody `form:"policies,omitempty" json:"policies,omitempty" xml:"policies,omitempty"`
+}
+
+// GetPolicyResponseBody is the type of the "policy" service "GetPolicy"
+// endpoint HTTP response body.
+type GetPolicyResponseBody struct {
+	// Policy repository.
+	Repository *string `form:"repository,omitempty" json:"repository,omitempty" xml:"repository,omitempty"`
+	// Policy name.
+	PolicyName *string `form:"policyName,omitempty" json:"policyName,omitempty" xml:"policyName,omitempty"`
+	// Policy group.
+	Group *string `form:"group,omitempty" json:"group,omitempty" xml:"group,omitempty"`
+	// Policy version.
+	Version *string `form:"version,omitempty" json:"version,omitempty" xml:"version,omitempty"`
+	// Policy rego source code.
+	Rego *string `form:"rego,omitempty" json:"rego,omitempty" xml:"rego,omitempty"`
+	// Policy static data.
+	Data *string `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Policy static data optional configuration.
+	DataConfig *string `form:"dataConfig,omitempty" json:"dataConfig,omitempty" xml:"dataConfig,omitempty"`
+	// Locked specifies if the policy is locked or allowed to execute.
+	Locked *bool `form:"locked,omitempty" json:"locked,omitempty" xml:"locked,omitempty"`
+	// Last update (Unix timestamp).
+	LastUpdate *int64 `form:"lastUpdate,omitempty" json:"lastUpdate,omitempty" xml:"lastUpdate,omitempty"`
+	// Archived specifies if the policy is removed from its repository and cannot
+	// be evaluated.
+	Archived *bool `form:"archived,omitempty" json:"archived,omitempty" xml:"archived,omitempty"`
+	// Git commit from which the policy is synchronized.
+	Commit *PolicyCommitResponseBody `form:"commit,omitempty" json:"commit,omitempty" xml:"commit,omitempty"`
+	// Policy output JSON schema.
+	OutputSchema *string `form:"outputSchema,omitempty" json:"outputSchema,omitempty" xml:"outputSchema,omitempty"`
+	// Policy export configuration.
+	ExportConfig *string `form:"exportConfig,omitempty" json:"exportConfig,omitempty" xml:"exportConfig,omitempty"`
+	// METADATA annotations of the policy package.
+	Metadata *PolicyMetadataResponseBody `form:"metadata,omitempty" json:"metadata,omitempty" xml:"metadata,omitempty"`
+	// Details of the policy lock.
+	Lock *PolicyLockResponseBody `form:"lock,omitempty" json:"lock,omitempty" xml:"lock,omitempty"`
+}
+
+// SubscriberDeliveriesResponseBody is the type of the "policy" service
+// "SubscriberDeliveries" endpoint HTTP response body.
+type SubscriberDeliveriesResponseBody struct {
+	// JSON array of webhook deliveries, oldest first.
+	Deliveries []*WebhookDeliveryResponseBody `form:"deliveries,omitempty" json:"deliveries,omitempty" xml:"deliveries,omitempty"`
+}
+
+// PolicyRefRequestBody is used to define fields on request body types.
+type PolicyRefRequestBody struct {
+	// Policy repository.
+	Repository string `form:"repository" json:"repository" xml:"repository"`
+	// Policy group.
+	Group string `form:"group" json:"group" xml:"group"`
+	// Policy name.
+	PolicyName string `form:"policyName" json:"policyName" xml:"policyName"`
+	// Policy version.
+	Version string `form:"version" json:"version" xml:"version"`
+}
+
+// PolicyRefResponseBody is used to define fields on response body types.
+type PolicyRefResponseBody struct {
+	// Policy repository.
+	Repository *string `form:"repository,omitempty" json:"repository,omitempty" xml:"repository,omitempty"`
+	// Policy group.
+	Group *string `form:"group,omitempty" json:"group,omitempty" xml:"group,omitempty"`
+	// Policy name.
+	PolicyName *string `form:"policyName,omitempty" json:"policyName,omitempty" xml:"policyName,omitempty"`
+	// Policy version.
+	Version *string `form:"version,omitempty" json:"version,omitempty" xml:"version,omitempty"`
+}
+
+// PolicyRevisionResultResponseBody is used to define fields on response body
+// types.
+type PolicyRevisionResultResponseBody struct {
+	// Revision number.
+	Revision *int `form:"revision,omitempty" json:"revision,omitempty" xml:"revision,omitempty"`
+	// SHA-256 hash of the policy source code, data and configuration.
+	Hash *string `form:"hash,omitempty" json:"hash,omitempty" xml:"hash,omitempty"`
+	// Source of the revision: sync, import, api, data refresh or rollback.
+	Source *string `form:"source,omitempty" json:"source,omitempty" xml:"source,omitempty"`
+	// Git commit of the revision, if known.
+	Commit *string `form:"commit,omitempty" json:"commit,omitempty" xml:"commit,omitempty"`
+	// Creation time of the revision (Unix timestamp).
+	CreatedAt *int64 `form:"createdAt,omitempty" json:"createdAt,omitempty" xml:"createdAt,omitempty"`
+	// Policy rego source code.
+	Rego *string `form:"rego,omitempty" json:"rego,omitempty" xml:"rego,omitempty"`
+	// Policy static data.
+	Data *string `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Policy static data optional configuration.
+	DataConfig *string `form:"dataConfig,omitempty" json:"dataConfig,omitempty" xml:"dataConfig,omitempty"`
+	// JSON schema for validation of the policy output.
+	OutputSchema *string `form:"outputSchema,omitempty" json:"outputSchema,omitempty" xml:"outputSchema,omitempty"`
+	// Policy bundle export configuration.
+	ExportConfig *string `form:"exportConfig,omitempty" json:"exportConfig,omitempty" xml:"exportConfig,omitempty"`
+}
+
+// PolicyRevisionDiffResponseBody is used to define fields on response body
+// types.
+type PolicyRevisionDiffResponseBody struct {
+	// Changed policy field: rego, data, dataConfig, outputSchema or exportConfig.
+	Field *string `form:"field,omitempty" json:"field,omitempty" xml:"field,omitempty"`
+	// Unified diff of the field.
+	Diff *string `form:"diff,omitempty" json:"diff,omitempty" xml:"diff,omitempty"`
 }
 
 // PolicyResponseBody is used to define fields on response body types.
@@ -64,6 +330,173 @@ type PolicyResponseBody struct {
 	Locked *bool `form:"locked,omitempty" json:"locked,omitempty" xml:"locked,omitempty"`
 	// Last update (Unix timestamp).
 	LastUpdate *int64 `form:"lastUpdate,omitempty" json:"lastUpdate,omitempty" xml:"lastUpdate,omitempty"`
+	// Archived specifies if the policy is removed from its repository and cannot
+	// be evaluated.
+	Archived *bool `form:"archived,omitempty" json:"archived,omitempty" xml:"archived,omitempty"`
+	// Git commit from which the policy is synchronized.
+	Commit *PolicyCommitResponseBody `form:"commit,omitempty" json:"commit,omitempty" xml:"commit,omitempty"`
+	// Policy output JSON schema.
+	OutputSchema *string `form:"outputSchema,omitempty" json:"outputSchema,omitempty" xml:"outputSchema,omitempty"`
+	// Policy export configuration.
+	ExportConfig *string `form:"exportConfig,omitempty" json:"exportConfig,omitempty" xml:"exportConfig,omitempty"`
+	// METADATA annotations of the policy package.
+	Metadata *PolicyMetadataResponseBody `form:"metadata,omitempty" json:"metadata,omitempty" xml:"metadata,omitempty"`
+	// Details of the policy lock.
+	Lock *PolicyLockResponseBody `form:"lock,omitempty" json:"lock,omitempty" xml:"lock,omitempty"`
+}
+
+// PolicyCommitResponseBody is used to define fields on response body types.
+type PolicyCommitResponseBody struct {
+	// Commit SHA.
+	Sha *string `form:"sha,omitempty" json:"sha,omitempty" xml:"sha,omitempty"`
+	// Commit time (Unix timestamp).
+	Time *int64 `form:"time,omitempty" json:"time,omitempty" xml:"time,omitempty"`
+	// Commit author.
+	Author *string `form:"author,omitempty" json:"author,omitempty" xml:"author,omitempty"`
+	// Git branch from which the commit is synchronized.
+	Branch *string `form:"branch,omitempty" json:"branch,omitempty" xml:"branch,omitempty"`
+}
+
+// PolicyMetadataResponseBody is used to define fields on response body types.
+type PolicyMetadataResponseBody struct {
+	// Policy title.
+	Title *string `form:"title,omitempty" json:"title,omitempty" xml:"title,omitempty"`
+	// Policy description.
+	Description *string `form:"description,omitempty" json:"description,omitempty" xml:"description,omitempty"`
+	// Policy authors.
+	Authors []string `form:"authors,omitempty" json:"authors,omitempty" xml:"authors,omitempty"`
+	// Organizations of the policy authors.
+	Organizations []string `form:"organizations,omitempty" json:"organizations,omitempty" xml:"organizations,omitempty"`
+	// URLs of resources related to the policy.
+	RelatedResources []string `form:"relatedResources,omitempty" json:"relatedResources,omitempty" xml:"relatedResources,omitempty"`
+	// Custom annotations.
+	Custom map[string]any `form:"custom,omitempty" json:"custom,omitempty" xml:"custom,omitempty"`
+}
+
+// PolicyLockResponseBody is used to define fields on response body types.
+type PolicyLockResponseBody struct {
+	// Reason for locking the policy.
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty" xml:"reason,omitempty"`
+	// Authenticated caller who locked the policy.
+	LockedBy *string `form:"lockedBy,omitempty" json:"lockedBy,omitempty" xml:"lockedBy,omitempty"`
+	// Lock time (Unix timestamp).
+	LockedAt *int64 `form:"lockedAt,omitempty" json:"lockedAt,omitempty" xml:"lockedAt,omitempty"`
+	// Time when the policy is unlocked automatically (Unix timestamp).
+	ExpiresAt *int64 `form:"expiresAt,omitempty" json:"expiresAt,omitempty" xml:"expiresAt,omitempty"`
+}
+
+// DependencyReportResponseBody is used to define fields on response body types.
+type DependencyReportResponseBody struct {
+	// Policy repository.
+	Repository *string `form:"repository,omitempty" json:"repository,omitempty" xml:"repository,omitempty"`
+	// Policy group.
+	Group *string `form:"group,omitempty" json:"group,omitempty" xml:"group,omitempty"`
+	// Policy name.
+	PolicyName *string `form:"policyName,omitempty" json:"policyName,omitempty" xml:"policyName,omitempty"`
+	// Policy version.
+	Version *string `form:"version,omitempty" json:"version,omitempty" xml:"version,omitempty"`
+	// Builtin and extension functions called by the policy.
+	Builtins []string `form:"builtins,omitempty" json:"builtins,omitempty" xml:"builtins,omitempty"`
+	// Packages imported by the policy.
+	Imports []string `form:"imports,omitempty" json:"imports,omitempty" xml:"imports,omitempty"`
+	// Data paths referenced by the policy.
+	DataPaths []string `form:"dataPaths,omitempty" json:"dataPaths,omitempty" xml:"dataPaths,omitempty"`
+	// Error parsing the policy source code.
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+}
+
+// WebhookDeliveryResponseBody is used to define fields on response body types.
+type WebhookDeliveryResponseBody struct {
+	// Delivery ID, which is sent in the X-Policy-Delivery-Id header.
+	ID *string `form:"id,omitempty" json:"id,omitempty" xml:"id,omitempty"`
+	// Policy change event sent to the webhook.
+	Event any `form:"event,omitempty" json:"event,omitempty" xml:"event,omitempty"`
+	// Delivery state: pending, delivered or dead.
+	State *string `form:"state,omitempty" json:"state,omitempty" xml:"state,omitempty"`
+	// Number of delivery attempts.
+	Attempts *int `form:"attempts,omitempty" json:"attempts,omitempty" xml:"attempts,omitempty"`
+	// Error of the last failed attempt.
+	LastError *string `form:"lastError,omitempty" json:"lastError,omitempty" xml:"lastError,omitempty"`
+	// Time of the next attempt of a pending delivery (Unix timestamp).
+	NextAttempt *int64 `form:"nextAttempt,omitempty" json:"nextAttempt,omitempty" xml:"nextAttempt,omitempty"`
+	// Creation time of the delivery (Unix timestamp).
+	CreatedAt *int64 `form:"createdAt,omitempty" json:"createdAt,omitempty" xml:"createdAt,omitempty"`
+	// Time of the last delivery update (Unix timestamp).
+	UpdatedAt *int64 `form:"updatedAt,omitempty" json:"updatedAt,omitempty" xml:"updatedAt,omitempty"`
+}
+
+// NewLockRequestBody builds the HTTP request body from the payload of the
+// "Lock" endpoint of the "policy" service.
+func NewLockRequestBody(p *policy.LockRequest) *LockRequestBody {
+	body := &LockRequestBody{
+		Reason:    p.Reason,
+		ExpiresAt: p.ExpiresAt,
+	}
+	return body
+}
+
+// NewBulkLockRequestBody builds the HTTP request body from the payload of the
+// "BulkLock" endpoint of the "policy" service.
+func NewBulkLockRequestBody(p *policy.BulkLockRequest) *BulkLockRequestBody {
+	body := &BulkLockRequestBody{
+		Repository: p.Repository,
+		Group:      p.Group,
+		PolicyName: p.PolicyName,
+		Version:    p.Version,
+		Reason:     p.Reason,
+		ExpiresAt:  p.ExpiresAt,
+	}
+	if p.Policies != nil {
+		body.Policies = make([]*PolicyRefRequestBody, len(p.Policies))
+		for i, val := range p.Policies {
+			body.Policies[i] = marshalPolicyPolicyRefToPolicyRefRequestBody(val)
+		}
+	}
+	return body
+}
+
+// NewBulkUnlockRequestBody builds the HTTP request body from the payload of
+// the "BulkUnlock" endpoint of the "policy" service.
+func NewBulkUnlockRequestBody(p *policy.BulkUnlockRequest) *BulkUnlockRequestBody {
+	body := &BulkUnlockRequestBody{
+		Repository: p.Repository,
+		Group:      p.Group,
+		PolicyName: p.PolicyName,
+		Version:    p.Version,
+	}
+	if p.Policies != nil {
+		body.Policies = make([]*PolicyRefRequestBody, len(p.Policies))
+		for i, val := range p.Policies {
+			body.Policies[i] = marshalPolicyPolicyRefToPolicyRefRequestBody(val)
+		}
+	}
+	return body
+}
+
+// NewCreatePolicyRequestBody builds the HTTP request body from the payload of
+// the "CreatePolicy" endpoint of the "policy" service.
+func NewCreatePolicyRequestBody(p *policy.PolicyRequest) *CreatePolicyRequestBody {
+	body := &CreatePolicyRequestBody{
+		Rego:         p.Rego,
+		Data:         p.Data,
+		DataConfig:   p.DataConfig,
+		OutputSchema: p.OutputSchema,
+		ExportConfig: p.ExportConfig,
+	}
+	return body
+}
+
+// NewUpdatePolicyRequestBody builds the HTTP request body from the payload of
+// the "UpdatePolicy" endpoint of the "policy" service.
+func NewUpdatePolicyRequestBody(p *policy.PolicyRequest) *UpdatePolicyRequestBody {
+	body := &UpdatePolicyRequestBody{
+		Rego:         p.Rego,
+		Data:         p.Data,
+		DataConfig:   p.DataConfig,
+		OutputSchema: p.OutputSchema,
+		ExportConfig: p.ExportConfig,
+	}
+	return body
 }
 
 // NewSetPolicyAutoImportRequestBody builds the HTTP request body from the
@@ -119,6 +552,95 @@ func NewValidateEvaluateResultOK(body any, eTag string) *policy.EvaluateResult {
 	return res
 }
 
+// NewBulkLockResultOK builds a "policy" service "BulkLock" endpoint result
+// from a HTTP "OK" response.
+func NewBulkLockResultOK(body *BulkLockResponseBody) *policy.BulkLockResult {
+	v := &policy.BulkLockResult{}
+	v.Policies = make([]*policy.PolicyRef, len(body.Policies))
+	for i, val := range body.Policies {
+		v.Policies[i] = unmarshalPolicyRefResponseBodyToPolicyPolicyRef(val)
+	}
+
+	return v
+}
+
+// NewBulkUnlockBulkLockResultOK builds a "policy" service "BulkUnlock"
+// endpoint result from a HTTP "OK" response.
+func NewBulkUnlockBulkLockResultOK(body *BulkUnlockResponseBody) *policy.BulkLockResult {
+	v := &policy.BulkLockResult{}
+	v.Policies = make([]*policy.PolicyRef, len(body.Policies))
+	for i, val := range body.Policies {
+		v.Policies[i] = unmarshalPolicyRefResponseBodyToPolicyPolicyRef(val)
+	}
+
+	return v
+}
+
+// NewPolicyRevisionsResultOK builds a "policy" service "PolicyRevisions"
+// endpoint result from a HTTP "OK" response.
+func NewPolicyRevisionsResultOK(body *PolicyRevisionsResponseBody) *policy.PolicyRevisionsResult {
+	v := &policy.PolicyRevisionsResult{}
+	v.Revisions = make([]*policy.PolicyRevisionResult, len(body.Revisions))
+	for i, val := range body.Revisions {
+		v.Revisions[i] = unmarshalPolicyRevisionResultResponseBodyToPolicyPolicyRevisionResult(val)
+	}
+
+	return v
+}
+
+// NewPolicyRevisionResultOK builds a "policy" service "PolicyRevision"
+// endpoint result from a HTTP "OK" response.
+func NewPolicyRevisionResultOK(body *PolicyRevisionResponseBody) *policy.PolicyRevisionResult {
+	v := &policy.PolicyRevisionResult{
+		Revision:     *body.Revision,
+		Hash:         *body.Hash,
+		Source:       *body.Source,
+		Commit:       body.Commit,
+		CreatedAt:    *body.CreatedAt,
+		Rego:         body.Rego,
+		Data:         body.Data,
+		DataConfig:   body.DataConfig,
+		OutputSchema: body.OutputSchema,
+		ExportConfig: body.ExportConfig,
+	}
+
+	return v
+}
+
+// NewDiffPolicyRevisionsResultOK builds a "policy" service
+// "DiffPolicyRevisions" endpoint result from a HTTP "OK" response.
+func NewDiffPolicyRevisionsResultOK(body *DiffPolicyRevisionsResponseBody) *policy.DiffPolicyRevisionsResult {
+	v := &policy.DiffPolicyRevisionsResult{
+		From: *body.From,
+		To:   *body.To,
+	}
+	v.Changes = make([]*policy.PolicyRevisionDiff, len(body.Changes))
+	for i, val := range body.Changes {
+		v.Changes[i] = unmarshalPolicyRevisionDiffResponseBodyToPolicyPolicyRevisionDiff(val)
+	}
+
+	return v
+}
+
+// NewRollbackPolicyPolicyRevisionResultOK builds a "policy" service
+// "RollbackPolicy" endpoint result from a HTTP "OK" response.
+func NewRollbackPolicyPolicyRevisionResultOK(body *RollbackPolicyResponseBody) *policy.PolicyRevisionResult {
+	v := &policy.PolicyRevisionResult{
+		Revision:     *body.Revision,
+		Hash:         *body.Hash,
+		Source:       *body.Source,
+		Commit:       body.Commit,
+		CreatedAt:    *body.CreatedAt,
+		Rego:         body.Rego,
+		Data:         body.Data,
+		DataConfig:   body.DataConfig,
+		OutputSchema: body.OutputSchema,
+		ExportConfig: body.ExportConfig,
+	}
+
+	return v
+}
+
 // NewExportBundleResultOK builds a "policy" service "ExportBundle" endpoint
 // result from a HTTP "OK" response.
 func NewExportBundleResultOK(contentType string, contentLength int, contentDisposition string) *policy.ExportBundleResult {
@@ -130,10 +652,23 @@ func NewExportBundleResultOK(contentType string, contentLength int, contentDispo
 	return v
 }
 
+// NewExportWasmBundleExportBundleResultOK builds a "policy" service
+// "ExportWasmBundle" endpoint result from a HTTP "OK" response.
+func NewExportWasmBundleExportBundleResultOK(contentType string, contentLength int, contentDisposition string) *policy.ExportBundleResult {
+	v := &policy.ExportBundleResult{}
+	v.ContentType = contentType
+	v.ContentLength = contentLength
+	v.ContentDisposition = contentDisposition
+
+	return v
+}
+
 // NewListPoliciesPoliciesResultOK builds a "policy" service "ListPolicies"
 // endpoint result from a HTTP "OK" response.
 func NewListPoliciesPoliciesResultOK(body *ListPoliciesResponseBody) *policy.PoliciesResult {
-	v := &policy.PoliciesResult{}
+	v := &policy.PoliciesResult{
+		NextCursor: body.NextCursor,
+	}
 	v.Policies = make([]*policy.Policy, len(body.Policies))
 	for i, val := range body.Policies {
 		v.Policies[i] = unmarshalPolicyResponseBodyToPolicyPolicy(val)
@@ -142,6 +677,166 @@ func NewListPoliciesPoliciesResultOK(body *ListPoliciesResponseBody) *policy.Pol
 	return v
 }
 
+// NewPolicyDependenciesResultOK builds a "policy" service "PolicyDependencies"
+// endpoint result from a HTTP "OK" response.
+func NewPolicyDependenciesResultOK(body *PolicyDependenciesResponseBody) *policy.PolicyDependenciesResult {
+	v := &policy.PolicyDependenciesResult{}
+	v.Policies = make([]*policy.DependencyReport, len(body.Policies))
+	for i, val := range body.Policies {
+		v.Policies[i] = unmarshalDependencyReportResponseBodyToPolicyDependencyReport(val)
+	}
+
+	return v
+}
+
+// NewGetPolicyPolicyOK builds a "policy" service "GetPolicy" endpoint result
+// from a HTTP "OK" response.
+func NewGetPolicyPolicyOK(body *GetPolicyResponseBody) *policy.Policy {
+	v := &policy.Policy{
+		Repository:   *body.Repository,
+		PolicyName:   *body.PolicyName,
+		Group:        *body.Group,
+		Version:      *body.Version,
+		Rego:         body.Rego,
+		Data:         body.Data,
+		DataConfig:   body.DataConfig,
+		Locked:       *body.Locked,
+		LastUpdate:   *body.LastUpdate,
+		Archived:     body.Archived,
+		OutputSchema: body.OutputSchema,
+		ExportConfig: body.ExportConfig,
+	}
+	if body.Commit != nil {
+		v.Commit = unmarshalPolicyCommitResponseBodyToPolicyPolicyCommit(body.Commit)
+	}
+	if body.Metadata != nil {
+		v.Metadata = unmarshalPolicyMetadataResponseBodyToPolicyPolicyMetadata(body.Metadata)
+	}
+	if body.Lock != nil {
+		v.Lock = unmarshalPolicyLockResponseBodyToPolicyPolicyLock(body.Lock)
+	}
+
+	return v
+}
+
+// NewSubscriberDeliveriesResultOK builds a "policy" service
+// "SubscriberDeliveries" endpoint result from a HTTP "OK" response.
+func NewSubscriberDeliveriesResultOK(body *SubscriberDeliveriesResponseBody) *policy.SubscriberDeliveriesResult {
+	v := &policy.SubscriberDeliveriesResult{}
+	v.Deliveries = make([]*policy.WebhookDelivery, len(body.Deliveries))
+	for i, val := range body.Deliveries {
+		v.Deliveries[i] = unmarshalWebhookDeliveryResponseBodyToPolicyWebhookDelivery(val)
+	}
+
+	return v
+}
+
+// ValidateBulkLockResponseBody runs the validations defined on
+// BulkLockResponseBody
+func ValidateBulkLockResponseBody(body *BulkLockResponseBody) (err error) {
+	if body.Policies == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("policies", "body"))
+	}
+	for _, e := range body.Policies {
+		if e != nil {
+			if err2 := ValidatePolicyRefResponseBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// ValidateBulkUnlockResponseBody runs the validations defined on
+// BulkUnlockResponseBody
+func ValidateBulkUnlockResponseBody(body *BulkUnlockResponseBody) (err error) {
+	if body.Policies == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("policies", "body"))
+	}
+	for _, e := range body.Policies {
+		if e != nil {
+			if err2 := ValidatePolicyRefResponseBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// ValidatePolicyRevisionsResponseBody runs the validations defined on
+// PolicyRevisionsResponseBody
+func ValidatePolicyRevisionsResponseBody(body *PolicyRevisionsResponseBody) (err error) {
+	if body.Revisions == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("revisions", "body"))
+	}
+	for _, e := range body.Revisions {
+		if e != nil {
+			if err2 := ValidatePolicyRevisionResultResponseBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// ValidatePolicyRevisionResponseBody runs the validations defined on
+// PolicyRevisionResponseBody
+func ValidatePolicyRevisionResponseBody(body *PolicyRevisionResponseBody) (err error) {
+	if body.Revision == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("revision", "body"))
+	}
+	if body.Hash == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("hash", "body"))
+	}
+	if body.Source == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("source", "body"))
+	}
+	if body.CreatedAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("createdAt", "body"))
+	}
+	return
+}
+
+// ValidateDiffPolicyRevisionsResponseBody runs the validations defined on
+// DiffPolicyRevisionsResponseBody
+func ValidateDiffPolicyRevisionsResponseBody(body *DiffPolicyRevisionsResponseBody) (err error) {
+	if body.From == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("from", "body"))
+	}
+	if body.To == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("to", "body"))
+	}
+	if body.Changes == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("changes", "body"))
+	}
+	for _, e := range body.Changes {
+		if e != nil {
+			if err2 := ValidatePolicyRevisionDiffResponseBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// ValidateRollbackPolicyResponseBody runs the validations defined on
+// RollbackPolicyResponseBody
+func ValidateRollbackPolicyResponseBody(body *RollbackPolicyResponseBody) (err error) {
+	if body.Revision == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("revision", "body"))
+	}
+	if body.Hash == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("hash", "body"))
+	}
+	if body.Source == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("source", "body"))
+	}
+	if body.CreatedAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("createdAt", "body"))
+	}
+	return
+}
+
 // ValidateListPoliciesResponseBody runs the validations defined on
 // ListPoliciesResponseBody
 func ValidateListPoliciesResponseBody(body *ListPoliciesResponseBody) (err error) {
@@ -158,6 +853,120 @@ func ValidateListPoliciesResponseBody(body *ListPoliciesResponseBody) (err error
 	return
 }
 
+// ValidatePolicyDependenciesResponseBody runs the validations defined on
+// PolicyDependenciesResponseBody
+func ValidatePolicyDependenciesResponseBody(body *PolicyDependenciesResponseBody) (err error) {
+	if body.Policies == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("policies", "body"))
+	}
+	for _, e := range body.Policies {
+		if e != nil {
+			if err2 := ValidateDependencyReportResponseBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// ValidateGetPolicyResponseBody runs the validations defined on
+// GetPolicyResponseBody
+func ValidateGetPolicyResponseBody(body *GetPolicyResponseBody) (err error) {
+	if body.Repository == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("repository", "body"))
+	}
+	if body.Group == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("group", "body"))
+	}
+	if body.PolicyName == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("policyName", "body"))
+	}
+	if body.Version == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("version", "body"))
+	}
+	if body.Locked == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("locked", "body"))
+	}
+	if body.LastUpdate == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("lastUpdate", "body"))
+	}
+	if body.Commit != nil {
+		if err2 := ValidatePolicyCommitResponseBody(body.Commit); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	if body.Lock != nil {
+		if err2 := ValidatePolicyLockResponseBody(body.Lock); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	return
+}
+
+// ValidateSubscriberDeliveriesResponseBody runs the validations defined on
+// SubscriberDeliveriesResponseBody
+func ValidateSubscriberDeliveriesResponseBody(body *SubscriberDeliveriesResponseBody) (err error) {
+	if body.Deliveries == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("deliveries", "body"))
+	}
+	for _, e := range body.Deliveries {
+		if e != nil {
+			if err2 := ValidateWebhookDeliveryResponseBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// ValidatePolicyRefResponseBody runs the validations defined on
+// PolicyRefResponseBody
+func ValidatePolicyRefResponseBody(body *PolicyRefResponseBody) (err error) {
+	if body.Repository == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("repository", "body"))
+	}
+	if body.Group == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("group", "body"))
+	}
+	if body.PolicyName == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("policyName", "body"))
+	}
+	if body.Version == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("version", "body"))
+	}
+	return
+}
+
+// ValidatePolicyRevisionResultResponseBody runs the validations defined on
+// PolicyRevisionResultResponseBody
+func ValidatePolicyRevisionResultResponseBody(body *PolicyRevisionResultResponseBody) (err error) {
+	if body.Revision == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("revision", "body"))
+	}
+	if body.Hash == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("hash", "body"))
+	}
+	if body.Source == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("source", "body"))
+	}
+	if body.CreatedAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("createdAt", "body"))
+	}
+	return
+}
+
+// ValidatePolicyRevisionDiffResponseBody runs the validations defined on
+// PolicyRevisionDiffResponseBody
+func ValidatePolicyRevisionDiffResponseBody(body *PolicyRevisionDiffResponseBody) (err error) {
+	if body.Field == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("field", "body"))
+	}
+	if body.Diff == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("diff", "body"))
+	}
+	return
+}
+
 // ValidatePolicyResponseBody runs the validations defined on PolicyResponseBody
 func ValidatePolicyResponseBody(body *PolicyResponseBody) (err error) {
 	if body.Repository == nil {
@@ -178,5 +987,90 @@ func ValidatePolicyResponseBody(body *PolicyResponseBody) (err error) {
 	if body.LastUpdate == nil {
 		err = goa.MergeErrors(err, goa.MissingFieldError("lastUpdate", "body"))
 	}
+	if body.Commit != nil {
+		if err2 := ValidatePolicyCommitResponseBody(body.Commit); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	if body.Lock != nil {
+		if err2 := ValidatePolicyLockResponseBody(body.Lock); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	return
+}
+
+// ValidatePolicyCommitResponseBody runs the validations defined on
+// PolicyCommitResponseBody
+func ValidatePolicyCommitResponseBody(body *PolicyCommitResponseBody) (err error) {
+	if body.Sha == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("sha", "body"))
+	}
+	if body.Time == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("time", "body"))
+	}
+	if body.Author == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("author", "body"))
+	}
+	return
+}
+
+// ValidatePolicyLockResponseBody runs the validations defined on
+// PolicyLockResponseBody
+func ValidatePolicyLockResponseBody(body *PolicyLockResponseBody) (err error) {
+	if body.LockedAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("lockedAt", "body"))
+	}
+	return
+}
+
+// ValidateDependencyReportResponseBody runs the validations defined on
+// DependencyReportResponseBody
+func ValidateDependencyReportResponseBody(body *DependencyReportResponseBody) (err error) {
+	if body.Repository == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("repository", "body"))
+	}
+	if body.Group == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("group", "body"))
+	}
+	if body.PolicyName == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("policyName", "body"))
+	}
+	if body.Version == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("version", "body"))
+	}
+	if body.Builtins == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("builtins", "body"))
+	}
+	if body.Imports == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("imports", "body"))
+	}
+	if body.DataPaths == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("dataPaths", "body"))
+	}
+	return
+}
+
+// ValidateWebhookDeliveryResponseBody runs the validations defined on
+// WebhookDeliveryResponseBody
+func ValidateWebhookDeliveryResponseBody(body *WebhookDeliveryResponseBody) (err error) {
+	if body.ID == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("id", "body"))
+	}
+	if body.Event == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("event", "body"))
+	}
+	if body.State == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("state", "body"))
+	}
+	if body.Attempts == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("attempts", "body"))
+	}
+	if body.CreatedAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("createdAt", "body"))
+	}
+	if body.UpdatedAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("updatedAt", "body"))
+	}
 	return
 }
@@ -167,6 +167,22 @@ func EncodeLockResponse(encoder func(context.Context, http.ResponseWriter) goaht
 // endpoint.
 func DecodeLockRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
 	return func(r *http.Request) (any, error) {
+		var (
+			body LockRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateLockRequestBody(&body)
+		if err != nil {
+			return nil, err
+		}
+
 		var (
 			repository string
 			group      string
@@ -179,7 +195,7 @@ func DecodeLockRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.De
 		group = params["group"]
 		policyName = params["policyName"]
 		version = params["version"]
-		payload := NewLockRequest(repository, group, policyName, version)
+		payload := NewLockRequest(&body, repository, group, policyName, version)
 
 		return payload, nil
 	}
@@ -216,6 +232,413 @@ func DecodeUnlockRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.
 	}
 }
 
+// EncodeBulkLockResponse returns an encoder for responses returned by the
+// policy BulkLock endpoint.
+func EncodeBulkLockResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.BulkLockResult)
+		enc := encoder(ctx, w)
+		body := NewBulkLockResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeBulkLockRequest returns a decoder for requests sent to the policy
+// BulkLock endpoint.
+func DecodeBulkLockRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			body BulkLockRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateBulkLockRequestBody(&body)
+		if err != nil {
+			return nil, err
+		}
+		payload := NewBulkLockRequest(&body)
+
+		return payload, nil
+	}
+}
+
+// EncodeBulkUnlockResponse returns an encoder for responses returned by the
+// policy BulkUnlock endpoint.
+func EncodeBulkUnlockResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.BulkLockResult)
+		enc := encoder(ctx, w)
+		body := NewBulkUnlockResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeBulkUnlockRequest returns a decoder for requests sent to the policy
+// BulkUnlock endpoint.
+func DecodeBulkUnlockRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			body BulkUnlockRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateBulkUnlockRequestBody(&body)
+		if err != nil {
+			return nil, err
+		}
+		payload := NewBulkUnlockRequest(&body)
+
+		return payload, nil
+	}
+}
+
+// EncodeCreatePolicyResponse returns an encoder for responses returned by the
+// policy CreatePolicy endpoint.
+func EncodeCreatePolicyResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(any)
+		enc := encoder(ctx, w)
+		body := res
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeCreatePolicyRequest returns a decoder for requests sent to the policy
+// CreatePolicy endpoint.
+func DecodeCreatePolicyRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			body CreatePolicyRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateCreatePolicyRequestBody(&body)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			repository string
+			group      string
+			policyName string
+			version    string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		payload := NewCreatePolicyPolicyRequest(&body, repository, group, policyName, version)
+
+		return payload, nil
+	}
+}
+
+// EncodeUpdatePolicyResponse returns an encoder for responses returned by the
+// policy UpdatePolicy endpoint.
+func EncodeUpdatePolicyResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(any)
+		enc := encoder(ctx, w)
+		body := res
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeUpdatePolicyRequest returns a decoder for requests sent to the policy
+// UpdatePolicy endpoint.
+func DecodeUpdatePolicyRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			body UpdatePolicyRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateUpdatePolicyRequestBody(&body)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			repository string
+			group      string
+			policyName string
+			version    string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		payload := NewUpdatePolicyPolicyRequest(&body, repository, group, policyName, version)
+
+		return payload, nil
+	}
+}
+
+// EncodeDeletePolicyResponse returns an encoder for responses returned by the
+// policy DeletePolicy endpoint.
+func EncodeDeletePolicyResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+}
+
+// DecodeDeletePolicyRequest returns a decoder for requests sent to the policy
+// DeletePolicy endpoint.
+func DecodeDeletePolicyRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			repository string
+			group      string
+			policyName string
+			version    string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		payload := NewDeletePolicyRequest(repository, group, policyName, version)
+
+		return payload, nil
+	}
+}
+
+// EncodePolicyRevisionsResponse returns an encoder for responses returned by
+// the policy PolicyRevisions endpoint.
+func EncodePolicyRevisionsResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.PolicyRevisionsResult)
+		enc := encoder(ctx, w)
+		body := NewPolicyRevisionsResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodePolicyRevisionsRequest returns a decoder for requests sent to the
+// policy PolicyRevisions endpoint.
+func DecodePolicyRevisionsRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			repository string
+			group      string
+			policyName string
+			version    string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		payload := NewPolicyRevisionsRequest(repository, group, policyName, version)
+
+		return payload, nil
+	}
+}
+
+// EncodePolicyRevisionResponse returns an encoder for responses returned by
+// the policy PolicyRevision endpoint.
+func EncodePolicyRevisionResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.PolicyRevisionResult)
+		enc := encoder(ctx, w)
+		body := NewPolicyRevisionResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodePolicyRevisionRequest returns a decoder for requests sent to the
+// policy PolicyRevision endpoint.
+func DecodePolicyRevisionRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			repository string
+			group      string
+			policyName string
+			version    string
+			revision   int
+			err        error
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		{
+			revisionRaw := params["revision"]
+			v, err2 := strconv.ParseInt(revisionRaw, 10, strconv.IntSize)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("revision", revisionRaw, "integer"))
+			}
+			revision = int(v)
+		}
+		if revision < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("revision", revision, 1, true))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewPolicyRevisionRequest(repository, group, policyName, version, revision)
+
+		return payload, nil
+	}
+}
+
+// EncodeDiffPolicyRevisionsResponse returns an encoder for responses returned
+// by the policy DiffPolicyRevisions endpoint.
+func EncodeDiffPolicyRevisionsResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.DiffPolicyRevisionsResult)
+		enc := encoder(ctx, w)
+		body := NewDiffPolicyRevisionsResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeDiffPolicyRevisionsRequest returns a decoder for requests sent to the
+// policy DiffPolicyRevisions endpoint.
+func DecodeDiffPolicyRevisionsRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			repository string
+			group      string
+			policyName string
+			version    string
+			from       int
+			to         int
+			err        error
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		{
+			fromRaw := r.URL.Query().Get("from")
+			if fromRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("from", "query string"))
+			}
+			v, err2 := strconv.ParseInt(fromRaw, 10, strconv.IntSize)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("from", fromRaw, "integer"))
+			}
+			from = int(v)
+		}
+		if from < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("from", from, 1, true))
+		}
+		{
+			toRaw := r.URL.Query().Get("to")
+			if toRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("to", "query string"))
+			}
+			v, err2 := strconv.ParseInt(toRaw, 10, strconv.IntSize)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("to", toRaw, "integer"))
+			}
+			to = int(v)
+		}
+		if to < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("to", to, 1, true))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewDiffPolicyRevisionsRequest(repository, group, policyName, version, from, to)
+
+		return payload, nil
+	}
+}
+
+// EncodeRollbackPolicyResponse returns an encoder for responses returned by
+// the policy RollbackPolicy endpoint.
+func EncodeRollbackPolicyResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.PolicyRevisionResult)
+		enc := encoder(ctx, w)
+		body := NewRollbackPolicyResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeRollbackPolicyRequest returns a decoder for requests sent to the
+// policy RollbackPolicy endpoint.
+func DecodeRollbackPolicyRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			repository string
+			group      string
+			policyName string
+			version    string
+			revision   int
+			err        error
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		{
+			revisionRaw := params["revision"]
+			v, err2 := strconv.ParseInt(revisionRaw, 10, strconv.IntSize)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("revision", revisionRaw, "integer"))
+			}
+			revision = int(v)
+		}
+		if revision < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("revision", revision, 1, true))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewRollbackPolicyRequest(repository, group, policyName, version, revision)
+
+		return payload, nil
+	}
+}
+
 // EncodeExportBundleResponse returns an encoder for responses returned by the
 // policy ExportBundle endpoint.
 func EncodeExportBundleResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
@@ -236,6 +659,59 @@ func EncodeExportBundleResponse(encoder func(context.Context, http.ResponseWrite
 // DecodeExportBundleRequest returns a decoder for requests sent to the policy
 // ExportBundle endpoint.
 func DecodeExportBundleRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			repository string
+			group      string
+			policyName string
+			version    string
+			target     string
+			err        error
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		targetRaw := r.URL.Query().Get("target")
+		if targetRaw != "" {
+			target = targetRaw
+		} else {
+			target = "rego"
+		}
+		if !(target == "rego" || target == "wasm") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("target", target, []any{"rego", "wasm"}))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewExportBundleRequest(repository, group, policyName, version, target)
+
+		return payload, nil
+	}
+}
+
+// EncodeExportWasmBundleResponse returns an encoder for responses returned by
+// the policy ExportWasmBundle endpoint.
+func EncodeExportWasmBundleResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.ExportBundleResult)
+		w.Header().Set("Content-Type", res.ContentType)
+		{
+			val := res.ContentLength
+			contentLengths := strconv.Itoa(val)
+			w.Header().Set("Content-Length", contentLengths)
+		}
+		w.Header().Set("Content-Disposition", res.ContentDisposition)
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+}
+
+// DecodeExportWasmBundleRequest returns a decoder for requests sent to the
+// policy ExportWasmBundle endpoint.
+func DecodeExportWasmBundleRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
 	return func(r *http.Request) (any, error) {
 		var (
 			repository string
@@ -249,7 +725,7 @@ func DecodeExportBundleRequest(mux goahttp.Muxer, decoder func(*http.Request) go
 		group = params["group"]
 		policyName = params["policyName"]
 		version = params["version"]
-		payload := NewExportBundleRequest(repository, group, policyName, version)
+		payload := NewExportWasmBundleRequest(repository, group, policyName, version)
 
 		return payload, nil
 	}
@@ -346,12 +822,21 @@ func EncodeListPoliciesResponse(encoder func(context.Context, http.ResponseWrite
 func DecodeListPoliciesRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
 	return func(r *http.Request) (any, error) {
 		var (
-			locked     *bool
-			policyName *string
-			rego       *bool
-			data       *bool
-			dataConfig *bool
-			err        error
+			locked       *bool
+			policyName   *string
+			rego         *bool
+			data         *bool
+			dataConfig   *bool
+			repository   *string
+			group        *string
+			version      *string
+			updatedSince *string
+			sort         string
+			order        string
+			cursor       *string
+			limit        *int
+			annotation   []string
+			err          error
 		)
 		{
 			lockedRaw := r.URL.Query().Get("locked")
@@ -397,10 +882,141 @@ func DecodeListPoliciesRequest(mux goahttp.Muxer, decoder func(*http.Request) go
 				dataConfig = &v
 			}
 		}
+		repositoryRaw := r.URL.Query().Get("repository")
+		if repositoryRaw != "" {
+			repository = &repositoryRaw
+		}
+		groupRaw := r.URL.Query().Get("group")
+		if groupRaw != "" {
+			group = &groupRaw
+		}
+		versionRaw := r.URL.Query().Get("version")
+		if versionRaw != "" {
+			version = &versionRaw
+		}
+		updatedSinceRaw := r.URL.Query().Get("updatedSince")
+		if updatedSinceRaw != "" {
+			updatedSince = &updatedSinceRaw
+		}
+		if updatedSince != nil {
+			err = goa.MergeErrors(err, goa.ValidateFormat("updatedSince", *updatedSince, goa.FormatDateTime))
+		}
+		sortRaw := r.URL.Query().Get("sort")
+		if sortRaw != "" {
+			sort = sortRaw
+		} else {
+			sort = "name"
+		}
+		if !(sort == "name" || sort == "lastUpdate") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("sort", sort, []any{"name", "lastUpdate"}))
+		}
+		orderRaw := r.URL.Query().Get("order")
+		if orderRaw != "" {
+			order = orderRaw
+		} else {
+			order = "asc"
+		}
+		if !(order == "asc" || order == "desc") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("order", order, []any{"asc", "desc"}))
+		}
+		cursorRaw := r.URL.Query().Get("cursor")
+		if cursorRaw != "" {
+			cursor = &cursorRaw
+		}
+		{
+			limitRaw := r.URL.Query().Get("limit")
+			if limitRaw != "" {
+				v, err2 := strconv.ParseInt(limitRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("limit", limitRaw, "integer"))
+				}
+				pv := int(v)
+				limit = &pv
+			}
+		}
+		if limit != nil {
+			if *limit < 1 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("limit", *limit, 1, true))
+			}
+		}
+		if limit != nil {
+			if *limit > 1000 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("limit", *limit, 1000, false))
+			}
+		}
+		annotation = r.URL.Query()["annotation"]
 		if err != nil {
 			return nil, err
 		}
-		payload := NewListPoliciesPoliciesRequest(locked, policyName, rego, data, dataConfig)
+		payload := NewListPoliciesPoliciesRequest(locked, policyName, rego, data, dataConfig, repository, group, version, updatedSince, sort, order, cursor, limit, annotation)
+
+		return payload, nil
+	}
+}
+
+// EncodePolicyDependenciesResponse returns an encoder for responses returned
+// by the policy PolicyDependencies endpoint.
+func EncodePolicyDependenciesResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.PolicyDependenciesResult)
+		enc := encoder(ctx, w)
+		body := NewPolicyDependenciesResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodePolicyDependenciesRequest returns a decoder for requests sent to the
+// policy PolicyDependencies endpoint.
+func DecodePolicyDependenciesRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			builtin    *string
+			repository *string
+		)
+		builtinRaw := r.URL.Query().Get("builtin")
+		if builtinRaw != "" {
+			builtin = &builtinRaw
+		}
+		repositoryRaw := r.URL.Query().Get("repository")
+		if repositoryRaw != "" {
+			repository = &repositoryRaw
+		}
+		payload := NewPolicyDependenciesRequest(builtin, repository)
+
+		return payload, nil
+	}
+}
+
+// EncodeGetPolicyResponse returns an encoder for responses returned by the
+// policy GetPolicy endpoint.
+func EncodeGetPolicyResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.Policy)
+		enc := encoder(ctx, w)
+		body := NewGetPolicyResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeGetPolicyRequest returns a decoder for requests sent to the policy
+// GetPolicy endpoint.
+func DecodeGetPolicyRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			repository string
+			group      string
+			policyName string
+			version    string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		payload := NewGetPolicyRequest(repository, group, policyName, version)
 
 		return payload, nil
 	}
@@ -542,19 +1158,257 @@ func DecodeSubscribeForPolicyChangeRequest(mux goahttp.Muxer, decoder func(*http
 	}
 }
 
+// EncodeSubscriberDeliveriesResponse returns an encoder for responses returned
+// by the policy SubscriberDeliveries endpoint.
+func EncodeSubscriberDeliveriesResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.SubscriberDeliveriesResult)
+		enc := encoder(ctx, w)
+		body := NewSubscriberDeliveriesResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeSubscriberDeliveriesRequest returns a decoder for requests sent to the
+// policy SubscriberDeliveries endpoint.
+func DecodeSubscriberDeliveriesRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			repository string
+			group      string
+			policyName string
+			version    string
+			subscriber string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		subscriber = params["subscriber"]
+		payload := NewSubscriberDeliveriesRequest(repository, group, policyName, version, subscriber)
+
+		return payload, nil
+	}
+}
+
+// unmarshalPolicyRefRequestBodyToPolicyPolicyRef builds a value of type
+// *policy.PolicyRef from a value of type *PolicyRefRequestBody.
+func unmarshalPolicyRefRequestBodyToPolicyPolicyRef(v *PolicyRefRequestBody) *policy.PolicyRef {
+	if v == nil {
+		return nil
+	}
+	res := &policy.PolicyRef{
+		Repository: *v.Repository,
+		Group:      *v.Group,
+		PolicyName: *v.PolicyName,
+		Version:    *v.Version,
+	}
+
+	return res
+}
+
+// marshalPolicyPolicyRefToPolicyRefResponseBody builds a value of type
+// *PolicyRefResponseBody from a value of type *policy.PolicyRef.
+func marshalPolicyPolicyRefToPolicyRefResponseBody(v *policy.PolicyRef) *PolicyRefResponseBody {
+	res := &PolicyRefResponseBody{
+		Repository: v.Repository,
+		Group:      v.Group,
+		PolicyName: v.PolicyName,
+		Version:    v.Version,
+	}
+
+	return res
+}
+
+// marshalPolicyPolicyRevisionResultToPolicyRevisionResultResponseBody builds a
+// value of type *PolicyRevisionResultResponseBody from a value of type
+// *policy.PolicyRevisionResult.
+func marshalPolicyPolicyRevisionResultToPolicyRevisionResultResponseBody(v *policy.PolicyRevisionResult) *PolicyRevisionResultResponseBody {
+	res := &PolicyRevisionResultResponseBody{
+		Revision:     v.Revision,
+		Hash:         v.Hash,
+		Source:       v.Source,
+		Commit:       v.Commit,
+		CreatedAt:    v.CreatedAt,
+		Rego:         v.Rego,
+		Data:         v.Data,
+		DataConfig:   v.DataConfig,
+		OutputSchema: v.OutputSchema,
+		ExportConfig: v.ExportConfig,
+	}
+
+	return res
+}
+
+// marshalPolicyPolicyRevisionDiffToPolicyRevisionDiffResponseBody builds a
+// value of type *PolicyRevisionDiffResponseBody from a value of type
+// *policy.PolicyRevisionDiff.
+func marshalPolicyPolicyRevisionDiffToPolicyRevisionDiffResponseBody(v *policy.PolicyRevisionDiff) *PolicyRevisionDiffResponseBody {
+	res := &PolicyRevisionDiffResponseBody{
+		Field: v.Field,
+		Diff:  v.Diff,
+	}
+
+	return res
+}
+
 // marshalPolicyPolicyToPolicyResponseBody builds a value of type
 // *PolicyResponseBody from a value of type *policy.Policy.
 func marshalPolicyPolicyToPolicyResponseBody(v *policy.Policy) *PolicyResponseBody {
 	res := &PolicyResponseBody{
+		Repository:   v.Repository,
+		PolicyName:   v.PolicyName,
+		Group:        v.Group,
+		Version:      v.Version,
+		Rego:         v.Rego,
+		Data:         v.Data,
+		DataConfig:   v.DataConfig,
+		Locked:       v.Locked,
+		LastUpdate:   v.LastUpdate,
+		Archived:     v.Archived,
+		OutputSchema: v.OutputSchema,
+		ExportConfig: v.ExportConfig,
+	}
+	if v.Commit != nil {
+		res.Commit = marshalPolicyPolicyCommitToPolicyCommitResponseBody(v.Commit)
+	}
+	if v.Metadata != nil {
+		res.Metadata = marshalPolicyPolicyMetadataToPolicyMetadataResponseBody(v.Metadata)
+	}
+	if v.Lock != nil {
+		res.Lock = marshalPolicyPolicyLockToPolicyLockResponseBody(v.Lock)
+	}
+
+	return res
+}
+
+// marshalPolicyPolicyCommitToPolicyCommitResponseBody builds a value of type
+// *PolicyCommitResponseBody from a value of type *policy.PolicyCommit.
+func marshalPolicyPolicyCommitToPolicyCommitResponseBody(v *policy.PolicyCommit) *PolicyCommitResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &PolicyCommitResponseBody{
+		Sha:    v.Sha,
+		Time:   v.Time,
+		Author: v.Author,
+		Branch: v.Branch,
+	}
+
+	return res
+}
+
+// marshalPolicyPolicyMetadataToPolicyMetadataResponseBody builds a value of
+// type *PolicyMetadataResponseBody from a value of type *policy.PolicyMetadata.
+func marshalPolicyPolicyMetadataToPolicyMetadataResponseBody(v *policy.PolicyMetadata) *PolicyMetadataResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &PolicyMetadataResponseBody{
+		Title:       v.Title,
+		Description: v.Description,
+	}
+	if v.Authors != nil {
+		res.Authors = make([]string, len(v.Authors))
+		for i, val := range v.Authors {
+			res.Authors[i] = val
+		}
+	}
+	if v.Organizations != nil {
+		res.Organizations = make([]string, len(v.Organizations))
+		for i, val := range v.Organizations {
+			res.Organizations[i] = val
+		}
+	}
+	if v.RelatedResources != nil {
+		res.RelatedResources = make([]string, len(v.RelatedResources))
+		for i, val := range v.RelatedResources {
+			res.RelatedResources[i] = val
+		}
+	}
+	if v.Custom != nil {
+		res.Custom = make(map[string]any, len(v.Custom))
+		for key, val := range v.Custom {
+			tk := key
+			tv := val
+			res.Custom[tk] = tv
+		}
+	}
+
+	return res
+}
+
+// marshalPolicyPolicyLockToPolicyLockResponseBody builds a value of type
+// *PolicyLockResponseBody from a value of type *policy.PolicyLock.
+func marshalPolicyPolicyLockToPolicyLockResponseBody(v *policy.PolicyLock) *PolicyLockResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &PolicyLockResponseBody{
+		Reason:    v.Reason,
+		LockedBy:  v.LockedBy,
+		LockedAt:  v.LockedAt,
+		ExpiresAt: v.ExpiresAt,
+	}
+
+	return res
+}
+
+// marshalPolicyDependencyReportToDependencyReportResponseBody builds a value
+// of type *DependencyReportResponseBody from a value of type
+// *policy.DependencyReport.
+func marshalPolicyDependencyReportToDependencyReportResponseBody(v *policy.DependencyReport) *DependencyReportResponseBody {
+	res := &DependencyReportResponseBody{
 		Repository: v.Repository,
-		PolicyName: v.PolicyName,
 		Group:      v.Group,
+		PolicyName: v.PolicyName,
 		Version:    v.Version,
-		Rego:       v.Rego,
-		Data:       v.Data,
-		DataConfig: v.DataConfig,
-		Locked:     v.Locked,
-		LastUpdate: v.LastUpdate,
+		Error:      v.Error,
+	}
+	if v.Builtins != nil {
+		res.Builtins = make([]string, len(v.Builtins))
+		for i, val := range v.Builtins {
+			res.Builtins[i] = val
+		}
+	} else {
+		res.Builtins = []string{}
+	}
+	if v.Imports != nil {
+		res.Imports = make([]string, len(v.Imports))
+		for i, val := range v.Imports {
+			res.Imports[i] = val
+		}
+	} else {
+		res.Imports = []string{}
+	}
+	if v.DataPaths != nil {
+		res.DataPaths = make([]string, len(v.DataPaths))
+		for i, val := range v.DataPaths {
+			res.DataPaths[i] = val
+		}
+	} else {
+		res.DataPaths = []string{}
+	}
+
+	return res
+}
+
+// marshalPolicyWebhookDeliveryToWebhookDeliveryResponseBody builds a value of
+// type *WebhookDeliveryResponseBody from a value of type
+// *policy.WebhookDelivery.
+func marshalPolicyWebhookDeliveryToWebhookDeliveryResponseBody(v *policy.WebhookDelivery) *WebhookDeliveryResponseBody {
+	res := &WebhookDeliveryResponseBody{
+		ID:          v.ID,
+		Event:       v.Event,
+		State:       v.State,
+		Attempts:    v.Attempts,
+		LastError:   v.LastError,
+		NextAttempt: v.NextAttempt,
+		CreatedAt:   v.CreatedAt,
+		UpdatedAt:   v.UpdatedAt,
 	}
 
 	return res
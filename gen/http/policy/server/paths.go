@@ -51,11 +51,61 @@ func UnlockPolicyPath(repository string, group string, policyName string, versio
 	return fmt.Sprintf("/policy/%v/%v/%v/%v/lock", repository, group, policyName, version)
 }
 
+// BulkLockPolicyPath returns the URL path to the policy service BulkLock HTTP endpoint.
+func BulkLockPolicyPath() string {
+	return "/v1/policies/lock"
+}
+
+// BulkUnlockPolicyPath returns the URL path to the policy service BulkUnlock HTTP endpoint.
+func BulkUnlockPolicyPath() string {
+	return "/v1/policies/unlock"
+}
+
+// CreatePolicyPolicyPath returns the URL path to the policy service CreatePolicy HTTP endpoint.
+func CreatePolicyPolicyPath(repository string, group string, policyName string, version string) string {
+	return fmt.Sprintf("/policy/%v/%v/%v/%v", repository, group, policyName, version)
+}
+
+// UpdatePolicyPolicyPath returns the URL path to the policy service UpdatePolicy HTTP endpoint.
+func UpdatePolicyPolicyPath(repository string, group string, policyName string, version string) string {
+	return fmt.Sprintf("/policy/%v/%v/%v/%v", repository, group, policyName, version)
+}
+
+// DeletePolicyPolicyPath returns the URL path to the policy service DeletePolicy HTTP endpoint.
+func DeletePolicyPolicyPath(repository string, group string, policyName string, version string) string {
+	return fmt.Sprintf("/policy/%v/%v/%v/%v", repository, group, policyName, version)
+}
+
+// PolicyRevisionsPolicyPath returns the URL path to the policy service PolicyRevisions HTTP endpoint.
+func PolicyRevisionsPolicyPath(repository string, group string, policyName string, version string) string {
+	return fmt.Sprintf("/policy/%v/%v/%v/%v/revisions", repository, group, policyName, version)
+}
+
+// PolicyRevisionPolicyPath returns the URL path to the policy service PolicyRevision HTTP endpoint.
+func PolicyRevisionPolicyPath(repository string, group string, policyName string, version string, revision int) string {
+	return fmt.Sprintf("/policy/%v/%v/%v/%v/revisions/%v", repository, group, policyName, version, revision)
+}
+
+// DiffPolicyRevisionsPolicyPath returns the URL path to the policy service DiffPolicyRevisions HTTP endpoint.
+func DiffPolicyRevisionsPolicyPath(repository string, group string, policyName string, version string) string {
+	return fmt.Sprintf("/policy/%v/%v/%v/%v/revisions/diff", repository, group, policyName, version)
+}
+
+// RollbackPolicyPolicyPath returns the URL path to the policy service RollbackPolicy HTTP endpoint.
+func RollbackPolicyPolicyPath(repository string, group string, policyName string, version string, revision int) string {
+	return fmt.Sprintf("/policy/%v/%v/%v/%v/revisions/%v/rollback", repository, group, policyName, version, revision)
+}
+
 // ExportBundlePolicyPath returns the URL path to the policy service ExportBundle HTTP endpoint.
 func ExportBundlePolicyPath(repository string, group string, policyName string, version string) string {
 	return fmt.Sprintf("/policy/%v/%v/%v/%v/export", repository, group, policyName, version)
 }
 
+// ExportWasmBundlePolicyPath returns the URL path to the policy service ExportWasmBundle HTTP endpoint.
+func ExportWasmBundlePolicyPath(repository string, group string, policyName string, version string) string {
+	return fmt.Sprintf("/policy/%v/%v/%v/%v/export/wasm", repository, group, policyName, version)
+}
+
 // PolicyPublicKeyPolicyPath returns the URL path to the policy service PolicyPublicKey HTTP endpoint.
 func PolicyPublicKeyPolicyPath(repository string, group string, policyName string, version string) string {
 	return fmt.Sprintf("/policy/%v/%v/%v/%v/key", repository, group, policyName, version)
@@ -71,6 +121,16 @@ func ListPoliciesPolicyPath() string {
 	return "/v1/policies"
 }
 
+// PolicyDependenciesPolicyPath returns the URL path to the policy service PolicyDependencies HTTP endpoint.
+func PolicyDependenciesPolicyPath() string {
+	return "/v1/policies/dependencies"
+}
+
+// GetPolicyPolicyPath returns the URL path to the policy service GetPolicy HTTP endpoint.
+func GetPolicyPolicyPath(repository string, group string, policyName string, version string) string {
+	return fmt.Sprintf("/v1/policies/%v/%v/%v/%v", repository, group, policyName, version)
+}
+
 // SetPolicyAutoImportPolicyPath returns the URL path to the policy service SetPolicyAutoImport HTTP endpoint.
 func SetPolicyAutoImportPolicyPath() string {
 	return "/v1/policy/import/config"
@@ -90,3 +150,8 @@ func DeletePolicyAutoImportPolicyPath() string {
 func SubscribeForPolicyChangePolicyPath(repository string, group string, policyName string, version string) string {
 	return fmt.Sprintf("/policy/%v/%v/%v/%v/notifychange", repository, group, policyName, version)
 }
+
+// SubscriberDeliveriesPolicyPath returns the URL path to the policy service SubscriberDeliveries HTTP endpoint.
+func SubscriberDeliveriesPolicyPath(repository string, group string, policyName string, version string, subscriber string) string {
+	return fmt.Sprintf("/policy/%v/%v/%v/%v/notifychange/%v/deliveries", repository, group, policyName, version, subscriber)
+}
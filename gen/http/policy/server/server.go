@@ -25,14 +25,27 @@ type Server struct {
 	Validate                 http.Handler
 	Lock                     http.Handler
 	Unlock                   http.Handler
+	BulkLock                 http.Handler
+	BulkUnlock               http.Handler
+	CreatePolicy             http.Handler
+	UpdatePolicy             http.Handler
+	DeletePolicy             http.Handler
+	PolicyRevisions          http.Handler
+	PolicyRevision           http.Handler
+	DiffPolicyRevisions      http.Handler
+	RollbackPolicy           http.Handler
 	ExportBundle             http.Handler
+	ExportWasmBundle         http.Handler
 	PolicyPublicKey          http.Handler
 	ImportBundle             http.Handler
 	ListPolicies             http.Handler
+	PolicyDependencies       http.Handler
+	GetPolicy                http.Handler
 	SetPolicyAutoImport      http.Handler
 	PolicyAutoImport         http.Handler
 	DeletePolicyAutoImport   http.Handler
 	SubscribeForPolicyChange http.Handler
+	SubscriberDeliveries     http.Handler
 }
 
 // MountPoint holds information about the mounted endpoints.
@@ -70,27 +83,53 @@ func New(
 			{"Validate", "POST", "/policy/{repository}/{group}/{policyName}/{version}/validation"},
 			{"Lock", "POST", "/policy/{repository}/{group}/{policyName}/{version}/lock"},
 			{"Unlock", "DELETE", "/policy/{repository}/{group}/{policyName}/{version}/lock"},
+			{"BulkLock", "POST", "/v1/policies/lock"},
+			{"BulkUnlock", "POST", "/v1/policies/unlock"},
+			{"CreatePolicy", "POST", "/policy/{repository}/{group}/{policyName}/{version}"},
+			{"UpdatePolicy", "PUT", "/policy/{repository}/{group}/{policyName}/{version}"},
+			{"DeletePolicy", "DELETE", "/policy/{repository}/{group}/{policyName}/{version}"},
+			{"PolicyRevisions", "GET", "/policy/{repository}/{group}/{policyName}/{version}/revisions"},
+			{"PolicyRevision", "GET", "/policy/{repository}/{group}/{policyName}/{version}/revisions/{revision}"},
+			{"DiffPolicyRevisions", "GET", "/policy/{repository}/{group}/{policyName}/{version}/revisions/diff"},
+			{"RollbackPolicy", "POST", "/policy/{repository}/{group}/{policyName}/{version}/revisions/{revision}/rollback"},
 			{"ExportBundle", "GET", "/policy/{repository}/{group}/{policyName}/{version}/export"},
+			{"ExportWasmBundle", "GET", "/policy/{repository}/{group}/{policyName}/{version}/export/wasm"},
 			{"PolicyPublicKey", "GET", "/policy/{repository}/{group}/{policyName}/{version}/key"},
 			{"ImportBundle", "POST", "/v1/policy/import"},
 			{"ListPolicies", "GET", "/v1/policies"},
+			{"PolicyDependencies", "GET", "/v1/policies/dependencies"},
+			{"GetPolicy", "GET", "/v1/policies/{repository}/{group}/{policyName}/{version}"},
 			{"SetPolicyAutoImport", "POST", "/v1/policy/import/config"},
 			{"PolicyAutoImport", "GET", "/v1/policy/import/config"},
 			{"DeletePolicyAutoImport", "DELETE", "/v1/policy/import/config"},
 			{"SubscribeForPolicyChange", "POST", "/policy/{repository}/{group}/{policyName}/{version}/notifychange"},
+			{"SubscriberDeliveries", "GET", "/policy/{repository}/{group}/{policyName}/{version}/notifychange/{subscriber}/deliveries"},
 		},
 		Evaluate:                 NewEvaluateHandler(e.Evaluate, mux, decoder, encoder, errhandler, formatter),
 		Validate:                 NewValidateHandler(e.Validate, mux, decoder, encoder, errhandler, formatter),
 		Lock:                     NewLockHandler(e.Lock, mux, decoder, encoder, errhandler, formatter),
 		Unlock:                   NewUnlockHandler(e.Unlock, mux, decoder, encoder, errhandler, formatter),
+		BulkLock:                 NewBulkLockHandler(e.BulkLock, mux, decoder, encoder, errhandler, formatter),
+		BulkUnlock:               NewBulkUnlockHandler(e.BulkUnlock, mux, decoder, encoder, errhandler, formatter),
+		CreatePolicy:             NewCreatePolicyHandler(e.CreatePolicy, mux, decoder, encoder, errhandler, formatter),
+		UpdatePolicy:             NewUpdatePolicyHandler(e.UpdatePolicy, mux, decoder, encoder, errhandler, formatter),
+		DeletePolicy:             NewDeletePolicyHandler(e.DeletePolicy, mux, decoder, encoder, errhandler, formatter),
+		PolicyRevisions:          NewPolicyRevisionsHandler(e.PolicyRevisions, mux, decoder, encoder, errhandler, formatter),
+		PolicyRevision:           NewPolicyRevisionHandler(e.PolicyRevision, mux, decoder, encoder, errhandler, formatter),
+		DiffPolicyRevisions:      NewDiffPolicyRevisionsHandler(e.DiffPolicyRevisions, mux, decoder, encoder, errhandler, formatter),
+		RollbackPolicy:           NewRollbackPolicyHandler(e.RollbackPolicy, mux, decoder, encoder, errhandler, formatter),
 		ExportBundle:             NewExportBundleHandler(e.ExportBundle, mux, decoder, encoder, errhandler, formatter),
+		ExportWasmBundle:         NewExportWasmBundleHandler(e.ExportWasmBundle, mux, decoder, encoder, errhandler, formatter),
 		PolicyPublicKey:          NewPolicyPublicKeyHandler(e.PolicyPublicKey, mux, decoder, encoder, errhandler, formatter),
 		ImportBundle:             NewImportBundleHandler(e.ImportBundle, mux, decoder, encoder, errhandler, formatter),
 		ListPolicies:             NewListPoliciesHandler(e.ListPolicies, mux, decoder, encoder, errhandler, formatter),
+		PolicyDependencies:       NewPolicyDependenciesHandler(e.PolicyDependencies, mux, decoder, encoder, errhandler, formatter),
+		GetPolicy:                NewGetPolicyHandler(e.GetPolicy, mux, decoder, encoder, errhandler, formatter),
 		SetPolicyAutoImport:      NewSetPolicyAutoImportHandler(e.SetPolicyAutoImport, mux, decoder, encoder, errhandler, formatter),
 		PolicyAutoImport:         NewPolicyAutoImportHandler(e.PolicyAutoImport, mux, decoder, encoder, errhandler, formatter),
 		DeletePolicyAutoImport:   NewDeletePolicyAutoImportHandler(e.DeletePolicyAutoImport, mux, decoder, encoder, errhandler, formatter),
 		SubscribeForPolicyChange: NewSubscribeForPolicyChangeHandler(e.SubscribeForPolicyChange, mux, decoder, encoder, errhandler, formatter),
+		SubscriberDeliveries:     NewSubscriberDeliveriesHandler(e.SubscriberDeliveries, mux, decoder, encoder, errhandler, formatter),
 	}
 }
 
@@ -103,14 +142,27 @@ func (s *Server) Use(m func(http.Handler) http.Handler) {
 	s.Validate = m(s.Validate)
 	s.Lock = m(s.Lock)
 	s.Unlock = m(s.Unlock)
+	s.BulkLock = m(s.BulkLock)
+	s.BulkUnlock = m(s.BulkUnlock)
+	s.CreatePolicy = m(s.CreatePolicy)
+	s.UpdatePolicy = m(s.UpdatePolicy)
+	s.DeletePolicy = m(s.DeletePolicy)
+	s.PolicyRevisions = m(s.PolicyRevisions)
+	s.PolicyRevision = m(s.PolicyRevision)
+	s.DiffPolicyRevisions = m(s.DiffPolicyRevisions)
+	s.RollbackPolicy = m(s.RollbackPolicy)
 	s.ExportBundle = m(s.ExportBundle)
+	s.ExportWasmBundle = m(s.ExportWasmBundle)
 	s.PolicyPublicKey = m(s.PolicyPublicKey)
 	s.ImportBundle = m(s.ImportBundle)
 	s.ListPolicies = m(s.ListPolicies)
+	s.PolicyDependencies = m(s.PolicyDependencies)
+	s.GetPolicy = m(s.GetPolicy)
 	s.SetPolicyAutoImport = m(s.SetPolicyAutoImport)
 	s.PolicyAutoImport = m(s.PolicyAutoImport)
 	s.DeletePolicyAutoImport = m(s.DeletePolicyAutoImport)
 	s.SubscribeForPolicyChange = m(s.SubscribeForPolicyChange)
+	s.SubscriberDeliveries = m(s.SubscriberDeliveries)
 }
 
 // MethodNames returns the methods served.
@@ -122,14 +174,27 @@ func Mount(mux goahttp.Muxer, h *Server) {
 	MountValidateHandler(mux, h.Validate)
 	MountLockHandler(mux, h.Lock)
 	MountUnlockHandler(mux, h.Unlock)
+	MountBulkLockHandler(mux, h.BulkLock)
+	MountBulkUnlockHandler(mux, h.BulkUnlock)
+	MountCreatePolicyHandler(mux, h.CreatePolicy)
+	MountUpdatePolicyHandler(mux, h.UpdatePolicy)
+	MountDeletePolicyHandler(mux, h.DeletePolicy)
+	MountPolicyRevisionsHandler(mux, h.PolicyRevisions)
+	MountPolicyRevisionHandler(mux, h.PolicyRevision)
+	MountDiffPolicyRevisionsHandler(mux, h.DiffPolicyRevisions)
+	MountRollbackPolicyHandler(mux, h.RollbackPolicy)
 	MountExportBundleHandler(mux, h.ExportBundle)
+	MountExportWasmBundleHandler(mux, h.ExportWasmBundle)
 	MountPolicyPublicKeyHandler(mux, h.PolicyPublicKey)
 	MountImportBundleHandler(mux, h.ImportBundle)
 	MountListPoliciesHandler(mux, h.ListPolicies)
+	MountPolicyDependenciesHandler(mux, h.PolicyDependencies)
+	MountGetPolicyHandler(mux, h.GetPolicy)
 	MountSetPolicyAutoImportHandler(mux, h.SetPolicyAutoImport)
 	MountPolicyAutoImportHandler(mux, h.PolicyAutoImport)
 	MountDeletePolicyAutoImportHandler(mux, h.DeletePolicyAutoImport)
 	MountSubscribeForPolicyChangeHandler(mux, h.SubscribeForPolicyChange)
+	MountSubscriberDeliveriesHandler(mux, h.SubscriberDeliveries)
 }
 
 // Mount configures the mux to serve the policy endpoints.
@@ -345,6 +410,465 @@ func NewUnlockHandler(
 	})
 }
 
+// MountBulkLockHandler configures the mux to serve the "policy" service
+// "BulkLock" endpoint.
+func MountBulkLockHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/v1/policies/lock", f)
+}
+
+// NewBulkLockHandler creates a HTTP handler which loads the HTTP request and
+// calls the "policy" service "BulkLock" endpoint.
+func NewBulkLockHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeBulkLockRequest(mux, decoder)
+		encodeResponse = EncodeBulkLockResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "BulkLock")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountBulkUnlockHandler configures the mux to serve the "policy" service
+// "BulkUnlock" endpoint.
+func MountBulkUnlockHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/v1/policies/unlock", f)
+}
+
+// NewBulkUnlockHandler creates a HTTP handler which loads the HTTP request and
+// calls the "policy" service "BulkUnlock" endpoint.
+func NewBulkUnlockHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeBulkUnlockRequest(mux, decoder)
+		encodeResponse = EncodeBulkUnlockResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "BulkUnlock")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountCreatePolicyHandler configures the mux to serve the "policy" service
+// "CreatePolicy" endpoint.
+func MountCreatePolicyHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/policy/{repository}/{group}/{policyName}/{version}", f)
+}
+
+// NewCreatePolicyHandler creates a HTTP handler which loads the HTTP request
+// and calls the "policy" service "CreatePolicy" endpoint.
+func NewCreatePolicyHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeCreatePolicyRequest(mux, decoder)
+		encodeResponse = EncodeCreatePolicyResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "CreatePolicy")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountUpdatePolicyHandler configures the mux to serve the "policy" service
+// "UpdatePolicy" endpoint.
+func MountUpdatePolicyHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("PUT", "/policy/{repository}/{group}/{policyName}/{version}", f)
+}
+
+// NewUpdatePolicyHandler creates a HTTP handler which loads the HTTP request
+// and calls the "policy" service "UpdatePolicy" endpoint.
+func NewUpdatePolicyHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeUpdatePolicyRequest(mux, decoder)
+		encodeResponse = EncodeUpdatePolicyResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "UpdatePolicy")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountDeletePolicyHandler configures the mux to serve the "policy" service
+// "DeletePolicy" endpoint.
+func MountDeletePolicyHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("DELETE", "/policy/{repository}/{group}/{policyName}/{version}", f)
+}
+
+// NewDeletePolicyHandler creates a HTTP handler which loads the HTTP request
+// and calls the "policy" service "DeletePolicy" endpoint.
+func NewDeletePolicyHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeDeletePolicyRequest(mux, decoder)
+		encodeResponse = EncodeDeletePolicyResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "DeletePolicy")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountPolicyRevisionsHandler configures the mux to serve the "policy" service
+// "PolicyRevisions" endpoint.
+func MountPolicyRevisionsHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/policy/{repository}/{group}/{policyName}/{version}/revisions", f)
+}
+
+// NewPolicyRevisionsHandler creates a HTTP handler which loads the HTTP
+// request and calls the "policy" service "PolicyRevisions" endpoint.
+func NewPolicyRevisionsHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodePolicyRevisionsRequest(mux, decoder)
+		encodeResponse = EncodePolicyRevisionsResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "PolicyRevisions")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountPolicyRevisionHandler configures the mux to serve the "policy" service
+// "PolicyRevision" endpoint.
+func MountPolicyRevisionHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/policy/{repository}/{group}/{policyName}/{version}/revisions/{revision}", f)
+}
+
+// NewPolicyRevisionHandler creates a HTTP handler which loads the HTTP request
+// and calls the "policy" service "PolicyRevision" endpoint.
+func NewPolicyRevisionHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodePolicyRevisionRequest(mux, decoder)
+		encodeResponse = EncodePolicyRevisionResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "PolicyRevision")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountDiffPolicyRevisionsHandler configures the mux to serve the "policy"
+// service "DiffPolicyRevisions" endpoint.
+func MountDiffPolicyRevisionsHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/policy/{repository}/{group}/{policyName}/{version}/revisions/diff", f)
+}
+
+// NewDiffPolicyRevisionsHandler creates a HTTP handler which loads the HTTP
+// request and calls the "policy" service "DiffPolicyRevisions" endpoint.
+func NewDiffPolicyRevisionsHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeDiffPolicyRevisionsRequest(mux, decoder)
+		encodeResponse = EncodeDiffPolicyRevisionsResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "DiffPolicyRevisions")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountRollbackPolicyHandler configures the mux to serve the "policy" service
+// "RollbackPolicy" endpoint.
+func MountRollbackPolicyHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/policy/{repository}/{group}/{policyName}/{version}/revisions/{revision}/rollback", f)
+}
+
+// NewRollbackPolicyHandler creates a HTTP handler which loads the HTTP request
+// and calls the "policy" service "RollbackPolicy" endpoint.
+func NewRollbackPolicyHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeRollbackPolicyRequest(mux, decoder)
+		encodeResponse = EncodeRollbackPolicyResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "RollbackPolicy")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
 // MountExportBundleHandler configures the mux to serve the "policy" service
 // "ExportBundle" endpoint.
 func MountExportBundleHandler(mux goahttp.Muxer, h http.Handler) {
@@ -413,6 +937,74 @@ func NewExportBundleHandler(
 	})
 }
 
+// MountExportWasmBundleHandler configures the mux to serve the "policy"
+// service "ExportWasmBundle" endpoint.
+func MountExportWasmBundleHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/policy/{repository}/{group}/{policyName}/{version}/export/wasm", f)
+}
+
+// NewExportWasmBundleHandler creates a HTTP handler which loads the HTTP
+// request and calls the "policy" service "ExportWasmBundle" endpoint.
+func NewExportWasmBundleHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeExportWasmBundleRequest(mux, decoder)
+		encodeResponse = EncodeExportWasmBundleResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "ExportWasmBundle")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		o := res.(*policy.ExportWasmBundleResponseData)
+		defer o.Body.Close()
+		// handle immediate read error like a returned error
+		buf := bufio.NewReader(o.Body)
+		if _, err := buf.Peek(1); err != nil && err != io.EOF {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, o.Result); err != nil {
+			errhandler(ctx, w, err)
+			return
+		}
+		if _, err := io.Copy(w, buf); err != nil {
+			if f, ok := w.(http.Flusher); ok {
+				f.Flush()
+			}
+			panic(http.ErrAbortHandler) // too late to write an error
+		}
+	})
+}
+
 // MountPolicyPublicKeyHandler configures the mux to serve the "policy" service
 // "PolicyPublicKey" endpoint.
 func MountPolicyPublicKeyHandler(mux goahttp.Muxer, h http.Handler) {
@@ -567,6 +1159,108 @@ func NewListPoliciesHandler(
 	})
 }
 
+// MountPolicyDependenciesHandler configures the mux to serve the "policy"
+// service "PolicyDependencies" endpoint.
+func MountPolicyDependenciesHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/v1/policies/dependencies", f)
+}
+
+// NewPolicyDependenciesHandler creates a HTTP handler which loads the HTTP
+// request and calls the "policy" service "PolicyDependencies" endpoint.
+func NewPolicyDependenciesHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodePolicyDependenciesRequest(mux, decoder)
+		encodeResponse = EncodePolicyDependenciesResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "PolicyDependencies")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountGetPolicyHandler configures the mux to serve the "policy" service
+// "GetPolicy" endpoint.
+func MountGetPolicyHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/v1/policies/{repository}/{group}/{policyName}/{version}", f)
+}
+
+// NewGetPolicyHandler creates a HTTP handler which loads the HTTP request and
+// calls the "policy" service "GetPolicy" endpoint.
+func NewGetPolicyHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeGetPolicyRequest(mux, decoder)
+		encodeResponse = EncodeGetPolicyResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "GetPolicy")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
 // MountSetPolicyAutoImportHandler configures the mux to serve the "policy"
 // service "SetPolicyAutoImport" endpoint.
 func MountSetPolicyAutoImportHandler(mux goahttp.Muxer, h http.Handler) {
@@ -764,3 +1458,54 @@ func NewSubscribeForPolicyChangeHandler(
 		}
 	})
 }
+
+// MountSubscriberDeliveriesHandler configures the mux to serve the "policy"
+// service "SubscriberDeliveries" endpoint.
+func MountSubscriberDeliveriesHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/policy/{repository}/{group}/{policyName}/{version}/notifychange/{subscriber}/deliveries", f)
+}
+
+// NewSubscriberDeliveriesHandler creates a HTTP handler which loads the HTTP
+// request and calls the "policy" service "SubscriberDeliveries" endpoint.
+func NewSubscriberDeliveriesHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeSubscriberDeliveriesRequest(mux, decoder)
+		encodeResponse = EncodeSubscriberDeliveriesResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "SubscriberDeliveries")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
@@ -14,6 +14,80 @@ import (
 	goa "goa.design/goa/v3/pkg"
 )
 
+// LockRequestBody is the type of the "policy" service "Lock" endpoint HTTP
+// request body.
+type LockRequestBody struct {
+	// Reason for locking the policy.
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty" xml:"reason,omitempty"`
+	// Time in RFC 3339 format when the policy is unlocked automatically (optional).
+	ExpiresAt *string `form:"expiresAt,omitempty" json:"expiresAt,omitempty" xml:"expiresAt,omitempty"`
+}
+
+// BulkLockRequestBody is the type of the "policy" service "BulkLock" endpoint
+// HTTP request body.
+type BulkLockRequestBody struct {
+	// Lock policies of a repository.
+	Repository *string `form:"repository,omitempty" json:"repository,omitempty" xml:"repository,omitempty"`
+	// Lock policies of a group.
+	Group *string `form:"group,omitempty" json:"group,omitempty" xml:"group,omitempty"`
+	// Lock policies with names matching a shell pattern, e.g. did*.
+	PolicyName *string `form:"policyName,omitempty" json:"policyName,omitempty" xml:"policyName,omitempty"`
+	// Lock policies with a version.
+	Version *string `form:"version,omitempty" json:"version,omitempty" xml:"version,omitempty"`
+	// Lock only the listed policies.
+	Policies []*PolicyRefRequestBody `form:"policies,omitempty" json:"policies,omitempty" xml:"policies,omitempty"`
+	// Reason for locking the policies.
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty" xml:"reason,omitempty"`
+	// Time in RFC 3339 format when the policies are unlocked automatically
+	// (optional).
+	ExpiresAt *string `form:"expiresAt,omitempty" json:"expiresAt,omitempty" xml:"expiresAt,omitempty"`
+}
+
+// BulkUnlockRequestBody is the type of the "policy" service "BulkUnlock"
+// endpoint HTTP request body.
+type BulkUnlockRequestBody struct {
+	// Unlock policies of a repository.
+	Repository *string `form:"repository,omitempty" json:"repository,omitempty" xml:"repository,omitempty"`
+	// Unlock policies of a group.
+	Group *string `form:"group,omitempty" json:"group,omitempty" xml:"group,omitempty"`
+	// Unlock policies with names matching a shell pattern, e.g. did*.
+	PolicyName *string `form:"policyName,omitempty" json:"policyName,omitempty" xml:"policyName,omitempty"`
+	// Unlock policies with a version.
+	Version *string `form:"version,omitempty" json:"version,omitempty" xml:"version,omitempty"`
+	// Unlock only the listed policies.
+	Policies []*PolicyRefRequestBody `form:"policies,omitempty" json:"policies,omitempty" xml:"policies,omitempty"`
+}
+
+// CreatePolicyRequestBody is the type of the "policy" service "CreatePolicy"
+// endpoint HTTP request body.
+type CreatePolicyRequestBody struct {
+	// Policy rego source code. The package declaration must be 'group.policyName'.
+	Rego *string `form:"rego,omitempty" json:"rego,omitempty" xml:"rego,omitempty"`
+	// Policy static data as JSON object (optional).
+	Data *string `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Policy static data configuration as JSON (optional).
+	DataConfig *string `form:"dataConfig,omitempty" json:"dataConfig,omitempty" xml:"dataConfig,omitempty"`
+	// JSON schema for validation of the policy output (optional).
+	OutputSchema *string `form:"outputSchema,omitempty" json:"outputSchema,omitempty" xml:"outputSchema,omitempty"`
+	// Policy bundle export configuration as JSON (optional).
+	ExportConfig *string `form:"exportConfig,omitempty" json:"exportConfig,omitempty" xml:"exportConfig,omitempty"`
+}
+
+// UpdatePolicyRequestBody is the type of the "policy" service "UpdatePolicy"
+// endpoint HTTP request body.
+type UpdatePolicyRequestBody struct {
+	// Policy rego source code. The package declaration must be 'group.policyName'.
+	Rego *string `form:"rego,omitempty" json:"rego,omitempty" xml:"rego,omitempty"`
+	// Policy static data as JSON object (optional).
+	Data *string `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Policy static data configuration as JSON (optional).
+	DataConfig *string `form:"dataConfig,omitempty" json:"dataConfig,omitempty" xml:"dataConfig,omitempty"`
+	// JSON schema for validation of the policy output (optional).
+	OutputSchema *string `form:"outputSchema,omitempty" json:"outputSchema,omitempty" xml:"outputSchema,omitempty"`
+	// Policy bundle export configuration as JSON (optional).
+	ExportConfig *string `form:"exportConfig,omitempty" json:"exportConfig,omitempty" xml:"exportConfig,omitempty"`
+}
+
 // SetPolicyAutoImportRequestBody is the type of the "policy" service
 // "SetPolicyAutoImport" endpoint HTTP request body.
 type SetPolicyAutoImportRequestBody struct {
@@ -39,11 +113,191 @@ type SubscribeForPolicyChangeRequestBody struct {
 	Subscriber *string `form:"subscriber,omitempty" json:"subscriber,omitempty" xml:"subscriber,omitempty"`
 }
 
+// BulkLockResponseBody is the type of the "policy" service "BulkLock" endpoint
+// HTTP response body.
+type BulkLockResponseBody struct {
+	// Locked or unlocked policies.
+	Policies []*PolicyRefResponseBody `form:"policies" json:"policies" xml:"policies"`
+}
+
+// BulkUnlockResponseBody is the type of the "policy" service "BulkUnlock"
+// endpoint HTTP response body.
+type BulkUnlockResponseBody struct {
+	// Locked or unlocked policies.
+	Policies []*PolicyRefResponseBody `form:"policies" json:"policies" xml:"policies"`
+}
+
+// PolicyRevisionsResponseBody is the type of the "policy" service
+// "PolicyRevisions" endpoint HTTP response body.
+type PolicyRevisionsResponseBody struct {
+	// JSON array of policy revisions without their content.
+	Revisions []*PolicyRevisionResultResponseBody `form:"revisions" json:"revisions" xml:"revisions"`
+}
+
+// PolicyRevisionResponseBody is the type of the "policy" service
+// "PolicyRevision" endpoint HTTP response body.
+type PolicyRevisionResponseBody struct {
+	// Revision number.
+	Revision int `form:"revision" json:"revision" xml:"revision"`
+	// SHA-256 hash of the policy source code, data and configuration.
+	Hash string `form:"hash" json:"hash" xml:"hash"`
+	// Source of the revision: sync, import, api, data refresh or rollback.
+	Source string `form:"source" json:"source" xml:"source"`
+	// Git commit of the revision, if known.
+	Commit *string `form:"commit,omitempty" json:"commit,omitempty" xml:"commit,omitempty"`
+	// Creation time of the revision (Unix timestamp).
+	CreatedAt int64 `form:"createdAt" json:"createdAt" xml:"createdAt"`
+	// Policy rego source code.
+	Rego *string `form:"rego,omitempty" json:"rego,omitempty" xml:"rego,omitempty"`
+	// Policy static data.
+	Data *string `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Policy static data optional configuration.
+	DataConfig *string `form:"dataConfig,omitempty" json:"dataConfig,omitempty" xml:"dataConfig,omitempty"`
+	// JSON schema for validation of the policy output.
+	OutputSchema *string `form:"outputSchema,omitempty" json:"outputSchema,omitempty" xml:"outputSchema,omitempty"`
+	// Policy bundle export configuration.
+	ExportConfig *string `form:"exportConfig,omitempty" json:"exportConfig,omitempty" xml:"exportConfig,omitempty"`
+}
+
+// DiffPolicyRevisionsResponseBody is the type of the "policy" service
+// "DiffPolicyRevisions" endpoint HTTP response body.
+type DiffPolicyRevisionsResponseBody struct {
+	// Revision compared from.
+	From int `form:"from" json:"from" xml:"from"`
+	// Revision compared to.
+	To int `form:"to" json:"to" xml:"to"`
+	// Differences of the changed policy fields.
+	Changes []*PolicyRevisionDiffResponseBody `form:"changes" json:"changes" xml:"changes"`
+}
+
+// RollbackPolicyResponseBody is the type of the "policy" service
+// "RollbackPolicy" endpoint HTTP response body.
+type RollbackPolicyResponseBody struct {
+	// Revision number.
+	Revision int `form:"revision" json:"revision" xml:"revision"`
+	// SHA-256 hash of the policy source code, data and configuration.
+	Hash string `form:"hash" json:"hash" xml:"hash"`
+	// Source of the revision: sync, import, api, data refresh or rollback.
+	Source string `form:"source" json:"source" xml:"source"`
+	// Git commit of the revision, if known.
+	Commit *string `form:"commit,omitempty" json:"commit,omitempty" xml:"commit,omitempty"`
+	// Creation time of the revision (Unix timestamp).
+	CreatedAt int64 `form:"createdAt" json:"createdAt" xml:"createdAt"`
+	// Policy rego source code.
+	Rego *string `form:"rego,omitempty" json:"rego,omitempty" xml:"rego,omitempty"`
+	// Policy static data.
+	Data *string `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Policy static data optional configuration.
+	DataConfig *string `form:"dataConfig,omitempty" json:"dataConfig,omitempty" xml:"dataConfig,omitempty"`
+	// JSON schema for validation of the policy output.
+	OutputSchema *string `form:"outputSchema,omitempty" json:"outputSchema,omitempty" xml:"outputSchema,omitempty"`
+	// Policy bundle export configuration.
+	ExportConfig *string `form:"exportConfig,omitempty" json:"exportConfig,omitempty" xml:"exportConfig,omitempty"`
+}
+
 // ListPoliciesResponseBody is the type of the "policy" service "ListPolicies"
 // endpoint HTTP response body.
 type ListPoliciesResponseBody struct {
 	// JSON array of policies.
 	Policies []*PolicyResponseBody `form:"policies" json:"policies" xml:"policies"`
+	// Cursor for the next page of policies, which is missing on the last page.
+	NextCursor *string `form:"nextCursor,omitempty" json:"nextCursor,omitempty" xml:"nextCursor,omitempty"`
+}
+
+// PolicyDependenciesResponseBody is the type of the "policy" service
+// "PolicyDependencies" endpoint HTTP response body.
+type PolicyDependenciesResponseBody struct {
+	// Dependencies of the policies.
+	Policies []*DependencyReportResponseBody `form:"policies" json:"policies" xml:"policies"`
+}
+
+// GetPolicyResponseBody is the type of the "policy" service "GetPolicy"
+// endpoint HTTP response body.
+type GetPolicyResponseBody struct {
+	// Policy repository.
+	Repository string `form:"repository" json:"repository" xml:"repository"`
+	// Policy name.
+	PolicyName string `form:"policyName" json:"policyName" xml:"policyName"`
+	// Policy group.
+	Group string `form:"group" json:"group" xml:"group"`
+	// Policy version.
+	Version string `form:"version" json:"version" xml:"version"`
+	// Policy rego source code.
+	Rego *string `form:"rego,omitempty" json:"rego,omitempty" xml:"rego,omitempty"`
+	// Policy static data.
+	Data *string `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Policy static data optional configuration.
+	DataConfig *string `form:"dataConfig,omitempty" json:"dataConfig,omitempty" xml:"dataConfig,omitempty"`
+	// Locked specifies if the policy is locked or allowed to execute.
+	Locked bool `form:"locked" json:"locked" xml:"locked"`
+	// Last update (Unix timestamp).
+	LastUpdate int64 `form:"lastUpdate" json:"lastUpdate" xml:"lastUpdate"`
+	// Archived specifies if the policy is removed from its repository and cannot
+	// be evaluated.
+	Archived *bool `form:"archived,omitempty" json:"archived,omitempty" xml:"archived,omitempty"`
+	// Git commit from which the policy is synchronized.
+	Commit *PolicyCommitResponseBody `form:"commit,omitempty" json:"commit,omitempty" xml:"commit,omitempty"`
+	// Policy output JSON schema.
+	OutputSchema *string `form:"outputSchema,omitempty" json:"outputSchema,omitempty" xml:"outputSchema,omitempty"`
+	// Policy export configuration.
+	ExportConfig *string `form:"exportConfig,omitempty" json:"exportConfig,omitempty" xml:"exportConfig,omitempty"`
+	// METADATA annotations of the policy package.
+	Metadata *PolicyMetadataResponseBody `form:"metadata,omitempty" json:"metadata,omitempty" xml:"metadata,omitempty"`
+	// Details of the policy lock.
+	Lock *PolicyLockResponseBody `form:"lock,omitempty" json:"lock,omitempty" xml:"lock,omitempty"`
+}
+
+// SubscriberDeliveriesResponseBody is the type of the "policy" service
+// "SubscriberDeliveries" endpoint HTTP response body.
+type SubscriberDeliveriesResponseBody struct {
+	// JSON array of webhook deliveries, oldest first.
+	Deliveries []*WebhookDeliveryResponseBody `form:"deliveries" json:"deliveries" xml:"deliveries"`
+}
+
+// PolicyRefResponseBody is used to define fields on response body types.
+type PolicyRefResponseBody struct {
+	// Policy repository.
+	Repository string `form:"repository" json:"repository" xml:"repository"`
+	// Policy group.
+	Group string `form:"group" json:"group" xml:"group"`
+	// Policy name.
+	PolicyName string `form:"policyName" json:"policyName" xml:"policyName"`
+	// Policy version.
+	Version string `form:"version" json:"version" xml:"version"`
+}
+
+// PolicyRevisionResultResponseBody is used to define fields on response body
+// types.
+type PolicyRevisionResultResponseBody struct {
+	// Revision number.
+	Revision int `form:"revision" json:"revision" xml:"revision"`
+	// SHA-256 hash of the policy source code, data and configuration.
+	Hash string `form:"hash" json:"hash" xml:"hash"`
+	// Source of the revision: sync, import, api, data refresh or rollback.
+	Source string `form:"source" json:"source" xml:"source"`
+	// Git commit of the revision, if known.
+	Commit *string `form:"commit,omitempty" json:"commit,omitempty" xml:"commit,omitempty"`
+	// Creation time of the revision (Unix timestamp).
+	CreatedAt int64 `form:"createdAt" json:"createdAt" xml:"createdAt"`
+	// Policy rego source code.
+	Rego *string `form:"rego,omitempty" json:"rego,omitempty" xml:"rego,omitempty"`
+	// Policy static data.
+	Data *string `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Policy static data optional configuration.
+	DataConfig *string `form:"dataConfig,omitempty" json:"dataConfig,omitempty" xml:"dataConfig,omitempty"`
+	// JSON schema for validation of the policy output.
+	OutputSchema *string `form:"outputSchema,omitempty" json:"outputSchema,omitempty" xml:"outputSchema,omitempty"`
+	// Policy bundle export configuration.
+	ExportConfig *string `form:"exportConfig,omitempty" json:"exportConfig,omitempty" xml:"exportConfig,omitempty"`
+}
+
+// PolicyRevisionDiffResponseBody is used to define fields on response body
+// types.
+type PolicyRevisionDiffResponseBody struct {
+	// Changed policy field: rego, data, dataConfig, outputSchema or exportConfig.
+	Field string `form:"field" json:"field" xml:"field"`
+	// Unified diff of the field.
+	Diff string `form:"diff" json:"diff" xml:"diff"`
 }
 
 // PolicyResponseBody is used to define fields on response body types.
@@ -66,12 +320,218 @@ type PolicyResponseBody struct {
 	Locked bool `form:"locked" json:"locked" xml:"locked"`
 	// Last update (Unix timestamp).
 	LastUpdate int64 `form:"lastUpdate" json:"lastUpdate" xml:"lastUpdate"`
+	// Archived specifies if the policy is removed from its repository and cannot
+	// be evaluated.
+	Archived *bool `form:"archived,omitempty" json:"archived,omitempty" xml:"archived,omitempty"`
+	// Git commit from which the policy is synchronized.
+	Commit *PolicyCommitResponseBody `form:"commit,omitempty" json:"commit,omitempty" xml:"commit,omitempty"`
+	// Policy output JSON schema.
+	OutputSchema *string `form:"outputSchema,omitempty" json:"outputSchema,omitempty" xml:"outputSchema,omitempty"`
+	// Policy export configuration.
+	ExportConfig *string `form:"exportConfig,omitempty" json:"exportConfig,omitempty" xml:"exportConfig,omitempty"`
+	// METADATA annotations of the policy package.
+	Metadata *PolicyMetadataResponseBody `form:"metadata,omitempty" json:"metadata,omitempty" xml:"metadata,omitempty"`
+	// Details of the policy lock.
+	Lock *PolicyLockResponseBody `form:"lock,omitempty" json:"lock,omitempty" xml:"lock,omitempty"`
+}
+
+// PolicyCommitResponseBody is used to define fields on response body types.
+type PolicyCommitResponseBody struct {
+	// Commit SHA.
+	Sha string `form:"sha" json:"sha" xml:"sha"`
+	// Commit time (Unix timestamp).
+	Time int64 `form:"time" json:"time" xml:"time"`
+	// Commit author.
+	Author string `form:"author" json:"author" xml:"author"`
+	// Git branch from which the commit is synchronized.
+	Branch *string `form:"branch,omitempty" json:"branch,omitempty" xml:"branch,omitempty"`
+}
+
+// PolicyMetadataResponseBody is used to define fields on response body types.
+type PolicyMetadataResponseBody struct {
+	// Policy title.
+	Title *string `form:"title,omitempty" json:"title,omitempty" xml:"title,omitempty"`
+	// Policy description.
+	Description *string `form:"description,omitempty" json:"description,omitempty" xml:"description,omitempty"`
+	// Policy authors.
+	Authors []string `form:"authors,omitempty" json:"authors,omitempty" xml:"authors,omitempty"`
+	// Organizations of the policy authors.
+	Organizations []string `form:"organizations,omitempty" json:"organizations,omitempty" xml:"organizations,omitempty"`
+	// URLs of resources related to the policy.
+	RelatedResources []string `form:"relatedResources,omitempty" json:"relatedResources,omitempty" xml:"relatedResources,omitempty"`
+	// Custom annotations.
+	Custom map[string]any `form:"custom,omitempty" json:"custom,omitempty" xml:"custom,omitempty"`
+}
+
+// PolicyLockResponseBody is used to define fields on response body types.
+type PolicyLockResponseBody struct {
+	// Reason for locking the policy.
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty" xml:"reason,omitempty"`
+	// Authenticated caller who locked the policy.
+	LockedBy *string `form:"lockedBy,omitempty" json:"lockedBy,omitempty" xml:"lockedBy,omitempty"`
+	// Lock time (Unix timestamp).
+	LockedAt int64 `form:"lockedAt" json:"lockedAt" xml:"lockedAt"`
+	// Time when the policy is unlocked automatically (Unix timestamp).
+	ExpiresAt *int64 `form:"expiresAt,omitempty" json:"expiresAt,omitempty" xml:"expiresAt,omitempty"`
+}
+
+// DependencyReportResponseBody is used to define fields on response body types.
+type DependencyReportResponseBody struct {
+	// Policy repository.
+	Repository string `form:"repository" json:"repository" xml:"repository"`
+	// Policy group.
+	Group string `form:"group" json:"group" xml:"group"`
+	// Policy name.
+	PolicyName string `form:"policyName" json:"policyName" xml:"policyName"`
+	// Policy version.
+	Version string `form:"version" json:"version" xml:"version"`
+	// Builtin and extension functions called by the policy.
+	Builtins []string `form:"builtins" json:"builtins" xml:"builtins"`
+	// Packages imported by the policy.
+	Imports []string `form:"imports" json:"imports" xml:"imports"`
+	// Data paths referenced by the policy.
+	DataPaths []string `form:"dataPaths" json:"dataPaths" xml:"dataPaths"`
+	// Error parsing the policy source code.
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+}
+
+// WebhookDeliveryResponseBody is used to define fields on response body types.
+type WebhookDeliveryResponseBody struct {
+	// Delivery ID, which is sent in the X-Policy-Delivery-Id header.
+	ID string `form:"id" json:"id" xml:"id"`
+	// Policy change event sent to the webhook.
+	Event any `form:"event" json:"event" xml:"event"`
+	// Delivery state: pending, delivered or dead.
+	State string `form:"state" json:"state" xml:"state"`
+	// Number of delivery attempts.
+	Attempts int `form:"attempts" json:"attempts" xml:"attempts"`
+	// Error of the last failed attempt.
+	LastError *string `form:"lastError,omitempty" json:"lastError,omitempty" xml:"lastError,omitempty"`
+	// Time of the next attempt of a pending delivery (Unix timestamp).
+	NextAttempt *int64 `form:"nextAttempt,omitempty" json:"nextAttempt,omitempty" xml:"nextAttempt,omitempty"`
+	// Creation time of the delivery (Unix timestamp).
+	CreatedAt int64 `form:"createdAt" json:"createdAt" xml:"createdAt"`
+	// Time of the last delivery update (Unix timestamp).
+	UpdatedAt int64 `form:"updatedAt" json:"updatedAt" xml:"updatedAt"`
+}
+
+// PolicyRefRequestBody is used to define fields on request body types.
+type PolicyRefRequestBody struct {
+	// Policy repository.
+	Repository *string `form:"repository,omitempty" json:"repository,omitempty" xml:"repository,omitempty"`
+	// Policy group.
+	Group *string `form:"group,omitempty" json:"group,omitempty" xml:"group,omitempty"`
+	// Policy name.
+	PolicyName *string `form:"policyName,omitempty" json:"policyName,omitempty" xml:"policyName,omitempty"`
+	// Policy version.
+	Version *string `form:"version,omitempty" json:"version,omitempty" xml:"version,omitempty"`
+}
+
+// NewBulkLockResponseBody builds the HTTP response body from the result of the
+// "BulkLock" endpoint of the "policy" service.
+func NewBulkLockResponseBody(res *policy.BulkLockResult) *BulkLockResponseBody {
+	body := &BulkLockResponseBody{}
+	if res.Policies != nil {
+		body.Policies = make([]*PolicyRefResponseBody, len(res.Policies))
+		for i, val := range res.Policies {
+			body.Policies[i] = marshalPolicyPolicyRefToPolicyRefResponseBody(val)
+		}
+	} else {
+		body.Policies = []*PolicyRefResponseBody{}
+	}
+	return body
+}
+
+// NewBulkUnlockResponseBody builds the HTTP response body from the result of
+// the "BulkUnlock" endpoint of the "policy" service.
+func NewBulkUnlockResponseBody(res *policy.BulkLockResult) *BulkUnlockResponseBody {
+	body := &BulkUnlockResponseBody{}
+	if res.Policies != nil {
+		body.Policies = make([]*PolicyRefResponseBody, len(res.Policies))
+		for i, val := range res.Policies {
+			body.Policies[i] = marshalPolicyPolicyRefToPolicyRefResponseBody(val)
+		}
+	} else {
+		body.Policies = []*PolicyRefResponseBody{}
+	}
+	return body
+}
+
+// NewPolicyRevisionsResponseBody builds the HTTP response body from the result
+// of the "PolicyRevisions" endpoint of the "policy" service.
+func NewPolicyRevisionsResponseBody(res *policy.PolicyRevisionsResult) *PolicyRevisionsResponseBody {
+	body := &PolicyRevisionsResponseBody{}
+	if res.Revisions != nil {
+		body.Revisions = make([]*PolicyRevisionResultResponseBody, len(res.Revisions))
+		for i, val := range res.Revisions {
+			body.Revisions[i] = marshalPolicyPolicyRevisionResultToPolicyRevisionResultResponseBody(val)
+		}
+	} else {
+		body.Revisions = []*PolicyRevisionResultResponseBody{}
+	}
+	return body
+}
+
+// NewPolicyRevisionResponseBody builds the HTTP response body from the result
+// of the "PolicyRevision" endpoint of the "policy" service.
+func NewPolicyRevisionResponseBody(res *policy.PolicyRevisionResult) *PolicyRevisionResponseBody {
+	body := &PolicyRevisionResponseBody{
+		Revision:     res.Revision,
+		Hash:         res.Hash,
+		Source:       res.Source,
+		Commit:       res.Commit,
+		CreatedAt:    res.CreatedAt,
+		Rego:         res.Rego,
+		Data:         res.Data,
+		DataConfig:   res.DataConfig,
+		OutputSchema: res.OutputSchema,
+		ExportConfig: res.ExportConfig,
+	}
+	return body
+}
+
+// NewDiffPolicyRevisionsResponseBody builds the HTTP response body from the
+// result of the "DiffPolicyRevisions" endpoint of the "policy" service.
+func NewDiffPolicyRevisionsResponseBody(res *policy.DiffPolicyRevisionsResult) *DiffPolicyRevisionsResponseBody {
+	body := &DiffPolicyRevisionsResponseBody{
+		From: res.From,
+		To:   res.To,
+	}
+	if res.Changes != nil {
+		body.Changes = make([]*PolicyRevisionDiffResponseBody, len(res.Changes))
+		for i, val := range res.Changes {
+			body.Changes[i] = marshalPolicyPolicyRevisionDiffToPolicyRevisionDiffResponseBody(val)
+		}
+	} else {
+		body.Changes = []*PolicyRevisionDiffResponseBody{}
+	}
+	return body
+}
+
+// NewRollbackPolicyResponseBody builds the HTTP response body from the result
+// of the "RollbackPolicy" endpoint of the "policy" service.
+func NewRollbackPolicyResponseBody(res *policy.PolicyRevisionResult) *RollbackPolicyResponseBody {
+	body := &RollbackPolicyResponseBody{
+		Revision:     res.Revision,
+		Hash:         res.Hash,
+		Source:       res.Source,
+		Commit:       res.Commit,
+		CreatedAt:    res.CreatedAt,
+		Rego:         res.Rego,
+		Data:         res.Data,
+		DataConfig:   res.DataConfig,
+		OutputSchema: res.OutputSchema,
+		ExportConfig: res.ExportConfig,
+	}
+	return body
 }
 
 // NewListPoliciesResponseBody builds the HTTP response body from the result of
 // the "ListPolicies" endpoint of the "policy" service.
 func NewListPoliciesResponseBody(res *policy.PoliciesResult) *ListPoliciesResponseBody {
-	body := &ListPoliciesResponseBody{}
+	body := &ListPoliciesResponseBody{
+		NextCursor: res.NextCursor,
+	}
 	if res.Policies != nil {
 		body.Policies = make([]*PolicyResponseBody, len(res.Policies))
 		for i, val := range res.Policies {
@@ -83,6 +543,65 @@ func NewListPoliciesResponseBody(res *policy.PoliciesResult) *ListPoliciesRespon
 	return body
 }
 
+// NewPolicyDependenciesResponseBody builds the HTTP response body from the
+// result of the "PolicyDependencies" endpoint of the "policy" service.
+func NewPolicyDependenciesResponseBody(res *policy.PolicyDependenciesResult) *PolicyDependenciesResponseBody {
+	body := &PolicyDependenciesResponseBody{}
+	if res.Policies != nil {
+		body.Policies = make([]*DependencyReportResponseBody, len(res.Policies))
+		for i, val := range res.Policies {
+			body.Policies[i] = marshalPolicyDependencyReportToDependencyReportResponseBody(val)
+		}
+	} else {
+		body.Policies = []*DependencyReportResponseBody{}
+	}
+	return body
+}
+
+// NewGetPolicyResponseBody builds the HTTP response body from the result of
+// the "GetPolicy" endpoint of the "policy" service.
+func NewGetPolicyResponseBody(res *policy.Policy) *GetPolicyResponseBody {
+	body := &GetPolicyResponseBody{
+		Repository:   res.Repository,
+		PolicyName:   res.PolicyName,
+		Group:        res.Group,
+		Version:      res.Version,
+		Rego:         res.Rego,
+		Data:         res.Data,
+		DataConfig:   res.DataConfig,
+		Locked:       res.Locked,
+		LastUpdate:   res.LastUpdate,
+		Archived:     res.Archived,
+		OutputSchema: res.OutputSchema,
+		ExportConfig: res.ExportConfig,
+	}
+	if res.Commit != nil {
+		body.Commit = marshalPolicyPolicyCommitToPolicyCommitResponseBody(res.Commit)
+	}
+	if res.Metadata != nil {
+		body.Metadata = marshalPolicyPolicyMetadataToPolicyMetadataResponseBody(res.Metadata)
+	}
+	if res.Lock != nil {
+		body.Lock = marshalPolicyPolicyLockToPolicyLockResponseBody(res.Lock)
+	}
+	return body
+}
+
+// NewSubscriberDeliveriesResponseBody builds the HTTP response body from the
+// result of the "SubscriberDeliveries" endpoint of the "policy" service.
+func NewSubscriberDeliveriesResponseBody(res *policy.SubscriberDeliveriesResult) *SubscriberDeliveriesResponseBody {
+	body := &SubscriberDeliveriesResponseBody{}
+	if res.Deliveries != nil {
+		body.Deliveries = make([]*WebhookDeliveryResponseBody, len(res.Deliveries))
+		for i, val := range res.Deliveries {
+			body.Deliveries[i] = marshalPolicyWebhookDeliveryToWebhookDeliveryResponseBody(val)
+		}
+	} else {
+		body.Deliveries = []*WebhookDeliveryResponseBody{}
+	}
+	return body
+}
+
 // NewEvaluateRequest builds a policy service Evaluate endpoint payload.
 func NewEvaluateRequest(body any, repository string, group string, policyName string, version string, evaluationID *string, ttl *int) *policy.EvaluateRequest {
 	v := body
@@ -116,8 +635,11 @@ func NewValidateEvaluateRequest(body any, repository string, group string, polic
 }
 
 // NewLockRequest builds a policy service Lock endpoint payload.
-func NewLockRequest(repository string, group string, policyName string, version string) *policy.LockRequest {
-	v := &policy.LockRequest{}
+func NewLockRequest(body *LockRequestBody, repository string, group string, policyName string, version string) *policy.LockRequest {
+	v := &policy.LockRequest{
+		Reason:    body.Reason,
+		ExpiresAt: body.ExpiresAt,
+	}
 	v.Repository = repository
 	v.Group = group
 	v.PolicyName = policyName
@@ -137,13 +659,163 @@ func NewUnlockRequest(repository string, group string, policyName string, versio
 	return v
 }
 
+// NewBulkLockRequest builds a policy service BulkLock endpoint payload.
+func NewBulkLockRequest(body *BulkLockRequestBody) *policy.BulkLockRequest {
+	v := &policy.BulkLockRequest{
+		Repository: body.Repository,
+		Group:      body.Group,
+		PolicyName: body.PolicyName,
+		Version:    body.Version,
+		Reason:     body.Reason,
+		ExpiresAt:  body.ExpiresAt,
+	}
+	if body.Policies != nil {
+		v.Policies = make([]*policy.PolicyRef, len(body.Policies))
+		for i, val := range body.Policies {
+			v.Policies[i] = unmarshalPolicyRefRequestBodyToPolicyPolicyRef(val)
+		}
+	}
+
+	return v
+}
+
+// NewBulkUnlockRequest builds a policy service BulkUnlock endpoint payload.
+func NewBulkUnlockRequest(body *BulkUnlockRequestBody) *policy.BulkUnlockRequest {
+	v := &policy.BulkUnlockRequest{
+		Repository: body.Repository,
+		Group:      body.Group,
+		PolicyName: body.PolicyName,
+		Version:    body.Version,
+	}
+	if body.Policies != nil {
+		v.Policies = make([]*policy.PolicyRef, len(body.Policies))
+		for i, val := range body.Policies {
+			v.Policies[i] = unmarshalPolicyRefRequestBodyToPolicyPolicyRef(val)
+		}
+	}
+
+	return v
+}
+
+// NewCreatePolicyPolicyRequest builds a policy service CreatePolicy endpoint
+// payload.
+func NewCreatePolicyPolicyRequest(body *CreatePolicyRequestBody, repository string, group string, policyName string, version string) *policy.PolicyRequest {
+	v := &policy.PolicyRequest{
+		Rego:         *body.Rego,
+		Data:         body.Data,
+		DataConfig:   body.DataConfig,
+		OutputSchema: body.OutputSchema,
+		ExportConfig: body.ExportConfig,
+	}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v
+}
+
+// NewUpdatePolicyPolicyRequest builds a policy service UpdatePolicy endpoint
+// payload.
+func NewUpdatePolicyPolicyRequest(body *UpdatePolicyRequestBody, repository string, group string, policyName string, version string) *policy.PolicyRequest {
+	v := &policy.PolicyRequest{
+		Rego:         *body.Rego,
+		Data:         body.Data,
+		DataConfig:   body.DataConfig,
+		OutputSchema: body.OutputSchema,
+		ExportConfig: body.ExportConfig,
+	}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v
+}
+
+// NewDeletePolicyRequest builds a policy service DeletePolicy endpoint payload.
+func NewDeletePolicyRequest(repository string, group string, policyName string, version string) *policy.DeletePolicyRequest {
+	v := &policy.DeletePolicyRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v
+}
+
+// NewPolicyRevisionsRequest builds a policy service PolicyRevisions endpoint
+// payload.
+func NewPolicyRevisionsRequest(repository string, group string, policyName string, version string) *policy.PolicyRevisionsRequest {
+	v := &policy.PolicyRevisionsRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v
+}
+
+// NewPolicyRevisionRequest builds a policy service PolicyRevision endpoint
+// payload.
+func NewPolicyRevisionRequest(repository string, group string, policyName string, version string, revision int) *policy.PolicyRevisionRequest {
+	v := &policy.PolicyRevisionRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+	v.Revision = revision
+
+	return v
+}
+
+// NewDiffPolicyRevisionsRequest builds a policy service DiffPolicyRevisions
+// endpoint payload.
+func NewDiffPolicyRevisionsRequest(repository string, group string, policyName string, version string, from int, to int) *policy.DiffPolicyRevisionsRequest {
+	v := &policy.DiffPolicyRevisionsRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+	v.From = from
+	v.To = to
+
+	return v
+}
+
+// NewRollbackPolicyRequest builds a policy service RollbackPolicy endpoint
+// payload.
+func NewRollbackPolicyRequest(repository string, group string, policyName string, version string, revision int) *policy.RollbackPolicyRequest {
+	v := &policy.RollbackPolicyRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+	v.Revision = revision
+
+	return v
+}
+
 // NewExportBundleRequest builds a policy service ExportBundle endpoint payload.
-func NewExportBundleRequest(repository string, group string, policyName string, version string) *policy.ExportBundleRequest {
+func NewExportBundleRequest(repository string, group string, policyName string, version string, target string) *policy.ExportBundleRequest {
 	v := &policy.ExportBundleRequest{}
 	v.Repository = repository
 	v.Group = group
 	v.PolicyName = policyName
 	v.Version = version
+	v.Target = target
+
+	return v
+}
+
+// NewExportWasmBundleRequest builds a policy service ExportWasmBundle endpoint
+// payload.
+func NewExportWasmBundleRequest(repository string, group string, policyName string, version string) *policy.ExportWasmBundleRequest {
+	v := &policy.ExportWasmBundleRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
 
 	return v
 }
@@ -170,13 +842,43 @@ func NewImportBundlePayload(length *int) *policy.ImportBundlePayload {
 
 // NewListPoliciesPoliciesRequest builds a policy service ListPolicies endpoint
 // payload.
-func NewListPoliciesPoliciesRequest(locked *bool, policyName *string, rego *bool, data *bool, dataConfig *bool) *policy.PoliciesRequest {
+func NewListPoliciesPoliciesRequest(locked *bool, policyName *string, rego *bool, data *bool, dataConfig *bool, repository *string, group *string, version *string, updatedSince *string, sort string, order string, cursor *string, limit *int, annotation []string) *policy.PoliciesRequest {
 	v := &policy.PoliciesRequest{}
 	v.Locked = locked
 	v.PolicyName = policyName
 	v.Rego = rego
 	v.Data = data
 	v.DataConfig = dataConfig
+	v.Repository = repository
+	v.Group = group
+	v.Version = version
+	v.UpdatedSince = updatedSince
+	v.Sort = sort
+	v.Order = order
+	v.Cursor = cursor
+	v.Limit = limit
+	v.Annotation = annotation
+
+	return v
+}
+
+// NewPolicyDependenciesRequest builds a policy service PolicyDependencies
+// endpoint payload.
+func NewPolicyDependenciesRequest(builtin *string, repository *string) *policy.PolicyDependenciesRequest {
+	v := &policy.PolicyDependenciesRequest{}
+	v.Builtin = builtin
+	v.Repository = repository
+
+	return v
+}
+
+// NewGetPolicyRequest builds a policy service GetPolicy endpoint payload.
+func NewGetPolicyRequest(repository string, group string, policyName string, version string) *policy.GetPolicyRequest {
+	v := &policy.GetPolicyRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
 
 	return v
 }
@@ -217,6 +919,84 @@ func NewSubscribeForPolicyChangeSubscribeRequest(body *SubscribeForPolicyChangeR
 	return v
 }
 
+// NewSubscriberDeliveriesRequest builds a policy service SubscriberDeliveries
+// endpoint payload.
+func NewSubscriberDeliveriesRequest(repository string, group string, policyName string, version string, subscriber string) *policy.SubscriberDeliveriesRequest {
+	v := &policy.SubscriberDeliveriesRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+	v.Subscriber = subscriber
+
+	return v
+}
+
+// ValidateLockRequestBody runs the validations defined on LockRequestBody
+func ValidateLockRequestBody(body *LockRequestBody) (err error) {
+	if body.ExpiresAt != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.expiresAt", *body.ExpiresAt, goa.FormatDateTime))
+	}
+	return
+}
+
+// ValidateBulkLockRequestBody runs the validations defined on
+// BulkLockRequestBody
+func ValidateBulkLockRequestBody(body *BulkLockRequestBody) (err error) {
+	for _, e := range body.Policies {
+		if e != nil {
+			if err2 := ValidatePolicyRefRequestBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	if body.ExpiresAt != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.expiresAt", *body.ExpiresAt, goa.FormatDateTime))
+	}
+	return
+}
+
+// ValidateBulkUnlockRequestBody runs the validations defined on
+// BulkUnlockRequestBody
+func ValidateBulkUnlockRequestBody(body *BulkUnlockRequestBody) (err error) {
+	for _, e := range body.Policies {
+		if e != nil {
+			if err2 := ValidatePolicyRefRequestBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// ValidateCreatePolicyRequestBody runs the validations defined on
+// CreatePolicyRequestBody
+func ValidateCreatePolicyRequestBody(body *CreatePolicyRequestBody) (err error) {
+	if body.Rego == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("rego", "body"))
+	}
+	if body.Rego != nil {
+		if utf8.RuneCountInString(*body.Rego) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("body.rego", *body.Rego, utf8.RuneCountInString(*body.Rego), 1, true))
+		}
+	}
+	return
+}
+
+// ValidateUpdatePolicyRequestBody runs the validations defined on
+// UpdatePolicyRequestBody
+func ValidateUpdatePolicyRequestBody(body *UpdatePolicyRequestBody) (err error) {
+	if body.Rego == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("rego", "body"))
+	}
+	if body.Rego != nil {
+		if utf8.RuneCountInString(*body.Rego) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("body.rego", *body.Rego, utf8.RuneCountInString(*body.Rego), 1, true))
+		}
+	}
+	return
+}
+
 // ValidateSetPolicyAutoImportRequestBody runs the validations defined on
 // SetPolicyAutoImportRequestBody
 func ValidateSetPolicyAutoImportRequestBody(body *SetPolicyAutoImportRequestBody) (err error) {
@@ -273,3 +1053,21 @@ func ValidateSubscribeForPolicyChangeRequestBody(body *SubscribeForPolicyChangeR
 	}
 	return
 }
+
+// ValidatePolicyRefRequestBody runs the validations defined on
+// PolicyRefRequestBody
+func ValidatePolicyRefRequestBody(body *PolicyRefRequestBody) (err error) {
+	if body.Repository == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("repository", "body"))
+	}
+	if body.Group == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("group", "body"))
+	}
+	if body.PolicyName == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("policyName", "body"))
+	}
+	if body.Version == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("version", "body"))
+	}
+	return
+}
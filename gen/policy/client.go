@@ -20,31 +20,57 @@ type Client struct {
 	ValidateEndpoint                 goa.Endpoint
 	LockEndpoint                     goa.Endpoint
 	UnlockEndpoint                   goa.Endpoint
+	BulkLockEndpoint                 goa.Endpoint
+	BulkUnlockEndpoint               goa.Endpoint
+	CreatePolicyEndpoint             goa.Endpoint
+	UpdatePolicyEndpoint             goa.Endpoint
+	DeletePolicyEndpoint             goa.Endpoint
+	PolicyRevisionsEndpoint          goa.Endpoint
+	PolicyRevisionEndpoint           goa.Endpoint
+	DiffPolicyRevisionsEndpoint      goa.Endpoint
+	RollbackPolicyEndpoint           goa.Endpoint
 	ExportBundleEndpoint             goa.Endpoint
+	ExportWasmBundleEndpoint         goa.Endpoint
 	PolicyPublicKeyEndpoint          goa.Endpoint
 	ImportBundleEndpoint             goa.Endpoint
 	ListPoliciesEndpoint             goa.Endpoint
+	PolicyDependenciesEndpoint       goa.Endpoint
+	GetPolicyEndpoint                goa.Endpoint
 	SetPolicyAutoImportEndpoint      goa.Endpoint
 	PolicyAutoImportEndpoint         goa.Endpoint
 	DeletePolicyAutoImportEndpoint   goa.Endpoint
 	SubscribeForPolicyChangeEndpoint goa.Endpoint
+	SubscriberDeliveriesEndpoint     goa.Endpoint
 }
 
 // NewClient initializes a "policy" service client given the endpoints.
-func NewClient(evaluate, validate, lock, unlock, exportBundle, policyPublicKey, importBundle, listPolicies, setPolicyAutoImport, policyAutoImport, deletePolicyAutoImport, subscribeForPolicyChange goa.Endpoint) *Client {
+func NewClient(evaluate, validate, lock, unlock, bulkLock, bulkUnlock, createPolicy, updatePolicy, deletePolicy, policyRevisions, policyRevision, diffPolicyRevisions, rollbackPolicy, exportBundle, exportWasmBundle, policyPublicKey, importBundle, listPolicies, policyDependencies, getPolicy, setPolicyAutoImport, policyAutoImport, deletePolicyAutoImport, subscribeForPolicyChange, subscriberDeliveries goa.Endpoint) *Client {
 	return &Client{
 		EvaluateEndpoint:                 evaluate,
 		ValidateEndpoint:                 validate,
 		LockEndpoint:                     lock,
 		UnlockEndpoint:                   unlock,
+		BulkLockEndpoint:                 bulkLock,
+		BulkUnlockEndpoint:               bulkUnlock,
+		CreatePolicyEndpoint:             createPolicy,
+		UpdatePolicyEndpoint:             updatePolicy,
+		DeletePolicyEndpoint:             deletePolicy,
+		PolicyRevisionsEndpoint:          policyRevisions,
+		PolicyRevisionEndpoint:           policyRevision,
+		DiffPolicyRevisionsEndpoint:      diffPolicyRevisions,
+		RollbackPolicyEndpoint:           rollbackPolicy,
 		ExportBundleEndpoint:             exportBundle,
+		ExportWasmBundleEndpoint:         exportWasmBundle,
 		PolicyPublicKeyEndpoint:          policyPublicKey,
 		ImportBundleEndpoint:             importBundle,
 		ListPoliciesEndpoint:             listPolicies,
+		PolicyDependenciesEndpoint:       policyDependencies,
+		GetPolicyEndpoint:                getPolicy,
 		SetPolicyAutoImportEndpoint:      setPolicyAutoImport,
 		PolicyAutoImportEndpoint:         policyAutoImport,
 		DeletePolicyAutoImportEndpoint:   deletePolicyAutoImport,
 		SubscribeForPolicyChangeEndpoint: subscribeForPolicyChange,
+		SubscriberDeliveriesEndpoint:     subscriberDeliveries,
 	}
 }
 
@@ -80,6 +106,93 @@ func (c *Client) Unlock(ctx context.Context, p *UnlockRequest) (err error) {
 	return
 }
 
+// BulkLock calls the "BulkLock" endpoint of the "policy" service.
+func (c *Client) BulkLock(ctx context.Context, p *BulkLockRequest) (res *BulkLockResult, err error) {
+	var ires any
+	ires, err = c.BulkLockEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*BulkLockResult), nil
+}
+
+// BulkUnlock calls the "BulkUnlock" endpoint of the "policy" service.
+func (c *Client) BulkUnlock(ctx context.Context, p *BulkUnlockRequest) (res *BulkLockResult, err error) {
+	var ires any
+	ires, err = c.BulkUnlockEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*BulkLockResult), nil
+}
+
+// CreatePolicy calls the "CreatePolicy" endpoint of the "policy" service.
+func (c *Client) CreatePolicy(ctx context.Context, p *PolicyRequest) (res any, err error) {
+	var ires any
+	ires, err = c.CreatePolicyEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(any), nil
+}
+
+// UpdatePolicy calls the "UpdatePolicy" endpoint of the "policy" service.
+func (c *Client) UpdatePolicy(ctx context.Context, p *PolicyRequest) (res any, err error) {
+	var ires any
+	ires, err = c.UpdatePolicyEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(any), nil
+}
+
+// DeletePolicy calls the "DeletePolicy" endpoint of the "policy" service.
+func (c *Client) DeletePolicy(ctx context.Context, p *DeletePolicyRequest) (err error) {
+	_, err = c.DeletePolicyEndpoint(ctx, p)
+	return
+}
+
+// PolicyRevisions calls the "PolicyRevisions" endpoint of the "policy" service.
+func (c *Client) PolicyRevisions(ctx context.Context, p *PolicyRevisionsRequest) (res *PolicyRevisionsResult, err error) {
+	var ires any
+	ires, err = c.PolicyRevisionsEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*PolicyRevisionsResult), nil
+}
+
+// PolicyRevision calls the "PolicyRevision" endpoint of the "policy" service.
+func (c *Client) PolicyRevision(ctx context.Context, p *PolicyRevisionRequest) (res *PolicyRevisionResult, err error) {
+	var ires any
+	ires, err = c.PolicyRevisionEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*PolicyRevisionResult), nil
+}
+
+// DiffPolicyRevisions calls the "DiffPolicyRevisions" endpoint of the "policy"
+// service.
+func (c *Client) DiffPolicyRevisions(ctx context.Context, p *DiffPolicyRevisionsRequest) (res *DiffPolicyRevisionsResult, err error) {
+	var ires any
+	ires, err = c.DiffPolicyRevisionsEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*DiffPolicyRevisionsResult), nil
+}
+
+// RollbackPolicy calls the "RollbackPolicy" endpoint of the "policy" service.
+func (c *Client) RollbackPolicy(ctx context.Context, p *RollbackPolicyRequest) (res *PolicyRevisionResult, err error) {
+	var ires any
+	ires, err = c.RollbackPolicyEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*PolicyRevisionResult), nil
+}
+
 // ExportBundle calls the "ExportBundle" endpoint of the "policy" service.
 func (c *Client) ExportBundle(ctx context.Context, p *ExportBundleRequest) (res *ExportBundleResult, resp io.ReadCloser, err error) {
 	var ires any
@@ -91,6 +204,18 @@ func (c *Client) ExportBundle(ctx context.Context, p *ExportBundleRequest) (res
 	return o.Result, o.Body, nil
 }
 
+// ExportWasmBundle calls the "ExportWasmBundle" endpoint of the "policy"
+// service.
+func (c *Client) ExportWasmBundle(ctx context.Context, p *ExportWasmBundleRequest) (res *ExportBundleResult, resp io.ReadCloser, err error) {
+	var ires any
+	ires, err = c.ExportWasmBundleEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	o := ires.(*ExportWasmBundleResponseData)
+	return o.Result, o.Body, nil
+}
+
 // PolicyPublicKey calls the "PolicyPublicKey" endpoint of the "policy" service.
 func (c *Client) PolicyPublicKey(ctx context.Context, p *PolicyPublicKeyRequest) (res any, err error) {
 	var ires any
@@ -121,6 +246,27 @@ func (c *Client) ListPolicies(ctx context.Context, p *PoliciesRequest) (res *Pol
 	return ires.(*PoliciesResult), nil
 }
 
+// PolicyDependencies calls the "PolicyDependencies" endpoint of the "policy"
+// service.
+func (c *Client) PolicyDependencies(ctx context.Context, p *PolicyDependenciesRequest) (res *PolicyDependenciesResult, err error) {
+	var ires any
+	ires, err = c.PolicyDependenciesEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*PolicyDependenciesResult), nil
+}
+
+// GetPolicy calls the "GetPolicy" endpoint of the "policy" service.
+func (c *Client) GetPolicy(ctx context.Context, p *GetPolicyRequest) (res *Policy, err error) {
+	var ires any
+	ires, err = c.GetPolicyEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*Policy), nil
+}
+
 // SetPolicyAutoImport calls the "SetPolicyAutoImport" endpoint of the "policy"
 // service.
 func (c *Client) SetPolicyAutoImport(ctx context.Context, p *SetPolicyAutoImportRequest) (res any, err error) {
@@ -164,3 +310,14 @@ func (c *Client) SubscribeForPolicyChange(ctx context.Context, p *SubscribeReque
 	}
 	return ires.(any), nil
 }
+
+// SubscriberDeliveries calls the "SubscriberDeliveries" endpoint of the
+// "policy" service.
+func (c *Client) SubscriberDeliveries(ctx context.Context, p *SubscriberDeliveriesRequest) (res *SubscriberDeliveriesResult, err error) {
+	var ires any
+	ires, err = c.SubscriberDeliveriesEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*SubscriberDeliveriesResult), nil
+}
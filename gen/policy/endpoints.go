@@ -20,14 +20,27 @@ type Endpoints struct {
 	Validate                 goa.Endpoint
 	Lock                     goa.Endpoint
 	Unlock                   goa.Endpoint
+	BulkLock                 goa.Endpoint
+	BulkUnlock               goa.Endpoint
+	CreatePolicy             goa.Endpoint
+	UpdatePolicy             goa.Endpoint
+	DeletePolicy             goa.Endpoint
+	PolicyRevisions          goa.Endpoint
+	PolicyRevision           goa.Endpoint
+	DiffPolicyRevisions      goa.Endpoint
+	RollbackPolicy           goa.Endpoint
 	ExportBundle             goa.Endpoint
+	ExportWasmBundle         goa.Endpoint
 	PolicyPublicKey          goa.Endpoint
 	ImportBundle             goa.Endpoint
 	ListPolicies             goa.Endpoint
+	PolicyDependencies       goa.Endpoint
+	GetPolicy                goa.Endpoint
 	SetPolicyAutoImport      goa.Endpoint
 	PolicyAutoImport         goa.Endpoint
 	DeletePolicyAutoImport   goa.Endpoint
 	SubscribeForPolicyChange goa.Endpoint
+	SubscriberDeliveries     goa.Endpoint
 }
 
 // ExportBundleResponseData holds both the result and the HTTP response body
@@ -39,6 +52,15 @@ type ExportBundleResponseData struct {
 	Body io.ReadCloser
 }
 
+// ExportWasmBundleResponseData holds both the result and the HTTP response
+// body reader of the "ExportWasmBundle" method.
+type ExportWasmBundleResponseData struct {
+	// Result is the method result.
+	Result *ExportBundleResult
+	// Body streams the HTTP response body.
+	Body io.ReadCloser
+}
+
 // ImportBundleRequestData holds both the payload and the HTTP request body
 // reader of the "ImportBundle" method.
 type ImportBundleRequestData struct {
@@ -55,14 +77,27 @@ func NewEndpoints(s Service) *Endpoints {
 		Validate:                 NewValidateEndpoint(s),
 		Lock:                     NewLockEndpoint(s),
 		Unlock:                   NewUnlockEndpoint(s),
+		BulkLock:                 NewBulkLockEndpoint(s),
+		BulkUnlock:               NewBulkUnlockEndpoint(s),
+		CreatePolicy:             NewCreatePolicyEndpoint(s),
+		UpdatePolicy:             NewUpdatePolicyEndpoint(s),
+		DeletePolicy:             NewDeletePolicyEndpoint(s),
+		PolicyRevisions:          NewPolicyRevisionsEndpoint(s),
+		PolicyRevision:           NewPolicyRevisionEndpoint(s),
+		DiffPolicyRevisions:      NewDiffPolicyRevisionsEndpoint(s),
+		RollbackPolicy:           NewRollbackPolicyEndpoint(s),
 		ExportBundle:             NewExportBundleEndpoint(s),
+		ExportWasmBundle:         NewExportWasmBundleEndpoint(s),
 		PolicyPublicKey:          NewPolicyPublicKeyEndpoint(s),
 		ImportBundle:             NewImportBundleEndpoint(s),
 		ListPolicies:             NewListPoliciesEndpoint(s),
+		PolicyDependencies:       NewPolicyDependenciesEndpoint(s),
+		GetPolicy:                NewGetPolicyEndpoint(s),
 		SetPolicyAutoImport:      NewSetPolicyAutoImportEndpoint(s),
 		PolicyAutoImport:         NewPolicyAutoImportEndpoint(s),
 		DeletePolicyAutoImport:   NewDeletePolicyAutoImportEndpoint(s),
 		SubscribeForPolicyChange: NewSubscribeForPolicyChangeEndpoint(s),
+		SubscriberDeliveries:     NewSubscriberDeliveriesEndpoint(s),
 	}
 }
 
@@ -72,14 +107,27 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.Validate = m(e.Validate)
 	e.Lock = m(e.Lock)
 	e.Unlock = m(e.Unlock)
+	e.BulkLock = m(e.BulkLock)
+	e.BulkUnlock = m(e.BulkUnlock)
+	e.CreatePolicy = m(e.CreatePolicy)
+	e.UpdatePolicy = m(e.UpdatePolicy)
+	e.DeletePolicy = m(e.DeletePolicy)
+	e.PolicyRevisions = m(e.PolicyRevisions)
+	e.PolicyRevision = m(e.PolicyRevision)
+	e.DiffPolicyRevisions = m(e.DiffPolicyRevisions)
+	e.RollbackPolicy = m(e.RollbackPolicy)
 	e.ExportBundle = m(e.ExportBundle)
+	e.ExportWasmBundle = m(e.ExportWasmBundle)
 	e.PolicyPublicKey = m(e.PolicyPublicKey)
 	e.ImportBundle = m(e.ImportBundle)
 	e.ListPolicies = m(e.ListPolicies)
+	e.PolicyDependencies = m(e.PolicyDependencies)
+	e.GetPolicy = m(e.GetPolicy)
 	e.SetPolicyAutoImport = m(e.SetPolicyAutoImport)
 	e.PolicyAutoImport = m(e.PolicyAutoImport)
 	e.DeletePolicyAutoImport = m(e.DeletePolicyAutoImport)
 	e.SubscribeForPolicyChange = m(e.SubscribeForPolicyChange)
+	e.SubscriberDeliveries = m(e.SubscriberDeliveries)
 }
 
 // NewEvaluateEndpoint returns an endpoint function that calls the method
@@ -118,6 +166,87 @@ func NewUnlockEndpoint(s Service) goa.Endpoint {
 	}
 }
 
+// NewBulkLockEndpoint returns an endpoint function that calls the method
+// "BulkLock" of service "policy".
+func NewBulkLockEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*BulkLockRequest)
+		return s.BulkLock(ctx, p)
+	}
+}
+
+// NewBulkUnlockEndpoint returns an endpoint function that calls the method
+// "BulkUnlock" of service "policy".
+func NewBulkUnlockEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*BulkUnlockRequest)
+		return s.BulkUnlock(ctx, p)
+	}
+}
+
+// NewCreatePolicyEndpoint returns an endpoint function that calls the method
+// "CreatePolicy" of service "policy".
+func NewCreatePolicyEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*PolicyRequest)
+		return s.CreatePolicy(ctx, p)
+	}
+}
+
+// NewUpdatePolicyEndpoint returns an endpoint function that calls the method
+// "UpdatePolicy" of service "policy".
+func NewUpdatePolicyEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*PolicyRequest)
+		return s.UpdatePolicy(ctx, p)
+	}
+}
+
+// NewDeletePolicyEndpoint returns an endpoint function that calls the method
+// "DeletePolicy" of service "policy".
+func NewDeletePolicyEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*DeletePolicyRequest)
+		return nil, s.DeletePolicy(ctx, p)
+	}
+}
+
+// NewPolicyRevisionsEndpoint returns an endpoint function that calls the
+// method "PolicyRevisions" of service "policy".
+func NewPolicyRevisionsEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*PolicyRevisionsRequest)
+		return s.PolicyRevisions(ctx, p)
+	}
+}
+
+// NewPolicyRevisionEndpoint returns an endpoint function that calls the method
+// "PolicyRevision" of service "policy".
+func NewPolicyRevisionEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*PolicyRevisionRequest)
+		return s.PolicyRevision(ctx, p)
+	}
+}
+
+// NewDiffPolicyRevisionsEndpoint returns an endpoint function that calls the
+// method "DiffPolicyRevisions" of service "policy".
+func NewDiffPolicyRevisionsEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*DiffPolicyRevisionsRequest)
+		return s.DiffPolicyRevisions(ctx, p)
+	}
+}
+
+// NewRollbackPolicyEndpoint returns an endpoint function that calls the method
+// "RollbackPolicy" of service "policy".
+func NewRollbackPolicyEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*RollbackPolicyRequest)
+		return s.RollbackPolicy(ctx, p)
+	}
+}
+
 // NewExportBundleEndpoint returns an endpoint function that calls the method
 // "ExportBundle" of service "policy".
 func NewExportBundleEndpoint(s Service) goa.Endpoint {
@@ -131,6 +260,19 @@ func NewExportBundleEndpoint(s Service) goa.Endpoint {
 	}
 }
 
+// NewExportWasmBundleEndpoint returns an endpoint function that calls the
+// method "ExportWasmBundle" of service "policy".
+func NewExportWasmBundleEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*ExportWasmBundleRequest)
+		res, body, err := s.ExportWasmBundle(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		return &ExportWasmBundleResponseData{Result: res, Body: body}, nil
+	}
+}
+
 // NewPolicyPublicKeyEndpoint returns an endpoint function that calls the
 // method "PolicyPublicKey" of service "policy".
 func NewPolicyPublicKeyEndpoint(s Service) goa.Endpoint {
@@ -158,6 +300,24 @@ func NewListPoliciesEndpoint(s Service) goa.Endpoint {
 	}
 }
 
+// NewPolicyDependenciesEndpoint returns an endpoint function that calls the
+// method "PolicyDependencies" of service "policy".
+func NewPolicyDependenciesEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*PolicyDependenciesRequest)
+		return s.PolicyDependencies(ctx, p)
+	}
+}
+
+// NewGetPolicyEndpoint returns an endpoint function that calls the method
+// "GetPolicy" of service "policy".
+func NewGetPolicyEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*GetPolicyRequest)
+		return s.GetPolicy(ctx, p)
+	}
+}
+
 // NewSetPolicyAutoImportEndpoint returns an endpoint function that calls the
 // method "SetPolicyAutoImport" of service "policy".
 func NewSetPolicyAutoImportEndpoint(s Service) goa.Endpoint {
@@ -192,3 +352,12 @@ func NewSubscribeForPolicyChangeEndpoint(s Service) goa.Endpoint {
 		return s.SubscribeForPolicyChange(ctx, p)
 	}
 }
+
+// NewSubscriberDeliveriesEndpoint returns an endpoint function that calls the
+// method "SubscriberDeliveries" of service "policy".
+func NewSubscriberDeliveriesEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*SubscriberDeliveriesRequest)
+		return s.SubscriberDeliveries(ctx, p)
+	}
+}
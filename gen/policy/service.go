@@ -23,8 +23,30 @@ type Service interface {
 	Lock(context.Context, *LockRequest) (err error)
 	// Unlock a policy so it can be evaluated again.
 	Unlock(context.Context, *UnlockRequest) (err error)
+	// Lock all unlocked policies matching the request, so that they cannot be
+	// evaluated.
+	BulkLock(context.Context, *BulkLockRequest) (res *BulkLockResult, err error)
+	// Unlock all locked policies matching the request, so they can be evaluated
+	// again.
+	BulkUnlock(context.Context, *BulkUnlockRequest) (res *BulkLockResult, err error)
+	// Create a new policy in storage.
+	CreatePolicy(context.Context, *PolicyRequest) (res any, err error)
+	// Update the source code, data and configuration of an existing policy.
+	UpdatePolicy(context.Context, *PolicyRequest) (res any, err error)
+	// Delete a policy from storage.
+	DeletePolicy(context.Context, *DeletePolicyRequest) (err error)
+	// List the revisions of a policy.
+	PolicyRevisions(context.Context, *PolicyRevisionsRequest) (res *PolicyRevisionsResult, err error)
+	// Show the source code, data and configuration of a policy revision.
+	PolicyRevision(context.Context, *PolicyRevisionRequest) (res *PolicyRevisionResult, err error)
+	// Show the differences between two revisions of a policy.
+	DiffPolicyRevisions(context.Context, *DiffPolicyRevisionsRequest) (res *DiffPolicyRevisionsResult, err error)
+	// Restore a policy revision as the current policy and notify subscribers.
+	RollbackPolicy(context.Context, *RollbackPolicyRequest) (res *PolicyRevisionResult, err error)
 	// Export a signed policy bundle.
 	ExportBundle(context.Context, *ExportBundleRequest) (res *ExportBundleResult, body io.ReadCloser, err error)
+	// Export a signed policy bundle with the policy compiled to WebAssembly.
+	ExportWasmBundle(context.Context, *ExportWasmBundleRequest) (res *ExportBundleResult, body io.ReadCloser, err error)
 	// PolicyPublicKey returns the public key in JWK format which must be used to
 	// verify a signed policy bundle.
 	PolicyPublicKey(context.Context, *PolicyPublicKeyRequest) (res any, err error)
@@ -32,6 +54,12 @@ type Service interface {
 	ImportBundle(context.Context, *ImportBundlePayload, io.ReadCloser) (res any, err error)
 	// List policies from storage with optional filters.
 	ListPolicies(context.Context, *PoliciesRequest) (res *PoliciesResult, err error)
+	// Report the builtin functions, imported packages and data paths used by
+	// policies.
+	PolicyDependencies(context.Context, *PolicyDependenciesRequest) (res *PolicyDependenciesResult, err error)
+	// Get the source code, data, configuration and METADATA annotations of a
+	// policy.
+	GetPolicy(context.Context, *GetPolicyRequest) (res *Policy, err error)
 	// SetPolicyAutoImport enables automatic import of policy bundle on a given
 	// time interval.
 	SetPolicyAutoImport(context.Context, *SetPolicyAutoImportRequest) (res any, err error)
@@ -42,6 +70,8 @@ type Service interface {
 	// Subscribe for policy change notifications by registering webhook callbacks
 	// which the policy service will call.
 	SubscribeForPolicyChange(context.Context, *SubscribeRequest) (res any, err error)
+	// List the webhook deliveries to a policy change subscriber.
+	SubscriberDeliveries(context.Context, *SubscriberDeliveriesRequest) (res *SubscriberDeliveriesResult, err error)
 }
 
 // ServiceName is the name of the service as defined in the design. This is the
@@ -52,7 +82,47 @@ const ServiceName = "policy"
 // MethodNames lists the service method names as defined in the design. These
 // are the same values that are set in the endpoint request contexts under the
 // MethodKey key.
-var MethodNames = [12]string{"Evaluate", "Validate", "Lock", "Unlock", "ExportBundle", "PolicyPublicKey", "ImportBundle", "ListPolicies", "SetPolicyAutoImport", "PolicyAutoImport", "DeletePolicyAutoImport", "SubscribeForPolicyChange"}
+var MethodNames = [25]string{"Evaluate", "Validate", "Lock", "Unlock", "BulkLock", "BulkUnlock", "CreatePolicy", "UpdatePolicy", "DeletePolicy", "PolicyRevisions", "PolicyRevision", "DiffPolicyRevisions", "RollbackPolicy", "ExportBundle", "ExportWasmBundle", "PolicyPublicKey", "ImportBundle", "ListPolicies", "PolicyDependencies", "GetPolicy", "SetPolicyAutoImport", "PolicyAutoImport", "DeletePolicyAutoImport", "SubscribeForPolicyChange", "SubscriberDeliveries"}
+
+// BulkLockRequest is the payload type of the policy service BulkLock method.
+type BulkLockRequest struct {
+	// Lock policies of a repository.
+	Repository *string
+	// Lock policies of a group.
+	Group *string
+	// Lock policies with names matching a shell pattern, e.g. did*.
+	PolicyName *string
+	// Lock policies with a version.
+	Version *string
+	// Lock only the listed policies.
+	Policies []*PolicyRef
+	// Reason for locking the policies.
+	Reason *string
+	// Time in RFC 3339 format when the policies are unlocked automatically
+	// (optional).
+	ExpiresAt *string
+}
+
+// BulkLockResult is the result type of the policy service BulkLock method.
+type BulkLockResult struct {
+	// Locked or unlocked policies.
+	Policies []*PolicyRef
+}
+
+// BulkUnlockRequest is the payload type of the policy service BulkUnlock
+// method.
+type BulkUnlockRequest struct {
+	// Unlock policies of a repository.
+	Repository *string
+	// Unlock policies of a group.
+	Group *string
+	// Unlock policies with names matching a shell pattern, e.g. did*.
+	PolicyName *string
+	// Unlock policies with a version.
+	Version *string
+	// Unlock only the listed policies.
+	Policies []*PolicyRef
+}
 
 // DeletePolicyAutoImportRequest is the payload type of the policy service
 // DeletePolicyAutoImport method.
@@ -61,6 +131,66 @@ type DeletePolicyAutoImportRequest struct {
 	PolicyURL string
 }
 
+// DeletePolicyRequest is the payload type of the policy service DeletePolicy
+// method.
+type DeletePolicyRequest struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+}
+
+type DependencyReport struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+	// Builtin and extension functions called by the policy.
+	Builtins []string
+	// Packages imported by the policy.
+	Imports []string
+	// Data paths referenced by the policy.
+	DataPaths []string
+	// Error parsing the policy source code.
+	Error *string
+}
+
+// DiffPolicyRevisionsRequest is the payload type of the policy service
+// DiffPolicyRevisions method.
+type DiffPolicyRevisionsRequest struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+	// Revision to compare from.
+	From int
+	// Revision to compare to.
+	To int
+}
+
+// DiffPolicyRevisionsResult is the result type of the policy service
+// DiffPolicyRevisions method.
+type DiffPolicyRevisionsResult struct {
+	// Revision compared from.
+	From int
+	// Revision compared to.
+	To int
+	// Differences of the changed policy fields.
+	Changes []*PolicyRevisionDiff
+}
+
 // EvaluateRequest is the payload type of the policy service Evaluate method.
 type EvaluateRequest struct {
 	// Policy repository.
@@ -100,6 +230,8 @@ type ExportBundleRequest struct {
 	PolicyName string
 	// Policy version.
 	Version string
+	// Export target format.
+	Target string
 }
 
 // ExportBundleResult is the result type of the policy service ExportBundle
@@ -113,6 +245,31 @@ type ExportBundleResult struct {
 	ContentDisposition string
 }
 
+// ExportWasmBundleRequest is the payload type of the policy service
+// ExportWasmBundle method.
+type ExportWasmBundleRequest struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+}
+
+// GetPolicyRequest is the payload type of the policy service GetPolicy method.
+type GetPolicyRequest struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+}
+
 // ImportBundlePayload is the payload type of the policy service ImportBundle
 // method.
 type ImportBundlePayload struct {
@@ -129,24 +286,40 @@ type LockRequest struct {
 	PolicyName string
 	// Policy version.
 	Version string
+	// Reason for locking the policy.
+	Reason *string
+	// Time in RFC 3339 format when the policy is unlocked automatically (optional).
+	ExpiresAt *string
 }
 
 // PoliciesRequest is the payload type of the policy service ListPolicies
 // method.
 type PoliciesRequest struct {
-	Locked     *bool
-	PolicyName *string
-	Rego       *bool
-	Data       *bool
-	DataConfig *bool
+	Locked       *bool
+	PolicyName   *string
+	Rego         *bool
+	Data         *bool
+	DataConfig   *bool
+	Repository   *string
+	Group        *string
+	Version      *string
+	UpdatedSince *string
+	Sort         string
+	Order        string
+	Cursor       *string
+	Limit        *int
+	Annotation   []string
 }
 
 // PoliciesResult is the result type of the policy service ListPolicies method.
 type PoliciesResult struct {
 	// JSON array of policies.
 	Policies []*Policy
+	// Cursor for the next page of policies, which is missing on the last page.
+	NextCursor *string
 }
 
+// Policy is the result type of the policy service GetPolicy method.
 type Policy struct {
 	// Policy repository.
 	Repository string
@@ -166,6 +339,70 @@ type Policy struct {
 	Locked bool
 	// Last update (Unix timestamp).
 	LastUpdate int64
+	// Archived specifies if the policy is removed from its repository and cannot
+	// be evaluated.
+	Archived *bool
+	// Git commit from which the policy is synchronized.
+	Commit *PolicyCommit
+	// Policy output JSON schema.
+	OutputSchema *string
+	// Policy export configuration.
+	ExportConfig *string
+	// METADATA annotations of the policy package.
+	Metadata *PolicyMetadata
+	// Details of the policy lock.
+	Lock *PolicyLock
+}
+
+type PolicyCommit struct {
+	// Commit SHA.
+	Sha string
+	// Commit time (Unix timestamp).
+	Time int64
+	// Commit author.
+	Author string
+	// Git branch from which the commit is synchronized.
+	Branch *string
+}
+
+// PolicyDependenciesRequest is the payload type of the policy service
+// PolicyDependencies method.
+type PolicyDependenciesRequest struct {
+	Builtin    *string
+	Repository *string
+}
+
+// PolicyDependenciesResult is the result type of the policy service
+// PolicyDependencies method.
+type PolicyDependenciesResult struct {
+	// Dependencies of the policies.
+	Policies []*DependencyReport
+}
+
+type PolicyLock struct {
+	// Reason for locking the policy.
+	Reason *string
+	// Authenticated caller who locked the policy.
+	LockedBy *string
+	// Lock time (Unix timestamp).
+	LockedAt int64
+	// Time when the policy is unlocked automatically (Unix timestamp).
+	ExpiresAt *int64
+}
+
+type PolicyMetadata struct {
+	// Policy title.
+	Title *string
+	// Policy description.
+	Description *string
+	// Policy authors.
+	Authors []string
+	// Organizations of the policy authors.
+	Organizations []string
+	// URLs of resources related to the policy.
+	RelatedResources []string
+	// Custom annotations.
+	Custom map[string]any
 }
 
 // PolicyPublicKeyRequest is the payload type of the policy service
@@ -181,6 +418,121 @@ type PolicyPublicKeyRequest struct {
 	Version string
 }
 
+type PolicyRef struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+}
+
+// PolicyRequest is the payload type of the policy service CreatePolicy method.
+type PolicyRequest struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+	// Policy rego source code. The package declaration must be 'group.policyName'.
+	Rego string
+	// Policy static data as JSON object (optional).
+	Data *string
+	// Policy static data configuration as JSON (optional).
+	DataConfig *string
+	// JSON schema for validation of the policy output (optional).
+	OutputSchema *string
+	// Policy bundle export configuration as JSON (optional).
+	ExportConfig *string
+}
+
+type PolicyRevisionDiff struct {
+	// Changed policy field: rego, data, dataConfig, outputSchema or exportConfig.
+	Field string
+	// Unified diff of the field.
+	Diff string
+}
+
+// PolicyRevisionRequest is the payload type of the policy service
+// PolicyRevision method.
+type PolicyRevisionRequest struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+	// Policy revision number.
+	Revision int
+}
+
+// PolicyRevisionResult is the result type of the policy service PolicyRevision
+// method.
+type PolicyRevisionResult struct {
+	// Revision number.
+	Revision int
+	// SHA-256 hash of the policy source code, data and configuration.
+	Hash string
+	// Source of the revision: sync, import, api, data refresh or rollback.
+	Source string
+	// Git commit of the revision, if known.
+	Commit *string
+	// Creation time of the revision (Unix timestamp).
+	CreatedAt int64
+	// Policy rego source code.
+	Rego *string
+	// Policy static data.
+	Data *string
+	// Policy static data optional configuration.
+	DataConfig *string
+	// JSON schema for validation of the policy output.
+	OutputSchema *string
+	// Policy bundle export configuration.
+	ExportConfig *string
+}
+
+// PolicyRevisionsRequest is the payload type of the policy service
+// PolicyRevisions method.
+type PolicyRevisionsRequest struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+}
+
+// PolicyRevisionsResult is the result type of the policy service
+// PolicyRevisions method.
+type PolicyRevisionsResult struct {
+	// JSON array of policy revisions without their content.
+	Revisions []*PolicyRevisionResult
+}
+
+// RollbackPolicyRequest is the payload type of the policy service
+// RollbackPolicy method.
+type RollbackPolicyRequest struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+	// Policy revision to restore.
+	Revision int
+}
+
 // SetPolicyAutoImportRequest is the payload type of the policy service
 // SetPolicyAutoImport method.
 type SetPolicyAutoImportRequest struct {
@@ -207,6 +559,28 @@ type SubscribeRequest struct {
 	Version string
 }
 
+// SubscriberDeliveriesRequest is the payload type of the policy service
+// SubscriberDeliveries method.
+type SubscriberDeliveriesRequest struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+	// Name of the subscriber for policy.
+	Subscriber string
+}
+
+// SubscriberDeliveriesResult is the result type of the policy service
+// SubscriberDeliveries method.
+type SubscriberDeliveriesResult struct {
+	// JSON array of webhook deliveries, oldest first.
+	Deliveries []*WebhookDelivery
+}
+
 // UnlockRequest is the payload type of the policy service Unlock method.
 type UnlockRequest struct {
 	// Policy repository.
@@ -218,3 +592,22 @@ type UnlockRequest struct {
 	// Policy version.
 	Version string
 }
+
+type WebhookDelivery struct {
+	// Delivery ID, which is sent in the X-Policy-Delivery-Id header.
+	ID string
+	// Policy change event sent to the webhook.
+	Event any
+	// Delivery state: pending, delivered or dead.
+	State string
+	// Number of delivery attempts.
+	Attempts int
+	// Error of the last failed attempt.
+	LastError *string
+	// Time of the next attempt of a pending delivery (Unix timestamp).
+	NextAttempt *int64
+	// Creation time of the delivery (Unix timestamp).
+	CreatedAt int64
+	// Time of the last delivery update (Unix timestamp).
+	UpdatedAt int64
+}
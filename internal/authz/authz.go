@@ -0,0 +1,243 @@
+package authz
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"go.uber.org/zap"
+	goa "goa.design/goa/v3/pkg"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+	goapolicy "gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/policy"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/caller"
+)
+
+//go:generate counterfeiter . Evaluator
+
+// Evaluator evaluates system authorization policies.
+type Evaluator interface {
+	Authorize(ctx context.Context, repository, group, name, version string, input map[string]interface{}) (bool, error)
+	// BulkTargets returns the policies changed by a bulk request.
+	BulkTargets(ctx context.Context, req interface{}) ([]*goapolicy.PolicyRef, error)
+}
+
+// Policy specifies the coordinates of a system authorization policy.
+type Policy struct {
+	Repository string
+	Group      string
+	Name       string
+	Version    string
+}
+
+// ParsePolicy parses policy coordinates in the form
+// "repository/group/name/version".
+func ParsePolicy(s string) (*Policy, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 4 {
+		return nil, errors.New(errors.BadRequest, "authorization policy must be in the form repository/group/name/version")
+	}
+
+	for _, p := range parts {
+		if p == "" {
+			return nil, errors.New(errors.BadRequest, "authorization policy must be in the form repository/group/name/version")
+		}
+	}
+
+	return &Policy{Repository: parts[0], Group: parts[1], Name: parts[2], Version: parts[3]}, nil
+}
+
+// evaluationMethods are authorized with the evaluation policy,
+// all other methods are authorized with the admin policy.
+var evaluationMethods = map[string]bool{
+	"Evaluate": true,
+	"Validate": true,
+}
+
+// bulkMethods change many policies selected by the request,
+// so they are authorized for each of the changed policies.
+var bulkMethods = map[string]bool{
+	"BulkLock":   true,
+	"BulkUnlock": true,
+}
+
+// Authorizer authorizes requests to the policy service endpoints
+// by evaluating system authorization policies.
+type Authorizer struct {
+	evaluator  Evaluator
+	admin      *Policy
+	evaluation *Policy
+	logger     *zap.Logger
+}
+
+// New creates an Authorizer. The admin policy authorizes requests to the
+// admin endpoints and the evaluation policy authorizes requests to the
+// evaluation endpoints. Requests are not authorized by a policy
+// if the corresponding policy is empty.
+func New(evaluator Evaluator, adminPolicy, evaluationPolicy string, logger *zap.Logger) (*Authorizer, error) {
+	a := &Authorizer{evaluator: evaluator, logger: logger}
+
+	if adminPolicy != "" {
+		p, err := ParsePolicy(adminPolicy)
+		if err != nil {
+			return nil, errors.New("invalid admin authorization policy", err)
+		}
+		a.admin = p
+	}
+
+	if evaluationPolicy != "" {
+		p, err := ParsePolicy(evaluationPolicy)
+		if err != nil {
+			return nil, errors.New("invalid evaluation authorization policy", err)
+		}
+		a.evaluation = p
+	}
+
+	return a, nil
+}
+
+// Middleware is a Goa endpoint middleware which authorizes each request
+// with the system authorization policy for the called method. The policy
+// input contains the called method, the HTTP method, the claims of the
+// caller's token and the coordinates of the requested policy, if any.
+//
+// Bulk requests are authorized once for every policy they change, with the
+// coordinates of the policy and the selector of the request in the input.
+//
+// The caller must be added to the request context by caller.Middleware.
+func (a *Authorizer) Middleware() func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			method, _ := ctx.Value(goa.MethodKey).(string)
+
+			pol := a.admin
+			if evaluationMethods[method] {
+				pol = a.evaluation
+			}
+			if pol == nil {
+				return e(ctx, req)
+			}
+
+			input := map[string]interface{}{
+				"method": method,
+				"claims": map[string]interface{}{},
+			}
+			if c, ok := caller.FromContext(ctx); ok {
+				input["verb"] = c.HTTPMethod
+				input["claims"] = c.Claims
+			}
+			if bulkMethods[method] {
+				return a.authorizeBulk(ctx, e, req, pol, method, input)
+			}
+
+			if coordinates := policyCoordinates(req); len(coordinates) > 0 {
+				input["policy"] = coordinates
+			}
+
+			if err := a.authorize(ctx, pol, method, input); err != nil {
+				return nil, err
+			}
+
+			return e(ctx, req)
+		}
+	}
+}
+
+// authorizeBulk authorizes a bulk request for every policy it changes.
+// The request is restricted to the authorized policies, so that it doesn't
+// change policies which are selected after it's authorized.
+func (a *Authorizer) authorizeBulk(ctx context.Context, e goa.Endpoint, req interface{}, pol *Policy, method string, input map[string]interface{}) (interface{}, error) {
+	if selector := policyCoordinates(req); len(selector) > 0 {
+		input["selector"] = selector
+	}
+
+	targets, err := a.evaluator.BulkTargets(ctx, req)
+	if err != nil {
+		// the endpoint returns the same error if the request is allowed
+		a.logger.Debug("error selecting policies of bulk request", zap.String("method", method), zap.Error(err))
+		targets = nil
+	}
+
+	if len(targets) == 0 {
+		if err := a.authorize(ctx, pol, method, input); err != nil {
+			return nil, err
+		}
+		return e(ctx, req)
+	}
+
+	for _, target := range targets {
+		targetInput := make(map[string]interface{}, len(input)+1)
+		for k, v := range input {
+			targetInput[k] = v
+		}
+		targetInput["policy"] = policyCoordinates(target)
+
+		if err := a.authorize(ctx, pol, method, targetInput); err != nil {
+			return nil, err
+		}
+	}
+
+	switch r := req.(type) {
+	case *goapolicy.BulkLockRequest:
+		restricted := *r
+		restricted.Policies = targets
+		req = &restricted
+	case *goapolicy.BulkUnlockRequest:
+		restricted := *r
+		restricted.Policies = targets
+		req = &restricted
+	}
+
+	return e(ctx, req)
+}
+
+// authorize evaluates the authorization policy with the given input
+// and returns an error if the request is not allowed.
+func (a *Authorizer) authorize(ctx context.Context, pol *Policy, method string, input map[string]interface{}) error {
+	allow, err := a.evaluator.Authorize(ctx, pol.Repository, pol.Group, pol.Name, pol.Version, input)
+	if err != nil {
+		a.logger.Error("error evaluating authorization policy", zap.String("method", method), zap.Error(err))
+		return errors.New(errors.Forbidden, "error authorizing request")
+	}
+
+	if !allow {
+		a.logger.Debug("request is not authorized", zap.String("method", method), zap.String("caller", caller.ID(ctx)))
+		return errors.New(errors.Forbidden, "request is not authorized")
+	}
+
+	return nil
+}
+
+// policyCoordinates returns the policy coordinates of a request payload.
+// Payloads of all policy service methods name the coordinates the same way.
+func policyCoordinates(req interface{}) map[string]interface{} {
+	v := reflect.ValueOf(req)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	coordinates := map[string]interface{}{}
+	fields := map[string]string{
+		"Repository": "repository",
+		"Group":      "group",
+		"PolicyName": "name",
+		"Version":    "version",
+	}
+	for field, name := range fields {
+		f := v.FieldByName(field)
+		if f.Kind() == reflect.Pointer {
+			if f.IsNil() {
+				continue
+			}
+			f = f.Elem()
+		}
+		if f.Kind() == reflect.String {
+			coordinates[name] = f.String()
+		}
+	}
+
+	return coordinates
+}
@@ -0,0 +1,295 @@
+package authz_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	goa "goa.design/goa/v3/pkg"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/ptr"
+	goapolicy "gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/policy"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/authz"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/authz/authzfakes"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/caller"
+)
+
+func TestParsePolicy(t *testing.T) {
+	p, err := authz.ParsePolicy("system/authz/admin/1.0")
+	require.NoError(t, err)
+	assert.Equal(t, &authz.Policy{Repository: "system", Group: "authz", Name: "admin", Version: "1.0"}, p)
+
+	_, err = authz.ParsePolicy("system/authz/admin")
+	assert.True(t, errors.Is(errors.BadRequest, err))
+
+	_, err = authz.ParsePolicy("system//admin/1.0")
+	assert.True(t, errors.Is(errors.BadRequest, err))
+}
+
+func TestNew(t *testing.T) {
+	_, err := authz.New(&authzfakes.FakeEvaluator{}, "system/authz/admin/1.0", "", zap.NewNop())
+	assert.NoError(t, err)
+
+	_, err = authz.New(&authzfakes.FakeEvaluator{}, "", "admin", zap.NewNop())
+	assert.ErrorContains(t, err, "invalid evaluation authorization policy")
+}
+
+func evaluator(allow bool) *authzfakes.FakeEvaluator {
+	fake := &authzfakes.FakeEvaluator{}
+	fake.AuthorizeReturns(allow, nil)
+	return fake
+}
+
+func TestAuthorizer_Middleware(t *testing.T) {
+	alice := &caller.Caller{
+		ID:         "alice",
+		Claims:     map[string]interface{}{"sub": "alice", "role": "admin"},
+		HTTPMethod: "POST",
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		req        interface{}
+		admin      string
+		evaluation string
+		evaluator  *authzfakes.FakeEvaluator
+
+		policy  string
+		input   map[string]interface{}
+		errtext string
+	}{
+		{
+			name:      "admin request is not authorized by a policy",
+			method:    "Lock",
+			req:       &goapolicy.LockRequest{},
+			evaluator: &authzfakes.FakeEvaluator{},
+		},
+		{
+			name:       "admin request is allowed",
+			method:     "Lock",
+			req:        &goapolicy.LockRequest{Repository: "policies", Group: "xfsc", PolicyName: "example", Version: "1.0"},
+			admin:      "system/authz/admin/1.0",
+			evaluation: "system/authz/evaluation/1.0",
+			evaluator:  evaluator(true),
+			policy:     "system/authz/admin/1.0",
+			input: map[string]interface{}{
+				"method": "Lock",
+				"verb":   "POST",
+				"claims": map[string]interface{}{"sub": "alice", "role": "admin"},
+				"policy": map[string]interface{}{"repository": "policies", "group": "xfsc", "name": "example", "version": "1.0"},
+			},
+		},
+		{
+			name:      "request with optional coordinates is allowed",
+			method:    "ListPolicies",
+			req:       &goapolicy.PoliciesRequest{Repository: ptr.String("policies")},
+			admin:     "system/authz/admin/1.0",
+			evaluator: evaluator(true),
+			policy:    "system/authz/admin/1.0",
+			input: map[string]interface{}{
+				"method": "ListPolicies",
+				"verb":   "POST",
+				"claims": map[string]interface{}{"sub": "alice", "role": "admin"},
+				"policy": map[string]interface{}{"repository": "policies"},
+			},
+		},
+		{
+			name:       "evaluation request is allowed",
+			method:     "Evaluate",
+			req:        &goapolicy.EvaluateRequest{Repository: "policies", Group: "xfsc", PolicyName: "example", Version: "1.0"},
+			admin:      "system/authz/admin/1.0",
+			evaluation: "system/authz/evaluation/1.0",
+			evaluator:  evaluator(true),
+			policy:     "system/authz/evaluation/1.0",
+			input: map[string]interface{}{
+				"method": "Evaluate",
+				"verb":   "POST",
+				"claims": map[string]interface{}{"sub": "alice", "role": "admin"},
+				"policy": map[string]interface{}{"repository": "policies", "group": "xfsc", "name": "example", "version": "1.0"},
+			},
+		},
+		{
+			name:      "evaluation request is not authorized by a policy",
+			method:    "Evaluate",
+			req:       &goapolicy.EvaluateRequest{},
+			admin:     "system/authz/admin/1.0",
+			evaluator: &authzfakes.FakeEvaluator{},
+		},
+		{
+			name:      "request is denied",
+			method:    "Unlock",
+			req:       &goapolicy.UnlockRequest{},
+			admin:     "system/authz/admin/1.0",
+			evaluator: evaluator(false),
+			policy:    "system/authz/admin/1.0",
+			errtext:   "request is not authorized",
+		},
+		{
+			name:   "error evaluating authorization policy",
+			method: "Unlock",
+			req:    &goapolicy.UnlockRequest{},
+			admin:  "system/authz/admin/1.0",
+			evaluator: &authzfakes.FakeEvaluator{AuthorizeStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string, m map[string]interface{}) (bool, error) {
+				return true, errors.New("some error")
+			}},
+			policy:  "system/authz/admin/1.0",
+			errtext: "error authorizing request",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, err := authz.New(test.evaluator, test.admin, test.evaluation, zap.NewNop())
+			require.NoError(t, err)
+
+			var called bool
+			endpoint := a.Middleware()(func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return nil, nil
+			})
+
+			ctx := context.WithValue(caller.ToContext(context.Background(), alice), goa.MethodKey, test.method)
+			_, err = endpoint(ctx, test.req)
+
+			if test.policy == "" {
+				assert.True(t, called)
+				assert.Equal(t, 0, test.evaluator.AuthorizeCallCount())
+				return
+			}
+
+			require.Equal(t, 1, test.evaluator.AuthorizeCallCount())
+			_, repository, group, name, version, input := test.evaluator.AuthorizeArgsForCall(0)
+			assert.Equal(t, test.policy, repository+"/"+group+"/"+name+"/"+version)
+
+			if test.errtext != "" {
+				assert.False(t, called)
+				require.Error(t, err)
+				assert.True(t, errors.Is(errors.Forbidden, err))
+				assert.ErrorContains(t, err, test.errtext)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, called)
+			assert.Equal(t, test.input, input)
+		})
+	}
+}
+
+func TestAuthorizer_MiddlewareBulk(t *testing.T) {
+	alice := &caller.Caller{ID: "alice", Claims: map[string]interface{}{"sub": "alice"}, HTTPMethod: "POST"}
+	public := &goapolicy.PolicyRef{Repository: "policies", Group: "xfsc", PolicyName: "public", Version: "1.0"}
+	secret := &goapolicy.PolicyRef{Repository: "policies", Group: "xfsc", PolicyName: "secret", Version: "1.0"}
+
+	// the secret policy must not be changed by alice
+	authorize := func(ctx context.Context, repository, group, name, version string, input map[string]interface{}) (bool, error) {
+		pol, _ := input["policy"].(map[string]interface{})
+		return pol["name"] != "secret", nil
+	}
+
+	tests := []struct {
+		name    string
+		method  string
+		req     interface{}
+		targets []*goapolicy.PolicyRef
+		err     error
+
+		evaluations int
+		selector    map[string]interface{}
+		forwarded   interface{}
+		errtext     string
+	}{
+		{
+			name:        "bulk lock of a forbidden policy by name pattern is denied",
+			method:      "BulkLock",
+			req:         &goapolicy.BulkLockRequest{Repository: ptr.String("policies"), PolicyName: ptr.String("*")},
+			targets:     []*goapolicy.PolicyRef{public, secret},
+			evaluations: 2,
+			selector:    map[string]interface{}{"repository": "policies", "name": "*"},
+			errtext:     "request is not authorized",
+		},
+		{
+			name:        "bulk unlock of a listed forbidden policy is denied",
+			method:      "BulkUnlock",
+			req:         &goapolicy.BulkUnlockRequest{Policies: []*goapolicy.PolicyRef{secret}},
+			targets:     []*goapolicy.PolicyRef{secret},
+			evaluations: 1,
+			errtext:     "request is not authorized",
+		},
+		{
+			name:        "bulk lock is restricted to the authorized policies",
+			method:      "BulkLock",
+			req:         &goapolicy.BulkLockRequest{Group: ptr.String("xfsc"), Reason: ptr.String("incident")},
+			targets:     []*goapolicy.PolicyRef{public},
+			evaluations: 1,
+			selector:    map[string]interface{}{"group": "xfsc"},
+			forwarded:   &goapolicy.BulkLockRequest{Group: ptr.String("xfsc"), Reason: ptr.String("incident"), Policies: []*goapolicy.PolicyRef{public}},
+		},
+		{
+			name:        "bulk lock without selected policies is authorized once",
+			method:      "BulkLock",
+			req:         &goapolicy.BulkLockRequest{Version: ptr.String("2.0")},
+			evaluations: 1,
+			selector:    map[string]interface{}{"version": "2.0"},
+			forwarded:   &goapolicy.BulkLockRequest{Version: ptr.String("2.0")},
+		},
+		{
+			name:        "error selecting policies is returned by the endpoint",
+			method:      "BulkUnlock",
+			req:         &goapolicy.BulkUnlockRequest{PolicyName: ptr.String("[")},
+			err:         errors.New(errors.BadRequest, "invalid policyName pattern"),
+			evaluations: 1,
+			selector:    map[string]interface{}{"name": "["},
+			forwarded:   &goapolicy.BulkUnlockRequest{PolicyName: ptr.String("[")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			evaluator := &authzfakes.FakeEvaluator{AuthorizeStub: authorize}
+			evaluator.BulkTargetsReturns(test.targets, test.err)
+
+			a, err := authz.New(evaluator, "system/authz/admin/1.0", "", zap.NewNop())
+			require.NoError(t, err)
+
+			var forwarded interface{}
+			endpoint := a.Middleware()(func(ctx context.Context, req interface{}) (interface{}, error) {
+				forwarded = req
+				return nil, nil
+			})
+
+			ctx := context.WithValue(caller.ToContext(context.Background(), alice), goa.MethodKey, test.method)
+			_, err = endpoint(ctx, test.req)
+
+			require.Equal(t, test.evaluations, evaluator.AuthorizeCallCount())
+			for i := 0; i < evaluator.AuthorizeCallCount(); i++ {
+				_, _, _, _, _, input := evaluator.AuthorizeArgsForCall(i)
+				assert.Equal(t, test.method, input["method"])
+				if test.selector != nil {
+					assert.Equal(t, test.selector, input["selector"])
+				} else {
+					assert.NotContains(t, input, "selector")
+				}
+				if i < len(test.targets) {
+					assert.Equal(t, test.targets[i].PolicyName, input["policy"].(map[string]interface{})["name"])
+				} else {
+					assert.NotContains(t, input, "policy")
+				}
+			}
+
+			if test.errtext != "" {
+				assert.Nil(t, forwarded)
+				assert.True(t, errors.Is(errors.Forbidden, err))
+				assert.ErrorContains(t, err, test.errtext)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.forwarded, forwarded)
+		})
+	}
+}
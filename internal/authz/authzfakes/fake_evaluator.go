@@ -0,0 +1,209 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package authzfakes
+
+import (
+	"context"
+	"sync"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/policy"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/authz"
+)
+
+type FakeEvaluator struct {
+	AuthorizeStub        func(context.Context, string, string, string, string, map[string]interface{}) (bool, error)
+	authorizeMutex       sync.RWMutex
+	authorizeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 map[string]interface{}
+	}
+	authorizeReturns struct {
+		result1 bool
+		result2 error
+	}
+	authorizeReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	BulkTargetsStub        func(context.Context, interface{}) ([]*policy.PolicyRef, error)
+	bulkTargetsMutex       sync.RWMutex
+	bulkTargetsArgsForCall []struct {
+		arg1 context.Context
+		arg2 interface{}
+	}
+	bulkTargetsReturns struct {
+		result1 []*policy.PolicyRef
+		result2 error
+	}
+	bulkTargetsReturnsOnCall map[int]struct {
+		result1 []*policy.PolicyRef
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeEvaluator) Authorize(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string, arg6 map[string]interface{}) (bool, error) {
+	fake.authorizeMutex.Lock()
+	ret, specificReturn := fake.authorizeReturnsOnCall[len(fake.authorizeArgsForCall)]
+	fake.authorizeArgsForCall = append(fake.authorizeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 map[string]interface{}
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.AuthorizeStub
+	fakeReturns := fake.authorizeReturns
+	fake.recordInvocation("Authorize", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.authorizeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeEvaluator) AuthorizeCallCount() int {
+	fake.authorizeMutex.RLock()
+	defer fake.authorizeMutex.RUnlock()
+	return len(fake.authorizeArgsForCall)
+}
+
+func (fake *FakeEvaluator) AuthorizeCalls(stub func(context.Context, string, string, string, string, map[string]interface{}) (bool, error)) {
+	fake.authorizeMutex.Lock()
+	defer fake.authorizeMutex.Unlock()
+	fake.AuthorizeStub = stub
+}
+
+func (fake *FakeEvaluator) AuthorizeArgsForCall(i int) (context.Context, string, string, string, string, map[string]interface{}) {
+	fake.authorizeMutex.RLock()
+	defer fake.authorizeMutex.RUnlock()
+	argsForCall := fake.authorizeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *FakeEvaluator) AuthorizeReturns(result1 bool, result2 error) {
+	fake.authorizeMutex.Lock()
+	defer fake.authorizeMutex.Unlock()
+	fake.AuthorizeStub = nil
+	fake.authorizeReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEvaluator) AuthorizeReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.authorizeMutex.Lock()
+	defer fake.authorizeMutex.Unlock()
+	fake.AuthorizeStub = nil
+	if fake.authorizeReturnsOnCall == nil {
+		fake.authorizeReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.authorizeReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEvaluator) BulkTargets(arg1 context.Context, arg2 interface{}) ([]*policy.PolicyRef, error) {
+	fake.bulkTargetsMutex.Lock()
+	ret, specificReturn := fake.bulkTargetsReturnsOnCall[len(fake.bulkTargetsArgsForCall)]
+	fake.bulkTargetsArgsForCall = append(fake.bulkTargetsArgsForCall, struct {
+		arg1 context.Context
+		arg2 interface{}
+	}{arg1, arg2})
+	stub := fake.BulkTargetsStub
+	fakeReturns := fake.bulkTargetsReturns
+	fake.recordInvocation("BulkTargets", []interface{}{arg1, arg2})
+	fake.bulkTargetsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeEvaluator) BulkTargetsCallCount() int {
+	fake.bulkTargetsMutex.RLock()
+	defer fake.bulkTargetsMutex.RUnlock()
+	return len(fake.bulkTargetsArgsForCall)
+}
+
+func (fake *FakeEvaluator) BulkTargetsCalls(stub func(context.Context, interface{}) ([]*policy.PolicyRef, error)) {
+	fake.bulkTargetsMutex.Lock()
+	defer fake.bulkTargetsMutex.Unlock()
+	fake.BulkTargetsStub = stub
+}
+
+func (fake *FakeEvaluator) BulkTargetsArgsForCall(i int) (context.Context, interface{}) {
+	fake.bulkTargetsMutex.RLock()
+	defer fake.bulkTargetsMutex.RUnlock()
+	argsForCall := fake.bulkTargetsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeEvaluator) BulkTargetsReturns(result1 []*policy.PolicyRef, result2 error) {
+	fake.bulkTargetsMutex.Lock()
+	defer fake.bulkTargetsMutex.Unlock()
+	fake.BulkTargetsStub = nil
+	fake.bulkTargetsReturns = struct {
+		result1 []*policy.PolicyRef
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEvaluator) BulkTargetsReturnsOnCall(i int, result1 []*policy.PolicyRef, result2 error) {
+	fake.bulkTargetsMutex.Lock()
+	defer fake.bulkTargetsMutex.Unlock()
+	fake.BulkTargetsStub = nil
+	if fake.bulkTargetsReturnsOnCall == nil {
+		fake.bulkTargetsReturnsOnCall = make(map[int]struct {
+			result1 []*policy.PolicyRef
+			result2 error
+		})
+	}
+	fake.bulkTargetsReturnsOnCall[i] = struct {
+		result1 []*policy.PolicyRef
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEvaluator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.authorizeMutex.RLock()
+	defer fake.authorizeMutex.RUnlock()
+	fake.bulkTargetsMutex.RLock()
+	defer fake.bulkTargetsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeEvaluator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ authz.Evaluator = new(FakeEvaluator)
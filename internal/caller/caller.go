@@ -0,0 +1,88 @@
+package caller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/jwt"
+)
+
+type key string
+
+const callerKey key = "caller"
+
+// Caller of a request to the policy service.
+type Caller struct {
+	// ID is the subject of the bearer token, or its
+	// client ID if the token has no subject.
+	ID string
+	// Claims of the bearer token.
+	Claims map[string]interface{}
+	// HTTPMethod of the request, which is POST for gRPC requests.
+	HTTPMethod string
+}
+
+// Middleware is an HTTP server middleware that adds the caller of
+// the request to the request context.
+//
+// The bearer token is not verified, so the middleware must be wrapped
+// by the authentication middleware.
+func Middleware() func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c := fromToken(r.Header.Get("Authorization"))
+			c.HTTPMethod = r.Method
+
+			h.ServeHTTP(w, r.WithContext(ToContext(r.Context(), c)))
+		})
+	}
+}
+
+func ToContext(ctx context.Context, c *Caller) context.Context {
+	return context.WithValue(ctx, callerKey, c)
+}
+
+func FromContext(ctx context.Context) (*Caller, bool) {
+	c, ok := ctx.Value(callerKey).(*Caller)
+	return c, ok
+}
+
+// ID returns the ID of the caller of the request or
+// an empty string if the caller is not known.
+func ID(ctx context.Context) string {
+	if c, ok := FromContext(ctx); ok {
+		return c.ID
+	}
+	return ""
+}
+
+func fromToken(header string) *Caller {
+	c := &Caller{Claims: map[string]interface{}{}}
+
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return c
+	}
+
+	t, err := jwt.ParseInsecure([]byte(token))
+	if err != nil {
+		return c
+	}
+
+	// claims are converted to JSON types, e.g. times to Unix timestamps
+	if b, err := json.Marshal(t); err == nil {
+		_ = json.Unmarshal(b, &c.Claims)
+	}
+
+	c.ID = t.Subject()
+	if c.ID == "" {
+		c.ID, _ = t.PrivateClaims()["client_id"].(string)
+	}
+	if c.ID == "" {
+		c.ID, _ = t.PrivateClaims()["azp"].(string)
+	}
+
+	return c
+}
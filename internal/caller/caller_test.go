@@ -0,0 +1,108 @@
+package caller_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/caller"
+)
+
+func token(t *testing.T, claims map[string]interface{}) string {
+	tok := jwt.New()
+	for name, value := range claims {
+		require.NoError(t, tok.Set(name, value))
+	}
+
+	signed, err := jwt.Sign(tok, jwt.WithKey(jwa.HS256, []byte("secret")))
+	require.NoError(t, err)
+
+	return "Bearer " + string(signed)
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		caller string
+	}{
+		{
+			name: "no authorization header",
+		},
+		{
+			name:   "invalid token",
+			header: "Bearer invalid",
+		},
+		{
+			name:   "token subject",
+			header: token(t, map[string]interface{}{"sub": "alice", "azp": "policyctl"}),
+			caller: "alice",
+		},
+		{
+			name:   "token client ID",
+			header: token(t, map[string]interface{}{"client_id": "policyctl"}),
+			caller: "policyctl",
+		},
+		{
+			name:   "token authorized party",
+			header: token(t, map[string]interface{}{"azp": "policyctl"}),
+			caller: "policyctl",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/example", nil)
+			if test.header != "" {
+				req.Header.Set("Authorization", test.header)
+			}
+
+			var called bool
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				assert.Equal(t, test.caller, caller.ID(r.Context()))
+
+				c, ok := caller.FromContext(r.Context())
+				require.True(t, ok)
+				assert.Equal(t, "POST", c.HTTPMethod)
+				if test.caller != "" {
+					assert.NotEmpty(t, c.Claims)
+				}
+			})
+
+			caller.Middleware()(next).ServeHTTP(httptest.NewRecorder(), req)
+			assert.True(t, called)
+		})
+	}
+}
+
+func TestMiddleware_Claims(t *testing.T) {
+	req := httptest.NewRequest("DELETE", "/example", nil)
+	req.Header.Set("Authorization", token(t, map[string]interface{}{
+		"sub":   "alice",
+		"exp":   time.Unix(2000000000, 0),
+		"roles": []string{"policy-admin"},
+	}))
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, ok := caller.FromContext(r.Context())
+		require.True(t, ok)
+		assert.Equal(t, &caller.Caller{
+			ID: "alice",
+			Claims: map[string]interface{}{
+				"sub":   "alice",
+				"exp":   float64(2000000000),
+				"roles": []interface{}{"policy-admin"},
+			},
+			HTTPMethod: "DELETE",
+		}, c)
+	})
+
+	caller.Middleware()(next).ServeHTTP(httptest.NewRecorder(), req)
+}
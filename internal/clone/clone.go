@@ -12,7 +12,9 @@ import (
 
 	git "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
 
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
 )
@@ -28,33 +30,74 @@ const (
 )
 
 type Cloner struct {
+	// folder is the folder in which the repository is cloned.
+	// If it's empty, cloneFolder is used.
+	folder string
 }
 
+// Auth contains the credentials for cloning a repository. Only one
+// authentication method is used, in order of precedence: an SSH key,
+// an access token or a username and password.
+type Auth struct {
+	User string
+	Pass string
+
+	// Token is an access token sent with HTTP basic authentication.
+	// The username is User or "oauth2" if User is empty.
+	Token string
+
+	// SSHKey is the path of a PEM encoded private key file and
+	// SSHKeyPassphrase is the passphrase of an encrypted key.
+	SSHKey           string
+	SSHKeyPassphrase string
+	// KnownHosts is the path of a known_hosts file used to verify the
+	// SSH host key. If empty, the SSH_KNOWN_HOSTS environment variable or
+	// the default known_hosts files of the user are used.
+	KnownHosts string
+}
+
+// New creates a cloner which clones repositories in cloneFolder.
+// The folder is removed, so that every clone starts from scratch.
 func New() (*Cloner, error) {
-	c := &Cloner{}
+	c := &Cloner{folder: cloneFolder}
 	if err := c.Cleanup(); err != nil {
 		return nil, err
 	}
 	return c, nil
 }
 
+// NewInFolder creates a cloner which clones repositories in the given
+// folder, so that multiple repositories can be cloned at the same time.
+// A repository cloned in the folder before is kept, so that Pull only
+// fetches the new commits of the repository.
+func NewInFolder(folder string) (*Cloner, error) {
+	return &Cloner{folder: folder}, nil
+}
+
 func (c *Cloner) Cleanup() error {
-	return os.RemoveAll(cloneFolder)
+	return os.RemoveAll(c.dir())
 }
 
 // Clone clones a Policy repository to cloneFolder and returns
 // the repository name
 func (c *Cloner) Clone(ctx context.Context, cloneURL, user, pass, branch string) (string, error) {
+	return c.CloneWithAuth(ctx, cloneURL, branch, &Auth{User: user, Pass: pass})
+}
+
+// CloneWithAuth clones a Policy repository with the given credentials
+// and returns the repository name
+func (c *Cloner) CloneWithAuth(ctx context.Context, cloneURL, branch string, auth *Auth) (string, error) {
 	opts := &git.CloneOptions{
 		URL:   cloneURL,
 		Depth: 1,
 	}
 
-	if user != "" && pass != "" {
-		opts.Auth = &http.BasicAuth{
-			Username: user,
-			Password: pass,
+	if auth != nil {
+		method, err := auth.method()
+		if err != nil {
+			return "", err
 		}
+		opts.Auth = method
 	}
 
 	if branch != "" {
@@ -62,31 +105,136 @@ func (c *Cloner) Clone(ctx context.Context, cloneURL, user, pass, branch string)
 		opts.SingleBranch = true
 	}
 
-	_, err := git.PlainCloneContext(ctx, cloneFolder, false, opts)
+	_, err := git.PlainCloneContext(ctx, c.dir(), false, opts)
 
-	return getRepoName(cloneURL), err
+	return RepoName(cloneURL), err
+}
+
+// method returns the transport authentication method for the credentials.
+func (a *Auth) method() (transport.AuthMethod, error) {
+	switch {
+	case a.SSHKey != "":
+		user := a.User
+		if user == "" {
+			user = ssh.DefaultUsername
+		}
+
+		keys, err := ssh.NewPublicKeysFromFile(user, a.SSHKey, a.SSHKeyPassphrase)
+		if err != nil {
+			return nil, fmt.Errorf("error loading ssh key: %v", err)
+		}
+
+		if a.KnownHosts != "" {
+			keys.HostKeyCallback, err = ssh.NewKnownHostsCallback(a.KnownHosts)
+			if err != nil {
+				return nil, fmt.Errorf("error loading known hosts: %v", err)
+			}
+		}
+
+		return keys, nil
+	case a.Token != "":
+		user := a.User
+		if user == "" {
+			user = "oauth2"
+		}
+
+		return &http.BasicAuth{Username: user, Password: a.Token}, nil
+	case a.User != "" && a.Pass != "":
+		return &http.BasicAuth{Username: a.User, Password: a.Pass}, nil
+	}
+
+	return nil, nil
+}
+
+// Commit returns the checked out commit of the cloned repository.
+func (c *Cloner) Commit() (*storage.Commit, error) {
+	repo, err := git.PlainOpen(c.dir())
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	var branch string
+	if head.Name().IsBranch() {
+		branch = head.Name().Short()
+	}
+
+	return &storage.Commit{
+		SHA:    commit.Hash.String(),
+		Time:   commit.Committer.When,
+		Author: fmt.Sprintf("%s <%s>", commit.Author.Name, commit.Author.Email),
+		Branch: branch,
+	}, nil
 }
 
 // IterateRepo iterates over the repoFolder and returns a map
 // of Policy structs
 func (c *Cloner) IterateRepo(repoFolder, repository string) (map[string]*storage.Policy, error) {
 	if repoFolder == "" {
-		repoFolder = cloneFolder
+		repoFolder = c.dir()
 	} else {
-		repoFolder = filepath.Join(cloneFolder, repoFolder)
+		repoFolder = filepath.Join(c.dir(), repoFolder)
 	}
 
+	return LoadPolicies(repoFolder, repository)
+}
+
+// LoadPolicies walks the given directory and returns a map of Policy
+// structs for all policies found in the directory tree. The directory
+// layout must be the same as in a policy repository, i.e. policies
+// are expected on paths like {group}/{name}/{version}/policy.rego
+// or in folders with a policy.yaml manifest, which describes the files
+// of the policy. Files and folders matching the patterns of .policyignore
+// files are skipped.
+func LoadPolicies(dir, repository string) (map[string]*storage.Policy, error) {
 	policies := make(map[string]*storage.Policy)
-	err := filepath.WalkDir(repoFolder, func(p string, d os.DirEntry, err error) error {
+	ignore := newIgnorer(dir)
+	err := filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-		if !d.IsDir() && d.Name() == policyFilename {
-			policy, err := createPolicy(p, repository)
-			if err != nil {
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		ignored, err := ignore.ignored(filepath.ToSlash(rel), d.IsDir())
+		if err != nil {
+			return err
+		}
+		if ignored {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		var policy *storage.Policy
+		switch {
+		case d.IsDir():
+			if existingFile(p, manifestFilename) == "" {
+				return nil
+			}
+			// all files in the folder of a manifest belong to the policy
+			if policy, err = loadManifestPolicy(dir, p, repository); err != nil {
+				return err
+			}
+			policies[constructKey(policy.Repository, policy.Group, policy.Name, policy.Version)] = policy
+			return filepath.SkipDir
+		case d.Name() == policyFilename:
+			if policy, err = createPolicy(p, repository); err != nil {
 				return err
 			}
-			policies[c.ConstructKey(policy.Repository, policy.Group, policy.Name, policy.Version)] = policy
+			policies[constructKey(policy.Repository, policy.Group, policy.Name, policy.Version)] = policy
 		}
 		return nil
 	})
@@ -122,7 +270,11 @@ func createPolicy(p, repository string) (*storage.Policy, error) {
 	dbFilename := group + "/" + name + "/" + version + "/" + policyFilename
 
 	// check if there is a data.json file in the same folder as the policy
-	dataBytes, err := os.ReadFile(filepath.Join(exPath, strings.TrimSuffix(p, policyFilename)+dataFilename))
+	dataPath := strings.TrimSuffix(p, policyFilename) + dataFilename
+	if !filepath.IsAbs(dataPath) {
+		dataPath = filepath.Join(exPath, dataPath)
+	}
+	dataBytes, err := os.ReadFile(dataPath)
 	if err != nil && !os.IsNotExist(err) {
 		return nil, err
 	}
@@ -165,15 +317,32 @@ func createPolicy(p, repository string) (*storage.Policy, error) {
 	}, nil
 }
 
+func (c *Cloner) dir() string {
+	if c.folder == "" {
+		return cloneFolder
+	}
+	return c.folder
+}
+
 func (c *Cloner) ConstructKey(repo, group, name, version string) string {
+	return constructKey(repo, group, name, version)
+}
+
+func constructKey(repo, group, name, version string) string {
 	return fmt.Sprintf("%s.%s.%s.%s", repo, group, name, version)
 }
 
-// getRepoName returns the repository name out of a clone url
+// RepoName returns the repository name out of a clone url
 //
 // Example: clone url - `https://gitlab.example.com/policy.git`; repository name - `policy`
-func getRepoName(url string) string {
-	ss := strings.Split(strings.TrimSuffix(url, ".git"), "/")
+// Example: clone url - `git@gitlab.example.com:policy.git`; repository name - `policy`
+func RepoName(url string) string {
+	ss := strings.FieldsFunc(strings.TrimSuffix(url, ".git"), func(r rune) bool {
+		return r == '/' || r == ':'
+	})
+	if len(ss) == 0 {
+		return ""
+	}
 
 	return ss[len(ss)-1]
 }
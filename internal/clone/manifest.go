@@ -0,0 +1,436 @@
+package clone
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/format"
+	"gopkg.in/yaml.v3"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+const (
+	manifestFilename = "policy.yaml"
+	ignoreFilename   = ".policyignore"
+)
+
+// manifest describes the files of a policy in a policy.yaml file. All file
+// paths are relative to the folder of the manifest and must be inside it.
+// Fields which are not set have the same defaults as policies without
+// a manifest, e.g. the policy.rego module and the data.json file.
+type manifest struct {
+	// Group, Name and Version of the policy. If they are not set,
+	// the version and name are the last two folders of the manifest path
+	// and the group are the folders before them joined with dots,
+	// e.g. {group}/{subgroup}/{name}/{version}/policy.yaml
+	Group   string `yaml:"group"`
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+
+	// Modules are the Rego files of the policy. All modules must declare
+	// the policy package and are combined in the policy source code.
+	Modules []string `yaml:"modules"`
+
+	// Data are JSON or YAML files with the static data of the policy.
+	// The top-level keys of multiple files are merged and must be unique.
+	Data []string `yaml:"data"`
+
+	// DataConfig, OutputSchema and ExportConfig are JSON or YAML files.
+	DataConfig   string `yaml:"dataConfig"`
+	OutputSchema string `yaml:"outputSchema"`
+	ExportConfig string `yaml:"exportConfig"`
+}
+
+// loadManifestPolicy creates a policy out of the policy.yaml manifest
+// in the given directory. root is the root directory of the policies.
+func loadManifestPolicy(root, dir, repository string) (*storage.Policy, error) {
+	content, err := os.ReadFile(filepath.Join(dir, manifestFilename))
+	if err != nil {
+		return nil, err
+	}
+
+	var m manifest
+	if err := yaml.Unmarshal(content, &m); err != nil {
+		return nil, fmt.Errorf("error parsing policy manifest in %s: %v", dir, err)
+	}
+
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return nil, err
+	}
+	rel = filepath.ToSlash(rel)
+
+	if m.Group == "" || m.Name == "" || m.Version == "" {
+		ss := strings.Split(rel, "/")
+		if len(ss) < 3 {
+			return nil, fmt.Errorf("failed to get policy name, version and group out of policy path: %s", rel)
+		}
+		setDefault(&m.Version, ss[len(ss)-1])
+		setDefault(&m.Name, ss[len(ss)-2])
+		setDefault(&m.Group, strings.Join(ss[:len(ss)-2], "."))
+	}
+
+	if len(m.Modules) == 0 {
+		m.Modules = []string{policyFilename}
+	}
+	if m.Data == nil {
+		m.Data = existingFiles(dir, dataFilename, "data.yaml", "data.yml")
+	}
+	setDefault(&m.DataConfig, existingFile(dir, dataConfigFilename))
+	setDefault(&m.OutputSchema, existingFile(dir, jsonSchemaFilename))
+	setDefault(&m.ExportConfig, existingFile(dir, exportConfigFilename))
+
+	regoSrc, err := readModules(dir, m.Modules)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := readData(dir, m.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	dataConfig, err := readJSONFile(dir, m.DataConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	outputSchema, err := readJSONFile(dir, m.OutputSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	exportConfig, err := readJSONFile(dir, m.ExportConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storage.Policy{
+		Repository:   repository,
+		Filename:     path.Join(rel, manifestFilename),
+		Name:         m.Name,
+		Group:        m.Group,
+		Version:      m.Version,
+		Rego:         regoSrc,
+		Data:         data,
+		DataConfig:   dataConfig,
+		OutputSchema: outputSchema,
+		ExportConfig: exportConfig,
+	}, nil
+}
+
+func setDefault(field *string, value string) {
+	if *field == "" {
+		*field = value
+	}
+}
+
+// existingFile returns the filename if the file exists in the directory.
+func existingFile(dir, filename string) string {
+	if _, err := os.Stat(filepath.Join(dir, filename)); err != nil {
+		return ""
+	}
+	return filename
+}
+
+func existingFiles(dir string, filenames ...string) []string {
+	var res []string
+	for _, filename := range filenames {
+		if existingFile(dir, filename) != "" {
+			res = append(res, filename)
+		}
+	}
+	return res
+}
+
+// readFile reads a file referenced by a manifest. The file must be inside
+// the directory of the manifest.
+func readFile(dir, filename string) ([]byte, error) {
+	if !filepath.IsLocal(filename) {
+		return nil, fmt.Errorf("policy file %q must be inside the policy folder %s", filename, dir)
+	}
+	return os.ReadFile(filepath.Join(dir, filename))
+}
+
+// readJSONFile reads a JSON or YAML file and returns its content as JSON.
+// The content of JSON files is returned without changes.
+func readJSONFile(dir, filename string) (string, error) {
+	if filename == "" {
+		return "", nil
+	}
+
+	content, err := readFile(dir, filename)
+	if err != nil {
+		return "", err
+	}
+
+	if !isYAML(filename) {
+		return string(content), nil
+	}
+
+	var value interface{}
+	if err := yaml.Unmarshal(content, &value); err != nil {
+		return "", fmt.Errorf("error parsing %s: %v", filename, err)
+	}
+
+	res, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("error converting %s to JSON: %v", filename, err)
+	}
+
+	return string(res), nil
+}
+
+func isYAML(filename string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// readData returns the policy data of the given JSON or YAML files as
+// a JSON object. The content of a single JSON file is returned without
+// changes, while the top-level keys of multiple files are merged.
+func readData(dir string, filenames []string) (string, error) {
+	if len(filenames) == 0 {
+		return "", nil
+	}
+
+	if len(filenames) == 1 {
+		return readJSONFile(dir, filenames[0])
+	}
+
+	data := make(map[string]interface{})
+	for _, filename := range filenames {
+		content, err := readJSONFile(dir, filename)
+		if err != nil {
+			return "", err
+		}
+
+		var fileData map[string]interface{}
+		if err := json.Unmarshal([]byte(content), &fileData); err != nil {
+			return "", fmt.Errorf("policy data in %s must be an object: %v", filename, err)
+		}
+
+		for key, value := range fileData {
+			if _, ok := data[key]; ok {
+				return "", fmt.Errorf("policy data key %q in %s is already defined", key, filename)
+			}
+			data[key] = value
+		}
+	}
+
+	res, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	return string(res), nil
+}
+
+// readModules returns the policy source code of the given Rego modules.
+// The source code of a single module is returned without changes.
+// Multiple modules must declare the same package. They are parsed and
+// merged in a single module with the package and the imports of all
+// modules, followed by the rules and comments of every module in order.
+// Syntax errors are reported with the positions in the module files.
+func readModules(dir string, filenames []string) (string, error) {
+	if len(filenames) == 1 {
+		content, err := readFile(dir, filenames[0])
+		if err != nil {
+			return "", err
+		}
+		return string(content), nil
+	}
+
+	var (
+		merged *ast.Module
+		rows   int
+	)
+	for _, filename := range filenames {
+		content, err := readFile(dir, filename)
+		if err != nil {
+			return "", err
+		}
+
+		module, err := ast.ParseModule(filename, string(content))
+		if err != nil {
+			return "", fmt.Errorf("error parsing policy module %s: %v", filename, err)
+		}
+		if module == nil {
+			return "", fmt.Errorf("policy module %s is empty", filename)
+		}
+
+		if merged == nil {
+			merged = &ast.Module{Package: module.Package}
+		} else if !module.Package.Path.Equal(merged.Package.Path) {
+			return "", fmt.Errorf("policy module %s must declare package %s", filename, strings.TrimPrefix(merged.Package.Path.String(), "data."))
+		}
+
+		// the formatter orders the statements and comments by their
+		// locations, so the modules are placed one after another
+		moveRows(module, rows)
+		rows += strings.Count(string(content), "\n") + 1
+
+		if err := mergeImports(merged, module.Imports); err != nil {
+			return "", fmt.Errorf("error merging policy module %s: %v", filename, err)
+		}
+		merged.Rules = append(merged.Rules, module.Rules...)
+		merged.Comments = append(merged.Comments, module.Comments...)
+	}
+
+	src, err := format.Ast(merged)
+	if err != nil {
+		return "", fmt.Errorf("error merging policy modules: %v", err)
+	}
+
+	return string(src), nil
+}
+
+// mergeImports adds the imports, which are not imported yet, to the
+// module. The imports are placed after the imports of the module.
+func mergeImports(module *ast.Module, imports []*ast.Import) error {
+	row := module.Package.Location.Row
+	for _, imp := range module.Imports {
+		if imp.Location.Row > row {
+			row = imp.Location.Row
+		}
+	}
+
+	for _, imp := range imports {
+		var found bool
+		for _, existing := range module.Imports {
+			if existing.Equal(imp) {
+				found = true
+				break
+			}
+			if existing.Name().Equal(imp.Name()) {
+				return fmt.Errorf("import %s conflicts with import %s", imp, existing)
+			}
+		}
+		if found {
+			continue
+		}
+
+		setRow(imp, row)
+		module.Imports = append(module.Imports, imp)
+	}
+
+	return nil
+}
+
+// moveRows moves the locations of the statements and comments
+// of a module by the given number of rows.
+func moveRows(module *ast.Module, rows int) {
+	moved := make(map[*ast.Location]bool)
+	move := func(loc *ast.Location) {
+		if loc != nil && !moved[loc] {
+			moved[loc] = true
+			loc.Row += rows
+		}
+	}
+
+	ast.NewGenericVisitor(func(x interface{}) bool {
+		if node, ok := x.(ast.Node); ok {
+			move(node.Loc())
+		}
+		return false
+	}).Walk(module)
+
+	for _, comment := range module.Comments {
+		move(comment.Location)
+	}
+}
+
+// setRow sets the row of the locations of a statement.
+func setRow(node ast.Node, row int) {
+	ast.NewGenericVisitor(func(x interface{}) bool {
+		if n, ok := x.(ast.Node); ok && n.Loc() != nil {
+			n.Loc().Row = row
+		}
+		return false
+	}).Walk(node)
+}
+
+// ignorer matches paths against the patterns of the .policyignore files,
+// which have the format of .gitignore files. Patterns of a .policyignore
+// file apply to the folder of the file and its subfolders.
+type ignorer struct {
+	root     string
+	patterns []gitignore.Pattern
+	// loaded contains the folders whose .policyignore files are loaded
+	loaded map[string]bool
+}
+
+func newIgnorer(root string) *ignorer {
+	return &ignorer{root: root, loaded: make(map[string]bool)}
+}
+
+// load reads the patterns of the .policyignore file in the
+// folder with the given path relative to the root folder.
+func (ig *ignorer) load(rel string) error {
+	if ig.loaded[rel] {
+		return nil
+	}
+	ig.loaded[rel] = true
+
+	content, err := os.ReadFile(filepath.Join(ig.root, filepath.FromSlash(rel), ignoreFilename))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	domain := segments(rel)
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		ig.patterns = append(ig.patterns, gitignore.ParsePattern(line, domain))
+	}
+
+	return nil
+}
+
+// ignored reports whether the path relative to the root folder is ignored.
+// The .policyignore files of the parent folders are loaded if needed.
+func (ig *ignorer) ignored(rel string, isDir bool) (bool, error) {
+	ss := segments(rel)
+	for i := 0; i < len(ss); i++ {
+		if err := ig.load(strings.Join(ss[:i], "/")); err != nil {
+			return false, err
+		}
+		// files in ignored folders are ignored as well
+		if i > 0 && gitignore.NewMatcher(ig.patterns).Match(ss[:i], true) {
+			return true, nil
+		}
+	}
+
+	return gitignore.NewMatcher(ig.patterns).Match(ss, isDir), nil
+}
+
+func segments(rel string) []string {
+	if rel == "" || rel == "." {
+		return []string{}
+	}
+	return strings.Split(rel, "/")
+}
+
+// sortedKeys returns the keys of a set in a deterministic order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
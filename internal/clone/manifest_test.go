@@ -0,0 +1,135 @@
+package clone_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/clone"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+func TestLoadPolicies(t *testing.T) {
+	root, err := filepath.Abs("testdata/policies")
+	require.NoError(t, err)
+
+	policies, err := clone.LoadPolicies(root, "policies")
+	require.NoError(t, err)
+
+	// policies in drafts/ and xfsc/plain/0.1 are ignored
+	assert.Equal(t, map[string]*storage.Policy{
+		"policies.xfsc.plain.1.0": {
+			Repository: "policies",
+			Filename:   "xfsc/plain/1.0/policy.rego",
+			Group:      "xfsc",
+			Name:       "plain",
+			Version:    "1.0",
+			Rego:       "package xfsc.plain\n\nallow := true\n",
+			Data:       `{"hello":"world"}`,
+		},
+		"policies.xfsc.modules.1.0": {
+			Repository: "policies",
+			Filename:   "xfsc/modules/1.0/policy.yaml",
+			Group:      "xfsc",
+			Name:       "modules",
+			Version:    "1.0",
+			Rego: `# METADATA
+# title: Modules
+package xfsc.modules
+
+import data.lib.users as users
+import future.keywords.contains
+import future.keywords.if
+import future.keywords.in
+
+# allow is true for admins
+allow if users.admin # admins only
+
+# the same import is declared once
+
+# roles of the caller
+roles contains role if {
+	some role in input.roles
+}
+`,
+			Data:         `{"hello":"world","roles":["admin","user"]}`,
+			DataConfig:   `{"period":"1h","url":"https://example.com/data"}`,
+			OutputSchema: `{"type":"object"}`,
+		},
+		"policies.example.custom.2.0": {
+			Repository: "policies",
+			Filename:   "custom/folder/policy.yaml",
+			Group:      "example",
+			Name:       "custom",
+			Version:    "2.0",
+			Rego:       "package example.custom\n",
+		},
+		"policies.nested.group.name.1.0": {
+			Repository: "policies",
+			Filename:   "nested/group/name/1.0/policy.yaml",
+			Group:      "nested.group",
+			Name:       "name",
+			Version:    "1.0",
+			Rego:       "package nested.group.name\n",
+		},
+	}, policies)
+
+	// the merged modules are a valid module with the policy metadata
+	module, err := ast.ParseModuleWithOpts("policy.rego", policies["policies.xfsc.modules.1.0"].Rego, ast.ParserOptions{ProcessAnnotation: true})
+	require.NoError(t, err)
+	assert.Equal(t, "data.xfsc.modules", module.Package.Path.String())
+	assert.Len(t, module.Rules, 2)
+	require.Len(t, module.Annotations, 1)
+	assert.Equal(t, "Modules", module.Annotations[0].Title)
+}
+
+func TestLoadPolicies_InvalidManifest(t *testing.T) {
+	tests := []struct {
+		name    string
+		errtext string
+	}{
+		{
+			name:    "manifest",
+			errtext: "error parsing policy manifest",
+		},
+		{
+			name:    "package",
+			errtext: "policy module b.rego must declare package xfsc.test",
+		},
+		{
+			name:    "syntax",
+			errtext: "error parsing policy module b.rego: 1 error occurred: b.rego:4: rego_parse_error: unexpected eof token",
+		},
+		{
+			name:    "import",
+			errtext: "error merging policy module b.rego: import import data.lib.roles as u conflicts with import import data.lib.users as u",
+		},
+		{
+			name:    "outside",
+			errtext: `policy file "../policy.rego" must be inside the policy folder`,
+		},
+		{
+			name:    "duplicate",
+			errtext: `policy data key "key" in b.yaml is already defined`,
+		},
+		{
+			name:    "array",
+			errtext: "policy data in b.json must be an object",
+		},
+		{
+			name:    "path",
+			errtext: "failed to get policy name, version and group out of policy path: test",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policies, err := clone.LoadPolicies(filepath.Join("testdata", "invalid", test.name), "policies")
+			assert.ErrorContains(t, err, test.errtext)
+			assert.Empty(t, policies)
+		})
+	}
+}
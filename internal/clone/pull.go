@@ -0,0 +1,226 @@
+package clone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+const remoteName = "origin"
+
+// Pull updates the repository which is already cloned in the cloner folder
+// to the latest commit of the branch, so that only new objects are fetched.
+// The repository is cloned if it's not cloned yet or if it's cloned from
+// another URL. Pull returns the repository name.
+func (c *Cloner) Pull(ctx context.Context, cloneURL, branch string, auth *Auth) (string, error) {
+	repo, err := git.PlainOpen(c.dir())
+	if errors.Is(err, git.ErrRepositoryNotExists) || (err == nil && !hasRemoteURL(repo, cloneURL)) {
+		if err := c.Cleanup(); err != nil {
+			return "", err
+		}
+		return c.CloneWithAuth(ctx, cloneURL, branch, auth)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if branch == "" {
+		head, err := repo.Head()
+		if err != nil {
+			return "", err
+		}
+		branch = head.Name().Short()
+	}
+
+	opts := &git.FetchOptions{
+		RemoteName: remoteName,
+		RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf("+refs/heads/%s:refs/remotes/%s/%s", branch, remoteName, branch))},
+		Depth:      1,
+		Force:      true,
+	}
+	if auth != nil {
+		if opts.Auth, err = auth.method(); err != nil {
+			return "", err
+		}
+	}
+
+	if err := repo.FetchContext(ctx, opts); err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return "", err
+	}
+
+	ref, err := repo.Reference(plumbing.NewRemoteReferenceName(remoteName, branch), true)
+	if err != nil {
+		return "", err
+	}
+
+	// check out the fetched commit on the branch
+	if err := repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName(branch))); err != nil {
+		return "", err
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", err
+	}
+
+	if err := worktree.Reset(&git.ResetOptions{Commit: ref.Hash(), Mode: git.HardReset}); err != nil {
+		return "", err
+	}
+
+	return RepoName(cloneURL), nil
+}
+
+func hasRemoteURL(repo *git.Repository, url string) bool {
+	remote, err := repo.Remote(remoteName)
+	if err != nil {
+		return false
+	}
+
+	for _, u := range remote.Config().URLs {
+		if u == url {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IterateChanges returns the policies in the repoFolder, which are changed
+// between the given commit and the checked out commit, and the filenames
+// of all policies changed or removed since the commit. The filenames have
+// the same format as the Filename field of policies.
+//
+// An error is returned if the given commit is not available in the
+// cloned repository, e.g. because the repository is cloned again
+// with a shallow history, or if a .policyignore file is changed.
+// Then all policies must be iterated instead.
+func (c *Cloner) IterateChanges(repoFolder, repository, since string) (map[string]*storage.Policy, []string, error) {
+	repo, err := git.PlainOpen(c.dir())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	from, err := repo.CommitObject(plumbing.NewHash(since))
+	if err != nil {
+		return nil, nil, fmt.Errorf("commit %s is not available: %v", since, err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	to, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files, err := changedFiles(from, to, repoFolder)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := filepath.Join(c.dir(), filepath.FromSlash(repoFolder))
+	dirs := make(map[string]bool)
+	for _, file := range files {
+		if path.Base(file) == ignoreFilename {
+			return nil, nil, fmt.Errorf("%s is changed", file)
+		}
+		dirs[policyDir(root, file)] = true
+	}
+
+	ignore := newIgnorer(root)
+	policies := make(map[string]*storage.Policy)
+	var filenames []string
+	for _, dir := range sortedKeys(dirs) {
+		filenames = append(filenames, path.Join(dir, manifestFilename))
+		// path to Rego policy must be {group}/{name}/{version}/policy.rego
+		if ss := strings.Split(dir, "/"); len(ss) >= 3 {
+			filenames = append(filenames, strings.Join(append(ss[len(ss)-3:], policyFilename), "/"))
+		}
+
+		if ignored, err := ignore.ignored(dir, true); err != nil {
+			return nil, nil, err
+		} else if ignored {
+			continue // policy is removed
+		}
+
+		var policy *storage.Policy
+		dirPath := filepath.Join(root, filepath.FromSlash(dir))
+		switch {
+		case existingFile(dirPath, manifestFilename) != "":
+			policy, err = loadManifestPolicy(root, dirPath, repository)
+		case existingFile(dirPath, policyFilename) != "":
+			policy, err = createPolicy(filepath.Join(dirPath, policyFilename), repository)
+		default:
+			continue // policy is removed
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		policies[constructKey(policy.Repository, policy.Group, policy.Name, policy.Version)] = policy
+	}
+
+	return policies, filenames, nil
+}
+
+// policyDir returns the folder of the policy, which a changed file belongs
+// to. It's the topmost folder with a policy manifest above the file or the
+// folder of the file otherwise. Paths are relative to the root folder.
+func policyDir(root, file string) string {
+	dir := path.Dir(file)
+	ss := segments(dir)
+	for i := 1; i <= len(ss); i++ {
+		candidate := strings.Join(ss[:i], "/")
+		if existingFile(filepath.Join(root, filepath.FromSlash(candidate)), manifestFilename) != "" {
+			return candidate
+		}
+	}
+	return dir
+}
+
+// changedFiles returns the paths relative to repoFolder
+// of the files, which are changed between two commits.
+func changedFiles(from, to *object.Commit, repoFolder string) ([]string, error) {
+	fromTree, err := from.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	toTree, err := to.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := object.DiffTree(fromTree, toTree)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := strings.Trim(filepath.ToSlash(repoFolder), "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	unique := make(map[string]bool)
+	for _, change := range changes {
+		for _, name := range []string{change.From.Name, change.To.Name} {
+			if name == "" || !strings.HasPrefix(name, prefix) {
+				continue
+			}
+			unique[strings.TrimPrefix(name, prefix)] = true
+		}
+	}
+
+	return sortedKeys(unique), nil
+}
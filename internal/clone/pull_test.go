@@ -0,0 +1,116 @@
+package clone_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/clone"
+)
+
+// commitFiles writes the files to the repository and commits them.
+func commitFiles(t *testing.T, repo *git.Repository, files map[string]string) string {
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	for name, content := range files {
+		filename := filepath.Join(worktree.Filesystem.Root(), filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(filename), 0755))
+		require.NoError(t, os.WriteFile(filename, []byte(content), 0600))
+		_, err = worktree.Add(name)
+		require.NoError(t, err)
+	}
+
+	hash, err := worktree.Commit("update policies", &git.CommitOptions{
+		Author: &object.Signature{Name: "alice", Email: "alice@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	return hash.String()
+}
+
+func TestCloner_PullIncremental(t *testing.T) {
+	remote := t.TempDir()
+	upstream, err := git.PlainInit(remote, false)
+	require.NoError(t, err)
+
+	first := commitFiles(t, upstream, map[string]string{
+		"example/one/1.0/policy.rego": "package example.one",
+		"example/two/1.0/policy.rego": "package example.two",
+	})
+
+	// every sync creates a new cloner in the same folder,
+	// like a sync which is executed again
+	folder := filepath.Join(t.TempDir(), "policies")
+	cloner, err := clone.NewInFolder(folder)
+	require.NoError(t, err)
+
+	_, err = cloner.Pull(context.Background(), remote, "", nil)
+	require.NoError(t, err)
+
+	commit, err := cloner.Commit()
+	require.NoError(t, err)
+	assert.Equal(t, first, commit.SHA)
+
+	second := commitFiles(t, upstream, map[string]string{
+		"example/two/1.0/policy.rego": "package example.two\n\nallow := true",
+	})
+
+	cloner, err = clone.NewInFolder(folder)
+	require.NoError(t, err)
+
+	_, err = cloner.Pull(context.Background(), remote, "", nil)
+	require.NoError(t, err)
+
+	commit, err = cloner.Commit()
+	require.NoError(t, err)
+	assert.Equal(t, second, commit.SHA)
+
+	// the commit of the first sync is kept in the clone,
+	// so only the changed policy is synced
+	policies, filenames, err := cloner.IterateChanges("", "policies", first)
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	for _, p := range policies {
+		assert.Equal(t, "two", p.Name)
+		assert.Equal(t, "package example.two\n\nallow := true", p.Rego)
+	}
+	assert.Contains(t, filenames, "example/two/1.0/policy.rego")
+	assert.NotContains(t, filenames, "example/one/1.0/policy.rego")
+}
+
+func TestCloner_PullFromAnotherURL(t *testing.T) {
+	folder := filepath.Join(t.TempDir(), "policies")
+
+	var commits []string
+	for _, rego := range []string{"package example.one", "package example.two"} {
+		remote := t.TempDir()
+		upstream, err := git.PlainInit(remote, false)
+		require.NoError(t, err)
+		commits = append(commits, commitFiles(t, upstream, map[string]string{"example/test/1.0/policy.rego": rego}))
+
+		cloner, err := clone.NewInFolder(folder)
+		require.NoError(t, err)
+
+		_, err = cloner.Pull(context.Background(), remote, "", nil)
+		require.NoError(t, err)
+	}
+
+	// the repository of the first URL is replaced
+	cloner, err := clone.NewInFolder(folder)
+	require.NoError(t, err)
+
+	commit, err := cloner.Commit()
+	require.NoError(t, err)
+	assert.Equal(t, commits[1], commit.SHA)
+
+	_, _, err = cloner.IterateChanges("", "policies", commits[0])
+	assert.ErrorContains(t, err, "is not available")
+}
@@ -0,0 +1,3 @@
+package xfsc.test
+
+import data.lib.users as u
@@ -0,0 +1,3 @@
+package xfsc.test
+
+import data.lib.roles as u
@@ -0,0 +1 @@
+package xfsc.test
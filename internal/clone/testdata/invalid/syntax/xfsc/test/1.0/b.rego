@@ -0,0 +1,3 @@
+package xfsc.test
+
+allow {
@@ -0,0 +1 @@
+package example.custom
@@ -0,0 +1 @@
+package nested.group.name
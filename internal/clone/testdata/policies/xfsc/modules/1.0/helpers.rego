@@ -0,0 +1,11 @@
+package xfsc.modules
+
+# the same import is declared once
+import future.keywords.if
+import future.keywords.in
+import future.keywords.contains
+
+# roles of the caller
+roles contains role if {
+	some role in input.roles
+}
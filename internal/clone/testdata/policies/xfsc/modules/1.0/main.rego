@@ -0,0 +1,10 @@
+# METADATA
+# title: Modules
+package xfsc.modules
+
+import future.keywords.if
+import data.lib.users as
+	users
+
+# allow is true for admins
+allow if users.admin # admins only
@@ -0,0 +1,415 @@
+package clone
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/pem"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	pgpSignaturePrefix = "-----BEGIN PGP SIGNATURE-----"
+	sshSignaturePrefix = "-----BEGIN SSH SIGNATURE-----"
+
+	sshSigMagic     = "SSHSIG"
+	sshSigNamespace = "git"
+)
+
+// Keyring contains the public keys of the maintainers, who are
+// trusted to sign the commits of a policy repository.
+type Keyring struct {
+	// gpg is an ASCII armored OpenPGP keyring
+	gpg string
+	// ssh contains the trusted SSH signing keys
+	ssh []*sshSigner
+}
+
+// sshSigner is a trusted SSH signing key of an allowed_signers file.
+type sshSigner struct {
+	key ssh.PublicKey
+	// principal identifies the signer. It's empty for keys
+	// in the authorized_keys format.
+	principal string
+	// namespaces are the signature namespaces for which the key is
+	// trusted. The key is trusted for all namespaces if it's empty.
+	namespaces []string
+	// validAfter and validBefore limit the commit times
+	// for which the key is trusted, if they are not zero.
+	validAfter  time.Time
+	validBefore time.Time
+}
+
+// LoadKeyring loads the trusted keys from an ASCII armored OpenPGP keyring
+// file and from a file with SSH public keys in the allowed_signers format.
+// Any of the files can be empty. LoadKeyring returns nil if both files
+// are empty, i.e. commits are not verified.
+func LoadKeyring(gpgKeyringFile, sshAllowedSignersFile string) (*Keyring, error) {
+	if gpgKeyringFile == "" && sshAllowedSignersFile == "" {
+		return nil, nil
+	}
+
+	keyring := &Keyring{}
+
+	if gpgKeyringFile != "" {
+		armored, err := os.ReadFile(gpgKeyringFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading gpg keyring: %v", err)
+		}
+		keyring.gpg = string(armored)
+	}
+
+	if sshAllowedSignersFile != "" {
+		keys, err := loadSSHKeys(sshAllowedSignersFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading ssh allowed signers: %v", err)
+		}
+		keyring.ssh = keys
+	}
+
+	return keyring, nil
+}
+
+// loadSSHKeys reads the SSH signing keys from a file in the allowed_signers
+// format of ssh-keygen, in which every line has the principals of the signer,
+// optional options and the public key. The namespaces, valid-after and
+// valid-before options are supported, while certificate authorities are not.
+// Lines with a public key only are trusted for every signer and namespace.
+func loadSSHKeys(filename string) ([]*sshSigner, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var signers []*sshSigner
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		signer, err := parseAllowedSigner(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", n, err)
+		}
+		signers = append(signers, signer)
+	}
+
+	return signers, scanner.Err()
+}
+
+// parseAllowedSigner parses a line of an allowed_signers file.
+func parseAllowedSigner(line string) (*sshSigner, error) {
+	fields := splitFields(line)
+
+	// the key is the first field which is followed by a public key
+	keyIndex := -1
+	var key ssh.PublicKey
+	for i := 0; i < len(fields)-1 && i <= 2; i++ {
+		k, _, _, _, err := ssh.ParseAuthorizedKey([]byte(fields[i] + " " + fields[i+1]))
+		if err == nil && k.Type() == fields[i] {
+			keyIndex, key = i, k
+			break
+		}
+	}
+	if keyIndex < 0 {
+		return nil, fmt.Errorf("invalid allowed signer: public key not found")
+	}
+
+	signer := &sshSigner{key: key}
+	if keyIndex == 0 {
+		return signer, nil
+	}
+
+	signer.principal = fields[0]
+	if keyIndex == 2 {
+		if err := signer.parseOptions(fields[1]); err != nil {
+			return nil, err
+		}
+	}
+
+	return signer, nil
+}
+
+// parseOptions parses the comma separated options of an allowed signer.
+func (s *sshSigner) parseOptions(options string) error {
+	for _, option := range splitOptions(options) {
+		name, value, _ := strings.Cut(option, "=")
+		value = strings.Trim(value, `"`)
+
+		var err error
+		switch strings.ToLower(name) {
+		case "namespaces":
+			s.namespaces = strings.Split(value, ",")
+		case "valid-after":
+			s.validAfter, err = parseSignerTime(value)
+		case "valid-before":
+			s.validBefore, err = parseSignerTime(value)
+		case "cert-authority":
+			return fmt.Errorf("certificate authorities are not supported")
+		default:
+			return fmt.Errorf("unsupported allowed signer option: %s", name)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid %s option: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+// parseSignerTime parses the time of the valid-after and valid-before
+// options in the form YYYYMMDD[HHMM[SS]]. The time is in UTC if it has
+// the Z suffix and in the local time zone otherwise.
+func parseSignerTime(value string) (time.Time, error) {
+	loc := time.Local
+	if v, ok := strings.CutSuffix(value, "Z"); ok {
+		value, loc = v, time.UTC
+	}
+
+	layouts := map[int]string{
+		8:  "20060102",
+		12: "200601021504",
+		14: "20060102150405",
+	}
+	layout, ok := layouts[len(value)]
+	if !ok {
+		return time.Time{}, fmt.Errorf("time must be in the form YYYYMMDD[HHMM[SS]][Z]: %s", value)
+	}
+
+	return time.ParseInLocation(layout, value, loc)
+}
+
+// splitFields splits a line at spaces, which are not in double quotes.
+func splitFields(line string) []string {
+	return splitUnquoted(line, " \t")
+}
+
+// splitOptions splits options at commas, which are not in double quotes.
+func splitOptions(options string) []string {
+	return splitUnquoted(options, ",")
+}
+
+// splitUnquoted splits s at the separator characters, which are
+// not in double quotes. Empty fields are omitted.
+func splitUnquoted(s, separators string) []string {
+	var (
+		fields []string
+		field  strings.Builder
+		quoted bool
+	)
+	for _, r := range s {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if !quoted && strings.ContainsRune(separators, r) {
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+			continue
+		}
+		field.WriteRune(r)
+	}
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+
+	return fields
+}
+
+// trusts reports whether the signer is trusted to sign
+// in the namespace at the given time.
+func (s *sshSigner) trusts(namespace string, at time.Time) bool {
+	if len(s.namespaces) > 0 {
+		var found bool
+		for _, ns := range s.namespaces {
+			if ns == namespace {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if !s.validAfter.IsZero() && at.Before(s.validAfter) {
+		return false
+	}
+	if !s.validBefore.IsZero() && !at.Before(s.validBefore) {
+		return false
+	}
+
+	return true
+}
+
+// identity returns the principal of the signer or
+// the fingerprint of its key if it has no principal.
+func (s *sshSigner) identity() string {
+	if s.principal != "" {
+		return s.principal
+	}
+	return ssh.FingerprintSHA256(s.key)
+}
+
+// VerifyCommit verifies the GPG or SSH signature of the checked out commit
+// with the trusted keys and returns the identity of the signing key.
+// An error is returned if the commit is not signed or is not signed by
+// a trusted key.
+func (c *Cloner) VerifyCommit(keyring *Keyring) (string, error) {
+	repo, err := git.PlainOpen(c.dir())
+	if err != nil {
+		return "", err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return "", err
+	}
+
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case commit.PGPSignature == "":
+		return "", fmt.Errorf("commit %s is not signed", commit.Hash)
+	case strings.HasPrefix(commit.PGPSignature, pgpSignaturePrefix):
+		if keyring.gpg == "" {
+			return "", fmt.Errorf("commit %s is signed with a gpg key, but no gpg keys are trusted", commit.Hash)
+		}
+
+		entity, err := commit.Verify(keyring.gpg)
+		if err != nil {
+			return "", fmt.Errorf("commit %s is not signed by a trusted gpg key: %v", commit.Hash, err)
+		}
+
+		for name := range entity.Identities {
+			return name, nil
+		}
+		return entity.PrimaryKey.KeyIdString(), nil
+	case strings.HasPrefix(commit.PGPSignature, sshSignaturePrefix):
+		signer, err := verifySSHSignature(commit, keyring.ssh)
+		if err != nil {
+			return "", fmt.Errorf("commit %s is not signed by a trusted ssh key: %v", commit.Hash, err)
+		}
+
+		return signer.identity(), nil
+	}
+
+	return "", fmt.Errorf("commit %s has an unsupported signature format", commit.Hash)
+}
+
+// sshSignature is the wire format of an SSH signature.
+// See https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+type sshSignature struct {
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+// sshSignedData is the data signed by an SSH signature.
+type sshSignedData struct {
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          []byte
+}
+
+// verifySSHSignature verifies the SSH signature of a commit and returns
+// the trusted signer, whose key signed the commit. Like git, the validity
+// of the signer is checked at the time of the commit.
+func verifySSHSignature(commit *object.Commit, trusted []*sshSigner) (*sshSigner, error) {
+	block, _ := pem.Decode([]byte(commit.PGPSignature))
+	if block == nil || !bytes.HasPrefix(block.Bytes, []byte(sshSigMagic)) {
+		return nil, fmt.Errorf("invalid signature")
+	}
+
+	var sig sshSignature
+	if err := ssh.Unmarshal(block.Bytes[len(sshSigMagic):], &sig); err != nil {
+		return nil, fmt.Errorf("invalid signature: %v", err)
+	}
+
+	if sig.Version != 1 || sig.Namespace != sshSigNamespace {
+		return nil, fmt.Errorf("invalid signature version or namespace")
+	}
+
+	key, err := ssh.ParsePublicKey(sig.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature public key: %v", err)
+	}
+
+	signer := trustedSigner(key, trusted, sig.Namespace, commit.Committer.When)
+	if signer == nil {
+		return nil, fmt.Errorf("key %s is not trusted", ssh.FingerprintSHA256(key))
+	}
+
+	var h hash.Hash
+	switch sig.HashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return nil, fmt.Errorf("unsupported hash algorithm: %s", sig.HashAlgorithm)
+	}
+
+	encoded := &plumbing.MemoryObject{}
+	if err := commit.EncodeWithoutSignature(encoded); err != nil {
+		return nil, err
+	}
+	r, err := encoded.Reader()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, err
+	}
+
+	signed := append([]byte(sshSigMagic), ssh.Marshal(sshSignedData{
+		Namespace:     sig.Namespace,
+		Reserved:      sig.Reserved,
+		HashAlgorithm: sig.HashAlgorithm,
+		Hash:          h.Sum(nil),
+	})...)
+
+	var signature ssh.Signature
+	if err := ssh.Unmarshal(sig.Signature, &signature); err != nil {
+		return nil, fmt.Errorf("invalid signature: %v", err)
+	}
+
+	if err := key.Verify(signed, &signature); err != nil {
+		return nil, err
+	}
+
+	return signer, nil
+}
+
+// trustedSigner returns the signer which trusts the key
+// in the namespace at the given time, if any.
+func trustedSigner(key ssh.PublicKey, trusted []*sshSigner, namespace string, at time.Time) *sshSigner {
+	for _, s := range trusted {
+		if bytes.Equal(s.key.Marshal(), key.Marshal()) && s.trusts(namespace, at) {
+			return s
+		}
+	}
+	return nil
+}
@@ -0,0 +1,302 @@
+package clone_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/pem"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/clone"
+)
+
+var commitTime = time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)
+
+func gpgEntity(t *testing.T, name string) (*openpgp.Entity, string) {
+	entity, err := openpgp.NewEntity(name, "", name+"@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	require.NoError(t, err)
+
+	var armored bytes.Buffer
+	w, err := armor.Encode(&armored, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+
+	return entity, armored.String()
+}
+
+func sshSigner(t *testing.T) (ssh.Signer, string) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(key)
+	require.NoError(t, err)
+
+	return signer, string(bytes.TrimSpace(ssh.MarshalAuthorizedKey(signer.PublicKey())))
+}
+
+// sshSign signs the encoded commit like `git commit -S` with an SSH key.
+func sshSign(t *testing.T, commit *object.Commit, signer ssh.Signer) string {
+	encoded := &plumbing.MemoryObject{}
+	require.NoError(t, commit.EncodeWithoutSignature(encoded))
+	r, err := encoded.Reader()
+	require.NoError(t, err)
+	h := sha512.New()
+	_, err = io.Copy(h, r)
+	require.NoError(t, err)
+
+	signed := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{"git", "", "sha512", h.Sum(nil)})...)
+	sig, err := signer.Sign(rand.Reader, signed)
+	require.NoError(t, err)
+
+	blob := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}{1, signer.PublicKey().Marshal(), "git", "", "sha512", ssh.Marshal(sig)})...)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "SSH SIGNATURE", Bytes: blob}))
+}
+
+// signedCommit commits a policy to a new repository in the folder.
+// The commit is signed with the gpg entity or the ssh signer, if any,
+// and its message is changed after signing if tampered is true.
+func signedCommit(t *testing.T, folder string, entity *openpgp.Entity, signer ssh.Signer, tampered bool) {
+	repo, err := git.PlainInit(folder, false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "policy.rego"), []byte("package example.test"), 0600))
+	_, err = worktree.Add("policy.rego")
+	require.NoError(t, err)
+
+	hash, err := worktree.Commit("add policy", &git.CommitOptions{
+		Author:  &object.Signature{Name: "alice", Email: "alice@example.com", When: commitTime},
+		SignKey: entity,
+	})
+	require.NoError(t, err)
+
+	if signer == nil && !tampered {
+		return
+	}
+
+	commit, err := repo.CommitObject(hash)
+	require.NoError(t, err)
+	if signer != nil {
+		commit.PGPSignature = sshSign(t, commit, signer)
+	}
+	if tampered {
+		commit.Message = "add another policy"
+	}
+
+	obj := repo.Storer.NewEncodedObject()
+	require.NoError(t, commit.Encode(obj))
+	hash, err = repo.Storer.SetEncodedObject(obj)
+	require.NoError(t, err)
+
+	head, err := repo.Head()
+	require.NoError(t, err)
+	require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference(head.Name(), hash)))
+}
+
+func writeKeys(t *testing.T, name, content string) string {
+	if content == "" {
+		return ""
+	}
+	filename := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(filename, []byte(content), 0600))
+	return filename
+}
+
+func TestCloner_VerifyCommit(t *testing.T) {
+	alice, aliceKeyring := gpgEntity(t, "alice")
+	mallory, _ := gpgEntity(t, "mallory")
+	bob, bobKey := sshSigner(t)
+	eve, eveKey := sshSigner(t)
+
+	tests := []struct {
+		name     string
+		entity   *openpgp.Entity
+		signer   ssh.Signer
+		tampered bool
+		gpg      string
+		ssh      string
+
+		identity string
+		errtext  string
+	}{
+		{
+			name:     "valid gpg signature",
+			entity:   alice,
+			gpg:      aliceKeyring,
+			identity: "alice <alice@example.com>",
+		},
+		{
+			name:    "gpg signature of a wrong key",
+			entity:  mallory,
+			gpg:     aliceKeyring,
+			errtext: "is not signed by a trusted gpg key",
+		},
+		{
+			name:     "tampered gpg signed commit",
+			entity:   alice,
+			tampered: true,
+			gpg:      aliceKeyring,
+			errtext:  "is not signed by a trusted gpg key",
+		},
+		{
+			name:    "gpg signature without trusted gpg keys",
+			entity:  alice,
+			ssh:     "bob@example.com " + bobKey,
+			errtext: "no gpg keys are trusted",
+		},
+		{
+			name:     "valid ssh signature",
+			signer:   bob,
+			ssh:      "bob@example.com " + bobKey,
+			identity: "bob@example.com",
+		},
+		{
+			name:     "valid ssh signature of a key without principal",
+			signer:   bob,
+			ssh:      bobKey,
+			identity: ssh.FingerprintSHA256(bob.PublicKey()),
+		},
+		{
+			name:    "ssh signature of a wrong key",
+			signer:  eve,
+			ssh:     "bob@example.com " + bobKey,
+			errtext: "key " + ssh.FingerprintSHA256(eve.PublicKey()) + " is not trusted",
+		},
+		{
+			name:     "tampered ssh signed commit",
+			signer:   bob,
+			tampered: true,
+			ssh:      "bob@example.com " + bobKey,
+			errtext:  "is not signed by a trusted ssh key: ssh: signature did not verify",
+		},
+		{
+			name:     "ssh key trusted for the git namespace and commit time",
+			signer:   eve,
+			ssh:      "bob@example.com " + bobKey + "\n# eve signs since 2029\neve@example.com namespaces=\"file,git\",valid-after=\"20290101\",valid-before=\"20310101Z\" " + eveKey,
+			identity: "eve@example.com",
+		},
+		{
+			name:    "ssh key trusted for another namespace",
+			signer:  eve,
+			ssh:     `eve@example.com namespaces="file" ` + eveKey,
+			errtext: "is not trusted",
+		},
+		{
+			name:    "ssh key trusted after the commit",
+			signer:  eve,
+			ssh:     `eve@example.com valid-after="20300101100001Z" ` + eveKey,
+			errtext: "is not trusted",
+		},
+		{
+			name:    "ssh key trusted before the commit",
+			signer:  eve,
+			ssh:     `eve@example.com valid-before="203001011000Z" ` + eveKey,
+			errtext: "is not trusted",
+		},
+		{
+			name:    "unsigned commit",
+			gpg:     aliceKeyring,
+			ssh:     "bob@example.com " + bobKey,
+			errtext: "is not signed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			folder := t.TempDir()
+			signedCommit(t, folder, test.entity, test.signer, test.tampered)
+
+			keyring, err := clone.LoadKeyring(writeKeys(t, "keyring.asc", test.gpg), writeKeys(t, "allowed_signers", test.ssh))
+			require.NoError(t, err)
+			require.NotNil(t, keyring)
+
+			cloner, err := clone.NewInFolder(folder)
+			require.NoError(t, err)
+
+			identity, err := cloner.VerifyCommit(keyring)
+			if test.errtext != "" {
+				assert.ErrorContains(t, err, test.errtext)
+				assert.Empty(t, identity)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.identity, identity)
+		})
+	}
+}
+
+func TestLoadKeyring(t *testing.T) {
+	_, key := sshSigner(t)
+
+	keyring, err := clone.LoadKeyring("", "")
+	require.NoError(t, err)
+	assert.Nil(t, keyring)
+
+	_, err = clone.LoadKeyring("missing.asc", "")
+	assert.ErrorContains(t, err, "error reading gpg keyring")
+
+	tests := []struct {
+		name    string
+		ssh     string
+		errtext string
+	}{
+		{
+			name:    "certificate authority",
+			ssh:     "*@example.com cert-authority " + key,
+			errtext: "line 1: certificate authorities are not supported",
+		},
+		{
+			name:    "unsupported option",
+			ssh:     "# maintainers\n\nbob@example.com no-touch-required " + key,
+			errtext: "line 3: unsupported allowed signer option: no-touch-required",
+		},
+		{
+			name:    "invalid time",
+			ssh:     `bob@example.com valid-after="2030-01-01" ` + key,
+			errtext: "invalid valid-after option: time must be in the form YYYYMMDD[HHMM[SS]][Z]",
+		},
+		{
+			name:    "missing public key",
+			ssh:     "bob@example.com ssh-ed25519",
+			errtext: "public key not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := clone.LoadKeyring("", writeKeys(t, "allowed_signers", test.ssh))
+			assert.ErrorContains(t, err, "error reading ssh allowed signers")
+			assert.ErrorContains(t, err, test.errtext)
+		})
+	}
+}
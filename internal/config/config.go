@@ -4,7 +4,10 @@ import "time"
 
 type Config struct {
 	HTTP        httpConfig
+	GRPC        grpcConfig
+	TLS         tlsConfig
 	Mongo       mongoConfig
+	Bolt        boltConfig
 	Cache       cacheConfig
 	Task        taskConfig
 	Signer      signerConfig
@@ -18,6 +21,7 @@ type Config struct {
 	Nats        natsConfig
 	Policy      policyConfig
 	AutoImport  autoimportConfig
+	Webhook     webhookConfig
 
 	// ExternalAddr specifies the external address where
 	// the policy service could be reached, so that
@@ -37,19 +41,59 @@ type httpConfig struct {
 	WriteTimeout time.Duration `envconfig:"HTTP_WRITE_TIMEOUT" default:"10s"`
 }
 
+// gRPC Server configuration
+type grpcConfig struct {
+	// Enabled specifies whether the gRPC server is started.
+	Enabled bool   `envconfig:"GRPC_ENABLED" default:"false"`
+	Host    string `envconfig:"GRPC_HOST"`
+	Port    string `envconfig:"GRPC_PORT" default:"8090"`
+}
+
+// TLS configuration of the HTTP and gRPC servers
+type tlsConfig struct {
+	// CertFile and KeyFile specify the server certificate. If they are set,
+	// the HTTP and gRPC servers accept only TLS connections.
+	CertFile string `envconfig:"TLS_CERT_FILE"`
+	KeyFile  string `envconfig:"TLS_KEY_FILE"`
+	// ClientCAFile specifies a CA bundle for verifying client certificates.
+	// If it's set, clients must present a certificate signed by one of the CAs.
+	ClientCAFile string `envconfig:"TLS_CLIENT_CA_FILE"`
+	// ClientCertOptional specifies whether clients without a certificate are
+	// accepted. Certificates presented by clients are verified anyway.
+	ClientCertOptional bool `envconfig:"TLS_CLIENT_CERT_OPTIONAL" default:"false"`
+	// ReloadInterval specifies how often the certificate files of the servers
+	// and the upstream clients are checked for changes and reloaded.
+	ReloadInterval time.Duration `envconfig:"TLS_RELOAD_INTERVAL" default:"30s"`
+}
+
 type cacheConfig struct {
 	// Addr specifies the address of the cache service.
 	Addr string `envconfig:"CACHE_ADDR"`
+	// CertFile and KeyFile specify a client certificate and CAFile a CA bundle
+	// for mutual TLS with the cache service.
+	CertFile string `envconfig:"CACHE_TLS_CERT_FILE"`
+	KeyFile  string `envconfig:"CACHE_TLS_KEY_FILE"`
+	CAFile   string `envconfig:"CACHE_TLS_CA_FILE"`
 }
 
 type taskConfig struct {
 	// Addr specifies the address of the task service.
 	Addr string `envconfig:"TASK_ADDR"`
+	// CertFile and KeyFile specify a client certificate and CAFile a CA bundle
+	// for mutual TLS with the task service.
+	CertFile string `envconfig:"TASK_TLS_CERT_FILE"`
+	KeyFile  string `envconfig:"TASK_TLS_KEY_FILE"`
+	CAFile   string `envconfig:"TASK_TLS_CA_FILE"`
 }
 
 type signerConfig struct {
 	// Addr specifies the address of the signer service.
 	Addr string `envconfig:"SIGNER_ADDR"`
+	// CertFile and KeyFile specify a client certificate and CAFile a CA bundle
+	// for mutual TLS with the signer service.
+	CertFile string `envconfig:"SIGNER_TLS_CERT_FILE"`
+	KeyFile  string `envconfig:"SIGNER_TLS_KEY_FILE"`
+	CAFile   string `envconfig:"SIGNER_TLS_CA_FILE"`
 }
 
 type didResolverConfig struct {
@@ -59,7 +103,7 @@ type didResolverConfig struct {
 
 // MongoDB configuration
 type mongoConfig struct {
-	Addr          string `envconfig:"MONGO_ADDR"` // required if POLICY_REPOSITORY_CLONE_URL is not set
+	Addr          string `envconfig:"MONGO_ADDR"` // required if POLICY_REPOSITORY_CLONE_URL, POLICY_LOCAL_DIR or BOLT_PATH is not set
 	User          string `envconfig:"MONGO_USER"`
 	Pass          string `envconfig:"MONGO_PASS"`
 	DB            string `envconfig:"MONGO_DBNAME" default:"policy"`
@@ -67,9 +111,16 @@ type mongoConfig struct {
 	AuthMechanism string `envconfig:"MONGO_AUTH_MECHANISM" default:"SCRAM-SHA-1"`
 }
 
+// Embedded bbolt database configuration
+type boltConfig struct {
+	// Path of the database file. If set and MONGO_ADDR is not set,
+	// policies are kept in an embedded database instead of MongoDB.
+	Path string `envconfig:"BOLT_PATH"`
+}
+
 // Policy repository configuration
 type policyConfig struct {
-	CloneURL string `envconfig:"POLICY_REPOSITORY_CLONE_URL"` // required if MONGO_ADDR is not set
+	CloneURL string `envconfig:"POLICY_REPOSITORY_CLONE_URL"` // required if MONGO_ADDR or BOLT_PATH is not set
 	User     string `envconfig:"POLICY_REPOSITORY_USER"`
 	Pass     string `envconfig:"POLICY_REPOSITORY_PASS"` // an Access Token is strongly recommended
 	Branch   string `envconfig:"POLICY_REPOSITORY_BRANCH"`
@@ -79,9 +130,39 @@ type policyConfig struct {
 	// are going to be fetched and used for evaluation.
 	Folder string `envconfig:"POLICY_REPOSITORY_FOLDER"`
 
+	// SyncInterval specifies how often the policy repository is pulled
+	// again to update the policies in memory storage. Periodic sync is
+	// disabled if it's not set.
+	SyncInterval time.Duration `envconfig:"POLICY_REPOSITORY_SYNC_INTERVAL"`
+	// OnRemove defines what happens with policies in memory storage, which
+	// are removed from the repository: keep, delete, lock or archive.
+	OnRemove string `envconfig:"POLICY_REPOSITORY_ON_REMOVE" default:"keep"`
+
+	// GPGKeyring is the path of an ASCII armored keyring and SSHAllowedSigners
+	// is the path of a file with SSH public keys of the maintainers trusted
+	// to sign commits. If any of them is set, policies are loaded only from
+	// a repository commit signed by a trusted key.
+	GPGKeyring        string `envconfig:"POLICY_REPOSITORY_GPG_KEYRING"`
+	SSHAllowedSigners string `envconfig:"POLICY_REPOSITORY_SSH_ALLOWED_SIGNERS"`
+
+	// LocalDir specifies a local folder containing policies, which is
+	// used instead of a Git repository for local development of policies.
+	// The folder must have the same layout as a policy repository and
+	// policies are reloaded when the policy files are changed.
+	LocalDir string `envconfig:"POLICY_LOCAL_DIR"`
+	// LocalRepository is the repository name of the policies loaded
+	// from LocalDir. If empty, the name of the folder is used.
+	LocalRepository string `envconfig:"POLICY_LOCAL_REPOSITORY"`
+	// LocalPollInterval specifies how often LocalDir is checked for changes.
+	LocalPollInterval time.Duration `envconfig:"POLICY_LOCAL_POLL_INTERVAL" default:"1s"`
+
 	// LockOnValidationFailure indicates whether a policy must be locked for execution
 	// if the policy output fails the schema validation.
 	LockOnValidationFailure bool `envconfig:"POLICY_LOCK_ON_VALIDATION_FAILURE" default:"false"`
+
+	// UnlockInterval specifies how often policies with
+	// expired locks are checked and unlocked.
+	UnlockInterval time.Duration `envconfig:"POLICY_UNLOCK_INTERVAL" default:"1m"`
 }
 
 type metricsConfig struct {
@@ -92,6 +173,11 @@ type metricsConfig struct {
 type ocmConfig struct {
 	// Addr specifies the address of the OCM server.
 	Addr string `envconfig:"OCM_ADDR" required:"true"`
+	// CertFile and KeyFile specify a client certificate and CAFile a CA bundle
+	// for mutual TLS with the OCM server.
+	CertFile string `envconfig:"OCM_TLS_CERT_FILE"`
+	KeyFile  string `envconfig:"OCM_TLS_KEY_FILE"`
+	CAFile   string `envconfig:"OCM_TLS_CA_FILE"`
 }
 
 // OAuth client configuration
@@ -111,6 +197,12 @@ type authConfig struct {
 	Enabled         bool          `envconfig:"AUTH_ENABLED" default:"false"`
 	JwkURL          string        `envconfig:"AUTH_JWK_URL"`
 	RefreshInterval time.Duration `envconfig:"AUTH_REFRESH_INTERVAL" default:"1h"`
+	// AdminPolicy is the system policy authorizing requests to the admin
+	// endpoints, given as repository/group/name/version.
+	AdminPolicy string `envconfig:"AUTH_ADMIN_POLICY"`
+	// EvaluationPolicy is the system policy authorizing requests to the
+	// evaluation endpoints, given as repository/group/name/version.
+	EvaluationPolicy string `envconfig:"AUTH_EVALUATION_POLICY"`
 }
 
 type ipFilterConfig struct {
@@ -131,3 +223,16 @@ type autoimportConfig struct {
 	// PollInterval specifies the interval between two policy bundle autoimport runs.
 	PollInterval time.Duration `envconfig:"AUTO_IMPORT_POLL_INTERVAL" default:"10s"`
 }
+
+type webhookConfig struct {
+	// MaxAttempts specifies how many times a webhook delivery is attempted
+	// before it's marked as dead.
+	MaxAttempts int `envconfig:"WEBHOOK_MAX_ATTEMPTS" default:"10"`
+	// RetryBackoff specifies the delay before the first retry of a failed
+	// delivery, which is doubled after every failed attempt up to MaxRetryBackoff.
+	RetryBackoff    time.Duration `envconfig:"WEBHOOK_RETRY_BACKOFF" default:"10s"`
+	MaxRetryBackoff time.Duration `envconfig:"WEBHOOK_MAX_RETRY_BACKOFF" default:"1h"`
+	// PollInterval specifies the interval between two checks for
+	// deliveries which must be retried.
+	PollInterval time.Duration `envconfig:"WEBHOOK_POLL_INTERVAL" default:"5s"`
+}
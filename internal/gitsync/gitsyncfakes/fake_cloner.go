@@ -0,0 +1,588 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package gitsyncfakes
+
+import (
+	"context"
+	"sync"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/clone"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/gitsync"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+type FakeCloner struct {
+	CleanupStub        func() error
+	cleanupMutex       sync.RWMutex
+	cleanupArgsForCall []struct {
+	}
+	cleanupReturns struct {
+		result1 error
+	}
+	cleanupReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CommitStub        func() (*storage.Commit, error)
+	commitMutex       sync.RWMutex
+	commitArgsForCall []struct {
+	}
+	commitReturns struct {
+		result1 *storage.Commit
+		result2 error
+	}
+	commitReturnsOnCall map[int]struct {
+		result1 *storage.Commit
+		result2 error
+	}
+	ConstructKeyStub        func(string, string, string, string) string
+	constructKeyMutex       sync.RWMutex
+	constructKeyArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	constructKeyReturns struct {
+		result1 string
+	}
+	constructKeyReturnsOnCall map[int]struct {
+		result1 string
+	}
+	IterateChangesStub        func(string, string, string) (map[string]*storage.Policy, []string, error)
+	iterateChangesMutex       sync.RWMutex
+	iterateChangesArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	iterateChangesReturns struct {
+		result1 map[string]*storage.Policy
+		result2 []string
+		result3 error
+	}
+	iterateChangesReturnsOnCall map[int]struct {
+		result1 map[string]*storage.Policy
+		result2 []string
+		result3 error
+	}
+	IterateRepoStub        func(string, string) (map[string]*storage.Policy, error)
+	iterateRepoMutex       sync.RWMutex
+	iterateRepoArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	iterateRepoReturns struct {
+		result1 map[string]*storage.Policy
+		result2 error
+	}
+	iterateRepoReturnsOnCall map[int]struct {
+		result1 map[string]*storage.Policy
+		result2 error
+	}
+	PullStub        func(context.Context, string, string, *clone.Auth) (string, error)
+	pullMutex       sync.RWMutex
+	pullArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 *clone.Auth
+	}
+	pullReturns struct {
+		result1 string
+		result2 error
+	}
+	pullReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	VerifyCommitStub        func(*clone.Keyring) (string, error)
+	verifyCommitMutex       sync.RWMutex
+	verifyCommitArgsForCall []struct {
+		arg1 *clone.Keyring
+	}
+	verifyCommitReturns struct {
+		result1 string
+		result2 error
+	}
+	verifyCommitReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCloner) Cleanup() error {
+	fake.cleanupMutex.Lock()
+	ret, specificReturn := fake.cleanupReturnsOnCall[len(fake.cleanupArgsForCall)]
+	fake.cleanupArgsForCall = append(fake.cleanupArgsForCall, struct {
+	}{})
+	stub := fake.CleanupStub
+	fakeReturns := fake.cleanupReturns
+	fake.recordInvocation("Cleanup", []interface{}{})
+	fake.cleanupMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCloner) CleanupCallCount() int {
+	fake.cleanupMutex.RLock()
+	defer fake.cleanupMutex.RUnlock()
+	return len(fake.cleanupArgsForCall)
+}
+
+func (fake *FakeCloner) CleanupCalls(stub func() error) {
+	fake.cleanupMutex.Lock()
+	defer fake.cleanupMutex.Unlock()
+	fake.CleanupStub = stub
+}
+
+func (fake *FakeCloner) CleanupReturns(result1 error) {
+	fake.cleanupMutex.Lock()
+	defer fake.cleanupMutex.Unlock()
+	fake.CleanupStub = nil
+	fake.cleanupReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCloner) CleanupReturnsOnCall(i int, result1 error) {
+	fake.cleanupMutex.Lock()
+	defer fake.cleanupMutex.Unlock()
+	fake.CleanupStub = nil
+	if fake.cleanupReturnsOnCall == nil {
+		fake.cleanupReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.cleanupReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCloner) Commit() (*storage.Commit, error) {
+	fake.commitMutex.Lock()
+	ret, specificReturn := fake.commitReturnsOnCall[len(fake.commitArgsForCall)]
+	fake.commitArgsForCall = append(fake.commitArgsForCall, struct {
+	}{})
+	stub := fake.CommitStub
+	fakeReturns := fake.commitReturns
+	fake.recordInvocation("Commit", []interface{}{})
+	fake.commitMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCloner) CommitCallCount() int {
+	fake.commitMutex.RLock()
+	defer fake.commitMutex.RUnlock()
+	return len(fake.commitArgsForCall)
+}
+
+func (fake *FakeCloner) CommitCalls(stub func() (*storage.Commit, error)) {
+	fake.commitMutex.Lock()
+	defer fake.commitMutex.Unlock()
+	fake.CommitStub = stub
+}
+
+func (fake *FakeCloner) CommitReturns(result1 *storage.Commit, result2 error) {
+	fake.commitMutex.Lock()
+	defer fake.commitMutex.Unlock()
+	fake.CommitStub = nil
+	fake.commitReturns = struct {
+		result1 *storage.Commit
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCloner) CommitReturnsOnCall(i int, result1 *storage.Commit, result2 error) {
+	fake.commitMutex.Lock()
+	defer fake.commitMutex.Unlock()
+	fake.CommitStub = nil
+	if fake.commitReturnsOnCall == nil {
+		fake.commitReturnsOnCall = make(map[int]struct {
+			result1 *storage.Commit
+			result2 error
+		})
+	}
+	fake.commitReturnsOnCall[i] = struct {
+		result1 *storage.Commit
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCloner) ConstructKey(arg1 string, arg2 string, arg3 string, arg4 string) string {
+	fake.constructKeyMutex.Lock()
+	ret, specificReturn := fake.constructKeyReturnsOnCall[len(fake.constructKeyArgsForCall)]
+	fake.constructKeyArgsForCall = append(fake.constructKeyArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ConstructKeyStub
+	fakeReturns := fake.constructKeyReturns
+	fake.recordInvocation("ConstructKey", []interface{}{arg1, arg2, arg3, arg4})
+	fake.constructKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCloner) ConstructKeyCallCount() int {
+	fake.constructKeyMutex.RLock()
+	defer fake.constructKeyMutex.RUnlock()
+	return len(fake.constructKeyArgsForCall)
+}
+
+func (fake *FakeCloner) ConstructKeyCalls(stub func(string, string, string, string) string) {
+	fake.constructKeyMutex.Lock()
+	defer fake.constructKeyMutex.Unlock()
+	fake.ConstructKeyStub = stub
+}
+
+func (fake *FakeCloner) ConstructKeyArgsForCall(i int) (string, string, string, string) {
+	fake.constructKeyMutex.RLock()
+	defer fake.constructKeyMutex.RUnlock()
+	argsForCall := fake.constructKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeCloner) ConstructKeyReturns(result1 string) {
+	fake.constructKeyMutex.Lock()
+	defer fake.constructKeyMutex.Unlock()
+	fake.ConstructKeyStub = nil
+	fake.constructKeyReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeCloner) ConstructKeyReturnsOnCall(i int, result1 string) {
+	fake.constructKeyMutex.Lock()
+	defer fake.constructKeyMutex.Unlock()
+	fake.ConstructKeyStub = nil
+	if fake.constructKeyReturnsOnCall == nil {
+		fake.constructKeyReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.constructKeyReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeCloner) IterateChanges(arg1 string, arg2 string, arg3 string) (map[string]*storage.Policy, []string, error) {
+	fake.iterateChangesMutex.Lock()
+	ret, specificReturn := fake.iterateChangesReturnsOnCall[len(fake.iterateChangesArgsForCall)]
+	fake.iterateChangesArgsForCall = append(fake.iterateChangesArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.IterateChangesStub
+	fakeReturns := fake.iterateChangesReturns
+	fake.recordInvocation("IterateChanges", []interface{}{arg1, arg2, arg3})
+	fake.iterateChangesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeCloner) IterateChangesCallCount() int {
+	fake.iterateChangesMutex.RLock()
+	defer fake.iterateChangesMutex.RUnlock()
+	return len(fake.iterateChangesArgsForCall)
+}
+
+func (fake *FakeCloner) IterateChangesCalls(stub func(string, string, string) (map[string]*storage.Policy, []string, error)) {
+	fake.iterateChangesMutex.Lock()
+	defer fake.iterateChangesMutex.Unlock()
+	fake.IterateChangesStub = stub
+}
+
+func (fake *FakeCloner) IterateChangesArgsForCall(i int) (string, string, string) {
+	fake.iterateChangesMutex.RLock()
+	defer fake.iterateChangesMutex.RUnlock()
+	argsForCall := fake.iterateChangesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCloner) IterateChangesReturns(result1 map[string]*storage.Policy, result2 []string, result3 error) {
+	fake.iterateChangesMutex.Lock()
+	defer fake.iterateChangesMutex.Unlock()
+	fake.IterateChangesStub = nil
+	fake.iterateChangesReturns = struct {
+		result1 map[string]*storage.Policy
+		result2 []string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCloner) IterateChangesReturnsOnCall(i int, result1 map[string]*storage.Policy, result2 []string, result3 error) {
+	fake.iterateChangesMutex.Lock()
+	defer fake.iterateChangesMutex.Unlock()
+	fake.IterateChangesStub = nil
+	if fake.iterateChangesReturnsOnCall == nil {
+		fake.iterateChangesReturnsOnCall = make(map[int]struct {
+			result1 map[string]*storage.Policy
+			result2 []string
+			result3 error
+		})
+	}
+	fake.iterateChangesReturnsOnCall[i] = struct {
+		result1 map[string]*storage.Policy
+		result2 []string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCloner) IterateRepo(arg1 string, arg2 string) (map[string]*storage.Policy, error) {
+	fake.iterateRepoMutex.Lock()
+	ret, specificReturn := fake.iterateRepoReturnsOnCall[len(fake.iterateRepoArgsForCall)]
+	fake.iterateRepoArgsForCall = append(fake.iterateRepoArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.IterateRepoStub
+	fakeReturns := fake.iterateRepoReturns
+	fake.recordInvocation("IterateRepo", []interface{}{arg1, arg2})
+	fake.iterateRepoMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCloner) IterateRepoCallCount() int {
+	fake.iterateRepoMutex.RLock()
+	defer fake.iterateRepoMutex.RUnlock()
+	return len(fake.iterateRepoArgsForCall)
+}
+
+func (fake *FakeCloner) IterateRepoCalls(stub func(string, string) (map[string]*storage.Policy, error)) {
+	fake.iterateRepoMutex.Lock()
+	defer fake.iterateRepoMutex.Unlock()
+	fake.IterateRepoStub = stub
+}
+
+func (fake *FakeCloner) IterateRepoArgsForCall(i int) (string, string) {
+	fake.iterateRepoMutex.RLock()
+	defer fake.iterateRepoMutex.RUnlock()
+	argsForCall := fake.iterateRepoArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCloner) IterateRepoReturns(result1 map[string]*storage.Policy, result2 error) {
+	fake.iterateRepoMutex.Lock()
+	defer fake.iterateRepoMutex.Unlock()
+	fake.IterateRepoStub = nil
+	fake.iterateRepoReturns = struct {
+		result1 map[string]*storage.Policy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCloner) IterateRepoReturnsOnCall(i int, result1 map[string]*storage.Policy, result2 error) {
+	fake.iterateRepoMutex.Lock()
+	defer fake.iterateRepoMutex.Unlock()
+	fake.IterateRepoStub = nil
+	if fake.iterateRepoReturnsOnCall == nil {
+		fake.iterateRepoReturnsOnCall = make(map[int]struct {
+			result1 map[string]*storage.Policy
+			result2 error
+		})
+	}
+	fake.iterateRepoReturnsOnCall[i] = struct {
+		result1 map[string]*storage.Policy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCloner) Pull(arg1 context.Context, arg2 string, arg3 string, arg4 *clone.Auth) (string, error) {
+	fake.pullMutex.Lock()
+	ret, specificReturn := fake.pullReturnsOnCall[len(fake.pullArgsForCall)]
+	fake.pullArgsForCall = append(fake.pullArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 *clone.Auth
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.PullStub
+	fakeReturns := fake.pullReturns
+	fake.recordInvocation("Pull", []interface{}{arg1, arg2, arg3, arg4})
+	fake.pullMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCloner) PullCallCount() int {
+	fake.pullMutex.RLock()
+	defer fake.pullMutex.RUnlock()
+	return len(fake.pullArgsForCall)
+}
+
+func (fake *FakeCloner) PullCalls(stub func(context.Context, string, string, *clone.Auth) (string, error)) {
+	fake.pullMutex.Lock()
+	defer fake.pullMutex.Unlock()
+	fake.PullStub = stub
+}
+
+func (fake *FakeCloner) PullArgsForCall(i int) (context.Context, string, string, *clone.Auth) {
+	fake.pullMutex.RLock()
+	defer fake.pullMutex.RUnlock()
+	argsForCall := fake.pullArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeCloner) PullReturns(result1 string, result2 error) {
+	fake.pullMutex.Lock()
+	defer fake.pullMutex.Unlock()
+	fake.PullStub = nil
+	fake.pullReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCloner) PullReturnsOnCall(i int, result1 string, result2 error) {
+	fake.pullMutex.Lock()
+	defer fake.pullMutex.Unlock()
+	fake.PullStub = nil
+	if fake.pullReturnsOnCall == nil {
+		fake.pullReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.pullReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCloner) VerifyCommit(arg1 *clone.Keyring) (string, error) {
+	fake.verifyCommitMutex.Lock()
+	ret, specificReturn := fake.verifyCommitReturnsOnCall[len(fake.verifyCommitArgsForCall)]
+	fake.verifyCommitArgsForCall = append(fake.verifyCommitArgsForCall, struct {
+		arg1 *clone.Keyring
+	}{arg1})
+	stub := fake.VerifyCommitStub
+	fakeReturns := fake.verifyCommitReturns
+	fake.recordInvocation("VerifyCommit", []interface{}{arg1})
+	fake.verifyCommitMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCloner) VerifyCommitCallCount() int {
+	fake.verifyCommitMutex.RLock()
+	defer fake.verifyCommitMutex.RUnlock()
+	return len(fake.verifyCommitArgsForCall)
+}
+
+func (fake *FakeCloner) VerifyCommitCalls(stub func(*clone.Keyring) (string, error)) {
+	fake.verifyCommitMutex.Lock()
+	defer fake.verifyCommitMutex.Unlock()
+	fake.VerifyCommitStub = stub
+}
+
+func (fake *FakeCloner) VerifyCommitArgsForCall(i int) *clone.Keyring {
+	fake.verifyCommitMutex.RLock()
+	defer fake.verifyCommitMutex.RUnlock()
+	argsForCall := fake.verifyCommitArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCloner) VerifyCommitReturns(result1 string, result2 error) {
+	fake.verifyCommitMutex.Lock()
+	defer fake.verifyCommitMutex.Unlock()
+	fake.VerifyCommitStub = nil
+	fake.verifyCommitReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCloner) VerifyCommitReturnsOnCall(i int, result1 string, result2 error) {
+	fake.verifyCommitMutex.Lock()
+	defer fake.verifyCommitMutex.Unlock()
+	fake.VerifyCommitStub = nil
+	if fake.verifyCommitReturnsOnCall == nil {
+		fake.verifyCommitReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.verifyCommitReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCloner) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.cleanupMutex.RLock()
+	defer fake.cleanupMutex.RUnlock()
+	fake.commitMutex.RLock()
+	defer fake.commitMutex.RUnlock()
+	fake.constructKeyMutex.RLock()
+	defer fake.constructKeyMutex.RUnlock()
+	fake.iterateChangesMutex.RLock()
+	defer fake.iterateChangesMutex.RUnlock()
+	fake.iterateRepoMutex.RLock()
+	defer fake.iterateRepoMutex.RUnlock()
+	fake.pullMutex.RLock()
+	defer fake.pullMutex.RUnlock()
+	fake.verifyCommitMutex.RLock()
+	defer fake.verifyCommitMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeCloner) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ gitsync.Cloner = new(FakeCloner)
@@ -0,0 +1,364 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package gitsyncfakes
+
+import (
+	"context"
+	"sync"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/gitsync"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+type FakeStorage struct {
+	DeletePolicyStub        func(context.Context, string, string, string, string) error
+	deletePolicyMutex       sync.RWMutex
+	deletePolicyArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}
+	deletePolicyReturns struct {
+		result1 error
+	}
+	deletePolicyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetPoliciesStub        func(context.Context, *bool, *string) ([]*storage.Policy, error)
+	getPoliciesMutex       sync.RWMutex
+	getPoliciesArgsForCall []struct {
+		arg1 context.Context
+		arg2 *bool
+		arg3 *string
+	}
+	getPoliciesReturns struct {
+		result1 []*storage.Policy
+		result2 error
+	}
+	getPoliciesReturnsOnCall map[int]struct {
+		result1 []*storage.Policy
+		result2 error
+	}
+	SavePolicyStub        func(context.Context, *storage.Policy) error
+	savePolicyMutex       sync.RWMutex
+	savePolicyArgsForCall []struct {
+		arg1 context.Context
+		arg2 *storage.Policy
+	}
+	savePolicyReturns struct {
+		result1 error
+	}
+	savePolicyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetPolicyLockStub        func(context.Context, string, string, string, string, *storage.PolicyLock) error
+	setPolicyLockMutex       sync.RWMutex
+	setPolicyLockArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 *storage.PolicyLock
+	}
+	setPolicyLockReturns struct {
+		result1 error
+	}
+	setPolicyLockReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeStorage) DeletePolicy(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string) error {
+	fake.deletePolicyMutex.Lock()
+	ret, specificReturn := fake.deletePolicyReturnsOnCall[len(fake.deletePolicyArgsForCall)]
+	fake.deletePolicyArgsForCall = append(fake.deletePolicyArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.DeletePolicyStub
+	fakeReturns := fake.deletePolicyReturns
+	fake.recordInvocation("DeletePolicy", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.deletePolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStorage) DeletePolicyCallCount() int {
+	fake.deletePolicyMutex.RLock()
+	defer fake.deletePolicyMutex.RUnlock()
+	return len(fake.deletePolicyArgsForCall)
+}
+
+func (fake *FakeStorage) DeletePolicyCalls(stub func(context.Context, string, string, string, string) error) {
+	fake.deletePolicyMutex.Lock()
+	defer fake.deletePolicyMutex.Unlock()
+	fake.DeletePolicyStub = stub
+}
+
+func (fake *FakeStorage) DeletePolicyArgsForCall(i int) (context.Context, string, string, string, string) {
+	fake.deletePolicyMutex.RLock()
+	defer fake.deletePolicyMutex.RUnlock()
+	argsForCall := fake.deletePolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeStorage) DeletePolicyReturns(result1 error) {
+	fake.deletePolicyMutex.Lock()
+	defer fake.deletePolicyMutex.Unlock()
+	fake.DeletePolicyStub = nil
+	fake.deletePolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) DeletePolicyReturnsOnCall(i int, result1 error) {
+	fake.deletePolicyMutex.Lock()
+	defer fake.deletePolicyMutex.Unlock()
+	fake.DeletePolicyStub = nil
+	if fake.deletePolicyReturnsOnCall == nil {
+		fake.deletePolicyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deletePolicyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) GetPolicies(arg1 context.Context, arg2 *bool, arg3 *string) ([]*storage.Policy, error) {
+	fake.getPoliciesMutex.Lock()
+	ret, specificReturn := fake.getPoliciesReturnsOnCall[len(fake.getPoliciesArgsForCall)]
+	fake.getPoliciesArgsForCall = append(fake.getPoliciesArgsForCall, struct {
+		arg1 context.Context
+		arg2 *bool
+		arg3 *string
+	}{arg1, arg2, arg3})
+	stub := fake.GetPoliciesStub
+	fakeReturns := fake.getPoliciesReturns
+	fake.recordInvocation("GetPolicies", []interface{}{arg1, arg2, arg3})
+	fake.getPoliciesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStorage) GetPoliciesCallCount() int {
+	fake.getPoliciesMutex.RLock()
+	defer fake.getPoliciesMutex.RUnlock()
+	return len(fake.getPoliciesArgsForCall)
+}
+
+func (fake *FakeStorage) GetPoliciesCalls(stub func(context.Context, *bool, *string) ([]*storage.Policy, error)) {
+	fake.getPoliciesMutex.Lock()
+	defer fake.getPoliciesMutex.Unlock()
+	fake.GetPoliciesStub = stub
+}
+
+func (fake *FakeStorage) GetPoliciesArgsForCall(i int) (context.Context, *bool, *string) {
+	fake.getPoliciesMutex.RLock()
+	defer fake.getPoliciesMutex.RUnlock()
+	argsForCall := fake.getPoliciesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStorage) GetPoliciesReturns(result1 []*storage.Policy, result2 error) {
+	fake.getPoliciesMutex.Lock()
+	defer fake.getPoliciesMutex.Unlock()
+	fake.GetPoliciesStub = nil
+	fake.getPoliciesReturns = struct {
+		result1 []*storage.Policy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) GetPoliciesReturnsOnCall(i int, result1 []*storage.Policy, result2 error) {
+	fake.getPoliciesMutex.Lock()
+	defer fake.getPoliciesMutex.Unlock()
+	fake.GetPoliciesStub = nil
+	if fake.getPoliciesReturnsOnCall == nil {
+		fake.getPoliciesReturnsOnCall = make(map[int]struct {
+			result1 []*storage.Policy
+			result2 error
+		})
+	}
+	fake.getPoliciesReturnsOnCall[i] = struct {
+		result1 []*storage.Policy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) SavePolicy(arg1 context.Context, arg2 *storage.Policy) error {
+	fake.savePolicyMutex.Lock()
+	ret, specificReturn := fake.savePolicyReturnsOnCall[len(fake.savePolicyArgsForCall)]
+	fake.savePolicyArgsForCall = append(fake.savePolicyArgsForCall, struct {
+		arg1 context.Context
+		arg2 *storage.Policy
+	}{arg1, arg2})
+	stub := fake.SavePolicyStub
+	fakeReturns := fake.savePolicyReturns
+	fake.recordInvocation("SavePolicy", []interface{}{arg1, arg2})
+	fake.savePolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStorage) SavePolicyCallCount() int {
+	fake.savePolicyMutex.RLock()
+	defer fake.savePolicyMutex.RUnlock()
+	return len(fake.savePolicyArgsForCall)
+}
+
+func (fake *FakeStorage) SavePolicyCalls(stub func(context.Context, *storage.Policy) error) {
+	fake.savePolicyMutex.Lock()
+	defer fake.savePolicyMutex.Unlock()
+	fake.SavePolicyStub = stub
+}
+
+func (fake *FakeStorage) SavePolicyArgsForCall(i int) (context.Context, *storage.Policy) {
+	fake.savePolicyMutex.RLock()
+	defer fake.savePolicyMutex.RUnlock()
+	argsForCall := fake.savePolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStorage) SavePolicyReturns(result1 error) {
+	fake.savePolicyMutex.Lock()
+	defer fake.savePolicyMutex.Unlock()
+	fake.SavePolicyStub = nil
+	fake.savePolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) SavePolicyReturnsOnCall(i int, result1 error) {
+	fake.savePolicyMutex.Lock()
+	defer fake.savePolicyMutex.Unlock()
+	fake.SavePolicyStub = nil
+	if fake.savePolicyReturnsOnCall == nil {
+		fake.savePolicyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.savePolicyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) SetPolicyLock(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string, arg6 *storage.PolicyLock) error {
+	fake.setPolicyLockMutex.Lock()
+	ret, specificReturn := fake.setPolicyLockReturnsOnCall[len(fake.setPolicyLockArgsForCall)]
+	fake.setPolicyLockArgsForCall = append(fake.setPolicyLockArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 *storage.PolicyLock
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.SetPolicyLockStub
+	fakeReturns := fake.setPolicyLockReturns
+	fake.recordInvocation("SetPolicyLock", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.setPolicyLockMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStorage) SetPolicyLockCallCount() int {
+	fake.setPolicyLockMutex.RLock()
+	defer fake.setPolicyLockMutex.RUnlock()
+	return len(fake.setPolicyLockArgsForCall)
+}
+
+func (fake *FakeStorage) SetPolicyLockCalls(stub func(context.Context, string, string, string, string, *storage.PolicyLock) error) {
+	fake.setPolicyLockMutex.Lock()
+	defer fake.setPolicyLockMutex.Unlock()
+	fake.SetPolicyLockStub = stub
+}
+
+func (fake *FakeStorage) SetPolicyLockArgsForCall(i int) (context.Context, string, string, string, string, *storage.PolicyLock) {
+	fake.setPolicyLockMutex.RLock()
+	defer fake.setPolicyLockMutex.RUnlock()
+	argsForCall := fake.setPolicyLockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *FakeStorage) SetPolicyLockReturns(result1 error) {
+	fake.setPolicyLockMutex.Lock()
+	defer fake.setPolicyLockMutex.Unlock()
+	fake.SetPolicyLockStub = nil
+	fake.setPolicyLockReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) SetPolicyLockReturnsOnCall(i int, result1 error) {
+	fake.setPolicyLockMutex.Lock()
+	defer fake.setPolicyLockMutex.Unlock()
+	fake.SetPolicyLockStub = nil
+	if fake.setPolicyLockReturnsOnCall == nil {
+		fake.setPolicyLockReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setPolicyLockReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.deletePolicyMutex.RLock()
+	defer fake.deletePolicyMutex.RUnlock()
+	fake.getPoliciesMutex.RLock()
+	defer fake.getPoliciesMutex.RUnlock()
+	fake.savePolicyMutex.RLock()
+	defer fake.savePolicyMutex.RUnlock()
+	fake.setPolicyLockMutex.RLock()
+	defer fake.setPolicyLockMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeStorage) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ gitsync.Storage = new(FakeStorage)
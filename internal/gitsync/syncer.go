@@ -0,0 +1,303 @@
+// Package gitsync provides a syncer which periodically pulls the policy
+// Git repository and applies new, modified and removed policies to the
+// policy storage. It's used with the memory storage, which otherwise loads
+// policies from the repository only once on service start-up.
+package gitsync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/clone"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+//go:generate counterfeiter . Cloner
+//go:generate counterfeiter . Storage
+
+// Actions for policies which are removed from the repository.
+const (
+	OnRemoveKeep    = "keep"
+	OnRemoveDelete  = "delete"
+	OnRemoveLock    = "lock"
+	OnRemoveArchive = "archive"
+)
+
+type Cloner interface {
+	Pull(ctx context.Context, cloneURL, branch string, auth *clone.Auth) (string, error)
+	IterateRepo(repoFolder, repository string) (map[string]*storage.Policy, error)
+	IterateChanges(repoFolder, repository, since string) (map[string]*storage.Policy, []string, error)
+	Commit() (*storage.Commit, error)
+	VerifyCommit(keyring *clone.Keyring) (string, error)
+	ConstructKey(repo, group, name, version string) string
+	Cleanup() error
+}
+
+type Storage interface {
+	GetPolicies(ctx context.Context, locked *bool, policyName *string) ([]*storage.Policy, error)
+	SavePolicy(ctx context.Context, policy *storage.Policy) error
+	DeletePolicy(ctx context.Context, repository, group, name, version string) error
+	SetPolicyLock(ctx context.Context, repository, group, name, version string, lock *storage.PolicyLock) error
+}
+
+// Repository contains the location and credentials of the policy repository.
+type Repository struct {
+	URL    string
+	User   string
+	Pass   string
+	Branch string
+	Folder string
+
+	// Keyring contains the keys trusted to sign the repository commits.
+	// If it's nil, commit signatures are not verified.
+	Keyring *clone.Keyring
+
+	// OnRemove defines what happens with policies in storage, which are
+	// removed from the repository: keep, delete, lock or archive.
+	// Policies are kept if it's empty.
+	OnRemove string
+}
+
+// Syncer periodically pulls the policy repository and saves in storage
+// the policies which are new or modified compared to the policies in
+// storage. Changes in storage notify the storage subscribers (e.g. regocache)
+// about the change. Policies removed from the repository are kept, deleted,
+// locked or archived in storage depending on the OnRemove action.
+//
+// The clone of the repository is kept between syncs, so that only the new
+// commits are fetched and only the policies changed since the last synced
+// commit are compared.
+type Syncer struct {
+	repo     Repository
+	cloner   Cloner
+	storage  Storage
+	interval time.Duration
+	logger   *zap.Logger
+
+	// commit is the last commit synced successfully.
+	commit string
+}
+
+// New creates a syncer for the given repository.
+func New(repo Repository, cloner Cloner, s Storage, interval time.Duration, logger *zap.Logger) (*Syncer, error) {
+	switch repo.OnRemove {
+	case "":
+		repo.OnRemove = OnRemoveKeep
+	case OnRemoveKeep, OnRemoveDelete, OnRemoveLock, OnRemoveArchive:
+	default:
+		return nil, errors.New(errors.BadRequest, fmt.Sprintf("invalid action for removed policies: %q", repo.OnRemove))
+	}
+
+	return &Syncer{
+		repo:     repo,
+		cloner:   cloner,
+		storage:  s,
+		interval: interval,
+		logger:   logger.With(zap.String("repository", repo.URL)),
+	}, nil
+}
+
+// Start syncs the policies on every interval until the context is cancelled.
+func (s *Syncer) Start(ctx context.Context) error {
+	s.logger.Info("syncing policies from repository", zap.Duration("interval", s.interval))
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := s.Sync(ctx); err != nil {
+				s.logger.Error("error syncing policies from repository", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Sync pulls the policy repository and saves in storage the policies
+// which are new or modified. The lock state of policies already in
+// storage is preserved. Policies removed from the repository are
+// handled with the OnRemove action.
+//
+// If the commit synced last time is available in the clone, only the
+// policies changed since that commit are compared. Otherwise, all
+// policies of the repository are compared with the policies in storage.
+func (s *Syncer) Sync(ctx context.Context) error {
+	auth := &clone.Auth{User: s.repo.User, Pass: s.repo.Pass}
+	repository, err := s.cloner.Pull(ctx, s.repo.URL, s.repo.Branch, auth)
+	if err != nil {
+		// the repository is cloned again on the next sync
+		s.cloner.Cleanup() //nolint:errcheck
+		return errors.New("error pulling repository", err)
+	}
+
+	commit, err := s.cloner.Commit()
+	if err != nil {
+		return errors.New("error getting repository commit", err)
+	}
+
+	if commit.SHA == s.commit {
+		s.logger.Debug("repository is not changed since the last sync", zap.String("commit", commit.SHA))
+		return nil
+	}
+
+	// refuse policies from commits which are not signed by a trusted key
+	if s.repo.Keyring != nil {
+		signer, err := s.cloner.VerifyCommit(s.repo.Keyring)
+		if err != nil {
+			return errors.New(errors.Forbidden, "refusing to sync policies", err)
+		}
+		s.logger.Debug("repository commit is verified", zap.String("commit", commit.SHA), zap.String("signer", signer))
+	}
+
+	var (
+		repoPolicies map[string]*storage.Policy
+		changed      map[string]bool
+	)
+	if s.commit != "" {
+		var filenames []string
+		repoPolicies, filenames, err = s.cloner.IterateChanges(s.repo.Folder, repository, s.commit)
+		if err != nil {
+			s.logger.Debug("comparing all policies of repository", zap.String("since", s.commit), zap.Error(err))
+		} else {
+			changed = make(map[string]bool, len(filenames))
+			for _, filename := range filenames {
+				changed[filename] = true
+			}
+		}
+	}
+	if changed == nil {
+		repoPolicies, err = s.cloner.IterateRepo(s.repo.Folder, repository)
+		if err != nil {
+			return errors.New("error iterating repository", err)
+		}
+	}
+
+	policies, err := s.storage.GetPolicies(ctx, nil, nil)
+	if err != nil {
+		return errors.New("error getting policies from storage", err)
+	}
+
+	// only the changed policies are compared in an incremental sync
+	currPolicies := make(map[string]*storage.Policy, len(policies))
+	for _, p := range policies {
+		if changed != nil && !changed[p.Filename] {
+			continue
+		}
+		currPolicies[s.cloner.ConstructKey(p.Repository, p.Group, p.Name, p.Version)] = p
+	}
+
+	ctx = storage.WithRevisionSource(ctx, storage.RevisionSourceSync, "")
+
+	var updated, failed int
+	for key, p := range repoPolicies {
+		p.Commit = commit
+
+		if curr, ok := currPolicies[key]; ok {
+			if equal(curr, p) && curr.Commit != nil && !curr.Archived {
+				continue
+			}
+			// keep the lock state of policies changed through the API
+			p.Locked = curr.Locked
+			p.Lock = curr.Lock
+		}
+
+		logger := policyLogger(s.logger, p)
+
+		p.LastUpdate = time.Now()
+		if err := s.storage.SavePolicy(ctx, p); err != nil {
+			logger.Error("error saving policy", zap.Error(err))
+			failed++
+			continue
+		}
+
+		updated++
+		logger.Info("policy is synced from repository")
+	}
+
+	removed, removeFailed := s.remove(ctx, repository, currPolicies, repoPolicies)
+	failed += removeFailed
+
+	s.logger.Debug("policies are synced from repository", zap.Int("updated", updated), zap.Int("removed", removed))
+
+	// policies which failed are compared again on the next sync
+	if failed == 0 {
+		s.commit = commit.SHA
+	}
+
+	return nil
+}
+
+// remove deletes, locks or archives the policies of the repository which are
+// in storage, but are removed from the repository. Policies which are already
+// locked or archived are skipped for the lock and archive actions. It returns
+// the number of removed policies and the number of policies which failed.
+func (s *Syncer) remove(ctx context.Context, repository string, currPolicies, repoPolicies map[string]*storage.Policy) (int, int) {
+	if s.repo.OnRemove == OnRemoveKeep {
+		return 0, 0
+	}
+
+	var removed, failed int
+	for key, p := range currPolicies {
+		if _, ok := repoPolicies[key]; ok || p.Repository != repository {
+			continue
+		}
+
+		var err error
+		switch s.repo.OnRemove {
+		case OnRemoveDelete:
+			err = s.storage.DeletePolicy(ctx, p.Repository, p.Group, p.Name, p.Version)
+		case OnRemoveLock:
+			if p.Locked {
+				continue
+			}
+			lock := &storage.PolicyLock{Reason: "policy is removed from the repository", Actor: "sync", LockedAt: time.Now()}
+			err = s.storage.SetPolicyLock(ctx, p.Repository, p.Group, p.Name, p.Version, lock)
+		case OnRemoveArchive:
+			if p.Archived {
+				continue
+			}
+			archived := *p
+			archived.Archived = true
+			archived.LastUpdate = time.Now()
+			err = s.storage.SavePolicy(ctx, &archived)
+		}
+
+		logger := policyLogger(s.logger, p)
+		if err != nil {
+			logger.Error("error removing policy", zap.String("action", s.repo.OnRemove), zap.Error(err))
+			failed++
+			continue
+		}
+
+		removed++
+		logger.Info("policy is removed from repository", zap.String("action", s.repo.OnRemove))
+	}
+
+	return removed, failed
+}
+
+func policyLogger(logger *zap.Logger, p *storage.Policy) *zap.Logger {
+	return logger.With(
+		zap.String("policyRepository", p.Repository),
+		zap.String("policyGroup", p.Group),
+		zap.String("policyName", p.Name),
+		zap.String("policyVersion", p.Version),
+	)
+}
+
+// equal compares the policy files content.
+func equal(p1, p2 *storage.Policy) bool {
+	return p1.Rego == p2.Rego &&
+		p1.Data == p2.Data &&
+		p1.DataConfig == p2.DataConfig &&
+		p1.OutputSchema == p2.OutputSchema &&
+		p1.ExportConfig == p2.ExportConfig &&
+		p1.Filename == p2.Filename
+}
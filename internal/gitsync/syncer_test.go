@@ -0,0 +1,359 @@
+package gitsync_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/clone"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/gitsync"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/gitsync/gitsyncfakes"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+var testCommit = &storage.Commit{SHA: "0123abcd", Author: "Author <author@example.com>"}
+
+func testPolicy(rego string, commit *storage.Commit) *storage.Policy {
+	return namedPolicy("test", rego, commit)
+}
+
+func namedPolicy(name, rego string, commit *storage.Commit) *storage.Policy {
+	return &storage.Policy{
+		Repository: "policies",
+		Group:      "example",
+		Name:       name,
+		Version:    "1.0",
+		Filename:   "example/" + name + "/1.0/policy.rego",
+		Rego:       rego,
+		Commit:     commit,
+	}
+}
+
+func policyMap(policies ...*storage.Policy) map[string]*storage.Policy {
+	res := make(map[string]*storage.Policy)
+	for _, p := range policies {
+		cpy := *p
+		res[p.Repository+p.Group+p.Name+p.Version] = &cpy
+	}
+	return res
+}
+
+func newCloner(policies ...*storage.Policy) *gitsyncfakes.FakeCloner {
+	return &gitsyncfakes.FakeCloner{
+		PullStub: func(ctx context.Context, s string, s2 string, auth *clone.Auth) (string, error) {
+			return "policies", nil
+		},
+		IterateRepoStub: func(s string, s2 string) (map[string]*storage.Policy, error) {
+			return policyMap(policies...), nil
+		},
+		CommitStub: func() (*storage.Commit, error) {
+			return testCommit, nil
+		},
+		ConstructKeyStub: func(repo string, group string, name string, version string) string {
+			return repo + group + name + version
+		},
+	}
+}
+
+func stored(policies ...*storage.Policy) *gitsyncfakes.FakeStorage {
+	fake := &gitsyncfakes.FakeStorage{}
+	fake.GetPoliciesReturns(policies, nil)
+	return fake
+}
+
+func TestNew(t *testing.T) {
+	_, err := gitsync.New(gitsync.Repository{OnRemove: "purge"}, newCloner(), stored(), time.Minute, zap.NewNop())
+	assert.True(t, errors.Is(errors.BadRequest, err))
+	assert.ErrorContains(t, err, `invalid action for removed policies: "purge"`)
+
+	for _, onRemove := range []string{"", gitsync.OnRemoveKeep, gitsync.OnRemoveDelete, gitsync.OnRemoveLock, gitsync.OnRemoveArchive} {
+		_, err := gitsync.New(gitsync.Repository{OnRemove: onRemove}, newCloner(), stored(), time.Minute, zap.NewNop())
+		assert.NoError(t, err)
+	}
+}
+
+func TestSyncer_Sync(t *testing.T) {
+	locked := func(p *storage.Policy) *storage.Policy {
+		p.Locked = true
+		return p
+	}
+	archived := func(p *storage.Policy) *storage.Policy {
+		p.Archived = true
+		return p
+	}
+	otherRepository := func(p *storage.Policy) *storage.Policy {
+		p.Repository = "other"
+		return p
+	}
+
+	tests := []struct {
+		name     string
+		keyring  *clone.Keyring
+		onRemove string
+		cloner   *gitsyncfakes.FakeCloner
+		storage  *gitsyncfakes.FakeStorage
+
+		saved   []*storage.Policy
+		deleted []string
+		locked  []string
+		errtext string
+	}{
+		{
+			name: "error pulling repository",
+			cloner: &gitsyncfakes.FakeCloner{
+				PullStub: func(ctx context.Context, s string, s2 string, auth *clone.Auth) (string, error) {
+					return "", errors.New("some error")
+				},
+			},
+			storage: &gitsyncfakes.FakeStorage{},
+			errtext: "error pulling repository",
+		},
+		{
+			name:    "commit is not signed by a trusted key",
+			keyring: &clone.Keyring{},
+			cloner: func() *gitsyncfakes.FakeCloner {
+				cloner := newCloner(testPolicy("package example.test", nil))
+				cloner.VerifyCommitReturns("", errors.New("commit 0123abcd is not signed"))
+				return cloner
+			}(),
+			storage: &gitsyncfakes.FakeStorage{},
+			errtext: "refusing to sync policies",
+		},
+		{
+			name:    "commit is signed by a trusted key",
+			keyring: &clone.Keyring{},
+			cloner: func() *gitsyncfakes.FakeCloner {
+				cloner := newCloner(testPolicy("package example.test", nil))
+				cloner.VerifyCommitReturns("Maintainer <maintainer@example.com>", nil)
+				return cloner
+			}(),
+			storage: &gitsyncfakes.FakeStorage{},
+			saved:   []*storage.Policy{testPolicy("package example.test", testCommit)},
+		},
+		{
+			name:   "error getting policies from storage",
+			cloner: newCloner(testPolicy("package example.test", nil)),
+			storage: &gitsyncfakes.FakeStorage{
+				GetPoliciesStub: func(ctx context.Context, b *bool, s *string) ([]*storage.Policy, error) {
+					return nil, errors.New("some error")
+				},
+			},
+			errtext: "error getting policies from storage",
+		},
+		{
+			name:    "policy is not changed",
+			cloner:  newCloner(testPolicy("package example.test", nil)),
+			storage: stored(testPolicy("package example.test", testCommit)),
+		},
+		{
+			name:    "policy is added to repository",
+			cloner:  newCloner(testPolicy("package example.test", nil)),
+			storage: &gitsyncfakes.FakeStorage{},
+			saved:   []*storage.Policy{testPolicy("package example.test", testCommit)},
+		},
+		{
+			name:    "policy is modified and keeps its lock state",
+			cloner:  newCloner(testPolicy("package example.test\n\nallow := true", nil)),
+			storage: stored(locked(testPolicy("package example.test", testCommit))),
+			saved:   []*storage.Policy{locked(testPolicy("package example.test\n\nallow := true", testCommit))},
+		},
+		{
+			name:    "policy commit is not recorded yet",
+			cloner:  newCloner(testPolicy("package example.test", nil)),
+			storage: stored(testPolicy("package example.test", nil)),
+			saved:   []*storage.Policy{testPolicy("package example.test", testCommit)},
+		},
+		{
+			name:     "archived policy is added to repository again",
+			onRemove: gitsync.OnRemoveArchive,
+			cloner:   newCloner(testPolicy("package example.test", nil)),
+			storage:  stored(archived(testPolicy("package example.test", testCommit))),
+			saved:    []*storage.Policy{testPolicy("package example.test", testCommit)},
+		},
+		{
+			name:     "removed policy is kept",
+			onRemove: gitsync.OnRemoveKeep,
+			cloner:   newCloner(),
+			storage:  stored(testPolicy("package example.test", testCommit)),
+		},
+		{
+			name:     "removed policy is deleted",
+			onRemove: gitsync.OnRemoveDelete,
+			cloner:   newCloner(testPolicy("package example.test", nil)),
+			storage: stored(
+				testPolicy("package example.test", testCommit),
+				namedPolicy("removed", "package example.removed", testCommit),
+				otherRepository(namedPolicy("other", "package example.other", testCommit)),
+			),
+			deleted: []string{"removed"},
+		},
+		{
+			name:     "removed policy is locked unless it's locked already",
+			onRemove: gitsync.OnRemoveLock,
+			cloner:   newCloner(),
+			storage: stored(
+				namedPolicy("removed", "package example.removed", testCommit),
+				locked(namedPolicy("locked", "package example.locked", testCommit)),
+			),
+			locked: []string{"removed"},
+		},
+		{
+			name:     "removed policy is archived unless it's archived already",
+			onRemove: gitsync.OnRemoveArchive,
+			cloner:   newCloner(),
+			storage: stored(
+				namedPolicy("removed", "package example.removed", testCommit),
+				archived(namedPolicy("archived", "package example.archived", testCommit)),
+			),
+			saved: []*storage.Policy{archived(namedPolicy("removed", "package example.removed", testCommit))},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repo := gitsync.Repository{URL: "https://example.com/policies.git", Keyring: test.keyring, OnRemove: test.onRemove}
+			syncer, err := gitsync.New(repo, test.cloner, test.storage, time.Minute, zap.NewNop())
+			require.NoError(t, err)
+
+			err = syncer.Sync(context.Background())
+			if test.errtext != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.errtext)
+				assert.Equal(t, 0, test.storage.SavePolicyCallCount())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, len(test.saved), test.storage.SavePolicyCallCount())
+			for i, expected := range test.saved {
+				ctx, saved := test.storage.SavePolicyArgsForCall(i)
+				assert.False(t, saved.LastUpdate.IsZero())
+				saved.LastUpdate = time.Time{}
+				assert.Equal(t, expected, saved)
+
+				source, _ := storage.RevisionSource(ctx, storage.RevisionSourceAPI)
+				assert.Equal(t, storage.RevisionSourceSync, source)
+			}
+
+			require.Equal(t, len(test.deleted), test.storage.DeletePolicyCallCount())
+			for i, name := range test.deleted {
+				_, repository, group, policyName, version := test.storage.DeletePolicyArgsForCall(i)
+				assert.Equal(t, []string{"policies", "example", name, "1.0"}, []string{repository, group, policyName, version})
+			}
+
+			require.Equal(t, len(test.locked), test.storage.SetPolicyLockCallCount())
+			for i, name := range test.locked {
+				_, _, _, policyName, _, lock := test.storage.SetPolicyLockArgsForCall(i)
+				assert.Equal(t, name, policyName)
+				require.NotNil(t, lock)
+				assert.Equal(t, "sync", lock.Actor)
+				assert.Equal(t, "policy is removed from the repository", lock.Reason)
+			}
+
+			// the clone is kept for the next sync
+			assert.Equal(t, 0, test.cloner.CleanupCallCount())
+		})
+	}
+
+	t.Run("clone is removed after an error pulling repository", func(t *testing.T) {
+		cloner := newCloner()
+		cloner.PullReturns("", errors.New("some error"))
+		syncer, err := gitsync.New(gitsync.Repository{}, cloner, stored(), time.Minute, zap.NewNop())
+		require.NoError(t, err)
+
+		assert.Error(t, syncer.Sync(context.Background()))
+		assert.Equal(t, 1, cloner.CleanupCallCount())
+	})
+}
+
+func TestSyncer_SyncIncremental(t *testing.T) {
+	nextCommit := &storage.Commit{SHA: "4567cdef"}
+	commits := []*storage.Commit{testCommit, testCommit, nextCommit}
+
+	cloner := newCloner(testPolicy("package example.test", nil), namedPolicy("removed", "package example.removed", nil))
+	cloner.CommitStub = func() (*storage.Commit, error) {
+		return commits[cloner.CommitCallCount()-1], nil
+	}
+	cloner.IterateChangesReturns(
+		policyMap(testPolicy("package example.test\n\nallow := true", nil)),
+		[]string{"example/test/1.0/policy.rego", "example/removed/1.0/policy.rego"},
+		nil,
+	)
+
+	storagePolicies := []*storage.Policy{
+		testPolicy("package example.test", testCommit),
+		namedPolicy("removed", "package example.removed", testCommit),
+		namedPolicy("unchanged", "package example.unchanged", testCommit),
+	}
+	store := stored(storagePolicies...)
+
+	syncer, err := gitsync.New(gitsync.Repository{Folder: "policies", OnRemove: gitsync.OnRemoveDelete}, cloner, store, time.Minute, zap.NewNop())
+	require.NoError(t, err)
+
+	// the first sync compares all policies
+	require.NoError(t, syncer.Sync(context.Background()))
+	assert.Equal(t, 1, cloner.IterateRepoCallCount())
+	assert.Equal(t, 0, cloner.IterateChangesCallCount())
+	assert.Equal(t, 0, store.SavePolicyCallCount())
+	// unchanged is not in the repository, but removed policies are
+	// compared with all policies of the repository only on a full sync
+	require.Equal(t, 1, store.DeletePolicyCallCount())
+	_, _, _, name, _ := store.DeletePolicyArgsForCall(0)
+	assert.Equal(t, "unchanged", name)
+
+	// the repository is not changed since the synced commit
+	require.NoError(t, syncer.Sync(context.Background()))
+	assert.Equal(t, 1, cloner.IterateRepoCallCount())
+	assert.Equal(t, 0, cloner.IterateChangesCallCount())
+	assert.Equal(t, 1, store.GetPoliciesCallCount())
+
+	// only the policies changed since the synced commit are compared
+	require.NoError(t, syncer.Sync(context.Background()))
+	assert.Equal(t, 1, cloner.IterateRepoCallCount())
+	require.Equal(t, 1, cloner.IterateChangesCallCount())
+	folder, repository, since := cloner.IterateChangesArgsForCall(0)
+	assert.Equal(t, []string{"policies", "policies", testCommit.SHA}, []string{folder, repository, since})
+
+	require.Equal(t, 1, store.SavePolicyCallCount())
+	_, saved := store.SavePolicyArgsForCall(0)
+	assert.Equal(t, "package example.test\n\nallow := true", saved.Rego)
+	assert.Equal(t, nextCommit, saved.Commit)
+
+	require.Equal(t, 2, store.DeletePolicyCallCount())
+	_, _, _, name, _ = store.DeletePolicyArgsForCall(1)
+	assert.Equal(t, "removed", name)
+}
+
+func TestSyncer_SyncFallback(t *testing.T) {
+	commits := []*storage.Commit{testCommit, {SHA: "4567cdef"}, {SHA: "89abcdef"}}
+
+	cloner := newCloner(testPolicy("package example.test", nil))
+	cloner.CommitStub = func() (*storage.Commit, error) {
+		return commits[cloner.CommitCallCount()-1], nil
+	}
+	cloner.IterateChangesReturns(nil, nil, errors.New("commit 0123abcd is not available"))
+
+	store := stored()
+	// the policy fails to save on the first sync
+	store.SavePolicyReturnsOnCall(0, errors.New("some error"))
+
+	syncer, err := gitsync.New(gitsync.Repository{}, cloner, store, time.Minute, zap.NewNop())
+	require.NoError(t, err)
+
+	// a policy which failed is compared again on the next sync
+	require.NoError(t, syncer.Sync(context.Background()))
+	require.NoError(t, syncer.Sync(context.Background()))
+	assert.Equal(t, 2, cloner.IterateRepoCallCount())
+	assert.Equal(t, 0, cloner.IterateChangesCallCount())
+	assert.Equal(t, 2, store.SavePolicyCallCount())
+
+	// all policies are compared if the synced commit is not available
+	require.NoError(t, syncer.Sync(context.Background()))
+	assert.Equal(t, 1, cloner.IterateChangesCallCount())
+	assert.Equal(t, 3, cloner.IterateRepoCallCount())
+}
@@ -0,0 +1,147 @@
+package grpcserver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// HTTPMiddleware adapts a standard HTTP server middleware to a gRPC
+// unary server interceptor, so that the same middlewares (authentication,
+// IP filtering, headers propagation) used by the HTTP transport can be
+// used by the gRPC transport.
+//
+// The incoming gRPC metadata is converted to HTTP request headers and
+// the peer address is set as request remote address. If the middleware
+// calls the next handler, the gRPC call proceeds with the (possibly modified)
+// request context. Otherwise the call is rejected with a gRPC status code
+// corresponding to the HTTP status code written by the middleware.
+//
+// If methods are given, the middleware is applied only to them. Methods
+// are specified with their full gRPC names, e.g. pb.PolicyService_Evaluate_FullMethodName.
+func HTTPMiddleware(middleware func(http.Handler) http.Handler, methods ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if len(methods) > 0 && !contains(methods, info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		var (
+			called bool
+			resp   interface{}
+			err    error
+		)
+
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			resp, err = handler(r.Context(), req)
+		})
+
+		rec := &responseRecorder{header: http.Header{}, status: http.StatusOK}
+		middleware(next).ServeHTTP(rec, httpRequest(ctx, info.FullMethod))
+
+		if !called {
+			msg := strings.TrimSpace(rec.body.String())
+			if msg == "" {
+				msg = http.StatusText(rec.status)
+			}
+			return nil, status.Error(codeFromHTTPStatus(rec.status), msg)
+		}
+
+		return resp, err
+	}
+}
+
+// httpRequest creates an HTTP request from the incoming gRPC call.
+func httpRequest(ctx context.Context, method string) *http.Request {
+	r, _ := http.NewRequestWithContext(ctx, http.MethodPost, method, nil)
+	r.RequestURI = method
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for name, values := range md {
+			if name == ":authority" {
+				if len(values) > 0 {
+					r.Host = values[0]
+				}
+				continue
+			}
+			for _, v := range values {
+				r.Header.Add(name, v)
+			}
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		if p.Addr != nil {
+			r.RemoteAddr = p.Addr.String()
+		}
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			r.TLS = &tlsInfo.State
+		}
+	}
+
+	return r
+}
+
+// responseRecorder captures the response of an HTTP middleware
+// which doesn't call the next handler.
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   strings.Builder
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+}
+
+// codeFromHTTPStatus maps HTTP status codes to gRPC status codes.
+func codeFromHTTPStatus(status int) codes.Code {
+	switch status {
+	case http.StatusOK:
+		return codes.OK
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusInternalServerError:
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package grpcserver_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/grpcserver"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/grpcserver/pb"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/header"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/request"
+)
+
+func TestHTTPMiddleware(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"authorization", "Bearer my-token",
+		":authority", "example.com",
+	))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234}})
+
+	evaluateInfo := &grpc.UnaryServerInfo{FullMethod: pb.PolicyService_Evaluate_FullMethodName}
+	lockInfo := &grpc.UnaryServerInfo{FullMethod: pb.PolicyService_Lock_FullMethodName}
+
+	t.Run("request is passed to the middleware", func(t *testing.T) {
+		middleware := func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "Bearer my-token", r.Header.Get("Authorization"))
+				assert.Equal(t, "example.com", r.Host)
+				assert.Equal(t, "10.0.0.1:1234", r.RemoteAddr)
+				next.ServeHTTP(w, r)
+			})
+		}
+
+		res, err := grpcserver.HTTPMiddleware(middleware)(ctx, "req", evaluateInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "res", nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "res", res)
+	})
+
+	t.Run("context values are passed to the handler", func(t *testing.T) {
+		expected := map[string]string{"Authorization": "Bearer my-token", "Host": "example.com"}
+
+		_, err := grpcserver.HTTPMiddleware(header.Middleware())(ctx, "req", evaluateInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+			value, ok := header.FromContext(ctx)
+			assert.True(t, ok)
+			assert.Equal(t, expected, value)
+			return nil, nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("client certificate is passed to the middleware", func(t *testing.T) {
+		tlsCtx := peer.NewContext(ctx, &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234},
+			AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{
+				{Subject: pkix.Name{CommonName: "client"}},
+			}}},
+		})
+
+		_, err := grpcserver.HTTPMiddleware(request.Middleware())(tlsCtx, "req", evaluateInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+			value, ok := request.FromContext(ctx)
+			assert.True(t, ok)
+			assert.Equal(t, "CN=client", value.ClientCertSubject)
+			assert.Equal(t, pb.PolicyService_Evaluate_FullMethodName, value.Path)
+			return nil, nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("request is rejected by the middleware", func(t *testing.T) {
+		middleware := func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "invalid authorization header", http.StatusUnauthorized)
+			})
+		}
+
+		res, err := grpcserver.HTTPMiddleware(middleware)(ctx, "req", evaluateInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+			assert.Fail(t, "handler must not be called")
+			return nil, nil
+		})
+		assert.Nil(t, res)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.Equal(t, "invalid authorization header", status.Convert(err).Message())
+	})
+
+	t.Run("middleware is applied only to the given methods", func(t *testing.T) {
+		middleware := func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			})
+		}
+		interceptor := grpcserver.HTTPMiddleware(middleware, pb.PolicyService_Evaluate_FullMethodName)
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "res", nil
+		}
+
+		res, err := interceptor(ctx, "req", evaluateInfo, handler)
+		assert.Nil(t, res)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Equal(t, "Forbidden", status.Convert(err).Message())
+
+		res, err = interceptor(ctx, "req", lockInfo, handler)
+		require.NoError(t, err)
+		assert.Equal(t, "res", res)
+	})
+}
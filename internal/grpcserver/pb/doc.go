@@ -0,0 +1,5 @@
+// Package pb contains the protocol buffer definitions and the generated
+// gRPC code of the policy service gRPC transport.
+package pb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative policy.proto
@@ -0,0 +1,55 @@
+package grpcserver_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	goapolicy "gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/policy"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/grpcserver/pb"
+)
+
+// TestProto_MatchesDesign checks that the protobuf messages have the same
+// fields as the Goa types generated from the design, so that fields added
+// to the design are not forgotten in policy.proto.
+func TestProto_MatchesDesign(t *testing.T) {
+	tests := []struct {
+		message proto.Message
+		goaType interface{}
+	}{
+		{message: &pb.EvaluateRequest{}, goaType: goapolicy.EvaluateRequest{}},
+		{message: &pb.EvaluateResponse{}, goaType: goapolicy.EvaluateResult{}},
+		{message: &pb.LockRequest{}, goaType: goapolicy.LockRequest{}},
+		{message: &pb.UnlockRequest{}, goaType: goapolicy.UnlockRequest{}},
+		{message: &pb.ListPoliciesRequest{}, goaType: goapolicy.PoliciesRequest{}},
+		{message: &pb.ListPoliciesResponse{}, goaType: goapolicy.PoliciesResult{}},
+		{message: &pb.Policy{}, goaType: goapolicy.Policy{}},
+		{message: &pb.PolicyLock{}, goaType: goapolicy.PolicyLock{}},
+		{message: &pb.PolicyCommit{}, goaType: goapolicy.PolicyCommit{}},
+		{message: &pb.PolicyMetadata{}, goaType: goapolicy.PolicyMetadata{}},
+	}
+
+	for _, test := range tests {
+		descriptor := test.message.ProtoReflect().Descriptor()
+		t.Run(string(descriptor.Name()), func(t *testing.T) {
+			// field names are compared without case and underscores,
+			// e.g. policy_name in proto is PolicyName in Goa
+			var protoFields []string
+			fields := descriptor.Fields()
+			for i := 0; i < fields.Len(); i++ {
+				protoFields = append(protoFields, strings.ReplaceAll(string(fields.Get(i).Name()), "_", ""))
+			}
+
+			var goaFields []string
+			typ := reflect.TypeOf(test.goaType)
+			for i := 0; i < typ.NumField(); i++ {
+				goaFields = append(goaFields, strings.ToLower(typ.Field(i).Name))
+			}
+
+			assert.ElementsMatch(t, goaFields, protoFields)
+		})
+	}
+}
@@ -0,0 +1,287 @@
+// Package grpcserver implements the gRPC transport of the policy service.
+//
+// The gRPC methods are mapped to the same Goa endpoints which are used
+// by the HTTP transport, so that both transports share the exact same
+// service implementation and endpoint middlewares. The protobuf messages
+// are not generated by Goa, because its gRPC generator doesn't support
+// attributes of type Any, which are used for the policy input and result.
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	goapolicy "gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/policy"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/grpcserver/pb"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/service"
+)
+
+type Server struct {
+	pb.UnimplementedPolicyServiceServer
+
+	endpoints *goapolicy.Endpoints
+}
+
+// New creates a gRPC server implementation calling the given policy endpoints.
+func New(endpoints *goapolicy.Endpoints) *Server {
+	return &Server{endpoints: endpoints}
+}
+
+func (s *Server) Evaluate(ctx context.Context, req *pb.EvaluateRequest) (*pb.EvaluateResponse, error) {
+	ctx = endpointContext(ctx, "Evaluate")
+	res, err := s.endpoints.Evaluate(ctx, evaluateRequest(req))
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+
+	return evaluateResponse(res.(*goapolicy.EvaluateResult))
+}
+
+func (s *Server) Validate(ctx context.Context, req *pb.EvaluateRequest) (*pb.EvaluateResponse, error) {
+	ctx = endpointContext(ctx, "Validate")
+	res, err := s.endpoints.Validate(ctx, evaluateRequest(req))
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+
+	return evaluateResponse(res.(*goapolicy.EvaluateResult))
+}
+
+func (s *Server) Lock(ctx context.Context, req *pb.LockRequest) (*pb.LockResponse, error) {
+	ctx = endpointContext(ctx, "Lock")
+	_, err := s.endpoints.Lock(ctx, &goapolicy.LockRequest{
+		Repository: req.Repository,
+		Group:      req.Group,
+		PolicyName: req.PolicyName,
+		Version:    req.Version,
+		Reason:     req.Reason,
+		ExpiresAt:  req.ExpiresAt,
+	})
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+
+	return &pb.LockResponse{}, nil
+}
+
+func (s *Server) Unlock(ctx context.Context, req *pb.UnlockRequest) (*pb.UnlockResponse, error) {
+	ctx = endpointContext(ctx, "Unlock")
+	_, err := s.endpoints.Unlock(ctx, &goapolicy.UnlockRequest{
+		Repository: req.Repository,
+		Group:      req.Group,
+		PolicyName: req.PolicyName,
+		Version:    req.Version,
+	})
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+
+	return &pb.UnlockResponse{}, nil
+}
+
+func (s *Server) ListPolicies(ctx context.Context, req *pb.ListPoliciesRequest) (*pb.ListPoliciesResponse, error) {
+	ctx = endpointContext(ctx, "ListPolicies")
+	payload := &goapolicy.PoliciesRequest{
+		Locked:       req.Locked,
+		PolicyName:   req.PolicyName,
+		Rego:         &req.Rego,
+		Data:         &req.Data,
+		DataConfig:   &req.DataConfig,
+		Repository:   req.Repository,
+		Group:        req.Group,
+		Version:      req.Version,
+		UpdatedSince: req.UpdatedSince,
+		Sort:         req.Sort,
+		Order:        req.Order,
+		Cursor:       req.Cursor,
+		Annotation:   req.Annotation,
+	}
+	if payload.Sort == "" {
+		payload.Sort = "name"
+	}
+	if payload.Order == "" {
+		payload.Order = "asc"
+	}
+	if req.Limit != nil {
+		limit := int(*req.Limit)
+		payload.Limit = &limit
+	}
+
+	// the Goa HTTP transport validates the query parameters before calling the endpoint
+	if err := validatePoliciesRequest(payload); err != nil {
+		return nil, err
+	}
+
+	res, err := s.endpoints.ListPolicies(ctx, payload)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+
+	result := res.(*goapolicy.PoliciesResult)
+	policies := make([]*pb.Policy, 0, len(result.Policies))
+	for _, p := range result.Policies {
+		policy, err := policyMessage(p)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "error encoding policy: %v", err)
+		}
+		policies = append(policies, policy)
+	}
+
+	response := &pb.ListPoliciesResponse{Policies: policies}
+	if result.NextCursor != nil {
+		response.NextCursor = *result.NextCursor
+	}
+
+	return response, nil
+}
+
+func policyMessage(p *goapolicy.Policy) (*pb.Policy, error) {
+	res := &pb.Policy{
+		Repository:   p.Repository,
+		PolicyName:   p.PolicyName,
+		Group:        p.Group,
+		Version:      p.Version,
+		Rego:         p.Rego,
+		Data:         p.Data,
+		DataConfig:   p.DataConfig,
+		Locked:       p.Locked,
+		LastUpdate:   p.LastUpdate,
+		Lock:         policyLock(p.Lock),
+		Archived:     p.Archived,
+		OutputSchema: p.OutputSchema,
+		ExportConfig: p.ExportConfig,
+	}
+
+	if p.Commit != nil {
+		res.Commit = &pb.PolicyCommit{
+			Sha:    p.Commit.Sha,
+			Time:   p.Commit.Time,
+			Author: p.Commit.Author,
+			Branch: p.Commit.Branch,
+		}
+	}
+
+	if p.Metadata != nil {
+		res.Metadata = &pb.PolicyMetadata{
+			Title:            p.Metadata.Title,
+			Description:      p.Metadata.Description,
+			Authors:          p.Metadata.Authors,
+			Organizations:    p.Metadata.Organizations,
+			RelatedResources: p.Metadata.RelatedResources,
+		}
+		if p.Metadata.Custom != nil {
+			custom, err := newStruct(p.Metadata.Custom)
+			if err != nil {
+				return nil, err
+			}
+			res.Metadata.Custom = custom
+		}
+	}
+
+	return res, nil
+}
+
+func policyLock(l *goapolicy.PolicyLock) *pb.PolicyLock {
+	if l == nil {
+		return nil
+	}
+
+	res := &pb.PolicyLock{
+		LockedAt:  l.LockedAt,
+		ExpiresAt: l.ExpiresAt,
+	}
+	if l.Reason != nil {
+		res.Reason = *l.Reason
+	}
+	if l.LockedBy != nil {
+		res.LockedBy = *l.LockedBy
+	}
+
+	return res
+}
+
+func validatePoliciesRequest(req *goapolicy.PoliciesRequest) error {
+	if req.Sort != "name" && req.Sort != "lastUpdate" {
+		return status.Errorf(codes.InvalidArgument, "sort must be one of \"name\", \"lastUpdate\", but got %q", req.Sort)
+	}
+	if req.Order != "asc" && req.Order != "desc" {
+		return status.Errorf(codes.InvalidArgument, "order must be one of \"asc\", \"desc\", but got %q", req.Order)
+	}
+	if req.Limit != nil && (*req.Limit < 1 || *req.Limit > 1000) {
+		return status.Errorf(codes.InvalidArgument, "limit must be between 1 and 1000, but got %d", *req.Limit)
+	}
+
+	return nil
+}
+
+// endpointContext sets the same context values which are set by
+// the Goa generated HTTP server before calling a service endpoint.
+func endpointContext(ctx context.Context, method string) context.Context {
+	ctx = context.WithValue(ctx, goa.MethodKey, method)
+	ctx = context.WithValue(ctx, goa.ServiceKey, goapolicy.ServiceName)
+	return ctx
+}
+
+func evaluateRequest(req *pb.EvaluateRequest) *goapolicy.EvaluateRequest {
+	r := &goapolicy.EvaluateRequest{
+		Repository:   req.Repository,
+		Group:        req.Group,
+		PolicyName:   req.PolicyName,
+		Version:      req.Version,
+		EvaluationID: req.EvaluationId,
+	}
+
+	if req.Input != nil {
+		r.Input = req.Input.AsMap()
+	}
+
+	if req.Ttl != nil {
+		ttl := int(*req.Ttl)
+		r.TTL = &ttl
+	}
+
+	return r
+}
+
+func evaluateResponse(res *goapolicy.EvaluateResult) (*pb.EvaluateResponse, error) {
+	result, ok := res.Result.(map[string]interface{})
+	if !ok {
+		result = map[string]interface{}{"result": res.Result}
+	}
+
+	s, err := newStruct(result)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error encoding policy result: %v", err)
+	}
+
+	return &pb.EvaluateResponse{Result: s, Etag: res.ETag}, nil
+}
+
+// newStruct converts a JSON object to a protobuf Struct. The object is
+// encoded to JSON and decoded again, because OPA returns numbers as
+// json.Number values, which are not supported by structpb.
+func newStruct(v map[string]interface{}) (*structpb.Struct, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	return structpb.NewStruct(m)
+}
+
+// grpcError converts a service error to gRPC status error, by using
+// the same error formatter as the HTTP transport.
+func grpcError(ctx context.Context, err error) error {
+	res := service.NewErrorResponse(ctx, err)
+	return status.Error(codeFromHTTPStatus(res.StatusCode()), err.Error())
+}
@@ -0,0 +1,232 @@
+package grpcserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/ptr"
+	goapolicy "gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/policy"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/grpcserver"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/grpcserver/pb"
+)
+
+// policyService is a stub of the policy service used for testing
+// the mapping between gRPC messages and service types.
+type policyService struct {
+	goapolicy.Service
+
+	evaluate     func(ctx context.Context, req *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error)
+	lock         func(ctx context.Context, req *goapolicy.LockRequest) error
+	listPolicies func(ctx context.Context, req *goapolicy.PoliciesRequest) (*goapolicy.PoliciesResult, error)
+}
+
+func (s *policyService) Evaluate(ctx context.Context, req *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error) {
+	return s.evaluate(ctx, req)
+}
+
+func (s *policyService) Validate(ctx context.Context, req *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error) {
+	return s.evaluate(ctx, req)
+}
+
+func (s *policyService) Lock(ctx context.Context, req *goapolicy.LockRequest) error {
+	return s.lock(ctx, req)
+}
+
+func (s *policyService) ListPolicies(ctx context.Context, req *goapolicy.PoliciesRequest) (*goapolicy.PoliciesResult, error) {
+	return s.listPolicies(ctx, req)
+}
+
+func TestServer_Evaluate(t *testing.T) {
+	input, err := structpb.NewStruct(map[string]interface{}{"msg": "hello"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		evaluate func(ctx context.Context, req *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error)
+
+		result  map[string]interface{}
+		etag    string
+		errCode codes.Code
+		errText string
+	}{
+		{
+			name: "policy not found",
+			evaluate: func(ctx context.Context, req *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error) {
+				return nil, errors.New(errors.NotFound, "policy not found")
+			},
+			errCode: codes.NotFound,
+			errText: "policy not found",
+		},
+		{
+			name: "policy is locked",
+			evaluate: func(ctx context.Context, req *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error) {
+				return nil, errors.New(errors.Forbidden, "policy is locked")
+			},
+			errCode: codes.PermissionDenied,
+			errText: "policy is locked",
+		},
+		{
+			name: "object result",
+			evaluate: func(ctx context.Context, req *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error) {
+				assert.Equal(t, "Evaluate", ctx.Value(goa.MethodKey))
+				assert.Equal(t, "myrepo", req.Repository)
+				assert.Equal(t, "example", req.Group)
+				assert.Equal(t, "myPolicy", req.PolicyName)
+				assert.Equal(t, "1.0", req.Version)
+				assert.Equal(t, map[string]interface{}{"msg": "hello"}, req.Input)
+				assert.Equal(t, ptr.Int(10), req.TTL)
+				return &goapolicy.EvaluateResult{Result: map[string]interface{}{"allow": true}, ETag: "etag"}, nil
+			},
+			result: map[string]interface{}{"allow": true},
+			etag:   "etag",
+		},
+		{
+			name: "numeric result",
+			evaluate: func(ctx context.Context, req *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error) {
+				// OPA returns numbers as json.Number
+				return &goapolicy.EvaluateResult{Result: map[string]interface{}{
+					"count": json.Number("3"),
+					"score": json.Number("0.5"),
+					"items": []interface{}{json.Number("1"), map[string]interface{}{"n": json.Number("2")}},
+				}, ETag: "etag"}, nil
+			},
+			result: map[string]interface{}{
+				"count": float64(3),
+				"score": 0.5,
+				"items": []interface{}{float64(1), map[string]interface{}{"n": float64(2)}},
+			},
+			etag: "etag",
+		},
+		{
+			name: "non-object result",
+			evaluate: func(ctx context.Context, req *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error) {
+				return &goapolicy.EvaluateResult{Result: []interface{}{"a", "b"}, ETag: "etag"}, nil
+			},
+			result: map[string]interface{}{"result": []interface{}{"a", "b"}},
+			etag:   "etag",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc := &policyService{evaluate: test.evaluate}
+			srv := grpcserver.New(goapolicy.NewEndpoints(svc))
+
+			res, err := srv.Evaluate(context.Background(), &pb.EvaluateRequest{
+				Repository: "myrepo",
+				Group:      "example",
+				PolicyName: "myPolicy",
+				Version:    "1.0",
+				Input:      input,
+				Ttl:        ptr.Int64(10),
+			})
+			if test.errText != "" {
+				require.Error(t, err)
+				assert.Nil(t, res)
+				assert.Equal(t, test.errCode, status.Code(err))
+				assert.Contains(t, err.Error(), test.errText)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, res)
+			assert.Equal(t, test.result, res.Result.AsMap())
+			assert.Equal(t, test.etag, res.Etag)
+		})
+	}
+}
+
+func TestServer_Lock(t *testing.T) {
+	svc := &policyService{lock: func(ctx context.Context, req *goapolicy.LockRequest) error {
+		assert.Equal(t, ptr.String("incident"), req.Reason)
+		assert.Equal(t, ptr.String("2100-01-01T00:00:00Z"), req.ExpiresAt)
+		return errors.New(errors.NotFound, "policy not found")
+	}}
+	srv := grpcserver.New(goapolicy.NewEndpoints(svc))
+
+	res, err := srv.Lock(context.Background(), &pb.LockRequest{
+		Repository: "myrepo",
+		Group:      "example",
+		PolicyName: "myPolicy",
+		Version:    "1.0",
+		Reason:     ptr.String("incident"),
+		ExpiresAt:  ptr.String("2100-01-01T00:00:00Z"),
+	})
+	assert.Nil(t, res)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServer_ListPolicies(t *testing.T) {
+	svc := &policyService{listPolicies: func(ctx context.Context, req *goapolicy.PoliciesRequest) (*goapolicy.PoliciesResult, error) {
+		assert.Equal(t, ptr.Bool(true), req.Locked)
+		assert.Equal(t, ptr.Bool(true), req.Rego)
+		assert.Equal(t, ptr.Bool(false), req.Data)
+		assert.Equal(t, ptr.String("myrepo"), req.Repository)
+		assert.Equal(t, "name", req.Sort)
+		assert.Equal(t, "desc", req.Order)
+		assert.Equal(t, ptr.Int(1), req.Limit)
+		assert.Equal(t, []string{"custom.domain=gaia-x"}, req.Annotation)
+		return &goapolicy.PoliciesResult{NextCursor: ptr.String("next"), Policies: []*goapolicy.Policy{
+			{
+				Repository: "myrepo",
+				PolicyName: "myPolicy",
+				Group:      "example",
+				Version:    "1.0",
+				Rego:       ptr.String("package example.myPolicy"),
+				Locked:     true,
+				LastUpdate: 1700000000,
+				Lock:       &goapolicy.PolicyLock{Reason: ptr.String("incident"), LockedBy: ptr.String("alice"), LockedAt: 1700000000},
+				Archived:   ptr.Bool(false),
+				Commit:     &goapolicy.PolicyCommit{Sha: "0123abcd", Time: 1700000000, Author: "alice", Branch: ptr.String("main")},
+				Metadata: &goapolicy.PolicyMetadata{
+					Title:   ptr.String("Example"),
+					Authors: []string{"alice"},
+					Custom:  map[string]interface{}{"domain": "gaia-x", "level": json.Number("2")},
+				},
+			},
+		}}, nil
+	}}
+	srv := grpcserver.New(goapolicy.NewEndpoints(svc))
+
+	res, err := srv.ListPolicies(context.Background(), &pb.ListPoliciesRequest{
+		Locked:     ptr.Bool(true),
+		Rego:       true,
+		Repository: ptr.String("myrepo"),
+		Order:      "desc",
+		Limit:      ptr.Int32(1),
+		Annotation: []string{"custom.domain=gaia-x"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "next", res.NextCursor)
+	require.Len(t, res.Policies, 1)
+	assert.Equal(t, "myrepo", res.Policies[0].Repository)
+	assert.Equal(t, "myPolicy", res.Policies[0].PolicyName)
+	assert.Equal(t, "package example.myPolicy", res.Policies[0].GetRego())
+	assert.Nil(t, res.Policies[0].Data)
+	assert.True(t, res.Policies[0].Locked)
+	assert.Equal(t, int64(1700000000), res.Policies[0].LastUpdate)
+	require.NotNil(t, res.Policies[0].Lock)
+	assert.Equal(t, "incident", res.Policies[0].Lock.Reason)
+	assert.Equal(t, "alice", res.Policies[0].Lock.LockedBy)
+	assert.Nil(t, res.Policies[0].Lock.ExpiresAt)
+	assert.False(t, res.Policies[0].GetArchived())
+	require.NotNil(t, res.Policies[0].Commit)
+	assert.Equal(t, "0123abcd", res.Policies[0].Commit.Sha)
+	assert.Equal(t, "main", res.Policies[0].Commit.GetBranch())
+	require.NotNil(t, res.Policies[0].Metadata)
+	assert.Equal(t, "Example", res.Policies[0].Metadata.GetTitle())
+	assert.Equal(t, []string{"alice"}, res.Policies[0].Metadata.Authors)
+	assert.Equal(t, map[string]interface{}{"domain": "gaia-x", "level": float64(2)}, res.Policies[0].Metadata.Custom.AsMap())
+
+	_, err = srv.ListPolicies(context.Background(), &pb.ListPoliciesRequest{Sort: "version"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,284 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package localrepofakes
+
+import (
+	"context"
+	"sync"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/localrepo"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+type FakeStorage struct {
+	DeletePolicyStub        func(context.Context, string, string, string, string) error
+	deletePolicyMutex       sync.RWMutex
+	deletePolicyArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}
+	deletePolicyReturns struct {
+		result1 error
+	}
+	deletePolicyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PolicyStub        func(context.Context, string, string, string, string) (*storage.Policy, error)
+	policyMutex       sync.RWMutex
+	policyArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}
+	policyReturns struct {
+		result1 *storage.Policy
+		result2 error
+	}
+	policyReturnsOnCall map[int]struct {
+		result1 *storage.Policy
+		result2 error
+	}
+	SavePolicyStub        func(context.Context, *storage.Policy) error
+	savePolicyMutex       sync.RWMutex
+	savePolicyArgsForCall []struct {
+		arg1 context.Context
+		arg2 *storage.Policy
+	}
+	savePolicyReturns struct {
+		result1 error
+	}
+	savePolicyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeStorage) DeletePolicy(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string) error {
+	fake.deletePolicyMutex.Lock()
+	ret, specificReturn := fake.deletePolicyReturnsOnCall[len(fake.deletePolicyArgsForCall)]
+	fake.deletePolicyArgsForCall = append(fake.deletePolicyArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.DeletePolicyStub
+	fakeReturns := fake.deletePolicyReturns
+	fake.recordInvocation("DeletePolicy", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.deletePolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStorage) DeletePolicyCallCount() int {
+	fake.deletePolicyMutex.RLock()
+	defer fake.deletePolicyMutex.RUnlock()
+	return len(fake.deletePolicyArgsForCall)
+}
+
+func (fake *FakeStorage) DeletePolicyCalls(stub func(context.Context, string, string, string, string) error) {
+	fake.deletePolicyMutex.Lock()
+	defer fake.deletePolicyMutex.Unlock()
+	fake.DeletePolicyStub = stub
+}
+
+func (fake *FakeStorage) DeletePolicyArgsForCall(i int) (context.Context, string, string, string, string) {
+	fake.deletePolicyMutex.RLock()
+	defer fake.deletePolicyMutex.RUnlock()
+	argsForCall := fake.deletePolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeStorage) DeletePolicyReturns(result1 error) {
+	fake.deletePolicyMutex.Lock()
+	defer fake.deletePolicyMutex.Unlock()
+	fake.DeletePolicyStub = nil
+	fake.deletePolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) DeletePolicyReturnsOnCall(i int, result1 error) {
+	fake.deletePolicyMutex.Lock()
+	defer fake.deletePolicyMutex.Unlock()
+	fake.DeletePolicyStub = nil
+	if fake.deletePolicyReturnsOnCall == nil {
+		fake.deletePolicyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deletePolicyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) Policy(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string) (*storage.Policy, error) {
+	fake.policyMutex.Lock()
+	ret, specificReturn := fake.policyReturnsOnCall[len(fake.policyArgsForCall)]
+	fake.policyArgsForCall = append(fake.policyArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.PolicyStub
+	fakeReturns := fake.policyReturns
+	fake.recordInvocation("Policy", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.policyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStorage) PolicyCallCount() int {
+	fake.policyMutex.RLock()
+	defer fake.policyMutex.RUnlock()
+	return len(fake.policyArgsForCall)
+}
+
+func (fake *FakeStorage) PolicyCalls(stub func(context.Context, string, string, string, string) (*storage.Policy, error)) {
+	fake.policyMutex.Lock()
+	defer fake.policyMutex.Unlock()
+	fake.PolicyStub = stub
+}
+
+func (fake *FakeStorage) PolicyArgsForCall(i int) (context.Context, string, string, string, string) {
+	fake.policyMutex.RLock()
+	defer fake.policyMutex.RUnlock()
+	argsForCall := fake.policyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeStorage) PolicyReturns(result1 *storage.Policy, result2 error) {
+	fake.policyMutex.Lock()
+	defer fake.policyMutex.Unlock()
+	fake.PolicyStub = nil
+	fake.policyReturns = struct {
+		result1 *storage.Policy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) PolicyReturnsOnCall(i int, result1 *storage.Policy, result2 error) {
+	fake.policyMutex.Lock()
+	defer fake.policyMutex.Unlock()
+	fake.PolicyStub = nil
+	if fake.policyReturnsOnCall == nil {
+		fake.policyReturnsOnCall = make(map[int]struct {
+			result1 *storage.Policy
+			result2 error
+		})
+	}
+	fake.policyReturnsOnCall[i] = struct {
+		result1 *storage.Policy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) SavePolicy(arg1 context.Context, arg2 *storage.Policy) error {
+	fake.savePolicyMutex.Lock()
+	ret, specificReturn := fake.savePolicyReturnsOnCall[len(fake.savePolicyArgsForCall)]
+	fake.savePolicyArgsForCall = append(fake.savePolicyArgsForCall, struct {
+		arg1 context.Context
+		arg2 *storage.Policy
+	}{arg1, arg2})
+	stub := fake.SavePolicyStub
+	fakeReturns := fake.savePolicyReturns
+	fake.recordInvocation("SavePolicy", []interface{}{arg1, arg2})
+	fake.savePolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStorage) SavePolicyCallCount() int {
+	fake.savePolicyMutex.RLock()
+	defer fake.savePolicyMutex.RUnlock()
+	return len(fake.savePolicyArgsForCall)
+}
+
+func (fake *FakeStorage) SavePolicyCalls(stub func(context.Context, *storage.Policy) error) {
+	fake.savePolicyMutex.Lock()
+	defer fake.savePolicyMutex.Unlock()
+	fake.SavePolicyStub = stub
+}
+
+func (fake *FakeStorage) SavePolicyArgsForCall(i int) (context.Context, *storage.Policy) {
+	fake.savePolicyMutex.RLock()
+	defer fake.savePolicyMutex.RUnlock()
+	argsForCall := fake.savePolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStorage) SavePolicyReturns(result1 error) {
+	fake.savePolicyMutex.Lock()
+	defer fake.savePolicyMutex.Unlock()
+	fake.SavePolicyStub = nil
+	fake.savePolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) SavePolicyReturnsOnCall(i int, result1 error) {
+	fake.savePolicyMutex.Lock()
+	defer fake.savePolicyMutex.Unlock()
+	fake.SavePolicyStub = nil
+	if fake.savePolicyReturnsOnCall == nil {
+		fake.savePolicyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.savePolicyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.deletePolicyMutex.RLock()
+	defer fake.deletePolicyMutex.RUnlock()
+	fake.policyMutex.RLock()
+	defer fake.policyMutex.RUnlock()
+	fake.savePolicyMutex.RLock()
+	defer fake.savePolicyMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeStorage) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ localrepo.Storage = new(FakeStorage)
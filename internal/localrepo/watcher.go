@@ -0,0 +1,156 @@
+// Package localrepo provides a watcher which reloads policies from
+// a local directory into the policy storage, when policy files change.
+// It's intended for local development of policies, so that policies
+// can be tested without pushing them to a Git repository.
+package localrepo
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/clone"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+//go:generate counterfeiter . Storage
+
+type Storage interface {
+	Policy(ctx context.Context, repository, group, name, version string) (*storage.Policy, error)
+	SavePolicy(ctx context.Context, policy *storage.Policy) error
+	DeletePolicy(ctx context.Context, repository, group, name, version string) error
+}
+
+// Watcher periodically scans a local directory with policies and
+// saves new and modified policies in the policy storage and deletes
+// the removed ones. Changes in storage notify the storage subscribers
+// (e.g. regocache) about the change.
+type Watcher struct {
+	dir          string
+	repository   string
+	storage      Storage
+	pollInterval time.Duration
+	logger       *zap.Logger
+
+	// policies contains the policies as they were last loaded from the directory
+	policies map[string]*storage.Policy
+}
+
+// New creates a watcher for the given directory. The initial policies
+// are the policies already loaded in storage from the directory, so that
+// only changes made after that are reloaded.
+func New(dir, repository string, policies map[string]*storage.Policy, s Storage, pollInterval time.Duration, logger *zap.Logger) *Watcher {
+	loaded := make(map[string]*storage.Policy, len(policies))
+	for key, p := range policies {
+		cpy := *p
+		loaded[key] = &cpy
+	}
+
+	return &Watcher{
+		dir:          dir,
+		repository:   repository,
+		storage:      s,
+		pollInterval: pollInterval,
+		logger:       logger.With(zap.String("dir", dir)),
+		policies:     loaded,
+	}
+}
+
+// Start watches the directory for changes until the context is cancelled.
+func (w *Watcher) Start(ctx context.Context) error {
+	w.logger.Info("watching local policy directory for changes")
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := w.Reload(ctx); err != nil {
+				w.logger.Error("error reloading local policies", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Reload loads the policies from the directory and saves in storage
+// the policies which are new or modified since the last reload. Policies
+// removed from the directory are deleted from storage.
+func (w *Watcher) Reload(ctx context.Context) error {
+	policies, err := clone.LoadPolicies(w.dir, w.repository)
+	if err != nil {
+		return err
+	}
+
+	for key, p := range policies {
+		if prev, ok := w.policies[key]; ok && equal(prev, p) {
+			continue
+		}
+
+		logger := w.logger.With(
+			zap.String("policyRepository", p.Repository),
+			zap.String("policyGroup", p.Group),
+			zap.String("policyName", p.Name),
+			zap.String("policyVersion", p.Version),
+		)
+
+		// keep the lock state of policies changed through the API
+		current, err := w.storage.Policy(ctx, p.Repository, p.Group, p.Name, p.Version)
+		if err != nil && !errors.Is(errors.NotFound, err) {
+			logger.Error("error getting policy from storage", zap.Error(err))
+			continue
+		}
+
+		updated := *p
+		updated.LastUpdate = time.Now()
+		if current != nil {
+			updated.Locked = current.Locked
+			updated.Lock = current.Lock
+		}
+
+		if err := w.storage.SavePolicy(storage.WithRevisionSource(ctx, storage.RevisionSourceSync, ""), &updated); err != nil {
+			logger.Error("error saving policy", zap.Error(err))
+			continue
+		}
+
+		w.policies[key] = p
+		logger.Info("local policy reloaded")
+	}
+
+	for key, p := range w.policies {
+		if _, ok := policies[key]; ok {
+			continue
+		}
+
+		logger := w.logger.With(
+			zap.String("policyRepository", p.Repository),
+			zap.String("policyGroup", p.Group),
+			zap.String("policyName", p.Name),
+			zap.String("policyVersion", p.Version),
+		)
+
+		err := w.storage.DeletePolicy(ctx, p.Repository, p.Group, p.Name, p.Version)
+		if err != nil && !errors.Is(errors.NotFound, err) {
+			logger.Error("error deleting policy", zap.Error(err))
+			continue
+		}
+
+		delete(w.policies, key)
+		logger.Info("local policy deleted")
+	}
+
+	return nil
+}
+
+// equal compares the policy files content.
+func equal(p1, p2 *storage.Policy) bool {
+	return p1.Rego == p2.Rego &&
+		p1.Data == p2.Data &&
+		p1.DataConfig == p2.DataConfig &&
+		p1.OutputSchema == p2.OutputSchema &&
+		p1.ExportConfig == p2.ExportConfig
+}
@@ -0,0 +1,174 @@
+package localrepo_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/clone"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/localrepo"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/localrepo/localrepofakes"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestWatcher_Reload(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(t *testing.T, dir string)
+		storage *localrepofakes.FakeStorage
+
+		saved []*storage.Policy
+	}{
+		{
+			name:    "no changes",
+			change:  func(t *testing.T, dir string) {},
+			storage: &localrepofakes.FakeStorage{},
+		},
+		{
+			name: "policy source code is changed",
+			change: func(t *testing.T, dir string) {
+				writeFile(t, filepath.Join(dir, "example", "test", "1.0", "policy.rego"), "package example.test\n\nallow := false")
+			},
+			storage: &localrepofakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, repository, group, name, version string) (*storage.Policy, error) {
+					return &storage.Policy{Locked: true}, nil
+				},
+			},
+			saved: []*storage.Policy{
+				{
+					Repository: "local",
+					Group:      "example",
+					Name:       "test",
+					Version:    "1.0",
+					Filename:   "example/test/1.0/policy.rego",
+					Rego:       "package example.test\n\nallow := false",
+					Locked:     true,
+				},
+			},
+		},
+		{
+			name: "policy data is changed",
+			change: func(t *testing.T, dir string) {
+				writeFile(t, filepath.Join(dir, "example", "test", "1.0", "data.json"), `{"key":"value"}`)
+			},
+			storage: &localrepofakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, repository, group, name, version string) (*storage.Policy, error) {
+					return &storage.Policy{}, nil
+				},
+			},
+			saved: []*storage.Policy{
+				{
+					Repository: "local",
+					Group:      "example",
+					Name:       "test",
+					Version:    "1.0",
+					Filename:   "example/test/1.0/policy.rego",
+					Rego:       "package example.test\n\nallow := true",
+					Data:       `{"key":"value"}`,
+				},
+			},
+		},
+		{
+			name: "new policy is added",
+			change: func(t *testing.T, dir string) {
+				writeFile(t, filepath.Join(dir, "example", "test", "2.0", "policy.rego"), "package example.test")
+			},
+			storage: &localrepofakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, repository, group, name, version string) (*storage.Policy, error) {
+					return nil, errors.New(errors.NotFound)
+				},
+			},
+			saved: []*storage.Policy{
+				{
+					Repository: "local",
+					Group:      "example",
+					Name:       "test",
+					Version:    "2.0",
+					Filename:   "example/test/2.0/policy.rego",
+					Rego:       "package example.test",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFile(t, filepath.Join(dir, "example", "test", "1.0", "policy.rego"), "package example.test\n\nallow := true")
+
+			policies, err := clone.LoadPolicies(dir, "local")
+			require.NoError(t, err)
+			require.Len(t, policies, 1)
+
+			watcher := localrepo.New(dir, "local", policies, test.storage, time.Second, zap.NewNop())
+			test.change(t, dir)
+
+			err = watcher.Reload(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, len(test.saved), test.storage.SavePolicyCallCount())
+			for i, expected := range test.saved {
+				_, saved := test.storage.SavePolicyArgsForCall(i)
+				assert.False(t, saved.LastUpdate.IsZero())
+				saved.LastUpdate = time.Time{}
+				assert.Equal(t, expected, saved)
+			}
+
+			// policies are saved only once
+			require.NoError(t, watcher.Reload(context.Background()))
+			assert.Equal(t, len(test.saved), test.storage.SavePolicyCallCount())
+		})
+	}
+}
+
+func TestWatcher_ReloadRetriesFailedSave(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "example", "test", "1.0", "policy.rego"), "package example.test")
+
+	storage := &localrepofakes.FakeStorage{}
+	storage.SavePolicyReturnsOnCall(0, errors.New("some error"))
+
+	watcher := localrepo.New(dir, "local", nil, storage, time.Second, zap.NewNop())
+
+	require.NoError(t, watcher.Reload(context.Background()))
+	require.NoError(t, watcher.Reload(context.Background()))
+	assert.Equal(t, 2, storage.SavePolicyCallCount())
+}
+
+func TestWatcher_ReloadDeletesRemovedPolicy(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "example", "test", "1.0", "policy.rego"), "package example.test")
+
+	policies, err := clone.LoadPolicies(dir, "local")
+	require.NoError(t, err)
+
+	storage := &localrepofakes.FakeStorage{}
+	storage.DeletePolicyReturnsOnCall(0, errors.New("some error"))
+
+	watcher := localrepo.New(dir, "local", policies, storage, time.Second, zap.NewNop())
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "example", "test", "1.0")))
+
+	// deletion is retried after failure
+	require.NoError(t, watcher.Reload(context.Background()))
+	require.NoError(t, watcher.Reload(context.Background()))
+	require.Equal(t, 2, storage.DeletePolicyCallCount())
+
+	_, repository, group, name, version := storage.DeletePolicyArgsForCall(1)
+	assert.Equal(t, []string{"local", "example", "test", "1.0"}, []string{repository, group, name, version})
+
+	// policy is deleted only once
+	require.NoError(t, watcher.Reload(context.Background()))
+	assert.Equal(t, 2, storage.DeletePolicyCallCount())
+	assert.Equal(t, 0, storage.SavePolicyCallCount())
+}
@@ -3,10 +3,16 @@ package notify
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
@@ -22,48 +28,105 @@ type Events interface {
 
 type Storage interface {
 	PolicySubscribers(ctx context.Context, policyRepository, policyName, policyGroup, policyVersion string) ([]*storage.Subscriber, error)
+	Subscriber(ctx context.Context, policyRepository, policyGroup, policyName, policyVersion, webhookURL, name string) (*storage.Subscriber, error)
+	// SaveDelivery creates or updates a webhook delivery.
+	SaveDelivery(ctx context.Context, delivery *storage.Delivery) error
+	// PendingDeliveries returns the pending webhook deliveries
+	// which must be attempted before the given time.
+	PendingDeliveries(ctx context.Context, before time.Time) ([]*storage.Delivery, error)
+	// ClaimDelivery atomically postpones the next attempt of a pending
+	// delivery from nextAttempt until the given time. It returns false if
+	// the delivery isn't pending anymore or its next attempt is changed,
+	// i.e. the delivery is claimed by another instance of the service.
+	ClaimDelivery(ctx context.Context, id string, nextAttempt, until time.Time) (bool, error)
 }
 
 type Notifier struct {
-	events  Events
-	storage Storage
-	client  *http.Client
-	logger  *zap.Logger
+	events      Events
+	storage     Storage
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	logger      *zap.Logger
 }
 
+// Headers of the webhook calls.
+const (
+	HeaderDeliveryID = "X-Policy-Delivery-Id"
+	HeaderTimestamp  = "X-Policy-Timestamp"
+	HeaderSignature  = "X-Policy-Signature"
+)
+
+// defaultAttemptTimeout is the timeout of a webhook call
+// if the HTTP client of the notifier has no timeout.
+const defaultAttemptTimeout = 30 * time.Second
+
+// Types of policy change events.
+const (
+	EventTypeChanged = "changed"
+	EventTypeDeleted = "deleted"
+)
+
 type EventPolicyChange struct {
 	Repository string `json:"repository"`
 	Name       string `json:"name"`
 	Version    string `json:"version"`
 	Group      string `json:"group"`
+	Type       string `json:"type"`
 }
 
 // New creates a policy change notifier for interested subscribers.
 // It can notify for policy changes both via MessageQueue or Web hooks.
-func New(events Events, storage Storage, client *http.Client, logger *zap.Logger) *Notifier {
-	return &Notifier{events: events, storage: storage, client: client, logger: logger}
+// Failed webhook calls are retried after the backoff duration, which is
+// doubled after every attempt up to maxBackoff, until maxAttempts is reached.
+func New(events Events, storage Storage, client *http.Client, maxAttempts int, backoff, maxBackoff time.Duration, logger *zap.Logger) *Notifier {
+	return &Notifier{
+		events:      events,
+		storage:     storage,
+		client:      client,
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+		maxBackoff:  maxBackoff,
+		logger:      logger,
+	}
 }
 
-// TODO unit testing PolicyDataChange
-
 // PolicyDataChange is called when the policies source code or data are updated
 // in storage. The function will notify subscribers of the given changes.
 func (n *Notifier) PolicyDataChange(ctx context.Context, policyRepository, policyName, policyGroup, policyVersion string) error {
-	logger := n.logger.With(zap.String("operation", "PolicyDataChange"))
+	return n.notify(ctx, &EventPolicyChange{
+		Repository: policyRepository,
+		Name:       policyName,
+		Version:    policyVersion,
+		Group:      policyGroup,
+		Type:       EventTypeChanged,
+	})
+}
 
-	event := &EventPolicyChange{
+// PolicyDeleted is called when a policy is deleted from storage.
+// The function will notify subscribers with a deleted event.
+func (n *Notifier) PolicyDeleted(ctx context.Context, policyRepository, policyName, policyGroup, policyVersion string) error {
+	return n.notify(ctx, &EventPolicyChange{
 		Repository: policyRepository,
 		Name:       policyName,
 		Version:    policyVersion,
 		Group:      policyGroup,
-	}
+		Type:       EventTypeDeleted,
+	})
+}
 
-	go func() {
+func (n *Notifier) notify(ctx context.Context, event *EventPolicyChange) error {
+	logger := n.logger.With(zap.String("operation", "PolicyDataChange"), zap.String("type", event.Type))
+
+	// subscribers are notified after the caller returns,
+	// so the notification must not be cancelled with it
+	go func(ctx context.Context) {
 		err := n.notifySubscribers(ctx, event)
 		if err != nil {
 			logger.Error("error notifying subscribers", zap.Error(err))
 		}
-	}()
+	}(context.WithoutCancel(ctx))
 
 	return n.events.Send(ctx, event)
 }
@@ -80,24 +143,177 @@ func (n *Notifier) notifySubscribers(ctx context.Context, event *EventPolicyChan
 	}
 
 	for _, subscriber := range subscribers {
-		if err := n.notifySubscriber(ctx, subscriber, eventJSON); err != nil {
-			n.logger.Error("error notifying subscriber webhook",
-				zap.Error(err),
-				zap.String("subscriber", subscriber.Name),
-				zap.String("webhookURL", subscriber.WebhookURL),
-			)
+		// the delivery is stored in the outbox before the first attempt,
+		// so that it's retried if the attempt fails. The retrier doesn't
+		// pick it up before the first attempt times out.
+		now := time.Now()
+		delivery := &storage.Delivery{
+			ID:               uuid.NewString(),
+			Subscriber:       subscriber.Name,
+			WebhookURL:       subscriber.WebhookURL,
+			PolicyRepository: subscriber.PolicyRepository,
+			PolicyName:       subscriber.PolicyName,
+			PolicyGroup:      subscriber.PolicyGroup,
+			PolicyVersion:    subscriber.PolicyVersion,
+			Event:            eventJSON,
+			State:            storage.DeliveryPending,
+			NextAttempt:      now.Add(n.claimDuration()),
+			CreatedAt:        now,
+		}
+		if err := n.storage.SaveDelivery(ctx, delivery); err != nil {
+			n.logger.Error("error storing webhook delivery", zap.Error(err), zap.String("subscriber", subscriber.Name))
+		}
+
+		n.deliver(ctx, subscriber, delivery)
+	}
+
+	return nil
+}
+
+// StartRetrier periodically retries the pending webhook deliveries.
+func (n *Notifier) StartRetrier(ctx context.Context, pollInterval time.Duration) {
+	for {
+		select {
+		case <-ctx.Done():
+			n.logger.Info("webhook retrier stopped", zap.Error(ctx.Err()))
+			return
+		case <-time.After(pollInterval):
+			if err := n.retryPending(ctx); err != nil {
+				n.logger.Error("error retrying webhook deliveries", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (n *Notifier) retryPending(ctx context.Context) error {
+	deliveries, err := n.storage.PendingDeliveries(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		logger := n.logger.With(zap.String("deliveryID", delivery.ID))
+
+		// other instances of the service retry the same deliveries,
+		// so a delivery is only attempted by the instance claiming it
+		until := time.Now().Add(n.claimDuration())
+		claimed, err := n.storage.ClaimDelivery(ctx, delivery.ID, delivery.NextAttempt, until)
+		if err != nil {
+			logger.Error("error claiming webhook delivery", zap.Error(err))
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		delivery.NextAttempt = until
+
+		subscriber, err := n.storage.Subscriber(ctx,
+			delivery.PolicyRepository,
+			delivery.PolicyGroup,
+			delivery.PolicyName,
+			delivery.PolicyVersion,
+			delivery.WebhookURL,
+			delivery.Subscriber,
+		)
+		if err != nil {
+			// the delivery is retried when its claim expires
+			if !errors.Is(errors.NotFound, err) {
+				logger.Error("error getting webhook delivery subscriber", zap.Error(err))
+				continue
+			}
+
+			delivery.State = storage.DeliveryDead
+			delivery.LastError = "subscriber not found"
+			if err := n.storage.SaveDelivery(ctx, delivery); err != nil {
+				logger.Error("error storing webhook delivery", zap.Error(err))
+			}
+			continue
 		}
+
+		n.deliver(ctx, subscriber, delivery)
 	}
 
 	return nil
 }
 
-func (n *Notifier) notifySubscriber(ctx context.Context, subscriber *storage.Subscriber, eventJSON []byte) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscriber.WebhookURL, bytes.NewBuffer(eventJSON))
+// deliver calls the subscriber webhook and stores the result of the attempt.
+// A failed delivery is retried with exponential backoff until the maximum
+// number of attempts is reached and the delivery becomes dead.
+func (n *Notifier) deliver(ctx context.Context, subscriber *storage.Subscriber, delivery *storage.Delivery) {
+	err := n.notifySubscriber(ctx, subscriber, delivery)
+	delivery.Attempts++
+
+	if err == nil {
+		delivery.State = storage.DeliveryDelivered
+		delivery.LastError = ""
+	} else {
+		n.logger.Error("error notifying subscriber webhook",
+			zap.Error(err),
+			zap.String("subscriber", subscriber.Name),
+			zap.String("webhookURL", subscriber.WebhookURL),
+			zap.String("deliveryID", delivery.ID),
+			zap.Int("attempts", delivery.Attempts),
+		)
+
+		delivery.LastError = err.Error()
+		if delivery.Attempts >= n.maxAttempts {
+			delivery.State = storage.DeliveryDead
+		} else {
+			delivery.NextAttempt = time.Now().Add(n.retryDelay(delivery.Attempts))
+		}
+	}
+
+	if err := n.storage.SaveDelivery(ctx, delivery); err != nil {
+		n.logger.Error("error storing webhook delivery", zap.Error(err), zap.String("deliveryID", delivery.ID))
+	}
+}
+
+// attemptTimeout returns the timeout of a webhook call.
+func (n *Notifier) attemptTimeout() time.Duration {
+	if n.client.Timeout > 0 {
+		return n.client.Timeout
+	}
+
+	return defaultAttemptTimeout
+}
+
+// claimDuration returns how long a delivery is claimed for an attempt.
+// It's longer than the attempt timeout, so that the delivery isn't
+// attempted again while the attempt is in flight.
+func (n *Notifier) claimDuration() time.Duration {
+	return n.attemptTimeout() + n.backoff
+}
+
+// retryDelay returns the delay before the next attempt, which
+// is doubled after every failed attempt up to the maximum backoff.
+func (n *Notifier) retryDelay(attempts int) time.Duration {
+	delay := n.backoff
+	for i := 1; i < attempts && delay < n.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > n.maxBackoff {
+		delay = n.maxBackoff
+	}
+
+	return delay
+}
+
+func (n *Notifier) notifySubscriber(ctx context.Context, subscriber *storage.Subscriber, delivery *storage.Delivery) error {
+	ctx, cancel := context.WithTimeout(ctx, n.attemptTimeout())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscriber.WebhookURL, bytes.NewBuffer(delivery.Event))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderDeliveryID, delivery.ID)
+
+	if subscriber.Secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(HeaderTimestamp, timestamp)
+		req.Header.Set(HeaderSignature, Signature(subscriber.Secret, timestamp, delivery.Event))
+	}
 
 	res, err := n.client.Do(req)
 	if err != nil {
@@ -105,13 +321,24 @@ func (n *Notifier) notifySubscriber(ctx context.Context, subscriber *storage.Sub
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode != http.StatusOK {
+	if res.StatusCode < 200 || res.StatusCode > 299 {
 		return errors.New(errors.GetKind(res.StatusCode), getErrorBody(res))
 	}
 
 	return nil
 }
 
+// Signature returns the signature of a webhook call, which is the hex encoded
+// HMAC-SHA256 of the timestamp and the body joined with a dot, prefixed
+// with the name of the hash function.
+func Signature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
 func getErrorBody(resp *http.Response) string {
 	body, err := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
 	if err != nil {
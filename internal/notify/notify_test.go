@@ -2,15 +2,21 @@ package notify_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/notify"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/notify/notifyfakes"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
@@ -82,7 +88,7 @@ func TestNotify_PolicyDataChange(t *testing.T) {
 			core, logs := observer.New(zap.ErrorLevel)
 			logger := zap.New(core)
 
-			notifier := notify.New(test.events, test.storage, http.DefaultClient, logger)
+			notifier := notify.New(test.events, test.storage, http.DefaultClient, 3, time.Second, time.Minute, logger)
 			err := notifier.PolicyDataChange(context.Background(),
 				test.eventPolicyChange.Repository,
 				test.eventPolicyChange.Name,
@@ -105,3 +111,289 @@ func TestNotify_PolicyDataChange(t *testing.T) {
 		})
 	}
 }
+
+func TestNotify_PolicyDataChangeEvent(t *testing.T) {
+	var sent any
+	events := &notifyfakes.FakeEvents{SendStub: func(ctx context.Context, a any) error {
+		sent = a
+		return nil
+	}}
+	storage := &notifyfakes.FakeStorage{PolicySubscribersStub: func(ctx context.Context, s1, s2, s3, s4 string) ([]*storage.Subscriber, error) {
+		return []*storage.Subscriber{}, nil
+	}}
+
+	notifier := notify.New(events, storage, http.DefaultClient, 3, time.Second, time.Minute, zap.NewNop())
+	err := notifier.PolicyDataChange(context.Background(), "exampleRepo", "exampleName", "exampleGroup", "exampleVersion")
+	assert.NoError(t, err)
+
+	assert.Equal(t, &notify.EventPolicyChange{
+		Repository: "exampleRepo",
+		Name:       "exampleName",
+		Version:    "exampleVersion",
+		Group:      "exampleGroup",
+		Type:       notify.EventTypeChanged,
+	}, sent)
+
+	// the subscribers of the changed policy are notified
+	assert.Eventually(t, func() bool { return storage.PolicySubscribersCallCount() == 1 }, time.Second, 5*time.Millisecond)
+	_, repository, name, group, version := storage.PolicySubscribersArgsForCall(0)
+	assert.Equal(t, []string{"exampleRepo", "exampleName", "exampleGroup", "exampleVersion"}, []string{repository, name, group, version})
+}
+
+func TestNotify_PolicyDeleted(t *testing.T) {
+	var sent any
+	events := &notifyfakes.FakeEvents{SendStub: func(ctx context.Context, a any) error {
+		sent = a
+		return nil
+	}}
+	storage := &notifyfakes.FakeStorage{PolicySubscribersStub: func(ctx context.Context, s1, s2, s3, s4 string) ([]*storage.Subscriber, error) {
+		return []*storage.Subscriber{}, nil
+	}}
+
+	notifier := notify.New(events, storage, http.DefaultClient, 3, time.Second, time.Minute, zap.NewNop())
+	err := notifier.PolicyDeleted(context.Background(), "exampleRepo", "exampleName", "exampleGroup", "exampleVersion")
+	assert.NoError(t, err)
+
+	assert.Equal(t, &notify.EventPolicyChange{
+		Repository: "exampleRepo",
+		Name:       "exampleName",
+		Version:    "exampleVersion",
+		Group:      "exampleGroup",
+		Type:       notify.EventTypeDeleted,
+	}, sent)
+}
+
+// deliveryStorage returns a fake storage which keeps
+// a copy of the saved deliveries.
+func deliveryStorage(subscribers []*storage.Subscriber) (*notifyfakes.FakeStorage, func() []storage.Delivery) {
+	var mu sync.Mutex
+	var saved []storage.Delivery
+
+	fake := &notifyfakes.FakeStorage{
+		PolicySubscribersStub: func(ctx context.Context, s1, s2, s3, s4 string) ([]*storage.Subscriber, error) {
+			return subscribers, nil
+		},
+		SaveDeliveryStub: func(ctx context.Context, delivery *storage.Delivery) error {
+			mu.Lock()
+			defer mu.Unlock()
+			saved = append(saved, *delivery)
+			return nil
+		},
+	}
+	fake.ClaimDeliveryReturns(true, nil)
+
+	return fake, func() []storage.Delivery {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]storage.Delivery(nil), saved...)
+	}
+}
+
+func TestNotify_WebhookDelivery(t *testing.T) {
+	type request struct {
+		header http.Header
+		body   []byte
+	}
+	requests := make(chan request, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- request{header: r.Header, body: body}
+	}))
+	defer srv.Close()
+
+	subscriber := &storage.Subscriber{Name: "sub", WebhookURL: srv.URL, Secret: "secret"}
+	fake, saved := deliveryStorage([]*storage.Subscriber{subscriber})
+	events := &notifyfakes.FakeEvents{}
+
+	notifier := notify.New(events, fake, http.DefaultClient, 3, time.Second, time.Minute, zap.NewNop())
+	err := notifier.PolicyDataChange(context.Background(), "exampleRepo", "exampleName", "exampleGroup", "exampleVersion")
+	require.NoError(t, err)
+
+	var req request
+	select {
+	case req = <-requests:
+	case <-time.After(time.Second):
+		t.Fatal("webhook is not called")
+	}
+
+	var event notify.EventPolicyChange
+	require.NoError(t, json.Unmarshal(req.body, &event))
+	assert.Equal(t, "exampleRepo", event.Repository)
+
+	timestamp := req.header.Get(notify.HeaderTimestamp)
+	assert.NotEmpty(t, timestamp)
+	assert.Equal(t, notify.Signature("secret", timestamp, req.body), req.header.Get(notify.HeaderSignature))
+
+	// the delivery is stored before and after the attempt
+	assert.Eventually(t, func() bool { return len(saved()) == 2 }, time.Second, 5*time.Millisecond)
+	deliveries := saved()
+	assert.Equal(t, storage.DeliveryPending, deliveries[0].State)
+	assert.Equal(t, req.header.Get(notify.HeaderDeliveryID), deliveries[0].ID)
+	// the retrier doesn't pick up the delivery before the first attempt times out
+	assert.WithinDuration(t, time.Now().Add(31*time.Second), deliveries[0].NextAttempt, 500*time.Millisecond)
+	assert.Equal(t, deliveries[0].ID, deliveries[1].ID)
+	assert.Equal(t, storage.DeliveryDelivered, deliveries[1].State)
+	assert.Equal(t, 1, deliveries[1].Attempts)
+	assert.Equal(t, "sub", deliveries[1].Subscriber)
+}
+
+func TestNotify_WebhookDeliveryCancelledContext(t *testing.T) {
+	called := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called <- struct{}{}
+	}))
+	defer srv.Close()
+
+	fake, saved := deliveryStorage([]*storage.Subscriber{{Name: "sub", WebhookURL: srv.URL}})
+	events := &notifyfakes.FakeEvents{}
+
+	// the request which changed the policy is already finished
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	notifier := notify.New(events, fake, http.DefaultClient, 3, time.Second, time.Minute, zap.NewNop())
+	err := notifier.PolicyDataChange(ctx, "exampleRepo", "exampleName", "exampleGroup", "exampleVersion")
+	require.NoError(t, err)
+
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		t.Fatal("webhook is not called")
+	}
+
+	assert.Eventually(t, func() bool { return len(saved()) == 2 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, storage.DeliveryDelivered, saved()[1].State)
+	for i := 0; i < fake.SaveDeliveryCallCount(); i++ {
+		ctx, _ := fake.SaveDeliveryArgsForCall(i)
+		assert.NoError(t, ctx.Err())
+	}
+}
+
+func TestNotify_WebhookDeliveryFailed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get(notify.HeaderSignature))
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	fake, saved := deliveryStorage([]*storage.Subscriber{{Name: "sub", WebhookURL: srv.URL}})
+	events := &notifyfakes.FakeEvents{}
+
+	notifier := notify.New(events, fake, http.DefaultClient, 3, time.Second, time.Minute, zap.NewNop())
+	err := notifier.PolicyDataChange(context.Background(), "exampleRepo", "exampleName", "exampleGroup", "exampleVersion")
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool { return len(saved()) == 2 }, time.Second, 5*time.Millisecond)
+	delivery := saved()[1]
+	assert.Equal(t, storage.DeliveryPending, delivery.State)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.NotEmpty(t, delivery.LastError)
+	assert.WithinDuration(t, time.Now().Add(time.Second), delivery.NextAttempt, 500*time.Millisecond)
+}
+
+func TestNotify_StartRetrier(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	fake, saved := deliveryStorage(nil)
+	fake.PendingDeliveriesReturnsOnCall(0, []*storage.Delivery{
+		{ID: "retried", Subscriber: "sub", WebhookURL: srv.URL, State: storage.DeliveryPending, Attempts: 2},
+		{ID: "dead", Subscriber: "sub", WebhookURL: srv.URL, State: storage.DeliveryPending, Attempts: 4},
+		{ID: "unsubscribed", Subscriber: "gone", WebhookURL: srv.URL, State: storage.DeliveryPending, Attempts: 1},
+		{ID: "claimed", Subscriber: "sub", WebhookURL: srv.URL, State: storage.DeliveryPending, Attempts: 1},
+	}, nil)
+	// the delivery is claimed by another instance of the service
+	fake.ClaimDeliveryStub = func(ctx context.Context, id string, nextAttempt, until time.Time) (bool, error) {
+		return id != "claimed", nil
+	}
+	fake.SubscriberStub = func(ctx context.Context, repo, group, name, version, webhookURL, subscriber string) (*storage.Subscriber, error) {
+		if subscriber == "gone" {
+			return nil, errors.New(errors.NotFound, "subscriber not found")
+		}
+		return &storage.Subscriber{Name: subscriber, WebhookURL: webhookURL}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	notifier := notify.New(&notifyfakes.FakeEvents{}, fake, http.DefaultClient, 5, time.Second, 3*time.Second, zap.NewNop())
+	go notifier.StartRetrier(ctx, 5*time.Millisecond)
+
+	assert.Eventually(t, func() bool { return len(saved()) == 3 }, time.Second, 5*time.Millisecond)
+	deliveries := make(map[string]storage.Delivery)
+	for _, d := range saved() {
+		deliveries[d.ID] = d
+	}
+
+	// the backoff is doubled after every attempt up to the maximum backoff
+	assert.Equal(t, storage.DeliveryPending, deliveries["retried"].State)
+	assert.Equal(t, 3, deliveries["retried"].Attempts)
+	assert.WithinDuration(t, time.Now().Add(3*time.Second), deliveries["retried"].NextAttempt, 500*time.Millisecond)
+
+	assert.Equal(t, storage.DeliveryDead, deliveries["dead"].State)
+	assert.Equal(t, 5, deliveries["dead"].Attempts)
+
+	assert.Equal(t, storage.DeliveryDead, deliveries["unsubscribed"].State)
+	assert.Equal(t, "subscriber not found", deliveries["unsubscribed"].LastError)
+
+	// the delivery claimed by another instance is skipped
+	assert.NotContains(t, deliveries, "claimed")
+	assert.Equal(t, 3, fake.SubscriberCallCount())
+}
+
+func TestNotify_StartRetrierErrors(t *testing.T) {
+	delivered := make(chan string, 3)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delivered <- r.Header.Get(notify.HeaderDeliveryID)
+	}))
+	defer srv.Close()
+
+	fake, saved := deliveryStorage(nil)
+	fake.PendingDeliveriesReturnsOnCall(0, []*storage.Delivery{
+		{ID: "unclaimed", Subscriber: "sub", WebhookURL: srv.URL, State: storage.DeliveryPending},
+		{ID: "unknown", Subscriber: "broken", WebhookURL: srv.URL, State: storage.DeliveryPending},
+		{ID: "delivered", Subscriber: "sub", WebhookURL: srv.URL, State: storage.DeliveryPending},
+	}, nil)
+	fake.ClaimDeliveryStub = func(ctx context.Context, id string, nextAttempt, until time.Time) (bool, error) {
+		if id == "unclaimed" {
+			return false, errors.New("connection refused")
+		}
+		return true, nil
+	}
+	fake.SubscriberStub = func(ctx context.Context, repo, group, name, version, webhookURL, subscriber string) (*storage.Subscriber, error) {
+		if subscriber == "broken" {
+			return nil, errors.New("connection refused")
+		}
+		return &storage.Subscriber{Name: subscriber, WebhookURL: webhookURL}, nil
+	}
+
+	core, logs := observer.New(zap.ErrorLevel)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	notifier := notify.New(&notifyfakes.FakeEvents{}, fake, http.DefaultClient, 5, time.Second, 3*time.Second, zap.New(core))
+	go notifier.StartRetrier(ctx, 5*time.Millisecond)
+
+	// the errors of a delivery don't stop the retry of the next deliveries
+	select {
+	case id := <-delivered:
+		assert.Equal(t, "delivered", id)
+	case <-time.After(time.Second):
+		t.Fatal("webhook is not called")
+	}
+	assert.Eventually(t, func() bool { return len(saved()) == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, storage.DeliveryDelivered, saved()[0].State)
+
+	// the deliveries which failed are retried when their claim expires
+	messages := make(map[string]string)
+	for _, entry := range logs.All() {
+		messages[entry.ContextMap()["deliveryID"].(string)] = entry.Message
+	}
+	assert.Equal(t, map[string]string{
+		"unclaimed": "error claiming webhook delivery",
+		"unknown":   "error getting webhook delivery subscriber",
+	}, messages)
+}
@@ -4,12 +4,43 @@ package notifyfakes
 import (
 	"context"
 	"sync"
+	"time"
 
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/notify"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
 )
 
 type FakeStorage struct {
+	ClaimDeliveryStub        func(context.Context, string, time.Time, time.Time) (bool, error)
+	claimDeliveryMutex       sync.RWMutex
+	claimDeliveryArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Time
+		arg4 time.Time
+	}
+	claimDeliveryReturns struct {
+		result1 bool
+		result2 error
+	}
+	claimDeliveryReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	PendingDeliveriesStub        func(context.Context, time.Time) ([]*storage.Delivery, error)
+	pendingDeliveriesMutex       sync.RWMutex
+	pendingDeliveriesArgsForCall []struct {
+		arg1 context.Context
+		arg2 time.Time
+	}
+	pendingDeliveriesReturns struct {
+		result1 []*storage.Delivery
+		result2 error
+	}
+	pendingDeliveriesReturnsOnCall map[int]struct {
+		result1 []*storage.Delivery
+		result2 error
+	}
 	PolicySubscribersStub        func(context.Context, string, string, string, string) ([]*storage.Subscriber, error)
 	policySubscribersMutex       sync.RWMutex
 	policySubscribersArgsForCall []struct {
@@ -27,10 +58,173 @@ type FakeStorage struct {
 		result1 []*storage.Subscriber
 		result2 error
 	}
+	SaveDeliveryStub        func(context.Context, *storage.Delivery) error
+	saveDeliveryMutex       sync.RWMutex
+	saveDeliveryArgsForCall []struct {
+		arg1 context.Context
+		arg2 *storage.Delivery
+	}
+	saveDeliveryReturns struct {
+		result1 error
+	}
+	saveDeliveryReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SubscriberStub        func(context.Context, string, string, string, string, string, string) (*storage.Subscriber, error)
+	subscriberMutex       sync.RWMutex
+	subscriberArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 string
+		arg7 string
+	}
+	subscriberReturns struct {
+		result1 *storage.Subscriber
+		result2 error
+	}
+	subscriberReturnsOnCall map[int]struct {
+		result1 *storage.Subscriber
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeStorage) ClaimDelivery(arg1 context.Context, arg2 string, arg3 time.Time, arg4 time.Time) (bool, error) {
+	fake.claimDeliveryMutex.Lock()
+	ret, specificReturn := fake.claimDeliveryReturnsOnCall[len(fake.claimDeliveryArgsForCall)]
+	fake.claimDeliveryArgsForCall = append(fake.claimDeliveryArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Time
+		arg4 time.Time
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ClaimDeliveryStub
+	fakeReturns := fake.claimDeliveryReturns
+	fake.recordInvocation("ClaimDelivery", []interface{}{arg1, arg2, arg3, arg4})
+	fake.claimDeliveryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStorage) ClaimDeliveryCallCount() int {
+	fake.claimDeliveryMutex.RLock()
+	defer fake.claimDeliveryMutex.RUnlock()
+	return len(fake.claimDeliveryArgsForCall)
+}
+
+func (fake *FakeStorage) ClaimDeliveryCalls(stub func(context.Context, string, time.Time, time.Time) (bool, error)) {
+	fake.claimDeliveryMutex.Lock()
+	defer fake.claimDeliveryMutex.Unlock()
+	fake.ClaimDeliveryStub = stub
+}
+
+func (fake *FakeStorage) ClaimDeliveryArgsForCall(i int) (context.Context, string, time.Time, time.Time) {
+	fake.claimDeliveryMutex.RLock()
+	defer fake.claimDeliveryMutex.RUnlock()
+	argsForCall := fake.claimDeliveryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeStorage) ClaimDeliveryReturns(result1 bool, result2 error) {
+	fake.claimDeliveryMutex.Lock()
+	defer fake.claimDeliveryMutex.Unlock()
+	fake.ClaimDeliveryStub = nil
+	fake.claimDeliveryReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) ClaimDeliveryReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.claimDeliveryMutex.Lock()
+	defer fake.claimDeliveryMutex.Unlock()
+	fake.ClaimDeliveryStub = nil
+	if fake.claimDeliveryReturnsOnCall == nil {
+		fake.claimDeliveryReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.claimDeliveryReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) PendingDeliveries(arg1 context.Context, arg2 time.Time) ([]*storage.Delivery, error) {
+	fake.pendingDeliveriesMutex.Lock()
+	ret, specificReturn := fake.pendingDeliveriesReturnsOnCall[len(fake.pendingDeliveriesArgsForCall)]
+	fake.pendingDeliveriesArgsForCall = append(fake.pendingDeliveriesArgsForCall, struct {
+		arg1 context.Context
+		arg2 time.Time
+	}{arg1, arg2})
+	stub := fake.PendingDeliveriesStub
+	fakeReturns := fake.pendingDeliveriesReturns
+	fake.recordInvocation("PendingDeliveries", []interface{}{arg1, arg2})
+	fake.pendingDeliveriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStorage) PendingDeliveriesCallCount() int {
+	fake.pendingDeliveriesMutex.RLock()
+	defer fake.pendingDeliveriesMutex.RUnlock()
+	return len(fake.pendingDeliveriesArgsForCall)
+}
+
+func (fake *FakeStorage) PendingDeliveriesCalls(stub func(context.Context, time.Time) ([]*storage.Delivery, error)) {
+	fake.pendingDeliveriesMutex.Lock()
+	defer fake.pendingDeliveriesMutex.Unlock()
+	fake.PendingDeliveriesStub = stub
+}
+
+func (fake *FakeStorage) PendingDeliveriesArgsForCall(i int) (context.Context, time.Time) {
+	fake.pendingDeliveriesMutex.RLock()
+	defer fake.pendingDeliveriesMutex.RUnlock()
+	argsForCall := fake.pendingDeliveriesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStorage) PendingDeliveriesReturns(result1 []*storage.Delivery, result2 error) {
+	fake.pendingDeliveriesMutex.Lock()
+	defer fake.pendingDeliveriesMutex.Unlock()
+	fake.PendingDeliveriesStub = nil
+	fake.pendingDeliveriesReturns = struct {
+		result1 []*storage.Delivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) PendingDeliveriesReturnsOnCall(i int, result1 []*storage.Delivery, result2 error) {
+	fake.pendingDeliveriesMutex.Lock()
+	defer fake.pendingDeliveriesMutex.Unlock()
+	fake.PendingDeliveriesStub = nil
+	if fake.pendingDeliveriesReturnsOnCall == nil {
+		fake.pendingDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 []*storage.Delivery
+			result2 error
+		})
+	}
+	fake.pendingDeliveriesReturnsOnCall[i] = struct {
+		result1 []*storage.Delivery
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStorage) PolicySubscribers(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string) ([]*storage.Subscriber, error) {
 	fake.policySubscribersMutex.Lock()
 	ret, specificReturn := fake.policySubscribersReturnsOnCall[len(fake.policySubscribersArgsForCall)]
@@ -99,11 +293,151 @@ func (fake *FakeStorage) PolicySubscribersReturnsOnCall(i int, result1 []*storag
 	}{result1, result2}
 }
 
+func (fake *FakeStorage) SaveDelivery(arg1 context.Context, arg2 *storage.Delivery) error {
+	fake.saveDeliveryMutex.Lock()
+	ret, specificReturn := fake.saveDeliveryReturnsOnCall[len(fake.saveDeliveryArgsForCall)]
+	fake.saveDeliveryArgsForCall = append(fake.saveDeliveryArgsForCall, struct {
+		arg1 context.Context
+		arg2 *storage.Delivery
+	}{arg1, arg2})
+	stub := fake.SaveDeliveryStub
+	fakeReturns := fake.saveDeliveryReturns
+	fake.recordInvocation("SaveDelivery", []interface{}{arg1, arg2})
+	fake.saveDeliveryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStorage) SaveDeliveryCallCount() int {
+	fake.saveDeliveryMutex.RLock()
+	defer fake.saveDeliveryMutex.RUnlock()
+	return len(fake.saveDeliveryArgsForCall)
+}
+
+func (fake *FakeStorage) SaveDeliveryCalls(stub func(context.Context, *storage.Delivery) error) {
+	fake.saveDeliveryMutex.Lock()
+	defer fake.saveDeliveryMutex.Unlock()
+	fake.SaveDeliveryStub = stub
+}
+
+func (fake *FakeStorage) SaveDeliveryArgsForCall(i int) (context.Context, *storage.Delivery) {
+	fake.saveDeliveryMutex.RLock()
+	defer fake.saveDeliveryMutex.RUnlock()
+	argsForCall := fake.saveDeliveryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStorage) SaveDeliveryReturns(result1 error) {
+	fake.saveDeliveryMutex.Lock()
+	defer fake.saveDeliveryMutex.Unlock()
+	fake.SaveDeliveryStub = nil
+	fake.saveDeliveryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) SaveDeliveryReturnsOnCall(i int, result1 error) {
+	fake.saveDeliveryMutex.Lock()
+	defer fake.saveDeliveryMutex.Unlock()
+	fake.SaveDeliveryStub = nil
+	if fake.saveDeliveryReturnsOnCall == nil {
+		fake.saveDeliveryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveDeliveryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) Subscriber(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string, arg6 string, arg7 string) (*storage.Subscriber, error) {
+	fake.subscriberMutex.Lock()
+	ret, specificReturn := fake.subscriberReturnsOnCall[len(fake.subscriberArgsForCall)]
+	fake.subscriberArgsForCall = append(fake.subscriberArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 string
+		arg7 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	stub := fake.SubscriberStub
+	fakeReturns := fake.subscriberReturns
+	fake.recordInvocation("Subscriber", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	fake.subscriberMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStorage) SubscriberCallCount() int {
+	fake.subscriberMutex.RLock()
+	defer fake.subscriberMutex.RUnlock()
+	return len(fake.subscriberArgsForCall)
+}
+
+func (fake *FakeStorage) SubscriberCalls(stub func(context.Context, string, string, string, string, string, string) (*storage.Subscriber, error)) {
+	fake.subscriberMutex.Lock()
+	defer fake.subscriberMutex.Unlock()
+	fake.SubscriberStub = stub
+}
+
+func (fake *FakeStorage) SubscriberArgsForCall(i int) (context.Context, string, string, string, string, string, string) {
+	fake.subscriberMutex.RLock()
+	defer fake.subscriberMutex.RUnlock()
+	argsForCall := fake.subscriberArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7
+}
+
+func (fake *FakeStorage) SubscriberReturns(result1 *storage.Subscriber, result2 error) {
+	fake.subscriberMutex.Lock()
+	defer fake.subscriberMutex.Unlock()
+	fake.SubscriberStub = nil
+	fake.subscriberReturns = struct {
+		result1 *storage.Subscriber
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) SubscriberReturnsOnCall(i int, result1 *storage.Subscriber, result2 error) {
+	fake.subscriberMutex.Lock()
+	defer fake.subscriberMutex.Unlock()
+	fake.SubscriberStub = nil
+	if fake.subscriberReturnsOnCall == nil {
+		fake.subscriberReturnsOnCall = make(map[int]struct {
+			result1 *storage.Subscriber
+			result2 error
+		})
+	}
+	fake.subscriberReturnsOnCall[i] = struct {
+		result1 *storage.Subscriber
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStorage) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.claimDeliveryMutex.RLock()
+	defer fake.claimDeliveryMutex.RUnlock()
+	fake.pendingDeliveriesMutex.RLock()
+	defer fake.pendingDeliveriesMutex.RUnlock()
 	fake.policySubscribersMutex.RLock()
 	defer fake.policySubscribersMutex.RUnlock()
+	fake.saveDeliveryMutex.RLock()
+	defer fake.saveDeliveryMutex.RUnlock()
+	fake.subscriberMutex.RLock()
+	defer fake.subscriberMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
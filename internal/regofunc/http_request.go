@@ -0,0 +1,25 @@
+package regofunc
+
+import (
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/open-policy-agent/opa/types"
+)
+
+// GetRequestFunc returns the external.http.request function, which
+// returns the given details of the request evaluating the policy.
+func GetRequestFunc(request map[string]interface{}) (*rego.Function, rego.BuiltinDyn) {
+	return &rego.Function{
+			Name:    "external.http.request",
+			Decl:    types.NewFunction(nil, types.NewObject(nil, types.NewDynamicProperty(types.S, types.A))),
+			Memoize: true,
+		},
+		func(bctx rego.BuiltinContext, terms []*ast.Term) (*ast.Term, error) {
+			v, err := ast.InterfaceToValue(request)
+			if err != nil {
+				return nil, err
+			}
+
+			return ast.NewTerm(v), nil
+		}
+}
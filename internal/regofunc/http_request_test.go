@@ -0,0 +1,49 @@
+package regofunc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/regofunc"
+)
+
+func TestGetRequestFunc(t *testing.T) {
+	request := map[string]interface{}{
+		"method": "POST",
+		"path":   "/policy/policies/xfsc/example/1.0/evaluation",
+		"query":  map[string][]string{"ttl": {"60"}},
+		"claims": map[string]interface{}{"sub": "alice"},
+	}
+
+	t.Run("get request", func(t *testing.T) {
+		r := rego.New(
+			rego.Query(`external.http.request()`),
+			rego.FunctionDyn(regofunc.GetRequestFunc(request)),
+		)
+		resultSet, err := r.Eval(context.Background())
+		require.NoError(t, err)
+
+		result := resultSet[0].Expressions[0].Value
+		assert.Equal(t, map[string]interface{}{
+			"method": "POST",
+			"path":   "/policy/policies/xfsc/example/1.0/evaluation",
+			"query":  map[string]interface{}{"ttl": []interface{}{"60"}},
+			"claims": map[string]interface{}{"sub": "alice"},
+		}, result)
+	})
+
+	t.Run("get caller subject", func(t *testing.T) {
+		r := rego.New(
+			rego.Query(`external.http.request().claims.sub`),
+			rego.FunctionDyn(regofunc.GetRequestFunc(request)),
+		)
+		resultSet, err := r.Eval(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "alice", resultSet[0].Expressions[0].Value)
+	})
+}
@@ -0,0 +1,52 @@
+package request
+
+import (
+	"context"
+	"net/http"
+)
+
+type key string
+
+const requestKey key = "request"
+
+// Request contains the details of an HTTP request which
+// are made available to the evaluated policies.
+type Request struct {
+	Method            string              `json:"method"`
+	Path              string              `json:"path"`
+	Query             map[string][]string `json:"query"`
+	ClientCertSubject string              `json:"clientCertSubject"`
+}
+
+// Middleware is an HTTP server middleware that gets the request method,
+// path, query parameters and client certificate subject and adds them
+// to a request context value.
+func Middleware() func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := ToContext(r.Context(), r)
+			req := r.WithContext(ctx)
+
+			h.ServeHTTP(w, req)
+		})
+	}
+}
+
+func ToContext(ctx context.Context, r *http.Request) context.Context {
+	req := &Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+	}
+
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		req.ClientCertSubject = r.TLS.PeerCertificates[0].Subject.String()
+	}
+
+	return context.WithValue(ctx, requestKey, req)
+}
+
+func FromContext(ctx context.Context) (*Request, bool) {
+	req, ok := ctx.Value(requestKey).(*Request)
+	return req, ok
+}
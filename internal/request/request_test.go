@@ -0,0 +1,38 @@
+package request_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/request"
+)
+
+func TestMiddleware(t *testing.T) {
+	expected := &request.Request{
+		Method:            "POST",
+		Path:              "/policy/policies/xfsc/example/1.0/evaluation",
+		Query:             map[string][]string{"ttl": {"60"}},
+		ClientCertSubject: "CN=client,O=XFSC",
+	}
+
+	req := httptest.NewRequest("POST", "/policy/policies/xfsc/example/1.0/evaluation?ttl=60", nil)
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{
+		{Subject: pkix.Name{CommonName: "client", Organization: []string{"XFSC"}}},
+	}}
+
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value, ok := request.FromContext(r.Context())
+		assert.True(t, ok)
+		assert.Equal(t, expected, value)
+	})
+
+	middleware := request.Middleware()
+	handlerToTest := middleware(nextHandler)
+	handlerToTest.ServeHTTP(httptest.NewRecorder(), req)
+}
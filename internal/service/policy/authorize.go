@@ -0,0 +1,36 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/open-policy-agent/opa/rego"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+)
+
+// Authorize evaluates a system authorization policy with the given input.
+// The policy must return an `allow` boolean value, otherwise the request
+// is not allowed. Authorization results are not stored in the cache.
+func (s *Service) Authorize(ctx context.Context, repository, group, name, version string, input map[string]interface{}) (bool, error) {
+	query, err := s.prepareQuery(ctx, repository, group, name, version, nil, nil)
+	if err != nil {
+		return false, errors.New("error preparing authorization policy", err)
+	}
+
+	resultSet, err := query.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return false, errors.New("error evaluating authorization policy", err)
+	}
+
+	if len(resultSet) == 0 || len(resultSet[0].Expressions) == 0 {
+		return false, nil
+	}
+
+	result, ok := resultSet[0].Expressions[0].Value.(map[string]interface{})
+	if !ok {
+		return false, nil
+	}
+
+	allow, _ := result["allow"].(bool)
+	return allow, nil
+}
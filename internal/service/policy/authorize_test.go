@@ -0,0 +1,85 @@
+package policy_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/service/policy"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/service/policy/policyfakes"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+func TestService_Authorize(t *testing.T) {
+	authzPolicy := func(rego string, locked bool) *policyfakes.FakeRegoCache {
+		return &policyfakes.FakeRegoCache{
+			GetStub: func(key string) (*storage.Policy, bool) {
+				return &storage.Policy{
+					Repository: "system",
+					Filename:   "policy.rego",
+					Group:      "authz",
+					Name:       "admin",
+					Version:    "1.0",
+					Rego:       rego,
+					Locked:     locked,
+				}, true
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		regocache *policyfakes.FakeRegoCache
+		input     map[string]interface{}
+
+		allow   bool
+		errkind errors.Kind
+		errtext string
+	}{
+		{
+			name:      "request is allowed",
+			regocache: authzPolicy(`package authz.admin default allow = false allow { input.claims.role == "admin" }`, false),
+			input:     map[string]interface{}{"claims": map[string]interface{}{"role": "admin"}},
+			allow:     true,
+		},
+		{
+			name:      "request is denied",
+			regocache: authzPolicy(`package authz.admin default allow = false allow { input.claims.role == "admin" }`, false),
+			input:     map[string]interface{}{"claims": map[string]interface{}{"role": "viewer"}},
+			allow:     false,
+		},
+		{
+			name:      "policy does not return allow",
+			regocache: authzPolicy(`package authz.admin result := true`, false),
+			input:     map[string]interface{}{},
+			allow:     false,
+		},
+		{
+			name:      "policy is locked",
+			regocache: authzPolicy(`package authz.admin allow := true`, true),
+			input:     map[string]interface{}{},
+			errkind:   errors.Forbidden,
+			errtext:   "policy is locked",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc := policy.New(context.Background(), nil, test.regocache, nil, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+			allow, err := svc.Authorize(context.Background(), "system", "authz", "admin", "1.0", test.input)
+			if test.errtext != "" {
+				assert.ErrorContains(t, err, test.errtext)
+				assert.True(t, errors.Is(test.errkind, err))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.allow, allow)
+		})
+	}
+}
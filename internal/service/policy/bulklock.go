@@ -0,0 +1,197 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/policy"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+// policySelector selects the policies of a bulk lock or unlock request.
+// Policies must match all given filters and must be in the list of
+// policies, if it's given.
+type policySelector struct {
+	repository *string
+	group      *string
+	name       *string
+	version    *string
+	policies   []*policy.PolicyRef
+}
+
+// BulkLock locks all unlocked policies matching the request at once.
+// Every locked policy notifies the policy change subscribers.
+func (s *Service) BulkLock(ctx context.Context, req *policy.BulkLockRequest) (*policy.BulkLockResult, error) {
+	logger := s.logger.With(zap.String("operation", "bulkLock"))
+
+	lock, err := newLock(ctx, req.Reason, req.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	policies, err := s.selectPolicies(ctx, &policySelector{
+		repository: req.Repository,
+		group:      req.Group,
+		name:       req.PolicyName,
+		version:    req.Version,
+		policies:   req.Policies,
+	}, false)
+	if err != nil {
+		logger.Error("error selecting policies", zap.Error(err))
+		return nil, err
+	}
+
+	if len(policies) > 0 {
+		if err := s.storage.SetPoliciesLock(ctx, policies, lock); err != nil {
+			logger.Error("error locking policies", zap.Error(err))
+			return nil, errors.New("error locking policies", err)
+		}
+	}
+
+	logger.Info("policies are locked", zap.Int("count", len(policies)))
+
+	return bulkLockResult(policies), nil
+}
+
+// BulkUnlock unlocks all locked policies matching the request at once.
+// Every unlocked policy notifies the policy change subscribers.
+func (s *Service) BulkUnlock(ctx context.Context, req *policy.BulkUnlockRequest) (*policy.BulkLockResult, error) {
+	logger := s.logger.With(zap.String("operation", "bulkUnlock"))
+
+	policies, err := s.selectPolicies(ctx, &policySelector{
+		repository: req.Repository,
+		group:      req.Group,
+		name:       req.PolicyName,
+		version:    req.Version,
+		policies:   req.Policies,
+	}, true)
+	if err != nil {
+		logger.Error("error selecting policies", zap.Error(err))
+		return nil, err
+	}
+
+	if len(policies) > 0 {
+		if err := s.storage.SetPoliciesLock(ctx, policies, nil); err != nil {
+			logger.Error("error unlocking policies", zap.Error(err))
+			return nil, errors.New("error unlocking policies", err)
+		}
+	}
+
+	logger.Info("policies are unlocked", zap.Int("count", len(policies)))
+
+	return bulkLockResult(policies), nil
+}
+
+// BulkTargets returns the policies which are locked by a BulkLock request
+// or unlocked by a BulkUnlock request, so that requests can be authorized
+// for each of them. Other requests have no targets.
+func (s *Service) BulkTargets(ctx context.Context, req interface{}) ([]*policy.PolicyRef, error) {
+	var (
+		policies []*storage.Policy
+		err      error
+	)
+	switch r := req.(type) {
+	case *policy.BulkLockRequest:
+		policies, err = s.selectPolicies(ctx, &policySelector{
+			repository: r.Repository,
+			group:      r.Group,
+			name:       r.PolicyName,
+			version:    r.Version,
+			policies:   r.Policies,
+		}, false)
+	case *policy.BulkUnlockRequest:
+		policies, err = s.selectPolicies(ctx, &policySelector{
+			repository: r.Repository,
+			group:      r.Group,
+			name:       r.PolicyName,
+			version:    r.Version,
+			policies:   r.Policies,
+		}, true)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return bulkLockResult(policies).Policies, nil
+}
+
+// selectPolicies returns the policies matching the selector which have
+// the given lock state. An error is returned if a listed policy is not found.
+func (s *Service) selectPolicies(ctx context.Context, sel *policySelector, locked bool) ([]*storage.Policy, error) {
+	if sel.repository == nil && sel.group == nil && sel.name == nil && sel.version == nil && len(sel.policies) == 0 {
+		return nil, errors.New(errors.BadRequest, "policies must be selected by repository, group, policyName, version or a list of policies")
+	}
+
+	if sel.name != nil {
+		if _, err := path.Match(*sel.name, ""); err != nil {
+			return nil, errors.New(errors.BadRequest, "invalid policyName pattern", err)
+		}
+	}
+
+	policies, _, err := s.storage.ListPolicies(ctx, &storage.PolicyQuery{
+		Repository: sel.repository,
+		Group:      sel.group,
+		Version:    sel.version,
+	})
+	if err != nil {
+		return nil, errors.New("error retrieving policies", err)
+	}
+
+	// listed policies which are not found yet
+	missing := make(map[string]bool, len(sel.policies))
+	for _, ref := range sel.policies {
+		missing[policyKey(ref.Repository, ref.Group, ref.PolicyName, ref.Version)] = true
+	}
+
+	var res []*storage.Policy
+	for _, p := range policies {
+		if sel.name != nil {
+			if ok, _ := path.Match(*sel.name, p.Name); !ok {
+				continue
+			}
+		}
+
+		if len(sel.policies) > 0 {
+			key := policyKey(p.Repository, p.Group, p.Name, p.Version)
+			if !missing[key] {
+				continue
+			}
+			delete(missing, key)
+		}
+
+		if p.Locked == locked {
+			res = append(res, p)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, errors.New(errors.NotFound, fmt.Sprintf("policies not found: %s", strings.Join(sortedKeys(missing), ", ")))
+	}
+
+	return res, nil
+}
+
+func policyKey(repository, group, name, version string) string {
+	return repository + "/" + group + "/" + name + "/" + version
+}
+
+func bulkLockResult(policies []*storage.Policy) *policy.BulkLockResult {
+	res := &policy.BulkLockResult{Policies: make([]*policy.PolicyRef, 0, len(policies))}
+	for _, p := range policies {
+		res.Policies = append(res.Policies, &policy.PolicyRef{
+			Repository: p.Repository,
+			Group:      p.Group,
+			PolicyName: p.Name,
+			Version:    p.Version,
+		})
+	}
+
+	return res
+}
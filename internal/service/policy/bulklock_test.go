@@ -0,0 +1,196 @@
+package policy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/ptr"
+	goapolicy "gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/policy"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/caller"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/service/policy/policyfakes"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+func bulkLockPolicies(ctx context.Context, q *storage.PolicyQuery) ([]*storage.Policy, string, error) {
+	return []*storage.Policy{
+		{Repository: "policies", Group: "xfsc", Name: "didResolve", Version: "1.0"},
+		{Repository: "policies", Group: "xfsc", Name: "didWeb", Version: "1.0", Locked: true},
+		{Repository: "policies", Group: "xfsc", Name: "didWeb", Version: "2.0"},
+		{Repository: "policies", Group: "xfsc", Name: "signer", Version: "1.0", Locked: true},
+	}, "", nil
+}
+
+func TestService_BulkLock(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *goapolicy.BulkLockRequest
+		storage *policyfakes.FakeStorage
+
+		res     *goapolicy.BulkLockResult
+		errkind errors.Kind
+		errtext string
+	}{
+		{
+			name:    "policies are not selected",
+			req:     &goapolicy.BulkLockRequest{Reason: ptr.String("incident")},
+			storage: &policyfakes.FakeStorage{},
+			errkind: errors.BadRequest,
+			errtext: "policies must be selected",
+		},
+		{
+			name:    "invalid name pattern",
+			req:     &goapolicy.BulkLockRequest{PolicyName: ptr.String("did[")},
+			storage: &policyfakes.FakeStorage{},
+			errkind: errors.BadRequest,
+			errtext: "invalid policyName pattern",
+		},
+		{
+			name: "error retrieving policies",
+			req:  &goapolicy.BulkLockRequest{Repository: ptr.String("policies")},
+			storage: &policyfakes.FakeStorage{ListPoliciesStub: func(ctx context.Context, q *storage.PolicyQuery) ([]*storage.Policy, string, error) {
+				return nil, "", errors.New("some error")
+			}},
+			errkind: errors.Unknown,
+			errtext: "error retrieving policies",
+		},
+		{
+			name: "listed policy is not found",
+			req: &goapolicy.BulkLockRequest{Policies: []*goapolicy.PolicyRef{
+				{Repository: "policies", Group: "xfsc", PolicyName: "didResolve", Version: "1.0"},
+				{Repository: "policies", Group: "xfsc", PolicyName: "unknown", Version: "1.0"},
+			}},
+			storage: &policyfakes.FakeStorage{ListPoliciesStub: bulkLockPolicies},
+			errkind: errors.NotFound,
+			errtext: "policies not found: policies/xfsc/unknown/1.0",
+		},
+		{
+			name: "error locking policies",
+			req:  &goapolicy.BulkLockRequest{Repository: ptr.String("policies")},
+			storage: &policyfakes.FakeStorage{
+				ListPoliciesStub: bulkLockPolicies,
+				SetPoliciesLockStub: func(ctx context.Context, policies []*storage.Policy, lock *storage.PolicyLock) error {
+					return errors.New(errors.Internal, "storage error")
+				},
+			},
+			errkind: errors.Internal,
+			errtext: "error locking policies",
+		},
+		{
+			name: "unlocked policies matching the name pattern are locked",
+			req: &goapolicy.BulkLockRequest{
+				Repository: ptr.String("policies"),
+				PolicyName: ptr.String("did*"),
+				Reason:     ptr.String("compromised trust anchor"),
+			},
+			storage: &policyfakes.FakeStorage{
+				ListPoliciesStub: func(ctx context.Context, q *storage.PolicyQuery) ([]*storage.Policy, string, error) {
+					assert.Equal(t, ptr.String("policies"), q.Repository)
+					return bulkLockPolicies(ctx, q)
+				},
+				SetPoliciesLockStub: func(ctx context.Context, policies []*storage.Policy, lock *storage.PolicyLock) error {
+					require.NotNil(t, lock)
+					assert.Equal(t, "compromised trust anchor", lock.Reason)
+					assert.Equal(t, "alice", lock.Actor)
+					assert.Len(t, policies, 2)
+					return nil
+				},
+			},
+			res: &goapolicy.BulkLockResult{Policies: []*goapolicy.PolicyRef{
+				{Repository: "policies", Group: "xfsc", PolicyName: "didResolve", Version: "1.0"},
+				{Repository: "policies", Group: "xfsc", PolicyName: "didWeb", Version: "2.0"},
+			}},
+		},
+		{
+			name: "listed policies are locked",
+			req: &goapolicy.BulkLockRequest{Policies: []*goapolicy.PolicyRef{
+				{Repository: "policies", Group: "xfsc", PolicyName: "didWeb", Version: "1.0"},
+				{Repository: "policies", Group: "xfsc", PolicyName: "didWeb", Version: "2.0"},
+			}},
+			storage: &policyfakes.FakeStorage{ListPoliciesStub: bulkLockPolicies},
+			res: &goapolicy.BulkLockResult{Policies: []*goapolicy.PolicyRef{
+				{Repository: "policies", Group: "xfsc", PolicyName: "didWeb", Version: "2.0"},
+			}},
+		},
+		{
+			name: "all matching policies are already locked",
+			req:  &goapolicy.BulkLockRequest{PolicyName: ptr.String("signer")},
+			storage: &policyfakes.FakeStorage{
+				ListPoliciesStub: bulkLockPolicies,
+				SetPoliciesLockStub: func(ctx context.Context, policies []*storage.Policy, lock *storage.PolicyLock) error {
+					return errors.New(errors.Internal, "no policies should be locked")
+				},
+			},
+			res: &goapolicy.BulkLockResult{Policies: []*goapolicy.PolicyRef{}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := caller.ToContext(context.Background(), &caller.Caller{ID: "alice"})
+			res, err := newRevisionsService(test.storage).BulkLock(ctx, test.req)
+			if test.errtext != "" {
+				require.Error(t, err)
+				e, ok := err.(*errors.Error)
+				assert.True(t, ok)
+				assert.Contains(t, e.Error(), test.errtext)
+				assert.Equal(t, test.errkind, e.Kind)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestService_BulkUnlock(t *testing.T) {
+	fake := &policyfakes.FakeStorage{
+		ListPoliciesStub: bulkLockPolicies,
+		SetPoliciesLockStub: func(ctx context.Context, policies []*storage.Policy, lock *storage.PolicyLock) error {
+			assert.Nil(t, lock)
+			return nil
+		},
+	}
+
+	res, err := newRevisionsService(fake).BulkUnlock(context.Background(), &goapolicy.BulkUnlockRequest{Group: ptr.String("xfsc")})
+	require.NoError(t, err)
+	assert.Equal(t, &goapolicy.BulkLockResult{Policies: []*goapolicy.PolicyRef{
+		{Repository: "policies", Group: "xfsc", PolicyName: "didWeb", Version: "1.0"},
+		{Repository: "policies", Group: "xfsc", PolicyName: "signer", Version: "1.0"},
+	}}, res)
+	assert.Equal(t, 1, fake.SetPoliciesLockCallCount())
+
+	_, err = newRevisionsService(fake).BulkUnlock(context.Background(), &goapolicy.BulkUnlockRequest{})
+	assert.True(t, errors.Is(errors.BadRequest, err))
+}
+
+func TestService_BulkTargets(t *testing.T) {
+	fake := &policyfakes.FakeStorage{ListPoliciesStub: bulkLockPolicies}
+	svc := newRevisionsService(fake)
+
+	targets, err := svc.BulkTargets(context.Background(), &goapolicy.BulkLockRequest{PolicyName: ptr.String("did*")})
+	require.NoError(t, err)
+	assert.Equal(t, []*goapolicy.PolicyRef{
+		{Repository: "policies", Group: "xfsc", PolicyName: "didResolve", Version: "1.0"},
+		{Repository: "policies", Group: "xfsc", PolicyName: "didWeb", Version: "2.0"},
+	}, targets)
+
+	targets, err = svc.BulkTargets(context.Background(), &goapolicy.BulkUnlockRequest{Version: ptr.String("1.0")})
+	require.NoError(t, err)
+	assert.Equal(t, []*goapolicy.PolicyRef{
+		{Repository: "policies", Group: "xfsc", PolicyName: "didWeb", Version: "1.0"},
+		{Repository: "policies", Group: "xfsc", PolicyName: "signer", Version: "1.0"},
+	}, targets)
+
+	_, err = svc.BulkTargets(context.Background(), &goapolicy.BulkUnlockRequest{})
+	assert.True(t, errors.Is(errors.BadRequest, err))
+
+	targets, err = svc.BulkTargets(context.Background(), &goapolicy.LockRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, targets)
+	assert.Equal(t, 2, fake.ListPoliciesCallCount())
+}
@@ -22,16 +22,25 @@ type ZipFile struct {
 
 type Metadata struct {
 	Policy struct {
-		Name       string    `json:"name"`
-		Group      string    `json:"group"`
-		Version    string    `json:"version"`
-		Repository string    `json:"repository"`
-		Locked     bool      `json:"locked"`
-		LastUpdate time.Time `json:"lastUpdate"`
+		Name       string          `json:"name"`
+		Group      string          `json:"group"`
+		Version    string          `json:"version"`
+		Repository string          `json:"repository"`
+		Locked     bool            `json:"locked"`
+		LastUpdate time.Time       `json:"lastUpdate"`
+		Commit     *MetadataCommit `json:"commit,omitempty"`
 	} `json:"policy"`
 	PublicKeyURL string `json:"publicKeyURL"`
 }
 
+// MetadataCommit is the Git commit from which the exported policy is synchronized.
+type MetadataCommit struct {
+	SHA    string    `json:"sha"`
+	Time   time.Time `json:"time"`
+	Author string    `json:"author"`
+	Branch string    `json:"branch,omitempty"`
+}
+
 // exportConfig specifies which signer namespace and key to be used
 // when exporting policy as signed bundle.
 type exportConfig struct {
@@ -102,6 +111,14 @@ func (s *Service) createMetadata(policy *storage.Policy) ([]byte, error) {
 	meta.Policy.Repository = policy.Repository
 	meta.Policy.Locked = policy.Locked
 	meta.Policy.LastUpdate = policy.LastUpdate
+	if policy.Commit != nil {
+		meta.Policy.Commit = &MetadataCommit{
+			SHA:    policy.Commit.SHA,
+			Time:   policy.Commit.Time,
+			Author: policy.Commit.Author,
+			Branch: policy.Commit.Branch,
+		}
+	}
 	meta.PublicKeyURL = s.policyPublicKeyURL(policy)
 	return json.Marshal(meta)
 }
@@ -128,7 +145,7 @@ func (s *Service) createZipArchive(files []ZipFile) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-func (s *Service) unzip(archive []byte) ([]ZipFile, error) {
+func unzip(archive []byte) ([]ZipFile, error) {
 	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
 	if err != nil {
 		return nil, err
@@ -164,7 +181,7 @@ func (s *Service) policyPublicKeyURL(policy *storage.Policy) string {
 }
 
 func (s *Service) policyFromBundle(bundle []byte) (*storage.Policy, error) {
-	bundleFiles, err := s.unzip(bundle)
+	bundleFiles, err := unzip(bundle)
 	if err != nil {
 		return nil, errors.New("error unzipping bundle archive", err)
 	}
@@ -183,6 +200,9 @@ func (s *Service) policyFromBundle(bundle []byte) (*storage.Policy, error) {
 			policy.Version = metadata.Policy.Version
 			policy.Locked = metadata.Policy.Locked
 			policy.LastUpdate = metadata.Policy.LastUpdate
+			if c := metadata.Policy.Commit; c != nil {
+				policy.Commit = &storage.Commit{SHA: c.SHA, Time: c.Time, Author: c.Author, Branch: c.Branch}
+			}
 			policy.Filename = policy.Group + "/" + policy.Name + "/" + policy.Version + "/" + "policy.rego"
 		case "policy.rego":
 			policy.Rego = string(f.Content)
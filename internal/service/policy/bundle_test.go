@@ -4,12 +4,17 @@ import (
 	"archive/zip"
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
 	"encoding/json"
 	"io"
 	"net/http"
 	"testing"
 	"time"
 
+	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -28,17 +33,24 @@ var testPolicy = &storage.Policy{
 	Data:       `{"hello":"static data"}`,
 	DataConfig: `{"cfg":"static data config"}`,
 	Locked:     true,
+	Commit: &storage.Commit{
+		SHA:    "8f1c2a0e5b9d4c3f7a6e1d2b3c4a5f6e7d8c9b0a",
+		Time:   time.Date(2023, 11, 6, 12, 0, 0, 0, time.UTC),
+		Author: "Jane Doe <jane@example.com>",
+		Branch: "main",
+	},
 	LastUpdate: time.Date(2023, 11, 7, 1, 0, 0, 0, time.UTC),
 }
 
 var testMetadata = Metadata{
 	Policy: struct {
-		Name       string    `json:"name"`
-		Group      string    `json:"group"`
-		Version    string    `json:"version"`
-		Repository string    `json:"repository"`
-		Locked     bool      `json:"locked"`
-		LastUpdate time.Time `json:"lastUpdate"`
+		Name       string          `json:"name"`
+		Group      string          `json:"group"`
+		Version    string          `json:"version"`
+		Repository string          `json:"repository"`
+		Locked     bool            `json:"locked"`
+		LastUpdate time.Time       `json:"lastUpdate"`
+		Commit     *MetadataCommit `json:"commit,omitempty"`
 	}{
 		Name:       "mypolicy",
 		Group:      "example",
@@ -46,6 +58,12 @@ var testMetadata = Metadata{
 		Repository: "myrepo",
 		Locked:     true,
 		LastUpdate: time.Date(2023, 11, 7, 1, 0, 0, 0, time.UTC),
+		Commit: &MetadataCommit{
+			SHA:    "8f1c2a0e5b9d4c3f7a6e1d2b3c4a5f6e7d8c9b0a",
+			Time:   time.Date(2023, 11, 6, 12, 0, 0, 0, time.UTC),
+			Author: "Jane Doe <jane@example.com>",
+			Branch: "main",
+		},
 	},
 	PublicKeyURL: "https://policyservice.com/policy/myrepo/example/mypolicy/1.0/key",
 }
@@ -110,3 +128,66 @@ func TestPolicy_policyFromBundle(t *testing.T) {
 	require.NotNil(t, policy)
 	assert.Equal(t, testPolicy, policy)
 }
+
+func TestVerifyBundle(t *testing.T) {
+	svc := New(context.Background(), nil, nil, nil, nil, "https://policyservice.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+	policyBundle, err := svc.createPolicyBundle(testPolicy)
+	require.NoError(t, err)
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	publicKey, err := jwk.FromRaw(privateKey.Public())
+	require.NoError(t, err)
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherPublicKey, err := jwk.FromRaw(otherKey.Public())
+	require.NoError(t, err)
+
+	// the signed payload is the sha256 hash of the bundle, which is
+	// hashed again with sha256 by the ECDSA signing algorithm
+	payload := sha256.Sum256(policyBundle)
+	hash := sha256.Sum256(payload[:])
+	signature, err := ecdsa.SignASN1(rand.Reader, privateKey, hash[:])
+	require.NoError(t, err)
+
+	signedBundle := func(files ...ZipFile) []byte {
+		buf := new(bytes.Buffer)
+		w := zip.NewWriter(buf)
+		for _, f := range files {
+			fw, err := w.Create(f.Name)
+			require.NoError(t, err)
+			_, err = fw.Write(f.Content)
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close())
+		return buf.Bytes()
+	}
+
+	t.Run("valid signature", func(t *testing.T) {
+		archive := signedBundle(
+			ZipFile{Name: BundleFilename, Content: policyBundle},
+			ZipFile{Name: BundleSignatureFilename, Content: signature},
+		)
+		metadata, err := VerifyBundle(archive, publicKey)
+		require.NoError(t, err)
+		assert.Equal(t, testMetadata, *metadata)
+	})
+
+	t.Run("signature with different key", func(t *testing.T) {
+		archive := signedBundle(
+			ZipFile{Name: BundleFilename, Content: policyBundle},
+			ZipFile{Name: BundleSignatureFilename, Content: signature},
+		)
+		metadata, err := VerifyBundle(archive, otherPublicKey)
+		assert.Nil(t, metadata)
+		assert.EqualError(t, err, "invalid signature")
+	})
+
+	t.Run("missing signature file", func(t *testing.T) {
+		archive := signedBundle(ZipFile{Name: BundleFilename, Content: policyBundle})
+		metadata, err := VerifyBundle(archive, publicKey)
+		assert.Nil(t, metadata)
+		assert.EqualError(t, err, "invalid bundle: expected to contain two files, but has: 1")
+	})
+}
@@ -15,31 +15,11 @@ import (
 )
 
 func (s *Service) verifyBundle(ctx context.Context, files []ZipFile) error {
-	policyBundleFile := files[0]
-	signatureFile := files[1]
-
-	if policyBundleFile.Name != BundleFilename {
-		return fmt.Errorf("verify bundle: invalid bundle filename: %q", files[0].Name)
-	}
-
-	if signatureFile.Name != BundleSignatureFilename {
-		return fmt.Errorf("verify bundle: invalid signature filename: %q", files[1].Name)
-	}
-
-	bundleFiles, err := s.unzip(policyBundleFile.Content)
+	metadata, err := bundleMetadata(files)
 	if err != nil {
 		return err
 	}
 
-	if len(bundleFiles) == 0 || bundleFiles[0].Name != "metadata.json" {
-		return fmt.Errorf("invalid bundle")
-	}
-
-	var metadata Metadata
-	if err := json.Unmarshal(bundleFiles[0].Content, &metadata); err != nil {
-		return fmt.Errorf("failed to unmarshal metadata: %v", err)
-	}
-
 	// whitelist is insecure to allow fetching keys from arbitrary external locations
 	// TODO: this can be fine-tuned with configuration variable so that organizations
 	// can specify trusted import locations.
@@ -58,25 +38,84 @@ func (s *Service) verifyBundle(ctx context.Context, files []ZipFile) error {
 		return fmt.Errorf("cannot get bundle verification key")
 	}
 
+	return verifySignature(files[0].Content, files[1].Content, verKey)
+}
+
+// VerifyBundle verifies the signature of a policy bundle ZIP archive
+// with the given public key. In contrast to the bundle import, the
+// verification key is not fetched from the bundle publicKeyURL, so
+// bundles can be verified offline. It returns the bundle metadata.
+func VerifyBundle(archive []byte, key jwk.Key) (*Metadata, error) {
+	files, err := unzip(archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unzip bundle: %v", err)
+	}
+
+	if len(files) != 2 {
+		return nil, fmt.Errorf("invalid bundle: expected to contain two files, but has: %d", len(files))
+	}
+
+	metadata, err := bundleMetadata(files)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifySignature(files[0].Content, files[1].Content, key); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// bundleMetadata validates the structure of the bundle files and
+// returns the metadata of the policy bundle.
+func bundleMetadata(files []ZipFile) (*Metadata, error) {
+	policyBundleFile := files[0]
+	signatureFile := files[1]
+
+	if policyBundleFile.Name != BundleFilename {
+		return nil, fmt.Errorf("verify bundle: invalid bundle filename: %q", files[0].Name)
+	}
+
+	if signatureFile.Name != BundleSignatureFilename {
+		return nil, fmt.Errorf("verify bundle: invalid signature filename: %q", files[1].Name)
+	}
+
+	bundleFiles, err := unzip(policyBundleFile.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bundleFiles) == 0 || bundleFiles[0].Name != "metadata.json" {
+		return nil, fmt.Errorf("invalid bundle")
+	}
+
+	var metadata Metadata
+	if err := json.Unmarshal(bundleFiles[0].Content, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %v", err)
+	}
+
+	return &metadata, nil
+}
+
+func verifySignature(bundle []byte, signature []byte, key jwk.Key) error {
 	// the payload that is signed on policy export is the sha256 hash of the
 	// policy bundle zip file itself, so this is the payload that should be verified
-	payload := sha256.Sum256(policyBundleFile.Content)
+	payload := sha256.Sum256(bundle)
 
-	switch kt := verKey.KeyType(); kt {
+	switch kt := key.KeyType(); kt {
 	case jwa.EC:
-		err = s.verifyECDSA(payload[:], signatureFile.Content, verKey)
+		return verifyECDSA(payload[:], signature, key)
 	case jwa.OKP:
-		err = s.verifyED25519(payload[:], signatureFile.Content, verKey)
+		return verifyED25519(payload[:], signature, key)
 	case jwa.RSA:
-		err = s.verifyRSA(payload[:], signatureFile.Content, verKey)
+		return verifyRSA(payload[:], signature, key)
 	default:
 		return fmt.Errorf("unsupported public key type: %v", kt)
 	}
-
-	return err
 }
 
-func (s *Service) verifyECDSA(payload []byte, signature []byte, key jwk.Key) error {
+func verifyECDSA(payload []byte, signature []byte, key jwk.Key) error {
 	// convert key from JWK to ecdsa.PublicKey
 	var ecdsaKey ecdsa.PublicKey
 	if err := key.Raw(&ecdsaKey); err != nil {
@@ -97,7 +136,7 @@ func (s *Service) verifyECDSA(payload []byte, signature []byte, key jwk.Key) err
 	return nil
 }
 
-func (s *Service) verifyED25519(payload []byte, signature []byte, key jwk.Key) error {
+func verifyED25519(payload []byte, signature []byte, key jwk.Key) error {
 	// convert key from JWK to ed25519.PublicKey
 	var ed25519Key ed25519.PublicKey
 	if err := key.Raw(&ed25519Key); err != nil {
@@ -114,7 +153,7 @@ func (s *Service) verifyED25519(payload []byte, signature []byte, key jwk.Key) e
 	return nil
 }
 
-func (s *Service) verifyRSA(payload []byte, signature []byte, key jwk.Key) error {
+func verifyRSA(payload []byte, signature []byte, key jwk.Key) error {
 	// convert key from JWK to rsa.PublicKey
 	var rsaKey rsa.PublicKey
 	if err := key.Raw(&rsaKey); err != nil {
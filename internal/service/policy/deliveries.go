@@ -0,0 +1,82 @@
+package policy
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+
+	"go.uber.org/zap"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/ptr"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/policy"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+// SubscriberDeliveries returns the history of webhook deliveries
+// to a policy change subscriber.
+func (s *Service) SubscriberDeliveries(ctx context.Context, req *policy.SubscriberDeliveriesRequest) (*policy.SubscriberDeliveriesResult, error) {
+	logger := s.logger.With(
+		zap.String("operation", "subscriberDeliveries"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("version", req.Version),
+		zap.String("subscriber", req.Subscriber),
+	)
+
+	deliveries, err := s.storage.SubscriberDeliveries(ctx, req.Repository, req.Group, req.PolicyName, req.Version, req.Subscriber)
+	if err != nil {
+		logger.Error("error getting webhook deliveries from storage", zap.Error(err))
+		return nil, errors.New("error getting webhook deliveries", err)
+	}
+
+	res := &policy.SubscriberDeliveriesResult{Deliveries: []*policy.WebhookDelivery{}}
+	for _, d := range deliveries {
+		delivery, err := deliveryResult(d)
+		if err != nil {
+			logger.Error("error decoding webhook delivery event", zap.Error(err), zap.String("deliveryID", d.ID))
+			return nil, errors.New("error decoding webhook delivery event", err)
+		}
+		res.Deliveries = append(res.Deliveries, delivery)
+	}
+
+	return res, nil
+}
+
+func deliveryResult(d *storage.Delivery) (*policy.WebhookDelivery, error) {
+	var event any
+	if err := json.Unmarshal(d.Event, &event); err != nil {
+		return nil, err
+	}
+
+	res := &policy.WebhookDelivery{
+		ID:        d.ID,
+		Event:     event,
+		State:     d.State,
+		Attempts:  d.Attempts,
+		CreatedAt: d.CreatedAt.Unix(),
+		UpdatedAt: d.UpdatedAt.Unix(),
+	}
+
+	if d.LastError != "" {
+		res.LastError = ptr.String(d.LastError)
+	}
+
+	if d.State == storage.DeliveryPending {
+		res.NextAttempt = ptr.Int64(d.NextAttempt.Unix())
+	}
+
+	return res, nil
+}
+
+// webhookSecret returns a random key for signing webhook calls.
+func webhookSecret() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(key), nil
+}
@@ -0,0 +1,110 @@
+package policy_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/ptr"
+	goapolicy "gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/policy"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/service/policy/policyfakes"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+func TestService_SubscriberDeliveries(t *testing.T) {
+	tests := []struct {
+		name    string
+		storage *policyfakes.FakeStorage
+
+		res     *goapolicy.SubscriberDeliveriesResult
+		errtext string
+	}{
+		{
+			name: "error getting deliveries",
+			storage: &policyfakes.FakeStorage{
+				SubscriberDeliveriesStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string, s5 string) ([]*storage.Delivery, error) {
+					return nil, errors.New("some error")
+				},
+			},
+			errtext: "error getting webhook deliveries",
+		},
+		{
+			name:    "subscriber has no deliveries",
+			storage: &policyfakes.FakeStorage{},
+			res:     &goapolicy.SubscriberDeliveriesResult{Deliveries: []*goapolicy.WebhookDelivery{}},
+		},
+		{
+			name: "deliveries are returned",
+			storage: &policyfakes.FakeStorage{
+				SubscriberDeliveriesStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string, s5 string) ([]*storage.Delivery, error) {
+					return []*storage.Delivery{
+						{
+							ID:          "1",
+							Event:       []byte(`{"repository":"policies"}`),
+							State:       storage.DeliveryDelivered,
+							Attempts:    1,
+							NextAttempt: time.Unix(1700000010, 0),
+							CreatedAt:   time.Unix(1700000000, 0),
+							UpdatedAt:   time.Unix(1700000001, 0),
+						},
+						{
+							ID:          "2",
+							Event:       []byte(`{"repository":"policies"}`),
+							State:       storage.DeliveryPending,
+							Attempts:    2,
+							LastError:   "connection refused",
+							NextAttempt: time.Unix(1700000040, 0),
+							CreatedAt:   time.Unix(1700000020, 0),
+							UpdatedAt:   time.Unix(1700000030, 0),
+						},
+					}, nil
+				},
+			},
+			res: &goapolicy.SubscriberDeliveriesResult{
+				Deliveries: []*goapolicy.WebhookDelivery{
+					{
+						ID:        "1",
+						Event:     map[string]interface{}{"repository": "policies"},
+						State:     storage.DeliveryDelivered,
+						Attempts:  1,
+						CreatedAt: 1700000000,
+						UpdatedAt: 1700000001,
+					},
+					{
+						ID:          "2",
+						Event:       map[string]interface{}{"repository": "policies"},
+						State:       storage.DeliveryPending,
+						Attempts:    2,
+						LastError:   ptr.String("connection refused"),
+						NextAttempt: ptr.Int64(1700000040),
+						CreatedAt:   1700000020,
+						UpdatedAt:   1700000030,
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := newRevisionsService(test.storage).SubscriberDeliveries(context.Background(), &goapolicy.SubscriberDeliveriesRequest{
+				Repository: "policies",
+				Group:      "testgroup",
+				PolicyName: "example",
+				Version:    "1.0",
+				Subscriber: "subscriber",
+			})
+			if test.errtext != "" {
+				assert.ErrorContains(t, err, test.errtext)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
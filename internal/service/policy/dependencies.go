@@ -0,0 +1,173 @@
+package policy
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/open-policy-agent/opa/ast"
+	"go.uber.org/zap"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/ptr"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/policy"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+// PolicyDependencies reports the builtin and extension functions, imported
+// packages and data paths used by the stored policies. If a builtin is given,
+// only the policies calling the builtin are returned.
+func (s *Service) PolicyDependencies(ctx context.Context, req *policy.PolicyDependenciesRequest) (*policy.PolicyDependenciesResult, error) {
+	logger := s.logger.With(zap.String("operation", "policyDependencies"))
+
+	policies, _, err := s.storage.ListPolicies(ctx, &storage.PolicyQuery{Repository: req.Repository, Rego: true})
+	if err != nil {
+		logger.Error("error retrieving policies", zap.Error(err))
+		return nil, errors.New("error retrieving policies", err)
+	}
+
+	res := &policy.PolicyDependenciesResult{Policies: []*policy.DependencyReport{}}
+	for _, p := range policies {
+		deps, err := analyzeDependencies(p.Rego)
+		if req.Builtin != nil && (err != nil || !deps.calls(*req.Builtin)) {
+			continue
+		}
+
+		item := &policy.DependencyReport{
+			Repository: p.Repository,
+			Group:      p.Group,
+			PolicyName: p.Name,
+			Version:    p.Version,
+			Builtins:   []string{},
+			Imports:    []string{},
+			DataPaths:  []string{},
+		}
+
+		if err != nil {
+			item.Error = ptr.String(err.Error())
+		} else {
+			item.Builtins = deps.builtins
+			item.Imports = deps.imports
+			item.DataPaths = deps.dataPaths
+		}
+
+		res.Policies = append(res.Policies, item)
+	}
+
+	return res, nil
+}
+
+// dependencies of a policy found in its source code.
+type dependencies struct {
+	builtins  []string
+	imports   []string
+	dataPaths []string
+}
+
+// analyzeDependencies parses the policy source code and returns the
+// called builtin functions, the imported packages and the constant
+// prefixes of the referenced data paths in sorted order. Calls of
+// functions defined in the policy or in data are not builtin calls.
+func analyzeDependencies(rego string) (*dependencies, error) {
+	module, err := ast.ParseModule("policy.rego", rego)
+	if err != nil {
+		return nil, err
+	}
+
+	// rules and functions defined in the policy
+	local := make(map[string]bool)
+	for _, rule := range module.Rules {
+		local[rule.Head.Ref()[0].String()] = true
+	}
+
+	// aliases of imported data packages, e.g. import data.lib.helpers as h
+	aliases := make(map[string]ast.Ref)
+	imports := make(map[string]bool)
+	for _, imp := range module.Imports {
+		path, ok := imp.Path.Value.(ast.Ref)
+		if !ok || ast.FutureRootDocument.Equal(path[0]) || ast.RegoRootDocument.Equal(path[0]) {
+			continue
+		}
+		imports[path.String()] = true
+
+		if !ast.DefaultRootDocument.Equal(path[0]) {
+			continue
+		}
+		alias := imp.Alias.String()
+		if alias == "" {
+			alias = strings.Trim(path[len(path)-1].String(), `"`)
+		}
+		aliases[alias] = path
+	}
+
+	builtins := make(map[string]bool)
+	addCall := func(operator ast.Ref) {
+		name := operator.String()
+		head := operator[0].String()
+		if ast.DefaultRootDocument.Equal(operator[0]) || local[head] || aliases[head] != nil {
+			return
+		}
+		// operators like := and == are builtins as well
+		if b, ok := ast.BuiltinMap[name]; ok && b.Infix != "" {
+			return
+		}
+		builtins[name] = true
+	}
+
+	dataPaths := make(map[string]bool)
+	for _, rule := range module.Rules {
+		ast.WalkExprs(rule, func(expr *ast.Expr) bool {
+			if expr.IsCall() {
+				addCall(expr.Operator())
+			}
+			return false
+		})
+
+		ast.WalkTerms(rule, func(term *ast.Term) bool {
+			if call, ok := term.Value.(ast.Call); ok {
+				if operator, ok := call[0].Value.(ast.Ref); ok {
+					addCall(operator)
+				}
+			}
+			return false
+		})
+
+		ast.WalkRefs(rule, func(ref ast.Ref) bool {
+			if path, ok := aliases[ref[0].String()]; ok {
+				ref = path.Concat(ref[1:])
+			}
+			if ast.DefaultRootDocument.Equal(ref[0]) {
+				dataPaths[ref.ConstantPrefix().String()] = true
+			}
+			return false
+		})
+	}
+
+	return &dependencies{
+		builtins:  sortedKeys(builtins),
+		imports:   sortedKeys(imports),
+		dataPaths: sortedKeys(dataPaths),
+	}, nil
+}
+
+// calls reports whether the policy calls the builtin. A builtin ending
+// with .* matches all builtins with the prefix, e.g. ocm.*
+func (d *dependencies) calls(builtin string) bool {
+	prefix, wildcard := strings.CutSuffix(builtin, "*")
+	for _, b := range d.builtins {
+		if b == builtin || (wildcard && strings.HasPrefix(b, prefix)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,146 @@
+package policy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/ptr"
+	goapolicy "gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/policy"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/service/policy/policyfakes"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+const dependenciesRego = `package testgroup.example
+
+import future.keywords.if
+import data.lib.helpers as h
+import data.issuers
+
+default allow := false
+
+allow if {
+	doc := did.resolve(input.did)
+	h.trusted(doc.issuer)
+	issuers[doc.issuer].active == true
+	count(data.settings.keys[input.kid]) > 0
+	is_valid(doc)
+}
+
+credentials := ocm.getLoginProofResult(input.presentationID)
+
+is_valid(doc) := startswith(doc.id, "did:web:")
+`
+
+func TestService_PolicyDependencies(t *testing.T) {
+	policies := []*storage.Policy{
+		{
+			Repository: "policies",
+			Group:      "testgroup",
+			Name:       "example",
+			Version:    "1.0",
+			Rego:       dependenciesRego,
+		},
+		{
+			Repository: "policies",
+			Group:      "testgroup",
+			Name:       "broken",
+			Version:    "1.0",
+			Rego:       "package testgroup.broken\n\nallow := {",
+		},
+	}
+
+	example := &goapolicy.DependencyReport{
+		Repository: "policies",
+		Group:      "testgroup",
+		PolicyName: "example",
+		Version:    "1.0",
+		Builtins:   []string{"count", "did.resolve", "ocm.getLoginProofResult", "startswith"},
+		Imports:    []string{"data.issuers", "data.lib.helpers"},
+		DataPaths:  []string{"data.issuers", "data.lib.helpers.trusted", "data.settings.keys"},
+	}
+
+	tests := []struct {
+		name    string
+		storage *policyfakes.FakeStorage
+		request *goapolicy.PolicyDependenciesRequest
+
+		res     *goapolicy.PolicyDependenciesResult
+		errkind errors.Kind
+		errtext string
+	}{
+		{
+			name: "error retrieving policies",
+			storage: &policyfakes.FakeStorage{ListPoliciesStub: func(ctx context.Context, q *storage.PolicyQuery) ([]*storage.Policy, string, error) {
+				return nil, "", errors.New("some error")
+			}},
+			request: &goapolicy.PolicyDependenciesRequest{},
+			errkind: errors.Unknown,
+			errtext: "error retrieving policies",
+		},
+		{
+			name: "dependencies of all policies",
+			storage: &policyfakes.FakeStorage{ListPoliciesStub: func(ctx context.Context, q *storage.PolicyQuery) ([]*storage.Policy, string, error) {
+				return policies, "", nil
+			}},
+			request: &goapolicy.PolicyDependenciesRequest{},
+			res: &goapolicy.PolicyDependenciesResult{Policies: []*goapolicy.DependencyReport{
+				example,
+				{
+					Repository: "policies",
+					Group:      "testgroup",
+					PolicyName: "broken",
+					Version:    "1.0",
+					Builtins:   []string{},
+					Imports:    []string{},
+					DataPaths:  []string{},
+					Error:      ptr.String("1 error occurred: policy.rego:3: rego_parse_error: unexpected assign token: expected rule value term (e.g., allow := <VALUE> { ... })\n\tallow := {\n\t      ^"),
+				},
+			}},
+		},
+		{
+			name: "policies calling a builtin",
+			storage: &policyfakes.FakeStorage{ListPoliciesStub: func(ctx context.Context, q *storage.PolicyQuery) ([]*storage.Policy, string, error) {
+				return policies, "", nil
+			}},
+			request: &goapolicy.PolicyDependenciesRequest{Builtin: ptr.String("did.resolve")},
+			res:     &goapolicy.PolicyDependenciesResult{Policies: []*goapolicy.DependencyReport{example}},
+		},
+		{
+			name: "policies calling builtins with a prefix",
+			storage: &policyfakes.FakeStorage{ListPoliciesStub: func(ctx context.Context, q *storage.PolicyQuery) ([]*storage.Policy, string, error) {
+				return policies, "", nil
+			}},
+			request: &goapolicy.PolicyDependenciesRequest{Builtin: ptr.String("ocm.*")},
+			res:     &goapolicy.PolicyDependenciesResult{Policies: []*goapolicy.DependencyReport{example}},
+		},
+		{
+			name: "no policies calling a builtin",
+			storage: &policyfakes.FakeStorage{ListPoliciesStub: func(ctx context.Context, q *storage.PolicyQuery) ([]*storage.Policy, string, error) {
+				return policies, "", nil
+			}},
+			request: &goapolicy.PolicyDependenciesRequest{Builtin: ptr.String("task.create")},
+			res:     &goapolicy.PolicyDependenciesResult{Policies: []*goapolicy.DependencyReport{}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := newRevisionsService(test.storage).PolicyDependencies(context.Background(), test.request)
+			if test.errtext != "" {
+				require.Error(t, err)
+				e, ok := err.(*errors.Error)
+				assert.True(t, ok)
+				assert.Contains(t, e.Error(), test.errtext)
+				assert.Equal(t, test.errkind, e.Kind)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
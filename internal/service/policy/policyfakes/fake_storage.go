@@ -98,6 +98,21 @@ type FakeStorage struct {
 	deleteDataReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeletePolicyStub        func(context.Context, string, string, string, string) error
+	deletePolicyMutex       sync.RWMutex
+	deletePolicyArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}
+	deletePolicyReturns struct {
+		result1 error
+	}
+	deletePolicyReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetDataStub        func(context.Context, string) (any, error)
 	getDataMutex       sync.RWMutex
 	getDataArgsForCall []struct {
@@ -112,20 +127,21 @@ type FakeStorage struct {
 		result1 any
 		result2 error
 	}
-	GetPoliciesStub        func(context.Context, *bool, *string) ([]*storage.Policy, error)
-	getPoliciesMutex       sync.RWMutex
-	getPoliciesArgsForCall []struct {
+	ListPoliciesStub        func(context.Context, *storage.PolicyQuery) ([]*storage.Policy, string, error)
+	listPoliciesMutex       sync.RWMutex
+	listPoliciesArgsForCall []struct {
 		arg1 context.Context
-		arg2 *bool
-		arg3 *string
+		arg2 *storage.PolicyQuery
 	}
-	getPoliciesReturns struct {
+	listPoliciesReturns struct {
 		result1 []*storage.Policy
-		result2 error
+		result2 string
+		result3 error
 	}
-	getPoliciesReturnsOnCall map[int]struct {
+	listPoliciesReturnsOnCall map[int]struct {
 		result1 []*storage.Policy
-		result2 error
+		result2 string
+		result3 error
 	}
 	ListenPolicyDataChangesStub        func(context.Context) error
 	listenPolicyDataChangesMutex       sync.RWMutex
@@ -155,6 +171,41 @@ type FakeStorage struct {
 		result1 *storage.Policy
 		result2 error
 	}
+	PolicyRevisionStub        func(context.Context, string, string, string, string, int) (*storage.PolicyRevision, error)
+	policyRevisionMutex       sync.RWMutex
+	policyRevisionArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 int
+	}
+	policyRevisionReturns struct {
+		result1 *storage.PolicyRevision
+		result2 error
+	}
+	policyRevisionReturnsOnCall map[int]struct {
+		result1 *storage.PolicyRevision
+		result2 error
+	}
+	PolicyRevisionsStub        func(context.Context, string, string, string, string) ([]*storage.PolicyRevision, error)
+	policyRevisionsMutex       sync.RWMutex
+	policyRevisionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}
+	policyRevisionsReturns struct {
+		result1 []*storage.PolicyRevision
+		result2 error
+	}
+	policyRevisionsReturnsOnCall map[int]struct {
+		result1 []*storage.PolicyRevision
+		result2 error
+	}
 	SaveAutoImportConfigStub        func(context.Context, *storage.PolicyAutoImport) error
 	saveAutoImportConfigMutex       sync.RWMutex
 	saveAutoImportConfigArgsForCall []struct {
@@ -192,7 +243,20 @@ type FakeStorage struct {
 	setDataReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SetPolicyLockStub        func(context.Context, string, string, string, string, bool) error
+	SetPoliciesLockStub        func(context.Context, []*storage.Policy, *storage.PolicyLock) error
+	setPoliciesLockMutex       sync.RWMutex
+	setPoliciesLockArgsForCall []struct {
+		arg1 context.Context
+		arg2 []*storage.Policy
+		arg3 *storage.PolicyLock
+	}
+	setPoliciesLockReturns struct {
+		result1 error
+	}
+	setPoliciesLockReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetPolicyLockStub        func(context.Context, string, string, string, string, *storage.PolicyLock) error
 	setPolicyLockMutex       sync.RWMutex
 	setPolicyLockArgsForCall []struct {
 		arg1 context.Context
@@ -200,7 +264,7 @@ type FakeStorage struct {
 		arg3 string
 		arg4 string
 		arg5 string
-		arg6 bool
+		arg6 *storage.PolicyLock
 	}
 	setPolicyLockReturns struct {
 		result1 error
@@ -227,6 +291,24 @@ type FakeStorage struct {
 		result1 *storage.Subscriber
 		result2 error
 	}
+	SubscriberDeliveriesStub        func(context.Context, string, string, string, string, string) ([]*storage.Delivery, error)
+	subscriberDeliveriesMutex       sync.RWMutex
+	subscriberDeliveriesArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 string
+	}
+	subscriberDeliveriesReturns struct {
+		result1 []*storage.Delivery
+		result2 error
+	}
+	subscriberDeliveriesReturnsOnCall map[int]struct {
+		result1 []*storage.Delivery
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -677,6 +759,71 @@ func (fake *FakeStorage) DeleteDataReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStorage) DeletePolicy(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string) error {
+	fake.deletePolicyMutex.Lock()
+	ret, specificReturn := fake.deletePolicyReturnsOnCall[len(fake.deletePolicyArgsForCall)]
+	fake.deletePolicyArgsForCall = append(fake.deletePolicyArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.DeletePolicyStub
+	fakeReturns := fake.deletePolicyReturns
+	fake.recordInvocation("DeletePolicy", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.deletePolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStorage) DeletePolicyCallCount() int {
+	fake.deletePolicyMutex.RLock()
+	defer fake.deletePolicyMutex.RUnlock()
+	return len(fake.deletePolicyArgsForCall)
+}
+
+func (fake *FakeStorage) DeletePolicyCalls(stub func(context.Context, string, string, string, string) error) {
+	fake.deletePolicyMutex.Lock()
+	defer fake.deletePolicyMutex.Unlock()
+	fake.DeletePolicyStub = stub
+}
+
+func (fake *FakeStorage) DeletePolicyArgsForCall(i int) (context.Context, string, string, string, string) {
+	fake.deletePolicyMutex.RLock()
+	defer fake.deletePolicyMutex.RUnlock()
+	argsForCall := fake.deletePolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeStorage) DeletePolicyReturns(result1 error) {
+	fake.deletePolicyMutex.Lock()
+	defer fake.deletePolicyMutex.Unlock()
+	fake.DeletePolicyStub = nil
+	fake.deletePolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) DeletePolicyReturnsOnCall(i int, result1 error) {
+	fake.deletePolicyMutex.Lock()
+	defer fake.deletePolicyMutex.Unlock()
+	fake.DeletePolicyStub = nil
+	if fake.deletePolicyReturnsOnCall == nil {
+		fake.deletePolicyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deletePolicyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStorage) GetData(arg1 context.Context, arg2 string) (any, error) {
 	fake.getDataMutex.Lock()
 	ret, specificReturn := fake.getDataReturnsOnCall[len(fake.getDataArgsForCall)]
@@ -742,70 +889,72 @@ func (fake *FakeStorage) GetDataReturnsOnCall(i int, result1 any, result2 error)
 	}{result1, result2}
 }
 
-func (fake *FakeStorage) GetPolicies(arg1 context.Context, arg2 *bool, arg3 *string) ([]*storage.Policy, error) {
-	fake.getPoliciesMutex.Lock()
-	ret, specificReturn := fake.getPoliciesReturnsOnCall[len(fake.getPoliciesArgsForCall)]
-	fake.getPoliciesArgsForCall = append(fake.getPoliciesArgsForCall, struct {
+func (fake *FakeStorage) ListPolicies(arg1 context.Context, arg2 *storage.PolicyQuery) ([]*storage.Policy, string, error) {
+	fake.listPoliciesMutex.Lock()
+	ret, specificReturn := fake.listPoliciesReturnsOnCall[len(fake.listPoliciesArgsForCall)]
+	fake.listPoliciesArgsForCall = append(fake.listPoliciesArgsForCall, struct {
 		arg1 context.Context
-		arg2 *bool
-		arg3 *string
-	}{arg1, arg2, arg3})
-	stub := fake.GetPoliciesStub
-	fakeReturns := fake.getPoliciesReturns
-	fake.recordInvocation("GetPolicies", []interface{}{arg1, arg2, arg3})
-	fake.getPoliciesMutex.Unlock()
+		arg2 *storage.PolicyQuery
+	}{arg1, arg2})
+	stub := fake.ListPoliciesStub
+	fakeReturns := fake.listPoliciesReturns
+	fake.recordInvocation("ListPolicies", []interface{}{arg1, arg2})
+	fake.listPoliciesMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1, ret.result2, ret.result3
 	}
-	return fakeReturns.result1, fakeReturns.result2
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
 }
 
-func (fake *FakeStorage) GetPoliciesCallCount() int {
-	fake.getPoliciesMutex.RLock()
-	defer fake.getPoliciesMutex.RUnlock()
-	return len(fake.getPoliciesArgsForCall)
+func (fake *FakeStorage) ListPoliciesCallCount() int {
+	fake.listPoliciesMutex.RLock()
+	defer fake.listPoliciesMutex.RUnlock()
+	return len(fake.listPoliciesArgsForCall)
 }
 
-func (fake *FakeStorage) GetPoliciesCalls(stub func(context.Context, *bool, *string) ([]*storage.Policy, error)) {
-	fake.getPoliciesMutex.Lock()
-	defer fake.getPoliciesMutex.Unlock()
-	fake.GetPoliciesStub = stub
+func (fake *FakeStorage) ListPoliciesCalls(stub func(context.Context, *storage.PolicyQuery) ([]*storage.Policy, string, error)) {
+	fake.listPoliciesMutex.Lock()
+	defer fake.listPoliciesMutex.Unlock()
+	fake.ListPoliciesStub = stub
 }
 
-func (fake *FakeStorage) GetPoliciesArgsForCall(i int) (context.Context, *bool, *string) {
-	fake.getPoliciesMutex.RLock()
-	defer fake.getPoliciesMutex.RUnlock()
-	argsForCall := fake.getPoliciesArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+func (fake *FakeStorage) ListPoliciesArgsForCall(i int) (context.Context, *storage.PolicyQuery) {
+	fake.listPoliciesMutex.RLock()
+	defer fake.listPoliciesMutex.RUnlock()
+	argsForCall := fake.listPoliciesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeStorage) GetPoliciesReturns(result1 []*storage.Policy, result2 error) {
-	fake.getPoliciesMutex.Lock()
-	defer fake.getPoliciesMutex.Unlock()
-	fake.GetPoliciesStub = nil
-	fake.getPoliciesReturns = struct {
+func (fake *FakeStorage) ListPoliciesReturns(result1 []*storage.Policy, result2 string, result3 error) {
+	fake.listPoliciesMutex.Lock()
+	defer fake.listPoliciesMutex.Unlock()
+	fake.ListPoliciesStub = nil
+	fake.listPoliciesReturns = struct {
 		result1 []*storage.Policy
-		result2 error
-	}{result1, result2}
+		result2 string
+		result3 error
+	}{result1, result2, result3}
 }
 
-func (fake *FakeStorage) GetPoliciesReturnsOnCall(i int, result1 []*storage.Policy, result2 error) {
-	fake.getPoliciesMutex.Lock()
-	defer fake.getPoliciesMutex.Unlock()
-	fake.GetPoliciesStub = nil
-	if fake.getPoliciesReturnsOnCall == nil {
-		fake.getPoliciesReturnsOnCall = make(map[int]struct {
+func (fake *FakeStorage) ListPoliciesReturnsOnCall(i int, result1 []*storage.Policy, result2 string, result3 error) {
+	fake.listPoliciesMutex.Lock()
+	defer fake.listPoliciesMutex.Unlock()
+	fake.ListPoliciesStub = nil
+	if fake.listPoliciesReturnsOnCall == nil {
+		fake.listPoliciesReturnsOnCall = make(map[int]struct {
 			result1 []*storage.Policy
-			result2 error
+			result2 string
+			result3 error
 		})
 	}
-	fake.getPoliciesReturnsOnCall[i] = struct {
+	fake.listPoliciesReturnsOnCall[i] = struct {
 		result1 []*storage.Policy
-		result2 error
-	}{result1, result2}
+		result2 string
+		result3 error
+	}{result1, result2, result3}
 }
 
 func (fake *FakeStorage) ListenPolicyDataChanges(arg1 context.Context) error {
@@ -937,6 +1086,143 @@ func (fake *FakeStorage) PolicyReturnsOnCall(i int, result1 *storage.Policy, res
 	}{result1, result2}
 }
 
+func (fake *FakeStorage) PolicyRevision(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string, arg6 int) (*storage.PolicyRevision, error) {
+	fake.policyRevisionMutex.Lock()
+	ret, specificReturn := fake.policyRevisionReturnsOnCall[len(fake.policyRevisionArgsForCall)]
+	fake.policyRevisionArgsForCall = append(fake.policyRevisionArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 int
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.PolicyRevisionStub
+	fakeReturns := fake.policyRevisionReturns
+	fake.recordInvocation("PolicyRevision", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.policyRevisionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStorage) PolicyRevisionCallCount() int {
+	fake.policyRevisionMutex.RLock()
+	defer fake.policyRevisionMutex.RUnlock()
+	return len(fake.policyRevisionArgsForCall)
+}
+
+func (fake *FakeStorage) PolicyRevisionCalls(stub func(context.Context, string, string, string, string, int) (*storage.PolicyRevision, error)) {
+	fake.policyRevisionMutex.Lock()
+	defer fake.policyRevisionMutex.Unlock()
+	fake.PolicyRevisionStub = stub
+}
+
+func (fake *FakeStorage) PolicyRevisionArgsForCall(i int) (context.Context, string, string, string, string, int) {
+	fake.policyRevisionMutex.RLock()
+	defer fake.policyRevisionMutex.RUnlock()
+	argsForCall := fake.policyRevisionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *FakeStorage) PolicyRevisionReturns(result1 *storage.PolicyRevision, result2 error) {
+	fake.policyRevisionMutex.Lock()
+	defer fake.policyRevisionMutex.Unlock()
+	fake.PolicyRevisionStub = nil
+	fake.policyRevisionReturns = struct {
+		result1 *storage.PolicyRevision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) PolicyRevisionReturnsOnCall(i int, result1 *storage.PolicyRevision, result2 error) {
+	fake.policyRevisionMutex.Lock()
+	defer fake.policyRevisionMutex.Unlock()
+	fake.PolicyRevisionStub = nil
+	if fake.policyRevisionReturnsOnCall == nil {
+		fake.policyRevisionReturnsOnCall = make(map[int]struct {
+			result1 *storage.PolicyRevision
+			result2 error
+		})
+	}
+	fake.policyRevisionReturnsOnCall[i] = struct {
+		result1 *storage.PolicyRevision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) PolicyRevisions(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string) ([]*storage.PolicyRevision, error) {
+	fake.policyRevisionsMutex.Lock()
+	ret, specificReturn := fake.policyRevisionsReturnsOnCall[len(fake.policyRevisionsArgsForCall)]
+	fake.policyRevisionsArgsForCall = append(fake.policyRevisionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.PolicyRevisionsStub
+	fakeReturns := fake.policyRevisionsReturns
+	fake.recordInvocation("PolicyRevisions", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.policyRevisionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStorage) PolicyRevisionsCallCount() int {
+	fake.policyRevisionsMutex.RLock()
+	defer fake.policyRevisionsMutex.RUnlock()
+	return len(fake.policyRevisionsArgsForCall)
+}
+
+func (fake *FakeStorage) PolicyRevisionsCalls(stub func(context.Context, string, string, string, string) ([]*storage.PolicyRevision, error)) {
+	fake.policyRevisionsMutex.Lock()
+	defer fake.policyRevisionsMutex.Unlock()
+	fake.PolicyRevisionsStub = stub
+}
+
+func (fake *FakeStorage) PolicyRevisionsArgsForCall(i int) (context.Context, string, string, string, string) {
+	fake.policyRevisionsMutex.RLock()
+	defer fake.policyRevisionsMutex.RUnlock()
+	argsForCall := fake.policyRevisionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeStorage) PolicyRevisionsReturns(result1 []*storage.PolicyRevision, result2 error) {
+	fake.policyRevisionsMutex.Lock()
+	defer fake.policyRevisionsMutex.Unlock()
+	fake.PolicyRevisionsStub = nil
+	fake.policyRevisionsReturns = struct {
+		result1 []*storage.PolicyRevision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) PolicyRevisionsReturnsOnCall(i int, result1 []*storage.PolicyRevision, result2 error) {
+	fake.policyRevisionsMutex.Lock()
+	defer fake.policyRevisionsMutex.Unlock()
+	fake.PolicyRevisionsStub = nil
+	if fake.policyRevisionsReturnsOnCall == nil {
+		fake.policyRevisionsReturnsOnCall = make(map[int]struct {
+			result1 []*storage.PolicyRevision
+			result2 error
+		})
+	}
+	fake.policyRevisionsReturnsOnCall[i] = struct {
+		result1 []*storage.PolicyRevision
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStorage) SaveAutoImportConfig(arg1 context.Context, arg2 *storage.PolicyAutoImport) error {
 	fake.saveAutoImportConfigMutex.Lock()
 	ret, specificReturn := fake.saveAutoImportConfigReturnsOnCall[len(fake.saveAutoImportConfigArgsForCall)]
@@ -1124,7 +1410,75 @@ func (fake *FakeStorage) SetDataReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeStorage) SetPolicyLock(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string, arg6 bool) error {
+func (fake *FakeStorage) SetPoliciesLock(arg1 context.Context, arg2 []*storage.Policy, arg3 *storage.PolicyLock) error {
+	var arg2Copy []*storage.Policy
+	if arg2 != nil {
+		arg2Copy = make([]*storage.Policy, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.setPoliciesLockMutex.Lock()
+	ret, specificReturn := fake.setPoliciesLockReturnsOnCall[len(fake.setPoliciesLockArgsForCall)]
+	fake.setPoliciesLockArgsForCall = append(fake.setPoliciesLockArgsForCall, struct {
+		arg1 context.Context
+		arg2 []*storage.Policy
+		arg3 *storage.PolicyLock
+	}{arg1, arg2Copy, arg3})
+	stub := fake.SetPoliciesLockStub
+	fakeReturns := fake.setPoliciesLockReturns
+	fake.recordInvocation("SetPoliciesLock", []interface{}{arg1, arg2Copy, arg3})
+	fake.setPoliciesLockMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStorage) SetPoliciesLockCallCount() int {
+	fake.setPoliciesLockMutex.RLock()
+	defer fake.setPoliciesLockMutex.RUnlock()
+	return len(fake.setPoliciesLockArgsForCall)
+}
+
+func (fake *FakeStorage) SetPoliciesLockCalls(stub func(context.Context, []*storage.Policy, *storage.PolicyLock) error) {
+	fake.setPoliciesLockMutex.Lock()
+	defer fake.setPoliciesLockMutex.Unlock()
+	fake.SetPoliciesLockStub = stub
+}
+
+func (fake *FakeStorage) SetPoliciesLockArgsForCall(i int) (context.Context, []*storage.Policy, *storage.PolicyLock) {
+	fake.setPoliciesLockMutex.RLock()
+	defer fake.setPoliciesLockMutex.RUnlock()
+	argsForCall := fake.setPoliciesLockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStorage) SetPoliciesLockReturns(result1 error) {
+	fake.setPoliciesLockMutex.Lock()
+	defer fake.setPoliciesLockMutex.Unlock()
+	fake.SetPoliciesLockStub = nil
+	fake.setPoliciesLockReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) SetPoliciesLockReturnsOnCall(i int, result1 error) {
+	fake.setPoliciesLockMutex.Lock()
+	defer fake.setPoliciesLockMutex.Unlock()
+	fake.SetPoliciesLockStub = nil
+	if fake.setPoliciesLockReturnsOnCall == nil {
+		fake.setPoliciesLockReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setPoliciesLockReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) SetPolicyLock(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string, arg6 *storage.PolicyLock) error {
 	fake.setPolicyLockMutex.Lock()
 	ret, specificReturn := fake.setPolicyLockReturnsOnCall[len(fake.setPolicyLockArgsForCall)]
 	fake.setPolicyLockArgsForCall = append(fake.setPolicyLockArgsForCall, struct {
@@ -1133,7 +1487,7 @@ func (fake *FakeStorage) SetPolicyLock(arg1 context.Context, arg2 string, arg3 s
 		arg3 string
 		arg4 string
 		arg5 string
-		arg6 bool
+		arg6 *storage.PolicyLock
 	}{arg1, arg2, arg3, arg4, arg5, arg6})
 	stub := fake.SetPolicyLockStub
 	fakeReturns := fake.setPolicyLockReturns
@@ -1154,13 +1508,13 @@ func (fake *FakeStorage) SetPolicyLockCallCount() int {
 	return len(fake.setPolicyLockArgsForCall)
 }
 
-func (fake *FakeStorage) SetPolicyLockCalls(stub func(context.Context, string, string, string, string, bool) error) {
+func (fake *FakeStorage) SetPolicyLockCalls(stub func(context.Context, string, string, string, string, *storage.PolicyLock) error) {
 	fake.setPolicyLockMutex.Lock()
 	defer fake.setPolicyLockMutex.Unlock()
 	fake.SetPolicyLockStub = stub
 }
 
-func (fake *FakeStorage) SetPolicyLockArgsForCall(i int) (context.Context, string, string, string, string, bool) {
+func (fake *FakeStorage) SetPolicyLockArgsForCall(i int) (context.Context, string, string, string, string, *storage.PolicyLock) {
 	fake.setPolicyLockMutex.RLock()
 	defer fake.setPolicyLockMutex.RUnlock()
 	argsForCall := fake.setPolicyLockArgsForCall[i]
@@ -1260,6 +1614,75 @@ func (fake *FakeStorage) SubscriberReturnsOnCall(i int, result1 *storage.Subscri
 	}{result1, result2}
 }
 
+func (fake *FakeStorage) SubscriberDeliveries(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string, arg6 string) ([]*storage.Delivery, error) {
+	fake.subscriberDeliveriesMutex.Lock()
+	ret, specificReturn := fake.subscriberDeliveriesReturnsOnCall[len(fake.subscriberDeliveriesArgsForCall)]
+	fake.subscriberDeliveriesArgsForCall = append(fake.subscriberDeliveriesArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.SubscriberDeliveriesStub
+	fakeReturns := fake.subscriberDeliveriesReturns
+	fake.recordInvocation("SubscriberDeliveries", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.subscriberDeliveriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStorage) SubscriberDeliveriesCallCount() int {
+	fake.subscriberDeliveriesMutex.RLock()
+	defer fake.subscriberDeliveriesMutex.RUnlock()
+	return len(fake.subscriberDeliveriesArgsForCall)
+}
+
+func (fake *FakeStorage) SubscriberDeliveriesCalls(stub func(context.Context, string, string, string, string, string) ([]*storage.Delivery, error)) {
+	fake.subscriberDeliveriesMutex.Lock()
+	defer fake.subscriberDeliveriesMutex.Unlock()
+	fake.SubscriberDeliveriesStub = stub
+}
+
+func (fake *FakeStorage) SubscriberDeliveriesArgsForCall(i int) (context.Context, string, string, string, string, string) {
+	fake.subscriberDeliveriesMutex.RLock()
+	defer fake.subscriberDeliveriesMutex.RUnlock()
+	argsForCall := fake.subscriberDeliveriesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *FakeStorage) SubscriberDeliveriesReturns(result1 []*storage.Delivery, result2 error) {
+	fake.subscriberDeliveriesMutex.Lock()
+	defer fake.subscriberDeliveriesMutex.Unlock()
+	fake.SubscriberDeliveriesStub = nil
+	fake.subscriberDeliveriesReturns = struct {
+		result1 []*storage.Delivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) SubscriberDeliveriesReturnsOnCall(i int, result1 []*storage.Delivery, result2 error) {
+	fake.subscriberDeliveriesMutex.Lock()
+	defer fake.subscriberDeliveriesMutex.Unlock()
+	fake.SubscriberDeliveriesStub = nil
+	if fake.subscriberDeliveriesReturnsOnCall == nil {
+		fake.subscriberDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 []*storage.Delivery
+			result2 error
+		})
+	}
+	fake.subscriberDeliveriesReturnsOnCall[i] = struct {
+		result1 []*storage.Delivery
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStorage) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1279,24 +1702,34 @@ func (fake *FakeStorage) Invocations() map[string][][]interface{} {
 	defer fake.deleteAutoImportConfigMutex.RUnlock()
 	fake.deleteDataMutex.RLock()
 	defer fake.deleteDataMutex.RUnlock()
+	fake.deletePolicyMutex.RLock()
+	defer fake.deletePolicyMutex.RUnlock()
 	fake.getDataMutex.RLock()
 	defer fake.getDataMutex.RUnlock()
-	fake.getPoliciesMutex.RLock()
-	defer fake.getPoliciesMutex.RUnlock()
+	fake.listPoliciesMutex.RLock()
+	defer fake.listPoliciesMutex.RUnlock()
 	fake.listenPolicyDataChangesMutex.RLock()
 	defer fake.listenPolicyDataChangesMutex.RUnlock()
 	fake.policyMutex.RLock()
 	defer fake.policyMutex.RUnlock()
+	fake.policyRevisionMutex.RLock()
+	defer fake.policyRevisionMutex.RUnlock()
+	fake.policyRevisionsMutex.RLock()
+	defer fake.policyRevisionsMutex.RUnlock()
 	fake.saveAutoImportConfigMutex.RLock()
 	defer fake.saveAutoImportConfigMutex.RUnlock()
 	fake.savePolicyMutex.RLock()
 	defer fake.savePolicyMutex.RUnlock()
 	fake.setDataMutex.RLock()
 	defer fake.setDataMutex.RUnlock()
+	fake.setPoliciesLockMutex.RLock()
+	defer fake.setPoliciesLockMutex.RUnlock()
 	fake.setPolicyLockMutex.RLock()
 	defer fake.setPolicyLockMutex.RUnlock()
 	fake.subscriberMutex.RLock()
 	defer fake.subscriberMutex.RUnlock()
+	fake.subscriberDeliveriesMutex.RLock()
+	defer fake.subscriberDeliveriesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
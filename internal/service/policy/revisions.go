@@ -0,0 +1,225 @@
+package policy
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"go.uber.org/zap"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/ptr"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/policy"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+// PolicyRevisions returns the revisions of a policy without their content.
+func (s *Service) PolicyRevisions(ctx context.Context, req *policy.PolicyRevisionsRequest) (*policy.PolicyRevisionsResult, error) {
+	logger := s.logger.With(
+		zap.String("operation", "policyRevisions"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("version", req.Version),
+	)
+
+	revisions, err := s.storage.PolicyRevisions(ctx, req.Repository, req.Group, req.PolicyName, req.Version)
+	if err != nil {
+		logger.Error("error getting policy revisions from storage", zap.Error(err))
+		return nil, errors.New("error getting policy revisions", err)
+	}
+
+	if len(revisions) == 0 {
+		return nil, errors.New(errors.NotFound, "policy revisions not found")
+	}
+
+	res := &policy.PolicyRevisionsResult{Revisions: []*policy.PolicyRevisionResult{}}
+	for _, rev := range revisions {
+		res.Revisions = append(res.Revisions, revisionResult(rev, false))
+	}
+
+	return res, nil
+}
+
+// PolicyRevision returns a single policy revision with its content.
+func (s *Service) PolicyRevision(ctx context.Context, req *policy.PolicyRevisionRequest) (*policy.PolicyRevisionResult, error) {
+	logger := s.logger.With(
+		zap.String("operation", "policyRevision"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("version", req.Version),
+		zap.Int("revision", req.Revision),
+	)
+
+	rev, err := s.storage.PolicyRevision(ctx, req.Repository, req.Group, req.PolicyName, req.Version, req.Revision)
+	if err != nil {
+		logger.Error("error getting policy revision from storage", zap.Error(err))
+		if errors.Is(errors.NotFound, err) {
+			return nil, err
+		}
+		return nil, errors.New("error getting policy revision", err)
+	}
+
+	return revisionResult(rev, true), nil
+}
+
+// DiffPolicyRevisions returns unified diffs of the policy fields
+// which differ between two policy revisions.
+func (s *Service) DiffPolicyRevisions(ctx context.Context, req *policy.DiffPolicyRevisionsRequest) (*policy.DiffPolicyRevisionsResult, error) {
+	logger := s.logger.With(
+		zap.String("operation", "diffPolicyRevisions"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("version", req.Version),
+		zap.Int("from", req.From),
+		zap.Int("to", req.To),
+	)
+
+	var revs []*storage.PolicyRevision
+	for _, revision := range []int{req.From, req.To} {
+		rev, err := s.storage.PolicyRevision(ctx, req.Repository, req.Group, req.PolicyName, req.Version, revision)
+		if err != nil {
+			logger.Error("error getting policy revision from storage", zap.Error(err))
+			if errors.Is(errors.NotFound, err) {
+				return nil, err
+			}
+			return nil, errors.New("error getting policy revision", err)
+		}
+		revs = append(revs, rev)
+	}
+
+	changes, err := diffRevisions(revs[0], revs[1])
+	if err != nil {
+		logger.Error("error comparing policy revisions", zap.Error(err))
+		return nil, errors.New("error comparing policy revisions", err)
+	}
+
+	return &policy.DiffPolicyRevisionsResult{
+		From:    req.From,
+		To:      req.To,
+		Changes: changes,
+	}, nil
+}
+
+// RollbackPolicy restores the content of a policy revision as the current
+// policy. The lock state of the policy is preserved. A policy which was
+// deleted is created again. Restoring the revision creates a new revision,
+// so the history of the policy is not changed.
+func (s *Service) RollbackPolicy(ctx context.Context, req *policy.RollbackPolicyRequest) (*policy.PolicyRevisionResult, error) {
+	logger := s.logger.With(
+		zap.String("operation", "rollbackPolicy"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("version", req.Version),
+		zap.Int("revision", req.Revision),
+	)
+
+	rev, err := s.storage.PolicyRevision(ctx, req.Repository, req.Group, req.PolicyName, req.Version, req.Revision)
+	if err != nil {
+		logger.Error("error getting policy revision from storage", zap.Error(err))
+		if errors.Is(errors.NotFound, err) {
+			return nil, err
+		}
+		return nil, errors.New("error getting policy revision", err)
+	}
+
+	pol, err := s.storage.Policy(ctx, req.Repository, req.Group, req.PolicyName, req.Version)
+	if err != nil {
+		if !errors.Is(errors.NotFound, err) {
+			logger.Error("error getting policy from storage", zap.Error(err))
+			return nil, errors.New("error rolling back policy", err)
+		}
+		pol = policyFromRequest(&policy.PolicyRequest{
+			Repository: req.Repository,
+			Group:      req.Group,
+			PolicyName: req.PolicyName,
+			Version:    req.Version,
+		})
+	}
+
+	rev.Apply(pol)
+	pol.LastUpdate = time.Now()
+
+	ctx = storage.WithRevisionSource(ctx, storage.RevisionSourceRollback, rev.Commit)
+	if err := s.storage.SavePolicy(ctx, pol); err != nil {
+		logger.Error("error saving policy", zap.Error(err))
+		return nil, errors.New("error saving policy", err)
+	}
+
+	revisions, err := s.storage.PolicyRevisions(ctx, req.Repository, req.Group, req.PolicyName, req.Version)
+	if err != nil || len(revisions) == 0 {
+		logger.Error("error getting policy revisions from storage", zap.Error(err))
+		return nil, errors.New("error getting policy revisions", err)
+	}
+
+	logger.Debug("policy is rolled back")
+
+	return revisionResult(revisions[len(revisions)-1], false), nil
+}
+
+func revisionResult(rev *storage.PolicyRevision, content bool) *policy.PolicyRevisionResult {
+	res := &policy.PolicyRevisionResult{
+		Revision:  rev.Revision,
+		Hash:      rev.Hash,
+		Source:    rev.Source,
+		CreatedAt: rev.CreatedAt.Unix(),
+	}
+
+	if rev.Commit != "" {
+		res.Commit = ptr.String(rev.Commit)
+	}
+
+	if content {
+		res.Rego = ptr.String(rev.Rego)
+		res.Data = ptr.String(rev.Data)
+		res.DataConfig = ptr.String(rev.DataConfig)
+		res.OutputSchema = ptr.String(rev.OutputSchema)
+		res.ExportConfig = ptr.String(rev.ExportConfig)
+	}
+
+	return res
+}
+
+// diffRevisions returns unified diffs of the changed fields of two revisions.
+func diffRevisions(from, to *storage.PolicyRevision) ([]*policy.PolicyRevisionDiff, error) {
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{name: "rego", from: from.Rego, to: to.Rego},
+		{name: "data", from: from.Data, to: to.Data},
+		{name: "dataConfig", from: from.DataConfig, to: to.DataConfig},
+		{name: "outputSchema", from: from.OutputSchema, to: to.OutputSchema},
+		{name: "exportConfig", from: from.ExportConfig, to: to.ExportConfig},
+	}
+
+	changes := []*policy.PolicyRevisionDiff{}
+	for _, field := range fields {
+		if field.from == field.to {
+			continue
+		}
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(field.from),
+			B:        difflib.SplitLines(field.to),
+			FromFile: field.name + "@" + revisionName(from),
+			ToFile:   field.name + "@" + revisionName(to),
+			Context:  3,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, &policy.PolicyRevisionDiff{Field: field.name, Diff: diff})
+	}
+
+	return changes, nil
+}
+
+func revisionName(rev *storage.PolicyRevision) string {
+	return "r" + strconv.Itoa(rev.Revision)
+}
@@ -0,0 +1,191 @@
+package policy_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/ptr"
+	goapolicy "gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/policy"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/service/policy"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/service/policy/policyfakes"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+func newRevisionsService(storage policy.Storage) *policy.Service {
+	return policy.New(context.Background(), storage, nil, nil, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+}
+
+func testRevision(revision int, rego string) *storage.PolicyRevision {
+	return &storage.PolicyRevision{
+		Repository: "policies",
+		Group:      "testgroup",
+		Name:       "example",
+		Version:    "1.0",
+		Revision:   revision,
+		Hash:       "hash",
+		Rego:       rego,
+		Source:     storage.RevisionSourceSync,
+		Commit:     "0123abcd",
+		CreatedAt:  time.Unix(1700000000, 0),
+	}
+}
+
+func TestService_PolicyRevisions(t *testing.T) {
+	tests := []struct {
+		name    string
+		storage *policyfakes.FakeStorage
+
+		res     *goapolicy.PolicyRevisionsResult
+		errkind errors.Kind
+		errtext string
+	}{
+		{
+			name: "error getting revisions",
+			storage: &policyfakes.FakeStorage{
+				PolicyRevisionsStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) ([]*storage.PolicyRevision, error) {
+					return nil, errors.New("some error")
+				},
+			},
+			errkind: errors.Unknown,
+			errtext: "error getting policy revisions",
+		},
+		{
+			name:    "policy has no revisions",
+			storage: &policyfakes.FakeStorage{},
+			errkind: errors.NotFound,
+			errtext: "policy revisions not found",
+		},
+		{
+			name: "revisions are returned without content",
+			storage: &policyfakes.FakeStorage{
+				PolicyRevisionsStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) ([]*storage.PolicyRevision, error) {
+					return []*storage.PolicyRevision{testRevision(1, "package testgroup.example")}, nil
+				},
+			},
+			res: &goapolicy.PolicyRevisionsResult{
+				Revisions: []*goapolicy.PolicyRevisionResult{
+					{
+						Revision:  1,
+						Hash:      "hash",
+						Source:    storage.RevisionSourceSync,
+						Commit:    ptr.String("0123abcd"),
+						CreatedAt: 1700000000,
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := newRevisionsService(test.storage).PolicyRevisions(context.Background(), &goapolicy.PolicyRevisionsRequest{
+				Repository: "policies",
+				Group:      "testgroup",
+				PolicyName: "example",
+				Version:    "1.0",
+			})
+			if test.errtext != "" {
+				require.Error(t, err)
+				e, ok := err.(*errors.Error)
+				assert.True(t, ok)
+				assert.Contains(t, e.Error(), test.errtext)
+				assert.Equal(t, test.errkind, e.Kind)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestService_DiffPolicyRevisions(t *testing.T) {
+	fake := &policyfakes.FakeStorage{
+		PolicyRevisionStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string, revision int) (*storage.PolicyRevision, error) {
+			switch revision {
+			case 1:
+				return testRevision(1, "package testgroup.example\n\nallow := false\n"), nil
+			case 2:
+				return testRevision(2, "package testgroup.example\n\nallow := true\n"), nil
+			}
+			return nil, errors.New(errors.NotFound, "policy revision not found")
+		},
+	}
+
+	req := &goapolicy.DiffPolicyRevisionsRequest{
+		Repository: "policies",
+		Group:      "testgroup",
+		PolicyName: "example",
+		Version:    "1.0",
+		From:       1,
+		To:         2,
+	}
+
+	res, err := newRevisionsService(fake).DiffPolicyRevisions(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, res.Changes, 1)
+	assert.Equal(t, "rego", res.Changes[0].Field)
+	assert.Contains(t, res.Changes[0].Diff, "--- rego@r1")
+	assert.Contains(t, res.Changes[0].Diff, "-allow := false")
+	assert.Contains(t, res.Changes[0].Diff, "+allow := true")
+
+	req.To = 3
+	_, err = newRevisionsService(fake).DiffPolicyRevisions(context.Background(), req)
+	assert.True(t, errors.Is(errors.NotFound, err))
+}
+
+func TestService_RollbackPolicy(t *testing.T) {
+	req := &goapolicy.RollbackPolicyRequest{
+		Repository: "policies",
+		Group:      "testgroup",
+		PolicyName: "example",
+		Version:    "1.0",
+		Revision:   1,
+	}
+
+	t.Run("revision is not found", func(t *testing.T) {
+		fake := &policyfakes.FakeStorage{
+			PolicyRevisionStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string, i int) (*storage.PolicyRevision, error) {
+				return nil, errors.New(errors.NotFound, "policy revision not found")
+			},
+		}
+
+		_, err := newRevisionsService(fake).RollbackPolicy(context.Background(), req)
+		assert.True(t, errors.Is(errors.NotFound, err))
+		assert.Equal(t, 0, fake.SavePolicyCallCount())
+	})
+
+	t.Run("revision is restored", func(t *testing.T) {
+		fake := &policyfakes.FakeStorage{
+			PolicyRevisionStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string, i int) (*storage.PolicyRevision, error) {
+				return testRevision(1, "package testgroup.example"), nil
+			},
+			PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+				return &storage.Policy{Repository: "policies", Group: "testgroup", Name: "example", Version: "1.0", Rego: "package testgroup.example\n\nallow := true", Locked: true}, nil
+			},
+			PolicyRevisionsStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) ([]*storage.PolicyRevision, error) {
+				return []*storage.PolicyRevision{testRevision(1, ""), testRevision(2, ""), testRevision(3, "")}, nil
+			},
+		}
+
+		res, err := newRevisionsService(fake).RollbackPolicy(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, 3, res.Revision)
+
+		require.Equal(t, 1, fake.SavePolicyCallCount())
+		ctx, saved := fake.SavePolicyArgsForCall(0)
+		assert.Equal(t, "package testgroup.example", saved.Rego)
+		assert.True(t, saved.Locked)
+
+		source, commit := storage.RevisionSource(ctx, storage.RevisionSourceAPI)
+		assert.Equal(t, storage.RevisionSourceRollback, source)
+		assert.Equal(t, "0123abcd", commit)
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/open-policy-agent/opa/ast"
 	"github.com/open-policy-agent/opa/rego"
 	"github.com/open-policy-agent/opa/storage/inmem"
 	"github.com/santhosh-tekuri/jsonschema/v5"
@@ -20,8 +21,10 @@ import (
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/ptr"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/policy"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/caller"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/header"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/regofunc"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/request"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
 )
 
@@ -32,6 +35,7 @@ import (
 
 const (
 	BundleFilename          = "policy_bundle.zip"
+	WasmBundleFilename      = "policy_bundle.tar.gz"
 	BundleSignatureFilename = "signature.raw"
 )
 
@@ -121,9 +125,13 @@ func (s *Service) Evaluate(ctx context.Context, req *policy.EvaluateRequest) (*p
 	)
 
 	headers, _ := header.FromContext(ctx)
-	query, err := s.prepareQuery(ctx, req.Repository, req.Group, req.PolicyName, req.Version, headers)
+	query, err := s.prepareQuery(ctx, req.Repository, req.Group, req.PolicyName, req.Version, headers, requestDetails(ctx))
 	if err != nil {
 		logger.Error("error getting prepared query", zap.Error(err))
+		// the caller must see why a locked or archived policy is forbidden
+		if errors.Is(errors.Forbidden, err) {
+			return nil, err
+		}
 		return nil, errors.New("error evaluating policy", err)
 	}
 
@@ -223,7 +231,8 @@ func (s *Service) Validate(ctx context.Context, req *policy.EvaluateRequest) (*p
 	if err := sch.Validate(res.Result); err != nil {
 		// lock the policy for execution if configured
 		if s.validationLock {
-			if err := s.lock(ctx, pol); err != nil {
+			lock := &storage.PolicyLock{Reason: "policy output schema validation failed", LockedAt: time.Now()}
+			if err := s.lock(ctx, pol, lock); err != nil {
 				logger.Error("error locking policy after validation failure", zap.Error(err))
 			}
 		}
@@ -245,6 +254,11 @@ func (s *Service) Lock(ctx context.Context, req *policy.LockRequest) error {
 		zap.String("version", req.Version),
 	)
 
+	lock, err := newLock(ctx, req.Reason, req.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
 	pol, err := s.storage.Policy(ctx, req.Repository, req.Group, req.PolicyName, req.Version)
 	if err != nil {
 		logger.Error("error getting policy from storage", zap.Error(err))
@@ -254,7 +268,7 @@ func (s *Service) Lock(ctx context.Context, req *policy.LockRequest) error {
 		return errors.New("error locking policy", err)
 	}
 
-	if err := s.lock(ctx, pol); err != nil {
+	if err := s.lock(ctx, pol, lock); err != nil {
 		logger.Error("error locking policy", zap.Error(err))
 		return err
 	}
@@ -264,12 +278,36 @@ func (s *Service) Lock(ctx context.Context, req *policy.LockRequest) error {
 	return nil
 }
 
-func (s *Service) lock(ctx context.Context, p *storage.Policy) error {
+// newLock returns the lock details of a lock request
+// made by the caller of the request.
+func newLock(ctx context.Context, reason, expiresAt *string) (*storage.PolicyLock, error) {
+	lock := &storage.PolicyLock{
+		Actor:    caller.ID(ctx),
+		LockedAt: time.Now(),
+	}
+	if reason != nil {
+		lock.Reason = *reason
+	}
+	if expiresAt != nil {
+		t, err := time.Parse(time.RFC3339, *expiresAt)
+		if err != nil {
+			return nil, errors.New(errors.BadRequest, "invalid expiresAt time", err)
+		}
+		if !t.After(lock.LockedAt) {
+			return nil, errors.New(errors.BadRequest, "expiresAt time must be in the future")
+		}
+		lock.ExpiresAt = &t
+	}
+
+	return lock, nil
+}
+
+func (s *Service) lock(ctx context.Context, p *storage.Policy, lock *storage.PolicyLock) error {
 	if p.Locked {
 		return errors.New(errors.Forbidden, "policy is already locked")
 	}
 
-	if err := s.storage.SetPolicyLock(ctx, p.Repository, p.Group, p.Name, p.Version, true); err != nil {
+	if err := s.storage.SetPolicyLock(ctx, p.Repository, p.Group, p.Name, p.Version, lock); err != nil {
 		return errors.New("error locking policy", err)
 	}
 
@@ -299,7 +337,7 @@ func (s *Service) Unlock(ctx context.Context, req *policy.UnlockRequest) error {
 		return errors.New(errors.Forbidden, "policy is unlocked")
 	}
 
-	if err := s.storage.SetPolicyLock(ctx, req.Repository, req.Group, req.PolicyName, req.Version, false); err != nil {
+	if err := s.storage.SetPolicyLock(ctx, req.Repository, req.Group, req.PolicyName, req.Version, nil); err != nil {
 		logger.Error("error unlocking policy", zap.Error(err))
 		return errors.New("error unlocking policy", err)
 	}
@@ -309,7 +347,205 @@ func (s *Service) Unlock(ctx context.Context, req *policy.UnlockRequest) error {
 	return nil
 }
 
+// CreatePolicy creates a new policy in storage.
+func (s *Service) CreatePolicy(ctx context.Context, req *policy.PolicyRequest) (any, error) {
+	logger := s.logger.With(
+		zap.String("operation", "createPolicy"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("version", req.Version),
+	)
+
+	_, err := s.storage.Policy(ctx, req.Repository, req.Group, req.PolicyName, req.Version)
+	if err == nil {
+		return nil, errors.New(errors.Exist, "policy already exists")
+	}
+	if !errors.Is(errors.NotFound, err) {
+		logger.Error("error getting policy from storage", zap.Error(err))
+		return nil, errors.New("error creating policy", err)
+	}
+
+	pol := policyFromRequest(req)
+	if err := s.compilePolicy(ctx, pol); err != nil {
+		logger.Error("invalid policy", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.storage.SavePolicy(ctx, pol); err != nil {
+		logger.Error("error saving policy", zap.Error(err))
+		return nil, errors.New("error saving policy", err)
+	}
+
+	logger.Debug("policy is created")
+
+	return policyResult(pol), nil
+}
+
+// UpdatePolicy replaces the source code, data and configuration
+// of an existing policy. The lock state of the policy is preserved.
+func (s *Service) UpdatePolicy(ctx context.Context, req *policy.PolicyRequest) (any, error) {
+	logger := s.logger.With(
+		zap.String("operation", "updatePolicy"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("version", req.Version),
+	)
+
+	current, err := s.storage.Policy(ctx, req.Repository, req.Group, req.PolicyName, req.Version)
+	if err != nil {
+		logger.Error("error getting policy from storage", zap.Error(err))
+		if errors.Is(errors.NotFound, err) {
+			return nil, err
+		}
+		return nil, errors.New("error updating policy", err)
+	}
+
+	pol := policyFromRequest(req)
+	pol.Locked = current.Locked
+	pol.Lock = current.Lock
+	if err := s.compilePolicy(ctx, pol); err != nil {
+		logger.Error("invalid policy", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.storage.SavePolicy(ctx, pol); err != nil {
+		logger.Error("error saving policy", zap.Error(err))
+		return nil, errors.New("error saving policy", err)
+	}
+
+	logger.Debug("policy is updated")
+
+	return policyResult(pol), nil
+}
+
+// DeletePolicy removes a policy from storage.
+func (s *Service) DeletePolicy(ctx context.Context, req *policy.DeletePolicyRequest) error {
+	logger := s.logger.With(
+		zap.String("operation", "deletePolicy"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("version", req.Version),
+	)
+
+	if err := s.storage.DeletePolicy(ctx, req.Repository, req.Group, req.PolicyName, req.Version); err != nil {
+		logger.Error("error deleting policy", zap.Error(err))
+		if errors.Is(errors.NotFound, err) {
+			return err
+		}
+		return errors.New("error deleting policy", err)
+	}
+
+	logger.Debug("policy is deleted")
+
+	return nil
+}
+
+// compilePolicy checks that the policy package matches the policy
+// group and name, and that the policy module and configurations are valid,
+// so that broken policies are not saved in storage.
+func (s *Service) compilePolicy(ctx context.Context, p *storage.Policy) error {
+	module, err := ast.ParseModule(p.Filename, p.Rego)
+	if err != nil {
+		return errors.New(errors.BadRequest, "error parsing policy source code", err)
+	}
+
+	// regoQuery must match both the package declaration inside the policy
+	// and the group and policy name.
+	regoQuery := fmt.Sprintf("data.%s.%s", p.Group, p.Name)
+	if module.Package.Path.String() != regoQuery {
+		return errors.New(errors.BadRequest, fmt.Sprintf("policy package must be %q", strings.TrimPrefix(regoQuery, "data.")))
+	}
+
+	if p.Data != "" {
+		var data map[string]interface{}
+		if err := json.Unmarshal([]byte(p.Data), &data); err != nil {
+			return errors.New(errors.BadRequest, "policy data must be a JSON object", err)
+		}
+	}
+
+	if p.DataConfig != "" && !json.Valid([]byte(p.DataConfig)) {
+		return errors.New(errors.BadRequest, "policy data configuration is not valid JSON")
+	}
+
+	if p.OutputSchema != "" {
+		if _, err := jsonschema.CompileString("", p.OutputSchema); err != nil {
+			return errors.New(errors.BadRequest, "error compiling output validation schema", err)
+		}
+	}
+
+	if p.ExportConfig != "" {
+		if _, err := policyExportConfig(p); err != nil {
+			return errors.New(errors.BadRequest, err)
+		}
+	}
+
+	regoArgs, err := s.buildRegoArgs(p.Filename, p.Rego, regoQuery, p.Data)
+	if err != nil {
+		return errors.New("error building rego runtime functions", err)
+	}
+	regoArgs = append(regoArgs, rego.Function1(regofunc.GetHeaderFunc(nil)))
+	regoArgs = append(regoArgs, rego.FunctionDyn(regofunc.GetRequestFunc(nil)))
+
+	if _, err := rego.New(regoArgs...).PrepareForEval(ctx); err != nil {
+		return errors.New(errors.BadRequest, "error compiling policy", err)
+	}
+
+	return nil
+}
+
+// policyFromRequest creates a storage policy from the request. The
+// filename follows the layout of policies in a policy repository.
+func policyFromRequest(req *policy.PolicyRequest) *storage.Policy {
+	p := &storage.Policy{
+		Filename:   strings.Join([]string{req.Group, req.PolicyName, req.Version, "policy.rego"}, "/"),
+		Repository: req.Repository,
+		Group:      req.Group,
+		Name:       req.PolicyName,
+		Version:    req.Version,
+		Rego:       req.Rego,
+		LastUpdate: time.Now(),
+	}
+
+	if req.Data != nil {
+		p.Data = *req.Data
+	}
+	if req.DataConfig != nil {
+		p.DataConfig = *req.DataConfig
+	}
+	if req.OutputSchema != nil {
+		p.OutputSchema = *req.OutputSchema
+	}
+	if req.ExportConfig != nil {
+		p.ExportConfig = *req.ExportConfig
+	}
+
+	return p
+}
+
+func policyResult(p *storage.Policy) map[string]interface{} {
+	return map[string]interface{}{
+		"repository": p.Repository,
+		"group":      p.Group,
+		"name":       p.Name,
+		"version":    p.Version,
+		"locked":     p.Locked,
+		"lastUpdate": p.LastUpdate,
+	}
+}
+
 func (s *Service) ExportBundle(ctx context.Context, req *policy.ExportBundleRequest) (*policy.ExportBundleResult, io.ReadCloser, error) {
+	if req.Target == exportTargetWasm {
+		return s.ExportWasmBundle(ctx, &policy.ExportWasmBundleRequest{
+			Repository: req.Repository,
+			Group:      req.Group,
+			PolicyName: req.PolicyName,
+			Version:    req.Version,
+		})
+	}
+
 	logger := s.logger.With(
 		zap.String("operation", "exportBundle"),
 		zap.String("repository", req.Repository),
@@ -340,21 +576,88 @@ func (s *Service) ExportBundle(ctx context.Context, req *policy.ExportBundleRequ
 		return nil, nil, err
 	}
 
+	signedBundle, err := s.signBundle(ctx, exportConfig, BundleFilename, bundle)
+	if err != nil {
+		logger.Error("error signing policy bundle", zap.Error(err))
+		return nil, nil, err
+	}
+
+	filename := fmt.Sprintf("%s_%s_%s_%s.zip", pol.Repository, pol.Group, pol.Name, pol.Version)
+	filename = strings.TrimSpace(filename)
+
+	return &policy.ExportBundleResult{
+		ContentType:        "application/zip",
+		ContentLength:      len(signedBundle),
+		ContentDisposition: fmt.Sprintf(`attachment; filename="%s"`, filename),
+	}, io.NopCloser(bytes.NewReader(signedBundle)), nil
+}
+
+// ExportWasmBundle compiles a policy and its static data to WebAssembly
+// and exports the result as a signed OPA bundle.
+func (s *Service) ExportWasmBundle(ctx context.Context, req *policy.ExportWasmBundleRequest) (*policy.ExportBundleResult, io.ReadCloser, error) {
+	logger := s.logger.With(
+		zap.String("operation", "exportWasmBundle"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("version", req.Version),
+	)
+
+	pol, err := s.storage.Policy(ctx, req.Repository, req.Group, req.PolicyName, req.Version)
+	if err != nil {
+		logger.Error("error getting policy from storage", zap.Error(err))
+		return nil, nil, err
+	}
+
+	exportConfig, err := policyExportConfig(pol)
+	if err != nil {
+		logger.Error(err.Error())
+		if err == errExportConfigNotFound {
+			return nil, nil, errors.New(errors.Forbidden, err)
+		}
+		return nil, nil, err
+	}
+
+	bundle, err := s.createWasmBundle(ctx, pol)
+	if err != nil {
+		logger.Error("error creating wasm policy bundle", zap.Error(err))
+		return nil, nil, err
+	}
+
+	signedBundle, err := s.signBundle(ctx, exportConfig, WasmBundleFilename, bundle)
+	if err != nil {
+		logger.Error("error signing wasm policy bundle", zap.Error(err))
+		return nil, nil, err
+	}
+
+	filename := fmt.Sprintf("%s_%s_%s_%s_wasm.zip", pol.Repository, pol.Group, pol.Name, pol.Version)
+	filename = strings.TrimSpace(filename)
+
+	return &policy.ExportBundleResult{
+		ContentType:        "application/zip",
+		ContentLength:      len(signedBundle),
+		ContentDisposition: fmt.Sprintf(`attachment; filename="%s"`, filename),
+	}, io.NopCloser(bytes.NewReader(signedBundle)), nil
+}
+
+// signBundle signs the given bundle with the signer key specified in the
+// policy export configuration and returns a zip archive containing both the
+// bundle and its detached signature.
+func (s *Service) signBundle(ctx context.Context, exportConfig *exportConfig, bundleFilename string, bundle []byte) ([]byte, error) {
 	// only the sha256 file digest will be signed, not the file itself
 	bundleDigest := sha256.Sum256(bundle)
 
 	// signer namespace and key are taken from policy export configuration
 	signature, err := s.signer.Sign(ctx, exportConfig.Namespace, exportConfig.Key, bundleDigest[:])
 	if err != nil {
-		logger.Error("error signing policy bundle", zap.Error(err))
-		return nil, nil, err
+		return nil, err
 	}
 
 	// the final ZIP file that will be exported to the client wraps the policy bundle
-	// zip file and the jws detached payload signature file
+	// file and the jws detached payload signature file
 	var files = []ZipFile{
 		{
-			Name:    BundleFilename,
+			Name:    bundleFilename,
 			Content: bundle,
 		},
 		{
@@ -365,18 +668,10 @@ func (s *Service) ExportBundle(ctx context.Context, req *policy.ExportBundleRequ
 
 	signedBundle, err := s.createZipArchive(files)
 	if err != nil {
-		logger.Error("error making final zip with signature", zap.Error(err))
-		return nil, nil, err
+		return nil, fmt.Errorf("error making final zip with signature: %v", err)
 	}
 
-	filename := fmt.Sprintf("%s_%s_%s_%s.zip", pol.Repository, pol.Group, pol.Name, pol.Version)
-	filename = strings.TrimSpace(filename)
-
-	return &policy.ExportBundleResult{
-		ContentType:        "application/zip",
-		ContentLength:      len(signedBundle),
-		ContentDisposition: fmt.Sprintf(`attachment; filename="%s"`, filename),
-	}, io.NopCloser(bytes.NewReader(signedBundle)), nil
+	return signedBundle, nil
 }
 
 // PolicyPublicKey returns the public key in JWK format which must be used to
@@ -426,7 +721,7 @@ func (s *Service) ImportBundle(ctx context.Context, _ *policy.ImportBundlePayloa
 		return nil, errors.New(errors.BadRequest, fmt.Errorf("error reading bundle payload: %v", err))
 	}
 
-	files, err := s.unzip(archive)
+	files, err := unzip(archive)
 	if err != nil {
 		logger.Error("failed to unzip bundle", zap.Error(err))
 		return nil, errors.New(errors.BadRequest, fmt.Errorf("failed to unzip bundle: %v", err))
@@ -450,7 +745,7 @@ func (s *Service) ImportBundle(ctx context.Context, _ *policy.ImportBundlePayloa
 		return nil, errors.New("cannot make policy from bundle", err)
 	}
 
-	if err := s.storage.SavePolicy(ctx, policy); err != nil {
+	if err := s.storage.SavePolicy(storage.WithRevisionSource(ctx, storage.RevisionSourceImport, ""), policy); err != nil {
 		logger.Error("error saving imported policy bundle", zap.Error(err))
 		return nil, errors.New("error saving imported policy bundle", err)
 	}
@@ -543,7 +838,44 @@ func (s *Service) DeletePolicyAutoImport(ctx context.Context, req *policy.Delete
 func (s *Service) ListPolicies(ctx context.Context, req *policy.PoliciesRequest) (*policy.PoliciesResult, error) {
 	logger := s.logger.With(zap.String("operation", "listPolicies"))
 
-	policies, err := s.storage.GetPolicies(ctx, req.Locked, req.PolicyName)
+	query := &storage.PolicyQuery{
+		Locked:     req.Locked,
+		Name:       req.PolicyName,
+		Repository: req.Repository,
+		Group:      req.Group,
+		Version:    req.Version,
+		Sort:       req.Sort,
+		Desc:       req.Order == "desc",
+		Rego:       req.Rego != nil && *req.Rego,
+		Data:       req.Data != nil && *req.Data,
+		DataConfig: req.DataConfig != nil && *req.DataConfig,
+	}
+
+	if req.UpdatedSince != nil {
+		since, err := time.Parse(time.RFC3339, *req.UpdatedSince)
+		if err != nil {
+			return nil, errors.New(errors.BadRequest, "invalid updatedSince time", err)
+		}
+		query.UpdatedSince = &since
+	}
+
+	if req.Cursor != nil {
+		query.After = *req.Cursor
+	}
+
+	if req.Limit != nil {
+		query.Limit = *req.Limit
+	}
+
+	if len(req.Annotation) > 0 {
+		custom, err := annotationFilter(req.Annotation)
+		if err != nil {
+			return nil, err
+		}
+		query.Custom = custom
+	}
+
+	policies, next, err := s.storage.ListPolicies(ctx, query)
 	if err != nil {
 		logger.Error("error retrieving policies", zap.Error(err))
 		return nil, errors.New("error retrieving policies", err)
@@ -552,14 +884,7 @@ func (s *Service) ListPolicies(ctx context.Context, req *policy.PoliciesRequest)
 	policiesResult := make([]*policy.Policy, 0, len(policies))
 
 	for _, p := range policies {
-		policy := &policy.Policy{
-			Repository: p.Repository,
-			PolicyName: p.Name,
-			Group:      p.Group,
-			Version:    p.Version,
-			Locked:     p.Locked,
-			LastUpdate: p.LastUpdate.Unix(),
-		}
+		policy := policyListItem(p)
 
 		if req.Rego != nil && *req.Rego {
 			policy.Rego = ptr.String(p.Rego)
@@ -576,7 +901,173 @@ func (s *Service) ListPolicies(ctx context.Context, req *policy.PoliciesRequest)
 		policiesResult = append(policiesResult, policy)
 	}
 
-	return &policy.PoliciesResult{Policies: policiesResult}, nil
+	result := &policy.PoliciesResult{Policies: policiesResult}
+	if next != "" {
+		result.NextCursor = ptr.String(next)
+	}
+
+	return result, nil
+}
+
+// GetPolicy returns a policy with its source code, data, configuration
+// and METADATA annotations.
+func (s *Service) GetPolicy(ctx context.Context, req *policy.GetPolicyRequest) (*policy.Policy, error) {
+	logger := s.logger.With(
+		zap.String("operation", "getPolicy"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("version", req.Version),
+	)
+
+	p, err := s.storage.Policy(ctx, req.Repository, req.Group, req.PolicyName, req.Version)
+	if err != nil {
+		if !errors.Is(errors.NotFound, err) {
+			logger.Error("error getting policy from storage", zap.Error(err))
+		}
+		return nil, errors.New("error getting policy", err)
+	}
+
+	res := policyListItem(p)
+	res.Rego = ptr.String(p.Rego)
+
+	if p.Data != "" {
+		res.Data = ptr.String(p.Data)
+	}
+
+	if p.DataConfig != "" {
+		res.DataConfig = ptr.String(p.DataConfig)
+	}
+
+	if p.OutputSchema != "" {
+		res.OutputSchema = ptr.String(p.OutputSchema)
+	}
+
+	if p.ExportConfig != "" {
+		res.ExportConfig = ptr.String(p.ExportConfig)
+	}
+
+	return res, nil
+}
+
+// policyListItem returns the policy without its source code, data and configuration.
+func policyListItem(p *storage.Policy) *policy.Policy {
+	res := &policy.Policy{
+		Repository: p.Repository,
+		PolicyName: p.Name,
+		Group:      p.Group,
+		Version:    p.Version,
+		Locked:     p.Locked,
+		LastUpdate: p.LastUpdate.Unix(),
+	}
+
+	if p.Archived {
+		res.Archived = ptr.Bool(true)
+	}
+
+	if p.Commit != nil {
+		res.Commit = commitResult(p.Commit)
+	}
+
+	if p.Metadata != nil {
+		res.Metadata = metadataResult(p.Metadata)
+	}
+
+	if p.Lock != nil {
+		res.Lock = lockResult(p.Lock)
+	}
+
+	return res
+}
+
+func metadataResult(m *storage.PolicyMetadata) *policy.PolicyMetadata {
+	res := &policy.PolicyMetadata{
+		Authors:          m.Authors,
+		Organizations:    m.Organizations,
+		RelatedResources: m.RelatedResources,
+		Custom:           m.Custom,
+	}
+
+	if m.Title != "" {
+		res.Title = ptr.String(m.Title)
+	}
+
+	if m.Description != "" {
+		res.Description = ptr.String(m.Description)
+	}
+
+	return res
+}
+
+func lockResult(l *storage.PolicyLock) *policy.PolicyLock {
+	res := &policy.PolicyLock{LockedAt: l.LockedAt.Unix()}
+
+	if l.Reason != "" {
+		res.Reason = ptr.String(l.Reason)
+	}
+
+	if l.Actor != "" {
+		res.LockedBy = ptr.String(l.Actor)
+	}
+
+	if l.ExpiresAt != nil {
+		res.ExpiresAt = ptr.Int64(l.ExpiresAt.Unix())
+	}
+
+	return res
+}
+
+// lockedError returns the error of evaluating a locked
+// policy, which contains the details of the lock.
+func lockedError(l *storage.PolicyLock) error {
+	if l == nil {
+		return errors.New(errors.Forbidden, "policy is locked")
+	}
+
+	msg := "policy is locked"
+	if l.Reason != "" {
+		msg += ": " + l.Reason
+	}
+
+	details := []string{"locked at " + l.LockedAt.UTC().Format(time.RFC3339)}
+	if l.Actor != "" {
+		details = append(details, "locked by "+l.Actor)
+	}
+	if l.ExpiresAt != nil {
+		details = append(details, "expires at "+l.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+
+	return errors.New(errors.Forbidden, msg+" ("+strings.Join(details, ", ")+")")
+}
+
+// annotationFilter parses filters of custom METADATA annotations
+// in the form custom.key=value.
+func annotationFilter(annotations []string) (map[string]string, error) {
+	filter := make(map[string]string, len(annotations))
+	for _, annotation := range annotations {
+		key, value, ok := strings.Cut(annotation, "=")
+		name, custom := strings.CutPrefix(key, "custom.")
+		if !ok || !custom || name == "" || strings.ContainsAny(name, ".$") {
+			return nil, errors.New(errors.BadRequest, fmt.Sprintf("invalid annotation filter %q, it must be in the form custom.key=value", annotation))
+		}
+		filter[name] = value
+	}
+
+	return filter, nil
+}
+
+func commitResult(c *storage.Commit) *policy.PolicyCommit {
+	res := &policy.PolicyCommit{
+		Sha:    c.SHA,
+		Time:   c.Time.Unix(),
+		Author: c.Author,
+	}
+
+	if c.Branch != "" {
+		res.Branch = ptr.String(c.Branch)
+	}
+
+	return res
 }
 
 func (s *Service) SubscribeForPolicyChange(ctx context.Context, req *policy.SubscribeRequest) (any, error) {
@@ -596,6 +1087,13 @@ func (s *Service) SubscribeForPolicyChange(ctx context.Context, req *policy.Subs
 		return nil, errors.New(errors.Exist, "subscriber already exist")
 	}
 
+	// the secret is returned only once to the subscriber,
+	// which uses it to verify the signatures of webhook calls
+	secret, err := webhookSecret()
+	if err != nil {
+		return nil, errors.New("error generating webhook secret", err)
+	}
+
 	subscriber, err := s.storage.CreateSubscriber(ctx, &storage.Subscriber{
 		Name:             req.Subscriber,
 		WebhookURL:       req.WebhookURL,
@@ -603,6 +1101,7 @@ func (s *Service) SubscribeForPolicyChange(ctx context.Context, req *policy.Subs
 		PolicyName:       req.PolicyName,
 		PolicyGroup:      req.Group,
 		PolicyVersion:    req.Version,
+		Secret:           secret,
 	})
 	if err != nil {
 		logger.Error("error storing policy change subscription", zap.Error(err))
@@ -615,7 +1114,7 @@ func (s *Service) SubscribeForPolicyChange(ctx context.Context, req *policy.Subs
 // prepareQuery tries to get a prepared query from the regocache.
 // If the policyCache entry is not found, it will try to prepare a new
 // query and will set it into the policyCache for future use.
-func (s *Service) prepareQuery(ctx context.Context, repository, group, policyName, version string, headers map[string]string) (*rego.PreparedEvalQuery, error) {
+func (s *Service) prepareQuery(ctx context.Context, repository, group, policyName, version string, headers map[string]string, request map[string]interface{}) (*rego.PreparedEvalQuery, error) {
 	// retrieve policy
 	pol, err := s.retrievePolicy(ctx, repository, group, policyName, version)
 	if err != nil {
@@ -624,7 +1123,12 @@ func (s *Service) prepareQuery(ctx context.Context, repository, group, policyNam
 
 	// if policy is locked, return an error
 	if pol.Locked {
-		return nil, errors.New(errors.Forbidden, "policy is locked")
+		return nil, lockedError(pol.Lock)
+	}
+
+	// archived policies are removed from their repository
+	if pol.Archived {
+		return nil, errors.New(errors.Forbidden, "policy is archived")
 	}
 
 	// regoQuery must match both the package declaration inside the policy
@@ -637,9 +1141,11 @@ func (s *Service) prepareQuery(ctx context.Context, repository, group, policyNam
 		return nil, errors.New("error building rego runtime functions", err)
 	}
 
-	// Append dynamically the external.http.header function on every request,
-	// because it is populated with different headers each time.
+	// Append dynamically the external.http.header and external.http.request
+	// functions on every request, because they are populated with different
+	// headers and request details each time.
 	regoArgs = append(regoArgs, rego.Function1(regofunc.GetHeaderFunc(headers)))
+	regoArgs = append(regoArgs, rego.FunctionDyn(regofunc.GetRequestFunc(request)))
 
 	newQuery, err := rego.New(
 		regoArgs...,
@@ -651,6 +1157,31 @@ func (s *Service) prepareQuery(ctx context.Context, repository, group, policyNam
 	return &newQuery, nil
 }
 
+// requestDetails returns the details of the request evaluating a policy
+// and the claims of the caller's verified token.
+func requestDetails(ctx context.Context) map[string]interface{} {
+	details := map[string]interface{}{
+		"method":            "",
+		"path":              "",
+		"query":             map[string][]string{},
+		"clientCertSubject": "",
+		"claims":            map[string]interface{}{},
+	}
+
+	if r, ok := request.FromContext(ctx); ok {
+		details["method"] = r.Method
+		details["path"] = r.Path
+		details["query"] = r.Query
+		details["clientCertSubject"] = r.ClientCertSubject
+	}
+
+	if c, ok := caller.FromContext(ctx); ok && c.Claims != nil {
+		details["claims"] = c.Claims
+	}
+
+	return details
+}
+
 func (s *Service) buildRegoArgs(filename, regoPolicy, regoQuery, regoData string) (availableFuncs []func(*rego.Rego), err error) {
 	availableFuncs = make([]func(*rego.Rego), 3)
 	availableFuncs[0] = rego.Module(filename, regoPolicy)
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/open-policy-agent/opa/bundle"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -18,7 +19,9 @@ import (
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/ptr"
 	goapolicy "gitlab.eclipse.org/eclipse/xfsc/tsa/policy/gen/policy"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/caller"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/header"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/request"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/service/policy"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/service/policy/policyfakes"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
@@ -66,6 +69,12 @@ func TestService_Evaluate(t *testing.T) {
 	// prepare test policy accessing headers during evaluation
 	testPolicyAccessingHeaders := `package testgroup.example token := external.http.header("Authorization")`
 
+	// prepare test policy accessing request details during evaluation
+	testPolicyAccessingRequest := `package testgroup.example
+		method := external.http.request().method
+		path := external.http.request().path
+		caller := external.http.request().claims.sub`
+
 	// prepare test request with empty body
 	testEmptyReq := func() *goapolicy.EvaluateRequest {
 		var body interface{}
@@ -105,6 +114,8 @@ func TestService_Evaluate(t *testing.T) {
 		res     *goapolicy.EvaluateResult
 		errkind errors.Kind
 		errtext string
+		// errmsg is the message returned to the caller
+		errmsg string
 	}{
 		{
 			name: "policy is found in policyCache",
@@ -174,6 +185,49 @@ func TestService_Evaluate(t *testing.T) {
 			res:     nil,
 			errkind: errors.Forbidden,
 			errtext: "policy is locked",
+			errmsg:  "policy is locked",
+		},
+		{
+			name: "policy is locked with lock details",
+			req:  testReq(),
+			regocache: &policyfakes.FakeRegoCache{
+				GetStub: func(key string) (*storage.Policy, bool) {
+					return nil, false
+				},
+			},
+			storage: &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+					expiresAt := time.Date(2030, 1, 2, 10, 0, 0, 0, time.UTC)
+					return &storage.Policy{Locked: true, Lock: &storage.PolicyLock{
+						Reason:    "incident 42",
+						Actor:     "alice",
+						LockedAt:  time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC),
+						ExpiresAt: &expiresAt,
+					}}, nil
+				},
+			},
+			res:     nil,
+			errkind: errors.Forbidden,
+			errtext: "policy is locked: incident 42 (locked at 2030-01-01T10:00:00Z, locked by alice, expires at 2030-01-02T10:00:00Z)",
+			errmsg:  "policy is locked: incident 42 (locked at 2030-01-01T10:00:00Z, locked by alice, expires at 2030-01-02T10:00:00Z)",
+		},
+		{
+			name: "policy is archived",
+			req:  testReq(),
+			regocache: &policyfakes.FakeRegoCache{
+				GetStub: func(key string) (*storage.Policy, bool) {
+					return nil, false
+				},
+			},
+			storage: &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+					return &storage.Policy{Archived: true}, nil
+				},
+			},
+			res:     nil,
+			errkind: errors.Forbidden,
+			errtext: "policy is archived",
+			errmsg:  "policy is archived",
 		},
 		{
 			name: "policy is found in storage and isn't locked",
@@ -345,6 +399,38 @@ func TestService_Evaluate(t *testing.T) {
 				Result: map[string]interface{}{"token": "my-token"},
 			},
 		},
+		{
+			name: "policy accessing request details is evaluated successfully",
+			ctx: caller.ToContext(
+				request.ToContext(context.Background(), httptest.NewRequest("POST", "/policy/policies/testgroup/example/1.0/evaluation", nil)),
+				&caller.Caller{ID: "alice", Claims: map[string]interface{}{"sub": "alice"}},
+			),
+			req: testReq(),
+			regocache: &policyfakes.FakeRegoCache{
+				GetStub: func(key string) (*storage.Policy, bool) {
+					return &storage.Policy{
+						Repository: "policies",
+						Name:       "example",
+						Group:      "testgroup",
+						Version:    "1.0",
+						Rego:       testPolicyAccessingRequest,
+						LastUpdate: time.Now(),
+					}, true
+				},
+			},
+			cache: &policyfakes.FakeCache{
+				SetStub: func(ctx context.Context, s string, s2 string, s3 string, bytes []byte, i int) error {
+					return nil
+				},
+			},
+			res: &goapolicy.EvaluateResult{
+				Result: map[string]interface{}{
+					"method": "POST",
+					"path":   "/policy/policies/testgroup/example/1.0/evaluation",
+					"caller": "alice",
+				},
+			},
+		},
 		{
 			name: "policy with empty input is evaluated successfully",
 			ctx:  ctxWithHeaders(),
@@ -402,6 +488,10 @@ func TestService_Evaluate(t *testing.T) {
 				assert.Contains(t, e.Error(), test.errtext)
 				assert.Equal(t, test.errkind, e.Kind)
 				assert.Equal(t, test.res, res)
+				// the message is the only description in the error response
+				if test.errmsg != "" {
+					assert.Equal(t, test.errmsg, e.Message)
+				}
 			}
 		})
 	}
@@ -697,7 +787,7 @@ func TestService_Lock(t *testing.T) {
 				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
 					return &storage.Policy{Locked: false}, nil
 				},
-				SetPolicyLockStub: func(ctx context.Context, repository, name, group, version string, lock bool) error {
+				SetPolicyLockStub: func(ctx context.Context, repository, name, group, version string, lock *storage.PolicyLock) error {
 					return errors.New(errors.Internal, "error locking policy")
 				},
 			},
@@ -711,7 +801,53 @@ func TestService_Lock(t *testing.T) {
 				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
 					return &storage.Policy{Locked: false}, nil
 				},
-				SetPolicyLockStub: func(ctx context.Context, repository, name, group, version string, lock bool) error {
+				SetPolicyLockStub: func(ctx context.Context, repository, name, group, version string, lock *storage.PolicyLock) error {
+					return nil
+				},
+			},
+			errtext: "",
+		},
+		{
+			name: "invalid expiration time",
+			req: &goapolicy.LockRequest{
+				Group:      "testgroup",
+				PolicyName: "example",
+				Version:    "1.0",
+				ExpiresAt:  ptr.String("tomorrow"),
+			},
+			storage: &policyfakes.FakeStorage{},
+			errkind: errors.BadRequest,
+			errtext: "invalid expiresAt time",
+		},
+		{
+			name: "expiration time in the past",
+			req: &goapolicy.LockRequest{
+				Group:      "testgroup",
+				PolicyName: "example",
+				Version:    "1.0",
+				ExpiresAt:  ptr.String("2020-01-01T00:00:00Z"),
+			},
+			storage: &policyfakes.FakeStorage{},
+			errkind: errors.BadRequest,
+			errtext: "expiresAt time must be in the future",
+		},
+		{
+			name: "policy is locked with reason, caller and expiration time",
+			req: &goapolicy.LockRequest{
+				Group:      "testgroup",
+				PolicyName: "example",
+				Version:    "1.0",
+				Reason:     ptr.String("incident 42"),
+				ExpiresAt:  ptr.String("2100-01-01T00:00:00Z"),
+			},
+			storage: &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+					return &storage.Policy{Locked: false}, nil
+				},
+				SetPolicyLockStub: func(ctx context.Context, repository, name, group, version string, lock *storage.PolicyLock) error {
+					if lock.Reason != "incident 42" || lock.Actor != "alice" || lock.ExpiresAt == nil || lock.ExpiresAt.Year() != 2100 {
+						return errors.New(errors.Internal, "unexpected lock details")
+					}
 					return nil
 				},
 			},
@@ -733,7 +869,7 @@ func TestService_Lock(t *testing.T) {
 				http.DefaultClient,
 				zap.NewNop(),
 			)
-			err := svc.Lock(context.Background(), test.req)
+			err := svc.Lock(caller.ToContext(context.Background(), &caller.Caller{ID: "alice"}), test.req)
 			if err == nil {
 				assert.Empty(t, test.errtext)
 			} else {
@@ -806,7 +942,7 @@ func TestService_Unlock(t *testing.T) {
 				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
 					return &storage.Policy{Locked: true}, nil
 				},
-				SetPolicyLockStub: func(ctx context.Context, repository, name, group, version string, lock bool) error {
+				SetPolicyLockStub: func(ctx context.Context, repository, name, group, version string, lock *storage.PolicyLock) error {
 					return errors.New(errors.Internal, "error unlocking policy")
 				},
 			},
@@ -820,7 +956,7 @@ func TestService_Unlock(t *testing.T) {
 				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
 					return &storage.Policy{Locked: true}, nil
 				},
-				SetPolicyLockStub: func(ctx context.Context, repository, name, group, version string, lock bool) error {
+				SetPolicyLockStub: func(ctx context.Context, repository, name, group, version string, lock *storage.PolicyLock) error {
 					return nil
 				},
 			},
@@ -856,6 +992,334 @@ func TestService_Unlock(t *testing.T) {
 	}
 }
 
+func TestService_CreatePolicy(t *testing.T) {
+	// prepare test request to be used in tests
+	testReq := func() *goapolicy.PolicyRequest {
+		return &goapolicy.PolicyRequest{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Version:    "1.0",
+			Rego:       "package testgroup.example\n\nallow := input.allow",
+			Data:       ptr.String(`{"hello":"world"}`),
+		}
+	}
+
+	notFound := func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+		return nil, errors.New(errors.NotFound)
+	}
+
+	tests := []struct {
+		name    string
+		req     *goapolicy.PolicyRequest
+		storage *policyfakes.FakeStorage
+
+		errkind errors.Kind
+		errtext string
+	}{
+		{
+			name: "policy already exists",
+			req:  testReq(),
+			storage: &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+					return &storage.Policy{}, nil
+				},
+			},
+			errkind: errors.Exist,
+			errtext: "policy already exists",
+		},
+		{
+			name: "error getting policy from storage",
+			req:  testReq(),
+			storage: &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+					return nil, errors.New("some error")
+				},
+			},
+			errkind: errors.Unknown,
+			errtext: "some error",
+		},
+		{
+			name: "policy source code cannot be parsed",
+			req: func() *goapolicy.PolicyRequest {
+				req := testReq()
+				req.Rego = "package testgroup.example\n\nallow :="
+				return req
+			}(),
+			storage: &policyfakes.FakeStorage{PolicyStub: notFound},
+			errkind: errors.BadRequest,
+			errtext: "error parsing policy source code",
+		},
+		{
+			name: "policy package doesn't match group and name",
+			req: func() *goapolicy.PolicyRequest {
+				req := testReq()
+				req.Rego = "package othergroup.example"
+				return req
+			}(),
+			storage: &policyfakes.FakeStorage{PolicyStub: notFound},
+			errkind: errors.BadRequest,
+			errtext: `policy package must be "testgroup.example"`,
+		},
+		{
+			name: "policy cannot be compiled",
+			req: func() *goapolicy.PolicyRequest {
+				req := testReq()
+				req.Rego = "package testgroup.example\n\nallow := unknownFunc(input)"
+				return req
+			}(),
+			storage: &policyfakes.FakeStorage{PolicyStub: notFound},
+			errkind: errors.BadRequest,
+			errtext: "error compiling policy",
+		},
+		{
+			name: "policy data is not a JSON object",
+			req: func() *goapolicy.PolicyRequest {
+				req := testReq()
+				req.Data = ptr.String(`["hello"]`)
+				return req
+			}(),
+			storage: &policyfakes.FakeStorage{PolicyStub: notFound},
+			errkind: errors.BadRequest,
+			errtext: "policy data must be a JSON object",
+		},
+		{
+			name: "invalid output schema",
+			req: func() *goapolicy.PolicyRequest {
+				req := testReq()
+				req.OutputSchema = ptr.String(`{"type":"invalid"}`)
+				return req
+			}(),
+			storage: &policyfakes.FakeStorage{PolicyStub: notFound},
+			errkind: errors.BadRequest,
+			errtext: "error compiling output validation schema",
+		},
+		{
+			name: "error saving policy",
+			req:  testReq(),
+			storage: &policyfakes.FakeStorage{
+				PolicyStub: notFound,
+				SavePolicyStub: func(ctx context.Context, p *storage.Policy) error {
+					return errors.New("some error")
+				},
+			},
+			errkind: errors.Unknown,
+			errtext: "error saving policy",
+		},
+		{
+			name:    "policy is created successfully",
+			req:     testReq(),
+			storage: &policyfakes.FakeStorage{PolicyStub: notFound},
+		},
+		{
+			name: "policy using the request details is created successfully",
+			req: func() *goapolicy.PolicyRequest {
+				req := testReq()
+				req.Rego = "package testgroup.example\n\nallow := external.http.request().method == \"POST\"\n\nauth := external.http.header(\"Authorization\")"
+				return req
+			}(),
+			storage: &policyfakes.FakeStorage{PolicyStub: notFound},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc := policy.New(
+				context.Background(),
+				test.storage,
+				nil,
+				nil,
+				nil,
+				"hostname.com",
+				false,
+				10*time.Second,
+				http.DefaultClient,
+				zap.NewNop(),
+			)
+			res, err := svc.CreatePolicy(context.Background(), test.req)
+			if test.errtext != "" {
+				require.Error(t, err)
+				e, ok := err.(*errors.Error)
+				assert.True(t, ok)
+				assert.Contains(t, e.Error(), test.errtext)
+				assert.Equal(t, test.errkind, e.Kind)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, 1, test.storage.SavePolicyCallCount())
+			_, saved := test.storage.SavePolicyArgsForCall(0)
+			assert.Equal(t, "testgroup/example/1.0/policy.rego", saved.Filename)
+			assert.Equal(t, test.req.Rego, saved.Rego)
+			assert.Equal(t, `{"hello":"world"}`, saved.Data)
+			assert.False(t, saved.LastUpdate.IsZero())
+			assert.Equal(t, "example", res.(map[string]interface{})["name"])
+		})
+	}
+}
+
+func TestService_UpdatePolicy(t *testing.T) {
+	// prepare test request to be used in tests
+	testReq := func() *goapolicy.PolicyRequest {
+		return &goapolicy.PolicyRequest{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Version:    "1.0",
+			Rego:       "package testgroup.example\n\nallow := false",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		req     *goapolicy.PolicyRequest
+		storage *policyfakes.FakeStorage
+
+		errkind errors.Kind
+		errtext string
+	}{
+		{
+			name: "policy is not found",
+			req:  testReq(),
+			storage: &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+					return nil, errors.New(errors.NotFound)
+				},
+			},
+			errkind: errors.NotFound,
+			errtext: "not found",
+		},
+		{
+			name: "invalid policy",
+			req: func() *goapolicy.PolicyRequest {
+				req := testReq()
+				req.ExportConfig = ptr.String("{")
+				return req
+			}(),
+			storage: &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+					return &storage.Policy{}, nil
+				},
+			},
+			errkind: errors.BadRequest,
+			errtext: "cannot unmarshal policy export configuration",
+		},
+		{
+			name: "policy is updated successfully and lock state is preserved",
+			req:  testReq(),
+			storage: &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+					return &storage.Policy{Locked: true, Rego: "package testgroup.example"}, nil
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc := policy.New(
+				context.Background(),
+				test.storage,
+				nil,
+				nil,
+				nil,
+				"hostname.com",
+				false,
+				10*time.Second,
+				http.DefaultClient,
+				zap.NewNop(),
+			)
+			_, err := svc.UpdatePolicy(context.Background(), test.req)
+			if test.errtext != "" {
+				require.Error(t, err)
+				e, ok := err.(*errors.Error)
+				assert.True(t, ok)
+				assert.Contains(t, e.Error(), test.errtext)
+				assert.Equal(t, test.errkind, e.Kind)
+				assert.Equal(t, 0, test.storage.SavePolicyCallCount())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, 1, test.storage.SavePolicyCallCount())
+			_, saved := test.storage.SavePolicyArgsForCall(0)
+			assert.Equal(t, test.req.Rego, saved.Rego)
+			assert.True(t, saved.Locked)
+		})
+	}
+}
+
+func TestService_DeletePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		storage *policyfakes.FakeStorage
+
+		errkind errors.Kind
+		errtext string
+	}{
+		{
+			name: "policy is not found",
+			storage: &policyfakes.FakeStorage{
+				DeletePolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) error {
+					return errors.New(errors.NotFound, "policy not found")
+				},
+			},
+			errkind: errors.NotFound,
+			errtext: "policy not found",
+		},
+		{
+			name: "error deleting policy",
+			storage: &policyfakes.FakeStorage{
+				DeletePolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) error {
+					return errors.New("some error")
+				},
+			},
+			errkind: errors.Unknown,
+			errtext: "error deleting policy",
+		},
+		{
+			name:    "policy is deleted successfully",
+			storage: &policyfakes.FakeStorage{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc := policy.New(
+				context.Background(),
+				test.storage,
+				nil,
+				nil,
+				nil,
+				"hostname.com",
+				false,
+				10*time.Second,
+				http.DefaultClient,
+				zap.NewNop(),
+			)
+			err := svc.DeletePolicy(context.Background(), &goapolicy.DeletePolicyRequest{
+				Repository: "policies",
+				Group:      "testgroup",
+				PolicyName: "example",
+				Version:    "1.0",
+			})
+			if test.errtext != "" {
+				require.Error(t, err)
+				e, ok := err.(*errors.Error)
+				assert.True(t, ok)
+				assert.Contains(t, e.Error(), test.errtext)
+				assert.Equal(t, test.errkind, e.Kind)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, 1, test.storage.DeletePolicyCallCount())
+			_, repository, group, name, version := test.storage.DeletePolicyArgsForCall(0)
+			assert.Equal(t, []string{"policies", "testgroup", "example", "1.0"}, []string{repository, group, name, version})
+		})
+	}
+}
+
 func TestService_ListPolicies(t *testing.T) {
 	boolTrue := true
 	boolFalse := false
@@ -869,8 +1333,8 @@ func TestService_ListPolicies(t *testing.T) {
 	}{
 		{
 			name: "storage return error",
-			storage: &policyfakes.FakeStorage{GetPoliciesStub: func(ctx context.Context, b *bool, s *string) ([]*storage.Policy, error) {
-				return nil, fmt.Errorf("some error")
+			storage: &policyfakes.FakeStorage{ListPoliciesStub: func(ctx context.Context, q *storage.PolicyQuery) ([]*storage.Policy, string, error) {
+				return nil, "", fmt.Errorf("some error")
 			}},
 			request: &goapolicy.PoliciesRequest{
 				Locked:     ptr.Bool(false),
@@ -883,7 +1347,7 @@ func TestService_ListPolicies(t *testing.T) {
 		},
 		{
 			name: "request without errors and any additional request parameter return all policies",
-			storage: &policyfakes.FakeStorage{GetPoliciesStub: func(ctx context.Context, b *bool, s *string) ([]*storage.Policy, error) {
+			storage: &policyfakes.FakeStorage{ListPoliciesStub: func(ctx context.Context, q *storage.PolicyQuery) ([]*storage.Policy, string, error) {
 				return []*storage.Policy{{
 					Repository: "policies",
 					Name:       "example",
@@ -903,8 +1367,9 @@ func TestService_ListPolicies(t *testing.T) {
 					Data:       "some data",
 					DataConfig: "data config",
 					Locked:     true,
+					Commit:     &storage.Commit{SHA: "8f1c2a0e", Time: time.Unix(1700000000, 0), Author: "Jane Doe <jane@example.com>", Branch: "main"},
 					LastUpdate: time.Time{},
-				}}, nil
+				}}, "", nil
 			}},
 			request: &goapolicy.PoliciesRequest{},
 
@@ -924,6 +1389,7 @@ func TestService_ListPolicies(t *testing.T) {
 						Group:      "example",
 						Version:    "example",
 						Locked:     true,
+						Commit:     &goapolicy.PolicyCommit{Sha: "8f1c2a0e", Time: 1700000000, Author: "Jane Doe <jane@example.com>", Branch: ptr.String("main")},
 						LastUpdate: time.Time{}.Unix(),
 					},
 				},
@@ -931,7 +1397,7 @@ func TestService_ListPolicies(t *testing.T) {
 		},
 		{
 			name: "request with only locked parameter equal to true returns only locked policies",
-			storage: &policyfakes.FakeStorage{GetPoliciesStub: func(ctx context.Context, b *bool, s *string) ([]*storage.Policy, error) {
+			storage: &policyfakes.FakeStorage{ListPoliciesStub: func(ctx context.Context, q *storage.PolicyQuery) ([]*storage.Policy, string, error) {
 				return []*storage.Policy{{
 					Repository: "policies",
 					Name:       "example",
@@ -942,7 +1408,7 @@ func TestService_ListPolicies(t *testing.T) {
 					DataConfig: "data config",
 					Locked:     true,
 					LastUpdate: time.Time{},
-				}}, nil
+				}}, "", nil
 			}},
 			request: &goapolicy.PoliciesRequest{
 				Locked: &boolTrue,
@@ -963,7 +1429,7 @@ func TestService_ListPolicies(t *testing.T) {
 		},
 		{
 			name: "request with only locked parameter equal to false returns only unlocked policies",
-			storage: &policyfakes.FakeStorage{GetPoliciesStub: func(ctx context.Context, b *bool, s *string) ([]*storage.Policy, error) {
+			storage: &policyfakes.FakeStorage{ListPoliciesStub: func(ctx context.Context, q *storage.PolicyQuery) ([]*storage.Policy, string, error) {
 				return []*storage.Policy{{
 					Repository: "policies",
 					Name:       "example",
@@ -974,7 +1440,7 @@ func TestService_ListPolicies(t *testing.T) {
 					DataConfig: "data config",
 					Locked:     false,
 					LastUpdate: time.Time{},
-				}}, nil
+				}}, "", nil
 			}},
 			request: &goapolicy.PoliciesRequest{
 				Locked: &boolFalse,
@@ -995,7 +1461,7 @@ func TestService_ListPolicies(t *testing.T) {
 		},
 		{
 			name: "request with all additional params set to true and policy name is blank",
-			storage: &policyfakes.FakeStorage{GetPoliciesStub: func(ctx context.Context, b *bool, s *string) ([]*storage.Policy, error) {
+			storage: &policyfakes.FakeStorage{ListPoliciesStub: func(ctx context.Context, q *storage.PolicyQuery) ([]*storage.Policy, string, error) {
 				return []*storage.Policy{{
 					Repository: "policies",
 					Name:       "example",
@@ -1006,7 +1472,7 @@ func TestService_ListPolicies(t *testing.T) {
 					DataConfig: "data config",
 					Locked:     true,
 					LastUpdate: time.Time{},
-				}}, nil
+				}}, "", nil
 			}},
 			request: &goapolicy.PoliciesRequest{
 				Locked:     ptr.Bool(true),
@@ -1034,7 +1500,7 @@ func TestService_ListPolicies(t *testing.T) {
 		},
 		{
 			name: "request with all additional params set to false and policy name is blank",
-			storage: &policyfakes.FakeStorage{GetPoliciesStub: func(ctx context.Context, b *bool, s *string) ([]*storage.Policy, error) {
+			storage: &policyfakes.FakeStorage{ListPoliciesStub: func(ctx context.Context, q *storage.PolicyQuery) ([]*storage.Policy, string, error) {
 				return []*storage.Policy{{
 					Repository: "policies",
 					Name:       "example",
@@ -1045,7 +1511,7 @@ func TestService_ListPolicies(t *testing.T) {
 					DataConfig: "data config",
 					Locked:     false,
 					LastUpdate: time.Time{},
-				}}, nil
+				}}, "", nil
 			}},
 			request: &goapolicy.PoliciesRequest{
 				Locked:     ptr.Bool(false),
@@ -1070,7 +1536,7 @@ func TestService_ListPolicies(t *testing.T) {
 		},
 		{
 			name: "request with policy name filter return every policy which contains the policy name",
-			storage: &policyfakes.FakeStorage{GetPoliciesStub: func(ctx context.Context, b *bool, s *string) ([]*storage.Policy, error) {
+			storage: &policyfakes.FakeStorage{ListPoliciesStub: func(ctx context.Context, q *storage.PolicyQuery) ([]*storage.Policy, string, error) {
 				return []*storage.Policy{{
 					Repository: "policies",
 					Name:       "example",
@@ -1081,7 +1547,7 @@ func TestService_ListPolicies(t *testing.T) {
 					DataConfig: "data config",
 					Locked:     false,
 					LastUpdate: time.Time{},
-				}}, nil
+				}}, "", nil
 			}},
 			request: &goapolicy.PoliciesRequest{
 				PolicyName: ptr.String("exam"),
@@ -1100,6 +1566,110 @@ func TestService_ListPolicies(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "request with filters, sort order and cursor returns the next page cursor",
+			storage: &policyfakes.FakeStorage{ListPoliciesStub: func(ctx context.Context, q *storage.PolicyQuery) ([]*storage.Policy, string, error) {
+				since := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+				expected := &storage.PolicyQuery{
+					Repository:   ptr.String("policies"),
+					Group:        ptr.String("example"),
+					Version:      ptr.String("1.0"),
+					UpdatedSince: &since,
+					Sort:         storage.SortByLastUpdate,
+					Desc:         true,
+					After:        "cursor1",
+					Limit:        1,
+				}
+				if !assert.Equal(t, expected, q) {
+					return nil, "", fmt.Errorf("unexpected query")
+				}
+				return []*storage.Policy{{
+					Repository: "policies",
+					Name:       "example",
+					Group:      "example",
+					Version:    "1.0",
+					LastUpdate: since,
+				}}, "cursor2", nil
+			}},
+			request: &goapolicy.PoliciesRequest{
+				Repository:   ptr.String("policies"),
+				Group:        ptr.String("example"),
+				Version:      ptr.String("1.0"),
+				UpdatedSince: ptr.String("2024-01-02T15:04:05Z"),
+				Sort:         "lastUpdate",
+				Order:        "desc",
+				Cursor:       ptr.String("cursor1"),
+				Limit:        ptr.Int(1),
+			},
+
+			response: &goapolicy.PoliciesResult{
+				Policies: []*goapolicy.Policy{
+					{
+						Repository: "policies",
+						PolicyName: "example",
+						Group:      "example",
+						Version:    "1.0",
+						LastUpdate: time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC).Unix(),
+					},
+				},
+				NextCursor: ptr.String("cursor2"),
+			},
+		},
+		{
+			name: "request with annotation filter returns policy metadata",
+			storage: &policyfakes.FakeStorage{ListPoliciesStub: func(ctx context.Context, q *storage.PolicyQuery) ([]*storage.Policy, string, error) {
+				if !assert.Equal(t, map[string]string{"domain": "gaia-x"}, q.Custom) {
+					return nil, "", fmt.Errorf("unexpected query")
+				}
+				return []*storage.Policy{{
+					Repository: "policies",
+					Name:       "example",
+					Group:      "example",
+					Version:    "1.0",
+					Metadata: &storage.PolicyMetadata{
+						Title:  "Example",
+						Custom: map[string]interface{}{"domain": "gaia-x"},
+					},
+				}}, "", nil
+			}},
+			request: &goapolicy.PoliciesRequest{
+				Annotation: []string{"custom.domain=gaia-x"},
+			},
+
+			response: &goapolicy.PoliciesResult{
+				Policies: []*goapolicy.Policy{
+					{
+						Repository: "policies",
+						PolicyName: "example",
+						Group:      "example",
+						Version:    "1.0",
+						LastUpdate: time.Time{}.Unix(),
+						Metadata: &goapolicy.PolicyMetadata{
+							Title:  ptr.String("Example"),
+							Custom: map[string]interface{}{"domain": "gaia-x"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "invalid annotation filter",
+			storage: &policyfakes.FakeStorage{},
+			request: &goapolicy.PoliciesRequest{
+				Annotation: []string{"title=Example"},
+			},
+
+			errText: "invalid annotation filter",
+		},
+		{
+			name:    "invalid updatedSince time",
+			storage: &policyfakes.FakeStorage{},
+			request: &goapolicy.PoliciesRequest{
+				UpdatedSince: ptr.String("yesterday"),
+			},
+
+			errText: "invalid updatedSince time",
+		},
 	}
 
 	for _, test := range tests {
@@ -1129,6 +1699,97 @@ func TestService_ListPolicies(t *testing.T) {
 	}
 }
 
+func TestService_GetPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		storage policy.Storage
+
+		response *goapolicy.Policy
+		errKind  errors.Kind
+	}{
+		{
+			name: "policy is not found",
+			storage: &policyfakes.FakeStorage{PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+				return nil, errors.New(errors.NotFound, "policy not found")
+			}},
+			errKind: errors.NotFound,
+		},
+		{
+			name: "error getting policy from storage",
+			storage: &policyfakes.FakeStorage{PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+				return nil, fmt.Errorf("some error")
+			}},
+			errKind: errors.Unknown,
+		},
+		{
+			name: "policy with metadata",
+			storage: &policyfakes.FakeStorage{PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+				return &storage.Policy{
+					Repository:   "policies",
+					Name:         "example",
+					Group:        "example",
+					Version:      "1.0",
+					Rego:         "package example.example",
+					OutputSchema: `{"type":"object"}`,
+					Locked:       true,
+					LastUpdate:   time.Unix(1700000000, 0),
+					Metadata: &storage.PolicyMetadata{
+						Title:   "Example",
+						Authors: []string{"Jane Doe <jane@example.com>"},
+					},
+				}, nil
+			}},
+			response: &goapolicy.Policy{
+				Repository:   "policies",
+				PolicyName:   "example",
+				Group:        "example",
+				Version:      "1.0",
+				Rego:         ptr.String("package example.example"),
+				OutputSchema: ptr.String(`{"type":"object"}`),
+				Locked:       true,
+				LastUpdate:   1700000000,
+				Metadata: &goapolicy.PolicyMetadata{
+					Title:   ptr.String("Example"),
+					Authors: []string{"Jane Doe <jane@example.com>"},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc := policy.New(
+				context.Background(),
+				test.storage,
+				nil,
+				nil,
+				nil,
+				"hostname.com",
+				false,
+				10*time.Second,
+				http.DefaultClient,
+				zap.NewNop(),
+			)
+			result, err := svc.GetPolicy(context.Background(), &goapolicy.GetPolicyRequest{
+				Repository: "policies",
+				Group:      "example",
+				PolicyName: "example",
+				Version:    "1.0",
+			})
+
+			if test.response == nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(test.errKind, err))
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.response, result)
+		})
+	}
+}
+
 func TestService_SubscribeForPolicyChange(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -1247,6 +1908,10 @@ func TestService_SubscribeForPolicyChange(t *testing.T) {
 			} else {
 				assert.NotNil(t, res)
 				assert.NoError(t, err)
+
+				// subscribers get a random secret for verifying webhook signatures
+				_, sub := test.storage.(*policyfakes.FakeStorage).CreateSubscriberArgsForCall(0)
+				assert.Len(t, sub.Secret, 64)
 			}
 		})
 	}
@@ -1461,6 +2126,78 @@ func TestService_ExportBundleSuccess(t *testing.T) {
 	assert.Equal(t, []byte("signature"), sig)
 }
 
+func TestService_ExportWasmBundle(t *testing.T) {
+	testPolicy := func(rego string) *storage.Policy {
+		return &storage.Policy{
+			Repository:   "myrepo",
+			Name:         "myname",
+			Group:        "mygroup",
+			Version:      "1.52",
+			Filename:     "mygroup/myname/1.52/policy.rego",
+			Rego:         rego,
+			Data:         `{"allowed":"yes"}`,
+			ExportConfig: `{"namespace":"transit","key":"key1"}`,
+			LastUpdate:   time.Date(2023, 10, 8, 0, 0, 0, 0, time.UTC),
+		}
+	}
+
+	signer := &policyfakes.FakeSigner{
+		SignStub: func(ctx context.Context, namespace, key string, data []byte) ([]byte, error) {
+			return []byte("signature"), nil
+		},
+	}
+
+	t.Run("policy uses extension functions", func(t *testing.T) {
+		storage := &policyfakes.FakeStorage{
+			PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+				return testPolicy(`package mygroup.myname doc := did.resolve(input.did) key := ocm.getRawProofResult(input.id)`), nil
+			},
+		}
+
+		svc := policy.New(context.Background(), storage, nil, nil, signer, "https://policyservice.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+		res, reader, err := svc.ExportWasmBundle(context.Background(), &goapolicy.ExportWasmBundleRequest{})
+		assert.Nil(t, res)
+		assert.Nil(t, reader)
+		require.Error(t, err)
+		assert.True(t, errors.Is(errors.BadRequest, err))
+		assert.ErrorContains(t, err, "did.resolve, ocm.getRawProofResult")
+	})
+
+	t.Run("policy is exported as signed wasm bundle", func(t *testing.T) {
+		storage := &policyfakes.FakeStorage{
+			PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+				return testPolicy(`package mygroup.myname default allow = false allow { is_allowed(input.msg) } is_allowed(msg) { msg == data.allowed }`), nil
+			},
+		}
+
+		svc := policy.New(context.Background(), storage, nil, nil, signer, "https://policyservice.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+		res, reader, err := svc.ExportBundle(context.Background(), &goapolicy.ExportBundleRequest{Target: "wasm"})
+		require.NoError(t, err)
+		require.NotNil(t, res)
+		require.NotNil(t, reader)
+
+		assert.Equal(t, "application/zip", res.ContentType)
+		assert.Equal(t, `attachment; filename="myrepo_mygroup_myname_1.52_wasm.zip"`, res.ContentDisposition)
+
+		archive, err := io.ReadAll(reader)
+		require.NoError(t, err)
+
+		r, err := zip.NewReader(bytes.NewReader(archive), int64(res.ContentLength))
+		require.NoError(t, err)
+		require.Len(t, r.File, 2)
+		assert.Equal(t, policy.WasmBundleFilename, r.File[0].Name)
+		assert.Equal(t, policy.BundleSignatureFilename, r.File[1].Name)
+
+		f, err := r.File[0].Open()
+		require.NoError(t, err)
+		b, err := bundle.NewReader(f).Read()
+		require.NoError(t, err)
+		require.Len(t, b.WasmModules, 1)
+		assert.Equal(t, "yes", b.Data["allowed"])
+		assert.Equal(t, "https://policyservice.com/policy/myrepo/mygroup/myname/1.52/key", b.Manifest.Metadata["publicKeyURL"])
+	})
+}
+
 func TestService_PolicyPublicKey(t *testing.T) {
 	tests := []struct {
 		name    string
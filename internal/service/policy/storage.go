@@ -9,12 +9,30 @@ import (
 type Storage interface {
 	Policy(ctx context.Context, repository, group, name, version string) (*storage.Policy, error)
 	SavePolicy(ctx context.Context, policy *storage.Policy) error
-	SetPolicyLock(ctx context.Context, repository, group, name, version string, lock bool) error
-	GetPolicies(ctx context.Context, locked *bool, policyName *string) ([]*storage.Policy, error)
+	// DeletePolicy removes a policy from storage and notifies
+	// the policy change subscribers.
+	DeletePolicy(ctx context.Context, repository, group, name, version string) error
+	// PolicyRevisions returns all revisions of a policy
+	// sorted by revision number.
+	PolicyRevisions(ctx context.Context, repository, group, name, version string) ([]*storage.PolicyRevision, error)
+	// PolicyRevision returns a single policy revision.
+	PolicyRevision(ctx context.Context, repository, group, name, version string, revision int) (*storage.PolicyRevision, error)
+	// SetPolicyLock locks a policy with the given lock details
+	// or unlocks it if the lock is nil.
+	SetPolicyLock(ctx context.Context, repository, group, name, version string, lock *storage.PolicyLock) error
+	// SetPoliciesLock locks or unlocks multiple policies at once. The
+	// policies are changed atomically if the storage supports it.
+	SetPoliciesLock(ctx context.Context, policies []*storage.Policy, lock *storage.PolicyLock) error
+	// ListPolicies returns a page of the policies matching the query and
+	// the cursor of the next page, which is empty on the last page.
+	ListPolicies(ctx context.Context, query *storage.PolicyQuery) ([]*storage.Policy, string, error)
 	AddPolicySubscribers(subscribers ...storage.PolicySubscriber)
 	ListenPolicyDataChanges(ctx context.Context) error
 	Subscriber(ctx context.Context, policyRepository, policyGroup, policyName, policyVersion, webhook, name string) (*storage.Subscriber, error)
 	CreateSubscriber(ctx context.Context, subscriber *storage.Subscriber) (*storage.Subscriber, error)
+	// SubscriberDeliveries returns the webhook deliveries to a subscriber
+	// sorted by creation time.
+	SubscriberDeliveries(ctx context.Context, policyRepository, policyGroup, policyName, policyVersion, subscriber string) ([]*storage.Delivery, error)
 	Close(ctx context.Context)
 	GetData(ctx context.Context, key string) (any, error)
 	SetData(ctx context.Context, key string, data map[string]interface{}) error
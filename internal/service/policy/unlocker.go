@@ -0,0 +1,65 @@
+package policy
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/ptr"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+// StartUnlocker periodically unlocks the policies with expired locks.
+// Unlocking a policy notifies the policy change subscribers.
+func (s *Service) StartUnlocker(ctx context.Context, pollInterval time.Duration) {
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Error("policy unlocker process stopped", zap.Error(ctx.Err()))
+			return
+		case <-time.After(pollInterval):
+			count, err := s.unlockExpired(ctx)
+			if err != nil {
+				s.logger.Error("error unlocking policies with expired locks", zap.Error(err))
+				continue
+			}
+
+			if count > 0 {
+				s.logger.Debug("policies with expired locks are unlocked", zap.Int("unlockCount", count))
+			}
+		}
+	}
+}
+
+func (s *Service) unlockExpired(ctx context.Context) (int, error) {
+	policies, _, err := s.storage.ListPolicies(ctx, &storage.PolicyQuery{Locked: ptr.Bool(true)})
+	if err != nil {
+		return 0, err
+	}
+
+	var unlocked int
+	now := time.Now()
+	for _, p := range policies {
+		if !p.Lock.Expired(now) {
+			continue
+		}
+
+		logger := s.logger.With(
+			zap.String("repository", p.Repository),
+			zap.String("group", p.Group),
+			zap.String("name", p.Name),
+			zap.String("version", p.Version),
+		)
+
+		if err := s.storage.SetPolicyLock(ctx, p.Repository, p.Group, p.Name, p.Version, nil); err != nil {
+			logger.Error("error unlocking policy", zap.Error(err))
+			continue
+		}
+
+		logger.Info("policy lock expired and the policy is unlocked", zap.Time("expiresAt", *p.Lock.ExpiresAt))
+		unlocked++
+	}
+
+	return unlocked, nil
+}
@@ -0,0 +1,55 @@
+package policy_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/service/policy/policyfakes"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+func TestService_StartUnlocker(t *testing.T) {
+	expired := time.Now().Add(-time.Minute)
+	valid := time.Now().Add(time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var unlocked []string
+	fake := &policyfakes.FakeStorage{
+		ListPoliciesStub: func(ctx context.Context, q *storage.PolicyQuery) ([]*storage.Policy, string, error) {
+			require.NotNil(t, q.Locked)
+			assert.True(t, *q.Locked)
+			return []*storage.Policy{
+				{Name: "expired", Locked: true, Lock: &storage.PolicyLock{ExpiresAt: &expired}},
+				{Name: "valid", Locked: true, Lock: &storage.PolicyLock{ExpiresAt: &valid}},
+				{Name: "permanent", Locked: true, Lock: &storage.PolicyLock{}},
+				{Name: "legacy", Locked: true},
+			}, "", nil
+		},
+		SetPolicyLockStub: func(ctx context.Context, repository, group, name, version string, lock *storage.PolicyLock) error {
+			assert.Nil(t, lock)
+			unlocked = append(unlocked, name)
+			cancel()
+			return nil
+		},
+	}
+
+	done := make(chan struct{})
+	go func() {
+		newRevisionsService(fake).StartUnlocker(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("policy unlocker is not stopped")
+	}
+
+	assert.Equal(t, []string{"expired"}, unlocked)
+}
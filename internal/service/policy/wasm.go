@@ -0,0 +1,136 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/bundle"
+	"github.com/open-policy-agent/opa/compile"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+const exportTargetWasm = "wasm"
+
+// createWasmBundle compiles the policy source code and its static data
+// to a WebAssembly module and returns it packed as OPA bundle (tar.gz).
+//
+// The compiled entrypoint is derived from the policy group and name
+// in the same way as the evaluation query, e.g. `mygroup/example`
+// for a policy with package declaration `package mygroup.example`.
+func (s *Service) createWasmBundle(ctx context.Context, pol *storage.Policy) ([]byte, error) {
+	module, err := ast.ParseModule(pol.Filename, pol.Rego)
+	if err != nil {
+		return nil, errors.New(errors.BadRequest, "error parsing policy source code", err)
+	}
+
+	if funcs := extensionFuncCalls(module); len(funcs) > 0 {
+		return nil, errors.New(
+			errors.BadRequest,
+			fmt.Sprintf("policy cannot be compiled to wasm, it uses extension functions which are not supported by the wasm target: %s", strings.Join(funcs, ", ")),
+		)
+	}
+
+	b := &bundle.Bundle{
+		Modules: []bundle.ModuleFile{
+			{
+				URL:    pol.Filename,
+				Path:   pol.Filename,
+				Raw:    []byte(pol.Rego),
+				Parsed: module,
+			},
+		},
+		Data: map[string]interface{}{},
+	}
+
+	if strings.TrimSpace(pol.Data) != "" {
+		if err := json.Unmarshal([]byte(pol.Data), &b.Data); err != nil {
+			return nil, errors.New("error decoding policy static data", err)
+		}
+	}
+
+	// the public key URL is included in the bundle manifest,
+	// so that verifiers know where to find the verification key
+	metadata := map[string]interface{}{
+		"repository":   pol.Repository,
+		"group":        pol.Group,
+		"name":         pol.Name,
+		"version":      pol.Version,
+		"publicKeyURL": s.policyPublicKeyURL(pol),
+	}
+
+	buf := new(bytes.Buffer)
+	compiler := compile.New().
+		WithTarget(compile.TargetWasm).
+		WithEntrypoints(fmt.Sprintf("%s/%s", pol.Group, pol.Name)).
+		WithBundle(b).
+		WithMetadata(&metadata).
+		WithOutput(buf)
+
+	if err := compiler.Build(ctx); err != nil {
+		return nil, errors.New(errors.BadRequest, "error compiling policy to wasm", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// extensionFuncCalls returns the names of the functions called by the module,
+// which are neither OPA built-in functions nor functions defined by the module
+// itself. Such functions are the Rego extension functions implemented in Go
+// (see package regofunc), which cannot be compiled to WebAssembly.
+func extensionFuncCalls(module *ast.Module) []string {
+	// functions and rules defined inside the module
+	defined := map[string]bool{}
+	for _, rule := range module.Rules {
+		defined[rule.Head.Ref().String()] = true
+	}
+
+	// import aliases and imported packages can be used as
+	// prefixes when calling functions defined in other modules
+	imported := map[string]bool{}
+	for _, imp := range module.Imports {
+		imported[imp.Name().String()] = true
+	}
+
+	found := map[string]bool{}
+	check := func(operator ast.Ref) {
+		name := operator.String()
+		if _, ok := ast.BuiltinMap[name]; ok {
+			return
+		}
+		if defined[name] || operator.HasPrefix(ast.DefaultRootRef) || imported[operator[0].String()] {
+			return
+		}
+		found[name] = true
+	}
+
+	ast.WalkExprs(module, func(expr *ast.Expr) bool {
+		if expr.IsCall() {
+			check(expr.Operator())
+		}
+		return false
+	})
+
+	ast.WalkTerms(module, func(term *ast.Term) bool {
+		if call, ok := term.Value.(ast.Call); ok {
+			if operator, ok := call[0].Value.(ast.Ref); ok {
+				check(operator)
+			}
+		}
+		return false
+	})
+
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,788 @@
+// Package boltdb implements policy storage on top of an embedded
+// bbolt key/value database stored in a single local file.
+//
+// It's intended for small deployments and test environments, which
+// need persistent storage without running a MongoDB replica set.
+// Policy change notifications are delivered in-process, so the database
+// file must not be shared between multiple instances of the service.
+package boltdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+// keySeparator separates the parts of composite keys. Policy coordinates
+// are URL path segments and cannot contain this character.
+const keySeparator = "\x00"
+
+var (
+	policyBucket        = []byte("policies")
+	subscriberBucket    = []byte("subscribers")
+	commonStorageBucket = []byte("common_storage")
+	autoImportBucket    = []byte("policy_auto_import")
+	revisionBucket      = []byte("policy_revisions")
+	deliveryBucket      = []byte("webhook_deliveries")
+)
+
+// change is a policy change sent to the policy change subscribers.
+type change struct {
+	policy  storage.Policy
+	deleted bool
+}
+
+type Storage struct {
+	db          *bolt.DB
+	subscribers []storage.PolicySubscriber
+	changes     chan change
+	logger      *zap.Logger
+}
+
+// New opens (or creates) the database file on the given path.
+func New(path string, logger *zap.Logger) (*Storage, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("error opening bolt database: %v", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{policyBucket, subscriberBucket, commonStorageBucket, autoImportBucket, revisionBucket, deliveryBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close() //nolint:errcheck
+		return nil, fmt.Errorf("error creating bolt database buckets: %v", err)
+	}
+
+	return &Storage{
+		db:      db,
+		changes: make(chan change),
+		logger:  logger,
+	}, nil
+}
+
+func policyKey(repository, group, name, version string) []byte {
+	return []byte(strings.Join([]string{repository, group, name, version}, keySeparator))
+}
+
+// revisionKey returns the key of a policy revision. Revision numbers are
+// zero padded, so that revisions of a policy are sorted by number.
+func revisionKey(repository, group, name, version string, revision int) []byte {
+	return append(revisionPrefix(repository, group, name, version), []byte(fmt.Sprintf("%010d", revision))...)
+}
+
+func revisionPrefix(repository, group, name, version string) []byte {
+	return append(policyKey(repository, group, name, version), []byte(keySeparator)...)
+}
+
+func subscriberKey(repository, group, name, version, webhookURL, subscriber string) []byte {
+	return []byte(strings.Join([]string{repository, group, name, version, subscriber, webhookURL}, keySeparator))
+}
+
+// deliveryKey returns the key of a webhook delivery. Deliveries of a subscriber
+// are sorted by creation time, which is zero padded.
+func deliveryKey(d *storage.Delivery) []byte {
+	prefix := deliveryPrefix(d.PolicyRepository, d.PolicyGroup, d.PolicyName, d.PolicyVersion, d.Subscriber)
+	return append(prefix, []byte(fmt.Sprintf("%020d%s%s", d.CreatedAt.UnixNano(), keySeparator, d.ID))...)
+}
+
+func deliveryPrefix(repository, group, name, version, subscriber string) []byte {
+	return []byte(strings.Join([]string{repository, group, name, version, subscriber, ""}, keySeparator))
+}
+
+// get decodes the JSON value stored under key in the given bucket.
+// It returns false if the key is not found.
+func get(tx *bolt.Tx, bucket, key []byte, v interface{}) (bool, error) {
+	data := tx.Bucket(bucket).Get(key)
+	if data == nil {
+		return false, nil
+	}
+
+	return true, json.Unmarshal(data, v)
+}
+
+// put stores v encoded as JSON under key in the given bucket.
+func put(tx *bolt.Tx, bucket, key []byte, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return tx.Bucket(bucket).Put(key, data)
+}
+
+func (s *Storage) Policy(_ context.Context, repository, group, name, version string) (*storage.Policy, error) {
+	var policy storage.Policy
+	err := s.db.View(func(tx *bolt.Tx) error {
+		found, err := get(tx, policyBucket, policyKey(repository, group, name, version), &policy)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errors.New(errors.NotFound, "policy not found")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (s *Storage) SavePolicy(ctx context.Context, policy *storage.Policy) error {
+	p := *policy
+	p.Metadata = storage.ParsePolicyMetadata(p.Rego)
+	p.LastUpdate = time.Now()
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		if err := put(tx, policyBucket, policyKey(p.Repository, p.Group, p.Name, p.Version), &p); err != nil {
+			return err
+		}
+		return addRevision(ctx, tx, &p, storage.RevisionSourceAPI)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.notify(&p, false)
+
+	return nil
+}
+
+func (s *Storage) DeletePolicy(ctx context.Context, repository, group, name, version string) error {
+	var policy storage.Policy
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		key := policyKey(repository, group, name, version)
+		found, err := get(tx, policyBucket, key, &policy)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errors.New(errors.NotFound, "policy not found")
+		}
+
+		return tx.Bucket(policyBucket).Delete(key)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.notify(&policy, true)
+
+	return nil
+}
+
+func (s *Storage) SetPolicyLock(ctx context.Context, repository, group, name, version string, lock *storage.PolicyLock) error {
+	var policy storage.Policy
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		key := policyKey(repository, group, name, version)
+		found, err := get(tx, policyBucket, key, &policy)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errors.New(errors.NotFound, "policy not found")
+		}
+
+		policy.Locked = lock != nil
+		policy.Lock = lock
+		policy.LastUpdate = time.Now()
+
+		return put(tx, policyBucket, key, &policy)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.notify(&policy, false)
+
+	return nil
+}
+
+// SetPoliciesLock locks or unlocks the policies in a single transaction,
+// so no policy is changed if any of them is not found.
+func (s *Storage) SetPoliciesLock(ctx context.Context, policies []*storage.Policy, lock *storage.PolicyLock) error {
+	changed := make([]*storage.Policy, 0, len(policies))
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		for _, p := range policies {
+			var policy storage.Policy
+			key := policyKey(p.Repository, p.Group, p.Name, p.Version)
+			found, err := get(tx, policyBucket, key, &policy)
+			if err != nil {
+				return err
+			}
+			if !found {
+				return errors.New(errors.NotFound, "policy not found")
+			}
+
+			policy.Locked = lock != nil
+			policy.Lock = lock
+			policy.LastUpdate = time.Now()
+
+			if err := put(tx, policyBucket, key, &policy); err != nil {
+				return err
+			}
+			changed = append(changed, &policy)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, p := range changed {
+		s.notify(p, false)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetPolicies(_ context.Context, locked *bool, policyName *string) ([]*storage.Policy, error) {
+	var name string
+	if policyName != nil {
+		name = strings.ToLower(strings.TrimSpace(*policyName))
+	}
+
+	var policies []*storage.Policy
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(policyBucket).ForEach(func(_, v []byte) error {
+			var p storage.Policy
+			if err := json.Unmarshal(v, &p); err != nil {
+				return err
+			}
+
+			if locked != nil && *locked != p.Locked {
+				return nil
+			}
+
+			if policyName != nil && !strings.Contains(strings.ToLower(p.Name), name) {
+				return nil
+			}
+
+			policies = append(policies, &p)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// ListPolicies returns a page of the policies matching the query
+// and the cursor of the next page.
+func (s *Storage) ListPolicies(_ context.Context, query *storage.PolicyQuery) ([]*storage.Policy, string, error) {
+	var policies []*storage.Policy
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(policyBucket).ForEach(func(_, v []byte) error {
+			var p storage.Policy
+			if err := json.Unmarshal(v, &p); err != nil {
+				return err
+			}
+
+			if query.Match(&p) {
+				policies = append(policies, &p)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return query.Page(policies)
+}
+
+// GetRefreshPolicies returns the policies whose data must be refreshed and
+// postpones their next refresh time. Bolt allows only one read-write
+// transaction at a time, so concurrent data refresh of a policy is prevented.
+func (s *Storage) GetRefreshPolicies(_ context.Context) ([]*storage.Policy, error) {
+	var policies []*storage.Policy
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(policyBucket)
+		updated := map[string]*storage.Policy{}
+		err := b.ForEach(func(k, v []byte) error {
+			var p storage.Policy
+			if err := json.Unmarshal(v, &p); err != nil {
+				return err
+			}
+
+			if p.NextDataRefreshTime.Before(time.Now()) && p.NextDataRefreshTime.After(time.Time{}) {
+				cpy := p
+				policies = append(policies, &cpy)
+
+				// postpone next refresh time for this policy
+				p.NextDataRefreshTime = time.Now().Add(storage.RefreshPostponePeriod)
+				updated[string(k)] = &p
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for k, p := range updated {
+			if err := put(tx, policyBucket, []byte(k), p); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// UpdateNextRefreshTime updates policy's data and next data refresh time.
+func (s *Storage) UpdateNextRefreshTime(ctx context.Context, p *storage.Policy, nextDataRefreshTime time.Time) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		key := policyKey(p.Repository, p.Group, p.Name, p.Version)
+
+		var policy storage.Policy
+		found, err := get(tx, policyBucket, key, &policy)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errors.New(errors.NotFound, "policy not found")
+		}
+
+		policy.Data = p.Data
+		policy.NextDataRefreshTime = nextDataRefreshTime
+
+		if err := put(tx, policyBucket, key, &policy); err != nil {
+			return err
+		}
+		return addRevision(ctx, tx, &policy, storage.RevisionSourceDataRefresh)
+	})
+}
+
+// addRevision stores a new revision of the policy if its content differs
+// from the latest revision.
+func addRevision(ctx context.Context, tx *bolt.Tx, policy *storage.Policy, defaultSource string) error {
+	latest, err := latestRevision(tx, policy.Repository, policy.Group, policy.Name, policy.Version)
+	if err != nil {
+		return err
+	}
+
+	hash := storage.ContentHash(policy)
+	if latest != nil && latest.Hash == hash {
+		return nil
+	}
+
+	source, commit := storage.RevisionSource(ctx, defaultSource)
+	rev := storage.NewPolicyRevision(policy, source, commit)
+	rev.Revision = 1
+	if latest != nil {
+		rev.Revision = latest.Revision + 1
+	}
+
+	return put(tx, revisionBucket, revisionKey(rev.Repository, rev.Group, rev.Name, rev.Version, rev.Revision), rev)
+}
+
+// latestRevision returns the latest revision of a policy or nil
+// if the policy has no revisions.
+func latestRevision(tx *bolt.Tx, repository, group, name, version string) (*storage.PolicyRevision, error) {
+	prefix := revisionPrefix(repository, group, name, version)
+
+	var latest []byte
+	c := tx.Bucket(revisionBucket).Cursor()
+	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		latest = v
+	}
+	if latest == nil {
+		return nil, nil
+	}
+
+	var rev storage.PolicyRevision
+	if err := json.Unmarshal(latest, &rev); err != nil {
+		return nil, err
+	}
+
+	return &rev, nil
+}
+
+func (s *Storage) PolicyRevisions(_ context.Context, repository, group, name, version string) ([]*storage.PolicyRevision, error) {
+	prefix := revisionPrefix(repository, group, name, version)
+
+	var revisions []*storage.PolicyRevision
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(revisionBucket).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			var rev storage.PolicyRevision
+			if err := json.Unmarshal(v, &rev); err != nil {
+				return err
+			}
+			revisions = append(revisions, &rev)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (s *Storage) PolicyRevision(_ context.Context, repository, group, name, version string, revision int) (*storage.PolicyRevision, error) {
+	var rev storage.PolicyRevision
+	err := s.db.View(func(tx *bolt.Tx) error {
+		found, err := get(tx, revisionBucket, revisionKey(repository, group, name, version, revision), &rev)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errors.New(errors.NotFound, "policy revision not found")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &rev, nil
+}
+
+func (s *Storage) AddPolicySubscribers(subscribers ...storage.PolicySubscriber) {
+	s.subscribers = subscribers
+}
+
+// notify sends the changed policy to the policy change listener.
+// The notification outlives the request which changed the policy,
+// so it isn't cancelled together with the request context.
+func (s *Storage) notify(policy *storage.Policy, deleted bool) {
+	go func(c change) {
+		select {
+		case s.changes <- c:
+		case <-time.After(10 * time.Second):
+		}
+	}(change{policy: *policy, deleted: deleted})
+}
+
+func (s *Storage) ListenPolicyDataChanges(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case c := <-s.changes:
+			p := c.policy
+			for _, subscriber := range s.subscribers {
+				var err error
+				if c.deleted {
+					err = storage.NotifyPolicyDeleted(ctx, subscriber, p.Repository, p.Name, p.Group, p.Version)
+				} else {
+					err = subscriber.PolicyDataChange(ctx, p.Repository, p.Name, p.Group, p.Version)
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+			s.logger.Info("bolt policy data changed")
+		}
+	}
+}
+
+func (s *Storage) Close(_ context.Context) {
+	s.db.Close() //nolint:errcheck
+}
+
+func (s *Storage) CreateSubscriber(_ context.Context, subscriber *storage.Subscriber) (*storage.Subscriber, error) {
+	subscriber.CreatedAt = time.Now()
+	subscriber.UpdatedAt = time.Now()
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		key := subscriberKey(
+			subscriber.PolicyRepository,
+			subscriber.PolicyGroup,
+			subscriber.PolicyName,
+			subscriber.PolicyVersion,
+			subscriber.WebhookURL,
+			subscriber.Name,
+		)
+		return put(tx, subscriberBucket, key, subscriber)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriber, nil
+}
+
+func (s *Storage) PolicySubscribers(_ context.Context, policyRepository, policyName, policyGroup, policyVersion string) ([]*storage.Subscriber, error) {
+	prefix := append(policyKey(policyRepository, policyGroup, policyName, policyVersion), keySeparator...)
+
+	var subscribers []*storage.Subscriber
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(subscriberBucket).Cursor()
+		for k, v := c.Seek(prefix); k != nil && strings.HasPrefix(string(k), string(prefix)); k, v = c.Next() {
+			var subscriber storage.Subscriber
+			if err := json.Unmarshal(v, &subscriber); err != nil {
+				return err
+			}
+			subscribers = append(subscribers, &subscriber)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return subscribers, nil
+}
+
+func (s *Storage) Subscriber(_ context.Context, policyRepository, policyGroup, policyName, policyVersion, webhookURL, name string) (*storage.Subscriber, error) {
+	var subscriber storage.Subscriber
+	err := s.db.View(func(tx *bolt.Tx) error {
+		key := subscriberKey(policyRepository, policyGroup, policyName, policyVersion, webhookURL, name)
+		found, err := get(tx, subscriberBucket, key, &subscriber)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errors.New(errors.NotFound, "subscriber not found")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscriber, nil
+}
+
+// SaveDelivery creates or updates a webhook delivery.
+func (s *Storage) SaveDelivery(_ context.Context, delivery *storage.Delivery) error {
+	delivery.UpdatedAt = time.Now()
+	if delivery.CreatedAt.IsZero() {
+		delivery.CreatedAt = delivery.UpdatedAt
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return put(tx, deliveryBucket, deliveryKey(delivery), delivery)
+	})
+}
+
+// PendingDeliveries returns the pending webhook deliveries
+// which must be attempted before the given time.
+func (s *Storage) PendingDeliveries(_ context.Context, before time.Time) ([]*storage.Delivery, error) {
+	var deliveries []*storage.Delivery
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(deliveryBucket).ForEach(func(k, v []byte) error {
+			var delivery storage.Delivery
+			if err := json.Unmarshal(v, &delivery); err != nil {
+				return err
+			}
+			if delivery.State == storage.DeliveryPending && !delivery.NextAttempt.After(before) {
+				deliveries = append(deliveries, &delivery)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// ClaimDelivery atomically postpones the next attempt of a pending
+// delivery from nextAttempt until the given time. It returns false if
+// the delivery isn't pending or its next attempt is changed.
+func (s *Storage) ClaimDelivery(_ context.Context, id string, nextAttempt, until time.Time) (bool, error) {
+	var claimed bool
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		c := tx.Bucket(deliveryBucket).Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			var delivery storage.Delivery
+			if err := json.Unmarshal(v, &delivery); err != nil {
+				return err
+			}
+			if delivery.ID != id {
+				continue
+			}
+			if delivery.State != storage.DeliveryPending || !delivery.NextAttempt.Equal(nextAttempt) {
+				return nil
+			}
+
+			delivery.NextAttempt = until
+			delivery.UpdatedAt = time.Now()
+			claimed = true
+			return put(tx, deliveryBucket, append([]byte(nil), k...), &delivery)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return claimed, nil
+}
+
+// SubscriberDeliveries returns the webhook deliveries
+// of a policy change subscriber sorted by creation time.
+func (s *Storage) SubscriberDeliveries(_ context.Context, policyRepository, policyGroup, policyName, policyVersion, subscriber string) ([]*storage.Delivery, error) {
+	prefix := deliveryPrefix(policyRepository, policyGroup, policyName, policyVersion, subscriber)
+
+	var deliveries []*storage.Delivery
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(deliveryBucket).Cursor()
+		for k, v := c.Seek(prefix); k != nil && strings.HasPrefix(string(k), string(prefix)); k, v = c.Next() {
+			var delivery storage.Delivery
+			if err := json.Unmarshal(v, &delivery); err != nil {
+				return err
+			}
+			deliveries = append(deliveries, &delivery)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (s *Storage) SetData(_ context.Context, key string, data map[string]interface{}) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return put(tx, commonStorageBucket, []byte(key), &storage.CommonStorage{Key: key, Data: data})
+	})
+}
+
+func (s *Storage) GetData(_ context.Context, key string) (any, error) {
+	var commonStorage storage.CommonStorage
+	err := s.db.View(func(tx *bolt.Tx) error {
+		found, err := get(tx, commonStorageBucket, []byte(key), &commonStorage)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("key: %s doesn't exist", key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return commonStorage.Data, nil
+}
+
+func (s *Storage) DeleteData(_ context.Context, key string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(commonStorageBucket)
+		if b.Get([]byte(key)) == nil {
+			return fmt.Errorf("key: %s doesn't exist", key)
+		}
+		return b.Delete([]byte(key))
+	})
+}
+
+func (s *Storage) SaveAutoImportConfig(_ context.Context, importConfig *storage.PolicyAutoImport) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return put(tx, autoImportBucket, []byte(importConfig.PolicyURL), importConfig)
+	})
+}
+
+// ActiveImportConfigs returns the import configurations whose import time
+// has been reached and sets their next import time.
+func (s *Storage) ActiveImportConfigs(_ context.Context) ([]*storage.PolicyAutoImport, error) {
+	var configs []*storage.PolicyAutoImport
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(autoImportBucket)
+		err := b.ForEach(func(_, v []byte) error {
+			var cfg storage.PolicyAutoImport
+			if err := json.Unmarshal(v, &cfg); err != nil {
+				return err
+			}
+			if !cfg.NextImport.After(time.Now()) {
+				configs = append(configs, &cfg)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, cfg := range configs {
+			next := *cfg
+			next.NextImport = time.Now().Add(cfg.Interval)
+			if err := put(tx, autoImportBucket, []byte(cfg.PolicyURL), &next); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return configs, nil
+}
+
+func (s *Storage) AutoImportConfigs(_ context.Context) ([]*storage.PolicyAutoImport, error) {
+	var configs []*storage.PolicyAutoImport
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(autoImportBucket).ForEach(func(_, v []byte) error {
+			var cfg storage.PolicyAutoImport
+			if err := json.Unmarshal(v, &cfg); err != nil {
+				return err
+			}
+			configs = append(configs, &cfg)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return configs, nil
+}
+
+func (s *Storage) AutoImportConfig(_ context.Context, policyURL string) (*storage.PolicyAutoImport, error) {
+	var cfg storage.PolicyAutoImport
+	err := s.db.View(func(tx *bolt.Tx) error {
+		found, err := get(tx, autoImportBucket, []byte(policyURL), &cfg)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errors.New(errors.NotFound, "auto import configuration not found")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+func (s *Storage) DeleteAutoImportConfig(_ context.Context, policyURL string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(autoImportBucket)
+		if b.Get([]byte(policyURL)) == nil {
+			return errors.New(errors.NotFound)
+		}
+		return b.Delete([]byte(policyURL))
+	})
+}
@@ -0,0 +1,507 @@
+package boltdb_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/ptr"
+	"go.uber.org/zap"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage/boltdb"
+)
+
+type subscriber struct {
+	changes chan []string
+	deleted chan []string
+	err     error
+}
+
+func (s *subscriber) PolicyDataChange(_ context.Context, repo, name, group, version string) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.changes <- []string{repo, name, group, version}
+	return nil
+}
+
+func (s *subscriber) PolicyDeleted(_ context.Context, repo, name, group, version string) error {
+	s.deleted <- []string{repo, name, group, version}
+	return nil
+}
+
+func newStorage(t *testing.T, path string) *boltdb.Storage {
+	s, err := boltdb.New(path, zap.NewNop())
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close(context.Background()) })
+	return s
+}
+
+func TestStorage_Policy(t *testing.T) {
+	s := newStorage(t, filepath.Join(t.TempDir(), "policy.db"))
+
+	p, err := s.Policy(context.Background(), "policies", "example", "test", "1.0")
+	assert.Nil(t, p)
+	assert.True(t, errors.Is(errors.NotFound, err))
+
+	err = s.SavePolicy(context.Background(), &storage.Policy{
+		Repository: "policies",
+		Group:      "example",
+		Name:       "test",
+		Version:    "1.0",
+		Rego:       "package example.test",
+	})
+	require.NoError(t, err)
+
+	p, err = s.Policy(context.Background(), "policies", "example", "test", "1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "package example.test", p.Rego)
+	assert.False(t, p.LastUpdate.IsZero())
+}
+
+func TestStorage_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.db")
+
+	s, err := boltdb.New(path, zap.NewNop())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, s.SavePolicy(ctx, &storage.Policy{Repository: "policies", Group: "example", Name: "test", Version: "1.0"}))
+	require.NoError(t, s.SetPolicyLock(ctx, "policies", "example", "test", "1.0", &storage.PolicyLock{LockedAt: time.Now()}))
+	require.NoError(t, s.SetData(ctx, "key", map[string]interface{}{"hello": "world"}))
+	require.NoError(t, s.SaveAutoImportConfig(ctx, &storage.PolicyAutoImport{PolicyURL: "https://example.com/bundle", Interval: time.Hour}))
+	_, err = s.CreateSubscriber(ctx, &storage.Subscriber{
+		Name:             "sub",
+		WebhookURL:       "https://example.com/hook",
+		PolicyRepository: "policies",
+		PolicyGroup:      "example",
+		PolicyName:       "test",
+		PolicyVersion:    "1.0",
+	})
+	require.NoError(t, err)
+	s.Close(ctx)
+
+	// everything is available after reopening the database
+	s = newStorage(t, path)
+
+	p, err := s.Policy(ctx, "policies", "example", "test", "1.0")
+	require.NoError(t, err)
+	assert.True(t, p.Locked)
+
+	data, err := s.GetData(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"hello": "world"}, data)
+
+	cfg, err := s.AutoImportConfig(ctx, "https://example.com/bundle")
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, cfg.Interval)
+
+	sub, err := s.Subscriber(ctx, "policies", "example", "test", "1.0", "https://example.com/hook", "sub")
+	require.NoError(t, err)
+	assert.Equal(t, "sub", sub.Name)
+}
+
+func TestStorage_SetPolicyLock(t *testing.T) {
+	s := newStorage(t, filepath.Join(t.TempDir(), "policy.db"))
+
+	expiresAt := time.Now().Add(time.Hour)
+	lock := &storage.PolicyLock{Reason: "incident", Actor: "alice", LockedAt: time.Now(), ExpiresAt: &expiresAt}
+
+	err := s.SetPolicyLock(context.Background(), "policies", "example", "test", "1.0", lock)
+	assert.True(t, errors.Is(errors.NotFound, err))
+
+	require.NoError(t, s.SavePolicy(context.Background(), &storage.Policy{Repository: "policies", Group: "example", Name: "test", Version: "1.0"}))
+	require.NoError(t, s.SetPolicyLock(context.Background(), "policies", "example", "test", "1.0", lock))
+
+	p, err := s.Policy(context.Background(), "policies", "example", "test", "1.0")
+	require.NoError(t, err)
+	assert.True(t, p.Locked)
+	require.NotNil(t, p.Lock)
+	assert.Equal(t, "incident", p.Lock.Reason)
+	assert.Equal(t, "alice", p.Lock.Actor)
+	assert.True(t, expiresAt.Equal(*p.Lock.ExpiresAt))
+
+	require.NoError(t, s.SetPolicyLock(context.Background(), "policies", "example", "test", "1.0", nil))
+
+	p, err = s.Policy(context.Background(), "policies", "example", "test", "1.0")
+	require.NoError(t, err)
+	assert.False(t, p.Locked)
+	assert.Nil(t, p.Lock)
+}
+
+func TestStorage_SetPoliciesLock(t *testing.T) {
+	s := newStorage(t, filepath.Join(t.TempDir(), "policy.db"))
+	ctx := context.Background()
+
+	foo := &storage.Policy{Repository: "policies", Group: "example", Name: "foo", Version: "1.0"}
+	bar := &storage.Policy{Repository: "policies", Group: "example", Name: "bar", Version: "1.0"}
+	require.NoError(t, s.SavePolicy(ctx, foo))
+	require.NoError(t, s.SavePolicy(ctx, bar))
+
+	// the transaction is rolled back if any policy is not found
+	missing := &storage.Policy{Repository: "policies", Group: "example", Name: "missing", Version: "1.0"}
+	err := s.SetPoliciesLock(ctx, []*storage.Policy{foo, missing}, &storage.PolicyLock{LockedAt: time.Now()})
+	assert.True(t, errors.Is(errors.NotFound, err))
+
+	p, err := s.Policy(ctx, "policies", "example", "foo", "1.0")
+	require.NoError(t, err)
+	assert.False(t, p.Locked)
+
+	require.NoError(t, s.SetPoliciesLock(ctx, []*storage.Policy{foo, bar}, &storage.PolicyLock{Reason: "incident", LockedAt: time.Now()}))
+
+	locked, err := s.GetPolicies(ctx, ptr.Bool(true), nil)
+	require.NoError(t, err)
+	require.Len(t, locked, 2)
+	assert.Equal(t, "incident", locked[0].Lock.Reason)
+
+	require.NoError(t, s.SetPoliciesLock(ctx, []*storage.Policy{foo, bar}, nil))
+
+	locked, err = s.GetPolicies(ctx, ptr.Bool(true), nil)
+	require.NoError(t, err)
+	assert.Empty(t, locked)
+}
+
+func TestStorage_DeletePolicy(t *testing.T) {
+	s := newStorage(t, filepath.Join(t.TempDir(), "policy.db"))
+
+	err := s.DeletePolicy(context.Background(), "policies", "example", "test", "1.0")
+	assert.True(t, errors.Is(errors.NotFound, err))
+
+	require.NoError(t, s.SavePolicy(context.Background(), &storage.Policy{Repository: "policies", Group: "example", Name: "test", Version: "1.0"}))
+	require.NoError(t, s.DeletePolicy(context.Background(), "policies", "example", "test", "1.0"))
+
+	_, err = s.Policy(context.Background(), "policies", "example", "test", "1.0")
+	assert.True(t, errors.Is(errors.NotFound, err))
+}
+
+func TestStorage_PolicyRevisions(t *testing.T) {
+	s := newStorage(t, filepath.Join(t.TempDir(), "policy.db"))
+	ctx := context.Background()
+
+	policy := &storage.Policy{Repository: "policies", Group: "example", Name: "test", Version: "1.0", Rego: "package example.test"}
+	require.NoError(t, s.SavePolicy(ctx, policy))
+	require.NoError(t, s.SetPolicyLock(ctx, "policies", "example", "test", "1.0", &storage.PolicyLock{LockedAt: time.Now()}))
+
+	// policies with a similar version don't share revisions
+	require.NoError(t, s.SavePolicy(ctx, &storage.Policy{Repository: "policies", Group: "example", Name: "test", Version: "1.01"}))
+
+	p, err := s.Policy(ctx, "policies", "example", "test", "1.0")
+	require.NoError(t, err)
+	p.Data = `{"new":"data"}`
+	require.NoError(t, s.UpdateNextRefreshTime(ctx, p, time.Now().Add(time.Hour)))
+
+	revisions, err := s.PolicyRevisions(ctx, "policies", "example", "test", "1.0")
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, 1, revisions[0].Revision)
+	assert.Equal(t, storage.RevisionSourceAPI, revisions[0].Source)
+	assert.Equal(t, 2, revisions[1].Revision)
+	assert.Equal(t, storage.RevisionSourceDataRefresh, revisions[1].Source)
+
+	rev, err := s.PolicyRevision(ctx, "policies", "example", "test", "1.0", 2)
+	require.NoError(t, err)
+	assert.Equal(t, `{"new":"data"}`, rev.Data)
+	assert.Equal(t, "package example.test", rev.Rego)
+
+	_, err = s.PolicyRevision(ctx, "policies", "example", "test", "1.0", 3)
+	assert.True(t, errors.Is(errors.NotFound, err))
+}
+
+func TestStorage_GetPolicies(t *testing.T) {
+	s := newStorage(t, filepath.Join(t.TempDir(), "policy.db"))
+	ctx := context.Background()
+
+	for _, name := range []string{"foo", "bar", "foobar"} {
+		require.NoError(t, s.SavePolicy(ctx, &storage.Policy{Repository: "policies", Group: "example", Name: name, Version: "1.0"}))
+	}
+	require.NoError(t, s.SetPolicyLock(ctx, "policies", "example", "bar", "1.0", &storage.PolicyLock{LockedAt: time.Now()}))
+
+	policies, err := s.GetPolicies(ctx, nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, policies, 3)
+
+	locked := true
+	policies, err = s.GetPolicies(ctx, &locked, nil)
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	assert.Equal(t, "bar", policies[0].Name)
+
+	name := "FOO"
+	policies, err = s.GetPolicies(ctx, nil, &name)
+	require.NoError(t, err)
+	assert.Len(t, policies, 2)
+}
+
+func TestStorage_ListPolicies(t *testing.T) {
+	s := newStorage(t, filepath.Join(t.TempDir(), "policy.db"))
+	ctx := context.Background()
+
+	for _, name := range []string{"foo", "bar", "foobar"} {
+		require.NoError(t, s.SavePolicy(ctx, &storage.Policy{Repository: "policies", Group: "example", Name: name, Version: "1.0", Rego: "package example." + name}))
+	}
+	require.NoError(t, s.SavePolicy(ctx, &storage.Policy{Repository: "other", Group: "example", Name: "foo", Version: "1.0"}))
+
+	repository := "policies"
+	query := &storage.PolicyQuery{Repository: &repository, Limit: 2, Data: true}
+	policies, next, err := s.ListPolicies(ctx, query)
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	assert.Equal(t, "bar", policies[0].Name)
+	assert.Equal(t, "foo", policies[1].Name)
+	assert.Empty(t, policies[0].Rego)
+	require.NotEmpty(t, next)
+
+	query.After = next
+	policies, next, err = s.ListPolicies(ctx, query)
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	assert.Equal(t, "foobar", policies[0].Name)
+	assert.Empty(t, next)
+
+	_, _, err = s.ListPolicies(ctx, &storage.PolicyQuery{After: "invalid cursor"})
+	assert.True(t, errors.Is(errors.BadRequest, err))
+}
+
+func TestStorage_GetRefreshPolicies(t *testing.T) {
+	s := newStorage(t, filepath.Join(t.TempDir(), "policy.db"))
+	ctx := context.Background()
+
+	for _, name := range []string{"refresh", "future", "never"} {
+		require.NoError(t, s.SavePolicy(ctx, &storage.Policy{Repository: "policies", Group: "example", Name: name, Version: "1.0"}))
+	}
+
+	refresh, err := s.Policy(ctx, "policies", "example", "refresh", "1.0")
+	require.NoError(t, err)
+	refresh.Data = `{"new":"data"}`
+	require.NoError(t, s.UpdateNextRefreshTime(ctx, refresh, time.Now().Add(-time.Minute)))
+
+	future, err := s.Policy(ctx, "policies", "example", "future", "1.0")
+	require.NoError(t, err)
+	require.NoError(t, s.UpdateNextRefreshTime(ctx, future, time.Now().Add(time.Hour)))
+
+	policies, err := s.GetRefreshPolicies(ctx)
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	assert.Equal(t, "refresh", policies[0].Name)
+	assert.Equal(t, `{"new":"data"}`, policies[0].Data)
+
+	// refresh of the returned policy is postponed
+	policies, err = s.GetRefreshPolicies(ctx)
+	require.NoError(t, err)
+	assert.Len(t, policies, 0)
+
+	err = s.UpdateNextRefreshTime(ctx, &storage.Policy{Repository: "policies", Name: "missing"}, time.Now())
+	assert.True(t, errors.Is(errors.NotFound, err))
+}
+
+func TestStorage_PolicySubscribers(t *testing.T) {
+	s := newStorage(t, filepath.Join(t.TempDir(), "policy.db"))
+	ctx := context.Background()
+
+	for _, sub := range []*storage.Subscriber{
+		{Name: "sub1", WebhookURL: "https://example.com/1", PolicyRepository: "policies", PolicyGroup: "example", PolicyName: "test", PolicyVersion: "1.0"},
+		{Name: "sub2", WebhookURL: "https://example.com/2", PolicyRepository: "policies", PolicyGroup: "example", PolicyName: "test", PolicyVersion: "1.0"},
+		{Name: "sub3", WebhookURL: "https://example.com/3", PolicyRepository: "policies", PolicyGroup: "example", PolicyName: "test", PolicyVersion: "1.01"},
+	} {
+		_, err := s.CreateSubscriber(ctx, sub)
+		require.NoError(t, err)
+	}
+
+	subscribers, err := s.PolicySubscribers(ctx, "policies", "test", "example", "1.0")
+	require.NoError(t, err)
+	require.Len(t, subscribers, 2)
+	assert.Equal(t, "sub1", subscribers[0].Name)
+	assert.Equal(t, "sub2", subscribers[1].Name)
+
+	_, err = s.Subscriber(ctx, "policies", "example", "test", "1.0", "https://example.com/3", "sub3")
+	assert.True(t, errors.Is(errors.NotFound, err))
+}
+
+func TestStorage_Deliveries(t *testing.T) {
+	s := newStorage(t, filepath.Join(t.TempDir(), "policy.db"))
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, d := range []*storage.Delivery{
+		{ID: "1", Subscriber: "sub1", PolicyRepository: "policies", PolicyGroup: "example", PolicyName: "test", PolicyVersion: "1.0", State: storage.DeliveryPending, NextAttempt: now.Add(-time.Minute), CreatedAt: now.Add(-2 * time.Minute)},
+		{ID: "2", Subscriber: "sub1", PolicyRepository: "policies", PolicyGroup: "example", PolicyName: "test", PolicyVersion: "1.0", State: storage.DeliveryPending, NextAttempt: now.Add(time.Minute), CreatedAt: now.Add(-time.Minute)},
+		{ID: "3", Subscriber: "sub2", PolicyRepository: "policies", PolicyGroup: "example", PolicyName: "test", PolicyVersion: "1.0", State: storage.DeliveryDead, NextAttempt: now.Add(-time.Minute), CreatedAt: now},
+	} {
+		require.NoError(t, s.SaveDelivery(ctx, d))
+	}
+
+	pending, err := s.PendingDeliveries(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "1", pending[0].ID)
+
+	// a pending delivery is claimed only once
+	claimed, err := s.ClaimDelivery(ctx, "1", pending[0].NextAttempt, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = s.ClaimDelivery(ctx, "1", pending[0].NextAttempt, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	claimed, err = s.ClaimDelivery(ctx, "3", now.Add(-time.Minute), now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	claiming, err := s.PendingDeliveries(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, claiming)
+
+	// the delivery is updated after a successful attempt
+	pending[0].State = storage.DeliveryDelivered
+	pending[0].Attempts = 1
+	require.NoError(t, s.SaveDelivery(ctx, pending[0]))
+
+	pending, err = s.PendingDeliveries(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	deliveries, err := s.SubscriberDeliveries(ctx, "policies", "example", "test", "1.0", "sub1")
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, "1", deliveries[0].ID)
+	assert.Equal(t, storage.DeliveryDelivered, deliveries[0].State)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.Equal(t, "2", deliveries[1].ID)
+}
+
+func TestStorage_Data(t *testing.T) {
+	s := newStorage(t, filepath.Join(t.TempDir(), "policy.db"))
+	ctx := context.Background()
+
+	_, err := s.GetData(ctx, "key")
+	assert.Error(t, err)
+	assert.Error(t, s.DeleteData(ctx, "key"))
+
+	require.NoError(t, s.SetData(ctx, "key", map[string]interface{}{"hello": "world"}))
+	require.NoError(t, s.DeleteData(ctx, "key"))
+
+	_, err = s.GetData(ctx, "key")
+	assert.Error(t, err)
+}
+
+func TestStorage_AutoImportConfigs(t *testing.T) {
+	s := newStorage(t, filepath.Join(t.TempDir(), "policy.db"))
+	ctx := context.Background()
+
+	require.NoError(t, s.SaveAutoImportConfig(ctx, &storage.PolicyAutoImport{PolicyURL: "https://example.com/active", Interval: time.Hour}))
+	require.NoError(t, s.SaveAutoImportConfig(ctx, &storage.PolicyAutoImport{PolicyURL: "https://example.com/later", Interval: time.Hour, NextImport: time.Now().Add(time.Hour)}))
+
+	configs, err := s.AutoImportConfigs(ctx)
+	require.NoError(t, err)
+	assert.Len(t, configs, 2)
+
+	active, err := s.ActiveImportConfigs(ctx)
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, "https://example.com/active", active[0].PolicyURL)
+
+	// next import time is moved forward
+	active, err = s.ActiveImportConfigs(ctx)
+	require.NoError(t, err)
+	assert.Len(t, active, 0)
+
+	require.NoError(t, s.DeleteAutoImportConfig(ctx, "https://example.com/active"))
+	err = s.DeleteAutoImportConfig(ctx, "https://example.com/active")
+	assert.True(t, errors.Is(errors.NotFound, err))
+
+	_, err = s.AutoImportConfig(ctx, "https://example.com/active")
+	assert.True(t, errors.Is(errors.NotFound, err))
+}
+
+func TestStorage_ListenPolicyDataChanges(t *testing.T) {
+	s := newStorage(t, filepath.Join(t.TempDir(), "policy.db"))
+
+	sub := &subscriber{changes: make(chan []string, 1)}
+	s.AddPolicySubscribers(sub)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.ListenPolicyDataChanges(ctx) //nolint:errcheck
+
+	require.NoError(t, s.SavePolicy(ctx, &storage.Policy{Repository: "policies", Group: "example", Name: "test", Version: "1.0"}))
+
+	select {
+	case change := <-sub.changes:
+		assert.Equal(t, []string{"policies", "test", "example", "1.0"}, change)
+	case <-time.After(5 * time.Second):
+		t.Fatal("policy change notification is not received")
+	}
+}
+
+func TestStorage_ListenPolicyDataChanges_RequestCancelled(t *testing.T) {
+	s := newStorage(t, filepath.Join(t.TempDir(), "policy.db"))
+
+	sub := &subscriber{changes: make(chan []string, 1)}
+	s.AddPolicySubscribers(sub)
+
+	reqCtx, cancelReq := context.WithCancel(context.Background())
+	require.NoError(t, s.SavePolicy(reqCtx, &storage.Policy{Repository: "policies", Group: "example", Name: "test", Version: "1.0"}))
+	cancelReq()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.ListenPolicyDataChanges(ctx) //nolint:errcheck
+
+	select {
+	case change := <-sub.changes:
+		assert.Equal(t, []string{"policies", "test", "example", "1.0"}, change)
+	case <-time.After(5 * time.Second):
+		t.Fatal("policy change notification is not received")
+	}
+}
+
+func TestStorage_ListenPolicyDataChanges_SubscriberError(t *testing.T) {
+	s := newStorage(t, filepath.Join(t.TempDir(), "policy.db"))
+	s.AddPolicySubscribers(&subscriber{err: errors.New("subscriber failed")})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	res := make(chan error, 1)
+	go func() { res <- s.ListenPolicyDataChanges(ctx) }()
+
+	require.NoError(t, s.SavePolicy(ctx, &storage.Policy{Repository: "policies", Group: "example", Name: "test", Version: "1.0"}))
+
+	select {
+	case err := <-res:
+		assert.ErrorContains(t, err, "subscriber failed")
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscriber error is not returned")
+	}
+}
+
+func TestStorage_ListenPolicyDeletes(t *testing.T) {
+	s := newStorage(t, filepath.Join(t.TempDir(), "policy.db"))
+
+	sub := &subscriber{changes: make(chan []string, 1), deleted: make(chan []string, 1)}
+	s.AddPolicySubscribers(sub)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.ListenPolicyDataChanges(ctx) //nolint:errcheck
+
+	require.NoError(t, s.SavePolicy(ctx, &storage.Policy{Repository: "policies", Group: "example", Name: "test", Version: "1.0"}))
+	<-sub.changes
+
+	require.NoError(t, s.DeletePolicy(ctx, "policies", "example", "test", "1.0"))
+
+	select {
+	case change := <-sub.deleted:
+		assert.Equal(t, []string{"policies", "test", "example", "1.0"}, change)
+	case <-sub.changes:
+		t.Fatal("policy deletion is notified as a change")
+	case <-time.After(5 * time.Second):
+		t.Fatal("policy deletion notification is not received")
+	}
+}
@@ -19,16 +19,24 @@ type KeyConstructor interface {
 	ConstructKey(repo, group, name, version string) string
 }
 
+// change is a policy change sent to the policy change subscribers.
+type change struct {
+	policy  storage.Policy
+	deleted bool
+}
+
 type Storage struct {
 	keyConstructor KeyConstructor
 	subscribers    []storage.PolicySubscriber
-	changes        chan storage.Policy
+	changes        chan change
 
-	mu       sync.RWMutex
-	policies map[string]*storage.Policy
+	mu        sync.RWMutex
+	policies  map[string]*storage.Policy
+	revisions map[string][]*storage.PolicyRevision
 
 	muSubscribers     sync.RWMutex
 	policySubscribers map[string]*storage.Subscriber
+	deliveries        []*storage.Delivery
 
 	muCommonStorage sync.RWMutex
 	commonStorage   map[string]interface{}
@@ -40,16 +48,25 @@ type Storage struct {
 }
 
 func New(c KeyConstructor, p map[string]*storage.Policy, l *zap.Logger) *Storage {
-	ch := make(chan storage.Policy)
+	ch := make(chan change)
 
-	return &Storage{
+	s := &Storage{
 		keyConstructor:    c,
 		changes:           ch,
 		policies:          p,
+		revisions:         map[string][]*storage.PolicyRevision{},
 		policySubscribers: map[string]*storage.Subscriber{},
 		commonStorage:     map[string]interface{}{},
 		logger:            l,
 	}
+
+	// initial policies are loaded from a policy repository
+	for key, policy := range p {
+		policy.Metadata = storage.ParsePolicyMetadata(policy.Rego)
+		s.addRevision(context.Background(), key, policy, storage.RevisionSourceSync)
+	}
+
+	return s
 }
 
 func (s *Storage) Policy(_ context.Context, repository, group, name, version string) (*storage.Policy, error) {
@@ -72,23 +89,20 @@ func (s *Storage) SavePolicy(ctx context.Context, policy *storage.Policy) error
 		policy.Version,
 	)
 
+	policy.Metadata = storage.ParsePolicyMetadata(policy.Rego)
+
 	s.mu.Lock()
 	s.policies[key] = policy
+	s.addRevision(ctx, key, policy, storage.RevisionSourceAPI)
 	s.mu.Unlock()
 
 	// send the changed policy to subscribers
-	go func(policy *storage.Policy) {
-		select {
-		case s.changes <- *policy:
-		case <-time.After(10 * time.Second):
-		case <-ctx.Done():
-		}
-	}(policy)
+	s.notify(policy, false)
 
 	return nil
 }
 
-func (s *Storage) SetPolicyLock(ctx context.Context, repository, group, name, version string, lock bool) error {
+func (s *Storage) DeletePolicy(_ context.Context, repository, group, name, version string) error {
 	key := s.keyConstructor.ConstructKey(repository, group, name, version)
 
 	s.mu.Lock()
@@ -98,16 +112,55 @@ func (s *Storage) SetPolicyLock(ctx context.Context, repository, group, name, ve
 		return errors.New(errors.NotFound, "policy not found in memory storage")
 	}
 
-	p.Locked = lock
+	delete(s.policies, key)
+
+	// send the deleted policy to subscribers
+	s.notify(p, true)
+
+	return nil
+}
+
+func (s *Storage) SetPolicyLock(_ context.Context, repository, group, name, version string, lock *storage.PolicyLock) error {
+	key := s.keyConstructor.ConstructKey(repository, group, name, version)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.policies[key]
+	if !ok {
+		return errors.New(errors.NotFound, "policy not found in memory storage")
+	}
+
+	p.Locked = lock != nil
+	p.Lock = lock
 
 	// send the changed policy to subscribers
-	go func(policy *storage.Policy) {
-		select {
-		case s.changes <- *policy:
-		case <-time.After(10 * time.Second):
-		case <-ctx.Done():
+	s.notify(p, false)
+
+	return nil
+}
+
+// SetPoliciesLock locks or unlocks the policies at once. No policy is
+// changed if any of them is not found.
+func (s *Storage) SetPoliciesLock(_ context.Context, policies []*storage.Policy, lock *storage.PolicyLock) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := make([]*storage.Policy, 0, len(policies))
+	for _, p := range policies {
+		current, ok := s.policies[s.keyConstructor.ConstructKey(p.Repository, p.Group, p.Name, p.Version)]
+		if !ok {
+			return errors.New(errors.NotFound, "policy not found in memory storage")
 		}
-	}(p)
+		changed = append(changed, current)
+	}
+
+	for _, p := range changed {
+		p.Locked = lock != nil
+		p.Lock = lock
+
+		// send the changed policy to subscribers
+		s.notify(p, false)
+	}
 
 	return nil
 }
@@ -139,6 +192,20 @@ func (s *Storage) GetPolicies(_ context.Context, locked *bool, policyName *strin
 	return res, nil
 }
 
+// ListPolicies returns a page of the policies matching the query
+// and the cursor of the next page.
+func (s *Storage) ListPolicies(_ context.Context, query *storage.PolicyQuery) ([]*storage.Policy, string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	policies := make([]*storage.Policy, 0, len(s.policies))
+	for _, p := range s.policies {
+		policies = append(policies, p)
+	}
+
+	return query.Page(policies)
+}
+
 func (s *Storage) GetRefreshPolicies(_ context.Context) ([]*storage.Policy, error) {
 	var res []*storage.Policy
 
@@ -157,39 +224,94 @@ func (s *Storage) GetRefreshPolicies(_ context.Context) ([]*storage.Policy, erro
 	return res, nil
 }
 
-func (s *Storage) UpdateNextRefreshTime(_ context.Context, p *storage.Policy, nextDataRefreshTime time.Time) error {
+func (s *Storage) UpdateNextRefreshTime(ctx context.Context, p *storage.Policy, nextDataRefreshTime time.Time) error {
 	key := s.keyConstructor.ConstructKey(p.Repository, p.Group, p.Name, p.Version)
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	_, ok := s.policies[key]
+	policy, ok := s.policies[key]
 	if !ok {
 		return errors.New(errors.NotFound, "policy not found in memory storage")
 	}
 
-	s.policies[key].NextDataRefreshTime = nextDataRefreshTime
+	policy.Data = p.Data
+	policy.NextDataRefreshTime = nextDataRefreshTime
+	s.addRevision(ctx, key, policy, storage.RevisionSourceDataRefresh)
 
 	return nil
 }
 
+// addRevision stores a new revision of the policy if its content differs
+// from the latest revision. The caller must hold the policies lock.
+func (s *Storage) addRevision(ctx context.Context, key string, policy *storage.Policy, defaultSource string) {
+	hash := storage.ContentHash(policy)
+	revisions := s.revisions[key]
+	if len(revisions) > 0 && revisions[len(revisions)-1].Hash == hash {
+		return
+	}
+
+	source, commit := storage.RevisionSource(ctx, defaultSource)
+	rev := storage.NewPolicyRevision(policy, source, commit)
+	rev.Revision = len(revisions) + 1
+	s.revisions[key] = append(revisions, rev)
+}
+
+func (s *Storage) PolicyRevisions(_ context.Context, repository, group, name, version string) ([]*storage.PolicyRevision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var res []*storage.PolicyRevision
+	for _, rev := range s.revisions[s.keyConstructor.ConstructKey(repository, group, name, version)] {
+		cpy := *rev
+		res = append(res, &cpy)
+	}
+
+	return res, nil
+}
+
+func (s *Storage) PolicyRevision(_ context.Context, repository, group, name, version string, revision int) (*storage.PolicyRevision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	revisions := s.revisions[s.keyConstructor.ConstructKey(repository, group, name, version)]
+	if revision < 1 || revision > len(revisions) {
+		return nil, errors.New(errors.NotFound, "policy revision not found in memory storage")
+	}
+
+	res := *revisions[revision-1]
+	return &res, nil
+}
+
 func (s *Storage) AddPolicySubscribers(subscribers ...storage.PolicySubscriber) {
 	s.subscribers = subscribers
 }
 
+// notify sends the changed policy to the policy change listener.
+// The notification outlives the request which changed the policy,
+// so it isn't cancelled together with the request context.
+func (s *Storage) notify(policy *storage.Policy, deleted bool) {
+	go func(c change) {
+		select {
+		case s.changes <- c:
+		case <-time.After(10 * time.Second):
+		}
+	}(change{policy: *policy, deleted: deleted})
+}
+
 func (s *Storage) ListenPolicyDataChanges(ctx context.Context) error {
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case p := <-s.changes:
+		case c := <-s.changes:
+			p := c.policy
 			for _, subscriber := range s.subscribers {
-				err := subscriber.PolicyDataChange(
-					ctx,
-					p.Repository,
-					p.Name,
-					p.Version,
-					p.Group,
-				)
+				var err error
+				if c.deleted {
+					err = storage.NotifyPolicyDeleted(ctx, subscriber, p.Repository, p.Name, p.Group, p.Version)
+				} else {
+					err = subscriber.PolicyDataChange(ctx, p.Repository, p.Name, p.Group, p.Version)
+				}
 				if err != nil {
 					return err
 				}
@@ -258,6 +380,87 @@ func (s *Storage) Subscriber(_ context.Context, policyRepository, policyGroup, p
 	return &res, nil
 }
 
+// SaveDelivery creates or updates a webhook delivery.
+func (s *Storage) SaveDelivery(_ context.Context, delivery *storage.Delivery) error {
+	s.muSubscribers.Lock()
+	defer s.muSubscribers.Unlock()
+
+	d := *delivery
+	d.UpdatedAt = time.Now()
+	for i := range s.deliveries {
+		if s.deliveries[i].ID == d.ID {
+			s.deliveries[i] = &d
+			return nil
+		}
+	}
+
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = d.UpdatedAt
+	}
+	s.deliveries = append(s.deliveries, &d)
+
+	return nil
+}
+
+// PendingDeliveries returns the pending webhook deliveries
+// which must be attempted before the given time.
+func (s *Storage) PendingDeliveries(_ context.Context, before time.Time) ([]*storage.Delivery, error) {
+	s.muSubscribers.RLock()
+	defer s.muSubscribers.RUnlock()
+
+	var res []*storage.Delivery
+	for _, d := range s.deliveries {
+		if d.State == storage.DeliveryPending && !d.NextAttempt.After(before) {
+			delivery := *d
+			res = append(res, &delivery)
+		}
+	}
+
+	return res, nil
+}
+
+// ClaimDelivery atomically postpones the next attempt of a pending
+// delivery from nextAttempt until the given time. It returns false if
+// the delivery isn't pending or its next attempt is changed.
+func (s *Storage) ClaimDelivery(_ context.Context, id string, nextAttempt, until time.Time) (bool, error) {
+	s.muSubscribers.Lock()
+	defer s.muSubscribers.Unlock()
+
+	for _, d := range s.deliveries {
+		if d.ID == id {
+			if d.State != storage.DeliveryPending || !d.NextAttempt.Equal(nextAttempt) {
+				return false, nil
+			}
+			d.NextAttempt = until
+			d.UpdatedAt = time.Now()
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// SubscriberDeliveries returns the webhook deliveries
+// of a policy change subscriber sorted by creation time.
+func (s *Storage) SubscriberDeliveries(_ context.Context, policyRepository, policyGroup, policyName, policyVersion, subscriber string) ([]*storage.Delivery, error) {
+	s.muSubscribers.RLock()
+	defer s.muSubscribers.RUnlock()
+
+	var res []*storage.Delivery
+	for _, d := range s.deliveries {
+		if d.PolicyRepository == policyRepository &&
+			d.PolicyGroup == policyGroup &&
+			d.PolicyName == policyName &&
+			d.PolicyVersion == policyVersion &&
+			d.Subscriber == subscriber {
+			delivery := *d
+			res = append(res, &delivery)
+		}
+	}
+
+	return res, nil
+}
+
 func (s *Storage) SaveAutoImportConfig(_ context.Context, importConfig *storage.PolicyAutoImport) error {
 	s.muAutoImport.Lock()
 	s.autoImport[importConfig.PolicyURL] = importConfig
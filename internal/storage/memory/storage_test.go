@@ -2,11 +2,14 @@ package memory_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/ptr"
 	"go.uber.org/zap"
 
 	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
@@ -49,6 +52,8 @@ func TestStorage_Policy(t *testing.T) {
 }
 
 func TestStorage_SetPolicyLock(t *testing.T) {
+	lock := &storage.PolicyLock{Reason: "incident", Actor: "alice", LockedAt: time.Now()}
+
 	// policy is not found in memory storage
 	keyConstructor := &memoryfakes.FakeKeyConstructor{ConstructKeyStub: func(s string, s2 string, s3 string, s4 string) string {
 		return "non-existing policy key"
@@ -56,7 +61,7 @@ func TestStorage_SetPolicyLock(t *testing.T) {
 
 	storage := memory.New(keyConstructor, makePolicies(), zap.NewNop())
 
-	err := storage.SetPolicyLock(context.Background(), "repo", "group", "name", "version", true)
+	err := storage.SetPolicyLock(context.Background(), "repo", "group", "name", "version", lock)
 	assert.Error(t, err)
 
 	e, ok := err.(*errors.Error)
@@ -73,9 +78,83 @@ func TestStorage_SetPolicyLock(t *testing.T) {
 	storage = memory.New(keyConstructor, policies, zap.NewNop())
 
 	// lock the policy
-	err = storage.SetPolicyLock(context.Background(), "repo", "group", "name", "version", true)
+	err = storage.SetPolicyLock(context.Background(), "repo", "group", "name", "version", lock)
 	assert.NoError(t, err)
 	assert.Equal(t, true, policies[validKey].Locked)
+	assert.Equal(t, lock, policies[validKey].Lock)
+
+	// unlock the policy
+	err = storage.SetPolicyLock(context.Background(), "repo", "group", "name", "version", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, false, policies[validKey].Locked)
+	assert.Nil(t, policies[validKey].Lock)
+}
+
+func TestStorage_SetPoliciesLock(t *testing.T) {
+	keyConstructor := &memoryfakes.FakeKeyConstructor{ConstructKeyStub: func(repo, group, name, version string) string {
+		return strings.Join([]string{repo, group, name, version}, ",")
+	}}
+	policies := makePolicies()
+	s := memory.New(keyConstructor, policies, zap.NewNop())
+	lock := &storage.PolicyLock{Reason: "incident", LockedAt: time.Now()}
+
+	// no policy is locked if any of them is not found
+	err := s.SetPoliciesLock(context.Background(), []*storage.Policy{
+		{Repository: "policies", Group: "example", Name: "foo", Version: "1.0"},
+		{Repository: "policies", Group: "example", Name: "missing", Version: "1.0"},
+	}, lock)
+	assert.True(t, errors.Is(errors.NotFound, err))
+	assert.False(t, policies[validKey].Locked)
+
+	// policies are locked
+	err = s.SetPoliciesLock(context.Background(), []*storage.Policy{
+		{Repository: "policies", Group: "example", Name: "foo", Version: "1.0"},
+		{Repository: "policies", Group: "example", Name: "examplePolicy", Version: "1.1"},
+	}, lock)
+	assert.NoError(t, err)
+	assert.True(t, policies[validKey].Locked)
+	assert.Equal(t, lock, policies[validKey].Lock)
+	assert.True(t, policies["policies,example,examplePolicy,1.1"].Locked)
+
+	// policies are unlocked
+	err = s.SetPoliciesLock(context.Background(), []*storage.Policy{
+		{Repository: "policies", Group: "example", Name: "foo", Version: "1.0"},
+		{Repository: "policies", Group: "example", Name: "bar", Version: "1.1"},
+	}, nil)
+	assert.NoError(t, err)
+	assert.False(t, policies[validKey].Locked)
+	assert.Nil(t, policies[validKey].Lock)
+	assert.False(t, policies["policies,example,bar,1.1"].Locked)
+}
+
+func TestStorage_DeletePolicy(t *testing.T) {
+	// policy is not found in memory storage
+	keyConstructor := &memoryfakes.FakeKeyConstructor{ConstructKeyStub: func(s string, s2 string, s3 string, s4 string) string {
+		return "non-existing policy key"
+	}}
+
+	storage := memory.New(keyConstructor, makePolicies(), zap.NewNop())
+
+	err := storage.DeletePolicy(context.Background(), "repo", "group", "name", "version")
+	assert.Error(t, err)
+
+	e, ok := err.(*errors.Error)
+	assert.True(t, ok)
+	assert.Equal(t, errors.NotFound, e.Kind)
+	assert.Contains(t, e.Error(), "policy not found in memory storage")
+
+	// policy is deleted successfully
+	keyConstructor = &memoryfakes.FakeKeyConstructor{ConstructKeyStub: func(s string, s2 string, s3 string, s4 string) string {
+		return validKey
+	}}
+
+	policies := makePolicies()
+	storage = memory.New(keyConstructor, policies, zap.NewNop())
+
+	err = storage.DeletePolicy(context.Background(), "repo", "group", "name", "version")
+	assert.NoError(t, err)
+	assert.NotContains(t, policies, validKey)
+	assert.Len(t, policies, 2)
 }
 
 func TestStorage_GetPolicies(t *testing.T) {
@@ -109,6 +188,98 @@ func TestStorage_GetPolicies(t *testing.T) {
 	assert.Contains(t, res[0].Name, name)
 }
 
+func TestStorage_ListPolicies(t *testing.T) {
+	policies := makePolicies()
+	policies[validKey].Rego = "package example.foo"
+	policies[validKey].LastUpdate = time.Now()
+	s := memory.New(nil, policies, zap.NewNop())
+
+	// policies are sorted by name and large fields are not returned
+	res, next, err := s.ListPolicies(context.Background(), &storage.PolicyQuery{})
+	assert.NoError(t, err)
+	assert.Empty(t, next)
+	assert.Equal(t, []string{"bar", "examplePolicy", "foo"}, policyNames(res))
+	assert.Empty(t, res[2].Rego)
+
+	// rego is returned when it's requested
+	res, _, err = s.ListPolicies(context.Background(), &storage.PolicyQuery{Name: ptr.String("foo"), Rego: true})
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, "package example.foo", res[0].Rego)
+
+	// get policies filtered by version and updated since
+	since := time.Now().Add(-time.Minute)
+	res, _, err = s.ListPolicies(context.Background(), &storage.PolicyQuery{Version: ptr.String("1.0"), UpdatedSince: &since})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"foo"}, policyNames(res))
+
+	// get pages of policies sorted by last update in descending order
+	query := &storage.PolicyQuery{Sort: storage.SortByLastUpdate, Desc: true, Limit: 2}
+	res, next, err = s.ListPolicies(context.Background(), query)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"foo", "examplePolicy"}, policyNames(res))
+	assert.NotEmpty(t, next)
+
+	query.After = next
+	res, next, err = s.ListPolicies(context.Background(), query)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"bar"}, policyNames(res))
+	assert.Empty(t, next)
+
+	// cursor of another sort order is rejected
+	query.Sort = storage.SortByName
+	_, _, err = s.ListPolicies(context.Background(), query)
+	assert.True(t, errors.Is(errors.BadRequest, err))
+}
+
+func TestStorage_ListPolicies_Metadata(t *testing.T) {
+	s := memory.New(&memoryfakes.FakeKeyConstructor{ConstructKeyStub: func(repo, group, name, version string) string {
+		return repo + group + name + version
+	}}, map[string]*storage.Policy{}, zap.NewNop())
+
+	rego := `# METADATA
+# title: Example
+# description: Example policy
+# authors:
+# - Jane Doe <jane@example.com>
+# custom:
+#   domain: gaia-x
+#   tags: [identity, trust]
+package example.annotated
+
+allow := true
+`
+	assert.NoError(t, s.SavePolicy(context.Background(), &storage.Policy{Repository: "policies", Group: "example", Name: "annotated", Version: "1.0", Rego: rego}))
+	assert.NoError(t, s.SavePolicy(context.Background(), &storage.Policy{Repository: "policies", Group: "example", Name: "plain", Version: "1.0", Rego: "package example.plain"}))
+
+	res, _, err := s.ListPolicies(context.Background(), &storage.PolicyQuery{Custom: map[string]string{"domain": "gaia-x", "tags": "trust"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"annotated"}, policyNames(res))
+	assert.Equal(t, &storage.PolicyMetadata{
+		Title:       "Example",
+		Description: "Example policy",
+		Authors:     []string{"Jane Doe <jane@example.com>"},
+		Custom:      map[string]interface{}{"domain": "gaia-x", "tags": []interface{}{"identity", "trust"}},
+	}, res[0].Metadata)
+
+	res, _, err = s.ListPolicies(context.Background(), &storage.PolicyQuery{Custom: map[string]string{"domain": "other"}})
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+
+	res, _, err = s.ListPolicies(context.Background(), &storage.PolicyQuery{Name: ptr.String("plain")})
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Nil(t, res[0].Metadata)
+}
+
+func policyNames(policies []*storage.Policy) []string {
+	var names []string
+	for _, p := range policies {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
 func TestStorage_GetRefreshPolicies(t *testing.T) {
 	nextDataRefreshTime := time.Now().Add(-5 * time.Minute) // time must be in the past
 
@@ -203,6 +374,95 @@ func TestStorage_PolicySubscriber(t *testing.T) {
 	})
 }
 
+func TestStorage_Deliveries(t *testing.T) {
+	s := memory.New(nil, nil, zap.NewNop())
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, d := range []*storage.Delivery{
+		{ID: "1", Subscriber: "sub1", PolicyRepository: "policies", PolicyGroup: "example", PolicyName: "test", PolicyVersion: "1.0", State: storage.DeliveryPending, NextAttempt: now.Add(-time.Minute), CreatedAt: now.Add(-2 * time.Minute)},
+		{ID: "2", Subscriber: "sub1", PolicyRepository: "policies", PolicyGroup: "example", PolicyName: "test", PolicyVersion: "1.0", State: storage.DeliveryPending, NextAttempt: now.Add(time.Minute), CreatedAt: now.Add(-time.Minute)},
+		{ID: "3", Subscriber: "sub2", PolicyRepository: "policies", PolicyGroup: "example", PolicyName: "test", PolicyVersion: "1.0", State: storage.DeliveryDead, NextAttempt: now.Add(-time.Minute), CreatedAt: now},
+	} {
+		require.NoError(t, s.SaveDelivery(ctx, d))
+	}
+
+	pending, err := s.PendingDeliveries(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "1", pending[0].ID)
+
+	// a pending delivery is claimed only once
+	claimed, err := s.ClaimDelivery(ctx, "1", pending[0].NextAttempt, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = s.ClaimDelivery(ctx, "1", pending[0].NextAttempt, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	claimed, err = s.ClaimDelivery(ctx, "3", now.Add(-time.Minute), now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	claiming, err := s.PendingDeliveries(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, claiming)
+
+	// the delivery is updated after a successful attempt
+	pending[0].State = storage.DeliveryDelivered
+	pending[0].Attempts = 1
+	require.NoError(t, s.SaveDelivery(ctx, pending[0]))
+
+	pending, err = s.PendingDeliveries(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	deliveries, err := s.SubscriberDeliveries(ctx, "policies", "example", "test", "1.0", "sub1")
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, "1", deliveries[0].ID)
+	assert.Equal(t, storage.DeliveryDelivered, deliveries[0].State)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.Equal(t, "2", deliveries[1].ID)
+}
+
+func TestStorage_PolicyRevisions(t *testing.T) {
+	keyConstructor := &memoryfakes.FakeKeyConstructor{ConstructKeyStub: func(s string, s2 string, s3 string, s4 string) string {
+		return validKey
+	}}
+	s := memory.New(keyConstructor, makePolicies(), zap.NewNop())
+	ctx := context.Background()
+
+	// initial policies are stored as the first revision
+	revisions, err := s.PolicyRevisions(ctx, "policies", "example", "foo", "1.0")
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 1)
+	assert.Equal(t, storage.RevisionSourceSync, revisions[0].Source)
+
+	policy := &storage.Policy{Repository: "policies", Group: "example", Name: "foo", Version: "1.0", Rego: "package example.foo"}
+	assert.NoError(t, s.SavePolicy(storage.WithRevisionSource(ctx, storage.RevisionSourceImport, "0123abcd"), policy))
+
+	// saving unchanged content doesn't create a revision
+	cpy := *policy
+	cpy.Locked = true
+	assert.NoError(t, s.SavePolicy(ctx, &cpy))
+
+	revisions, err = s.PolicyRevisions(ctx, "policies", "example", "foo", "1.0")
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 2)
+
+	rev, err := s.PolicyRevision(ctx, "policies", "example", "foo", "1.0", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "package example.foo", rev.Rego)
+	assert.Equal(t, storage.RevisionSourceImport, rev.Source)
+	assert.Equal(t, "0123abcd", rev.Commit)
+	assert.Equal(t, storage.ContentHash(policy), rev.Hash)
+
+	_, err = s.PolicyRevision(ctx, "policies", "example", "foo", "1.0", 3)
+	assert.True(t, errors.Is(errors.NotFound, err))
+}
+
 // makePolicies makes a valid policies map
 func makePolicies() map[string]*storage.Policy {
 	return map[string]*storage.Policy{
@@ -229,3 +489,84 @@ func makePolicies() map[string]*storage.Policy {
 		},
 	}
 }
+
+// subscriber records the policy changes and deletions.
+type subscriber struct {
+	changes chan string
+}
+
+func (s *subscriber) PolicyDataChange(_ context.Context, repo, name, group, version string) error {
+	s.changes <- "changed " + strings.Join([]string{repo, group, name, version}, ",")
+	return nil
+}
+
+func (s *subscriber) PolicyDeleted(_ context.Context, repo, name, group, version string) error {
+	s.changes <- "deleted " + strings.Join([]string{repo, group, name, version}, ",")
+	return nil
+}
+
+func TestStorage_ListenPolicyDataChanges_RequestCancelled(t *testing.T) {
+	keyConstructor := &memoryfakes.FakeKeyConstructor{ConstructKeyStub: func(repo, group, name, version string) string {
+		return strings.Join([]string{repo, group, name, version}, ",")
+	}}
+	lock := &storage.PolicyLock{Reason: "incident", LockedAt: time.Now()}
+
+	tests := []struct {
+		name   string
+		change func(ctx context.Context, s *memory.Storage) error
+		event  string
+	}{
+		{
+			name: "policy is saved",
+			change: func(ctx context.Context, s *memory.Storage) error {
+				return s.SavePolicy(ctx, &storage.Policy{Repository: "policies", Group: "example", Name: "new", Version: "1.0"})
+			},
+			event: "changed policies,example,new,1.0",
+		},
+		{
+			name: "policy is deleted",
+			change: func(ctx context.Context, s *memory.Storage) error {
+				return s.DeletePolicy(ctx, "policies", "example", "foo", "1.0")
+			},
+			event: "deleted " + validKey,
+		},
+		{
+			name: "policy is locked",
+			change: func(ctx context.Context, s *memory.Storage) error {
+				return s.SetPolicyLock(ctx, "policies", "example", "foo", "1.0", lock)
+			},
+			event: "changed " + validKey,
+		},
+		{
+			name: "policies are locked",
+			change: func(ctx context.Context, s *memory.Storage) error {
+				return s.SetPoliciesLock(ctx, []*storage.Policy{{Repository: "policies", Group: "example", Name: "foo", Version: "1.0"}}, lock)
+			},
+			event: "changed " + validKey,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := memory.New(keyConstructor, makePolicies(), zap.NewNop())
+			sub := &subscriber{changes: make(chan string, 1)}
+			s.AddPolicySubscribers(sub)
+
+			// the request which changed the policy is finished before the change is sent
+			reqCtx, cancelReq := context.WithCancel(context.Background())
+			require.NoError(t, test.change(reqCtx, s))
+			cancelReq()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go s.ListenPolicyDataChanges(ctx) //nolint:errcheck
+
+			select {
+			case event := <-sub.changes:
+				assert.Equal(t, test.event, event)
+			case <-time.After(5 * time.Second):
+				t.Fatal("policy change notification is not received")
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"github.com/open-policy-agent/opa/ast"
+)
+
+// PolicyMetadata contains the OPA METADATA annotations of the policy
+// package, which describe the policy in the policy catalog.
+type PolicyMetadata struct {
+	Title            string
+	Description      string
+	Authors          []string
+	Organizations    []string
+	RelatedResources []string
+	Custom           map[string]interface{}
+}
+
+// ParsePolicyMetadata returns the annotations of the package of a policy
+// source code. Nil is returned if the package is not annotated or if the
+// source code cannot be parsed.
+func ParsePolicyMetadata(rego string) *PolicyMetadata {
+	module, err := ast.ParseModuleWithOpts("policy.rego", rego, ast.ParserOptions{ProcessAnnotation: true})
+	if err != nil || module == nil {
+		return nil
+	}
+
+	var annotations *ast.Annotations
+	for _, a := range module.Annotations {
+		// package scope takes precedence over subpackages scope
+		if a.Scope == "package" || (a.Scope == "subpackages" && annotations == nil) {
+			annotations = a
+		}
+	}
+	if annotations == nil {
+		return nil
+	}
+
+	metadata := &PolicyMetadata{
+		Title:         annotations.Title,
+		Description:   annotations.Description,
+		Organizations: annotations.Organizations,
+		Custom:        annotations.Custom,
+	}
+	for _, author := range annotations.Authors {
+		metadata.Authors = append(metadata.Authors, author.String())
+	}
+	for _, resource := range annotations.RelatedResources {
+		metadata.RelatedResources = append(metadata.RelatedResources, resource.Ref.String())
+	}
+
+	return metadata
+}
+
+// HasCustomValue reports whether a custom annotation has the given
+// value or is a list containing the value.
+func (m *PolicyMetadata) HasCustomValue(key, value string) bool {
+	if m == nil {
+		return false
+	}
+
+	switch v := m.Custom[key].(type) {
+	case string:
+		return v == value
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s == value {
+				return true
+			}
+		}
+	}
+
+	return false
+}
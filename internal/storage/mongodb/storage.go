@@ -4,6 +4,8 @@ import (
 	"context"
 	goerrors "errors"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -20,18 +22,41 @@ const (
 	subscriberCollectionName = "subscribers"
 	commonStorage            = "common_storage"
 	autoImportCollection     = "policy_auto_import"
+	deliveryCollectionName   = "webhook_deliveries"
 	lockedField              = "locked"
 	policyNameField          = "name"
 	dataField                = "data"
+	regoField                = "rego"
+	dataConfigField          = "dataConfig"
+	outputSchemaField        = "outputSchema"
+	exportConfigField        = "exportConfig"
+	lastUpdateField          = "lastUpdate"
+	customMetadataField      = "metadata.custom."
 	nextDataRefreshTimeField = "nextDataRefreshTime"
 )
 
+// RevisionCollection is the name of the collection storing policy revisions.
+const RevisionCollection = "policy_revisions"
+
+// maxRevisionAttempts is how many times a revision is inserted, when
+// a concurrent change of the policy inserts the same revision number.
+const maxRevisionAttempts = 5
+
+// Revisions stores the revisions of policies. It's implemented by the
+// *mongo.Collection of RevisionCollection.
+type Revisions interface {
+	FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult
+	InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error)
+}
+
 type Storage struct {
 	db            *mongo.Client
 	policy        *mongo.Collection
 	subscriber    *mongo.Collection
 	commonStorage *mongo.Collection
 	autoImport    *mongo.Collection
+	revision      *mongo.Collection
+	delivery      *mongo.Collection
 	subscribers   []storage.PolicySubscriber
 	logger        *zap.Logger
 }
@@ -43,12 +68,19 @@ func New(db *mongo.Client, dbname, collection string, logger *zap.Logger) (*Stor
 
 	database := db.Database(dbname)
 
+	revision := database.Collection(RevisionCollection)
+	if err := CreateRevisionIndex(context.Background(), revision); err != nil {
+		return nil, err
+	}
+
 	return &Storage{
 		db:            db,
 		policy:        database.Collection(collection),
 		subscriber:    database.Collection(subscriberCollectionName),
 		commonStorage: database.Collection(commonStorage),
 		autoImport:    database.Collection(autoImportCollection),
+		revision:      revision,
+		delivery:      database.Collection(deliveryCollectionName),
 		logger:        logger,
 	}, nil
 }
@@ -84,22 +116,60 @@ func (s *Storage) SavePolicy(ctx context.Context, policy *storage.Policy) error
 		"name":       policy.Name,
 		"version":    policy.Version,
 	}
-	update := bson.M{"$set": bson.M{
-		"locked":              policy.Locked,
-		"rego":                policy.Rego,
-		"data":                policy.Data,
-		"dataConfig":          policy.DataConfig,
-		"outputSchema":        policy.OutputSchema,
-		"lastUpdate":          time.Now(),
-		"nextDataRefreshTime": time.Time{},
+	if _, err := s.policy.UpdateOne(ctx, filter, policyUpdate(policy, time.Now()), opts); err != nil {
+		return err
+	}
+
+	source, commit := storage.RevisionSource(ctx, storage.RevisionSourceAPI)
+	return SaveRevision(ctx, s.revision, policy, source, commit)
+}
+
+// policyUpdate returns the update of a saved policy. The data of a policy
+// with a data configuration is refreshed right away.
+func policyUpdate(policy *storage.Policy, now time.Time) bson.M {
+	var nextDataRefreshTime time.Time
+	if policy.DataConfig != "" {
+		nextDataRefreshTime = now
+	}
+
+	return bson.M{"$set": bson.M{
+		"filename":               policy.Filename,
+		"locked":                 policy.Locked,
+		"lock":                   policy.Lock,
+		"rego":                   policy.Rego,
+		"data":                   policy.Data,
+		"dataConfig":             policy.DataConfig,
+		"outputSchema":           policy.OutputSchema,
+		"exportConfig":           policy.ExportConfig,
+		"archived":               policy.Archived,
+		"commit":                 policy.Commit,
+		"metadata":               storage.ParsePolicyMetadata(policy.Rego),
+		"lastUpdate":             now,
+		nextDataRefreshTimeField: nextDataRefreshTime,
 	}}
+}
 
-	_, err := s.policy.UpdateOne(ctx, filter, update, opts)
+func (s *Storage) DeletePolicy(ctx context.Context, repository, group, name, version string) error {
+	res, err := s.policy.DeleteOne(ctx, bson.M{
+		"repository": repository,
+		"group":      group,
+		"name":       name,
+		"version":    version,
+	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	if res.DeletedCount == 0 {
+		return errors.New(errors.NotFound, "policy not found")
+	}
+
+	return nil
 }
 
-func (s *Storage) SetPolicyLock(ctx context.Context, repository, group, name, version string, lock bool) error {
+// SetPolicyLock locks a policy with the given lock details
+// or unlocks it if the lock is nil.
+func (s *Storage) SetPolicyLock(ctx context.Context, repository, group, name, version string, lock *storage.PolicyLock) error {
 	_, err := s.policy.UpdateOne(
 		ctx,
 		bson.M{
@@ -110,7 +180,8 @@ func (s *Storage) SetPolicyLock(ctx context.Context, repository, group, name, ve
 		},
 		bson.M{
 			"$set": bson.M{
-				"locked":     lock,
+				"locked":     lock != nil,
+				"lock":       lock,
 				"lastUpdate": time.Now(),
 			},
 		},
@@ -118,11 +189,50 @@ func (s *Storage) SetPolicyLock(ctx context.Context, repository, group, name, ve
 	return err
 }
 
+// SetPoliciesLock locks or unlocks the policies in a transaction,
+// so no policy is changed if any of them is not found.
+func (s *Storage) SetPoliciesLock(ctx context.Context, policies []*storage.Policy, lock *storage.PolicyLock) error {
+	callback := func(mCtx mongo.SessionContext) (interface{}, error) {
+		update := bson.M{"$set": bson.M{
+			"locked":     lock != nil,
+			"lock":       lock,
+			"lastUpdate": time.Now(),
+		}}
+
+		for _, p := range policies {
+			res, err := s.policy.UpdateOne(mCtx, bson.M{
+				"repository": p.Repository,
+				"group":      p.Group,
+				"name":       p.Name,
+				"version":    p.Version,
+			}, update)
+			if err != nil {
+				return nil, err
+			}
+			if res.MatchedCount == 0 {
+				return nil, errors.New(errors.NotFound, "policy not found")
+			}
+		}
+
+		return nil, nil
+	}
+
+	_, err := s.Transaction(ctx, callback)
+	return err
+}
+
 type PolicyEvent struct {
 	OperationType string         `bson:"operationType"`
 	Policy        storage.Policy `bson:"fullDocument"`
+	DocumentKey   struct {
+		ID primitive.ObjectID `bson:"_id"`
+	} `bson:"documentKey"`
 }
 
+// ListenPolicyDataChanges notifies the subscribers about changes in
+// the policy collection. Change events for deleted documents contain only
+// the document ID, so the coordinates of all policies are kept by ID
+// in order to notify the subscribers which policy is deleted.
 func (s *Storage) ListenPolicyDataChanges(ctx context.Context) error {
 	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
 	stream, err := s.policy.Watch(ctx, mongo.Pipeline{}, opts)
@@ -131,6 +241,11 @@ func (s *Storage) ListenPolicyDataChanges(ctx context.Context) error {
 	}
 	defer stream.Close(ctx)
 
+	policies, err := s.policyCoordinates(ctx)
+	if err != nil {
+		return errors.New("cannot get policy coordinates", err)
+	}
+
 	for stream.Next(ctx) {
 		var policyEvent PolicyEvent
 		err := stream.Decode(&policyEvent)
@@ -139,20 +254,70 @@ func (s *Storage) ListenPolicyDataChanges(ctx context.Context) error {
 		}
 
 		policy := policyEvent.Policy
+		if policy.MongoID.IsZero() { // the document is already deleted
+			policy = policies[policyEvent.DocumentKey.ID]
+		} else {
+			policies[policy.MongoID] = coordinates(&policy)
+		}
+
+		deleted := policyEvent.OperationType == "delete"
+		if deleted {
+			delete(policies, policyEvent.DocumentKey.ID)
+		}
 
 		for _, subscriber := range s.subscribers {
-			err := subscriber.PolicyDataChange(ctx, policy.Repository, policy.Name, policy.Group, policy.Version)
+			if deleted {
+				err = storage.NotifyPolicyDeleted(ctx, subscriber, policy.Repository, policy.Name, policy.Group, policy.Version)
+			} else {
+				err = subscriber.PolicyDataChange(ctx, policy.Repository, policy.Name, policy.Group, policy.Version)
+			}
 			if err != nil {
 				s.logger.Error("error notifying policy change subscribers", zap.Error(err))
 			}
 		}
 
-		s.logger.Info("mongo policy data changed")
+		s.logger.Info("mongo policy data changed", zap.String("operationType", policyEvent.OperationType))
 	}
 
 	return stream.Err()
 }
 
+// policyCoordinates returns the coordinates of all policies by document ID.
+func (s *Storage) policyCoordinates(ctx context.Context) (map[primitive.ObjectID]storage.Policy, error) {
+	opts := options.Find().SetProjection(bson.M{
+		"repository": 1,
+		"group":      1,
+		"name":       1,
+		"version":    1,
+	})
+	cursor, err := s.policy.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []*storage.Policy
+	if err := cursor.All(ctx, &policies); err != nil {
+		return nil, err
+	}
+
+	res := make(map[primitive.ObjectID]storage.Policy, len(policies))
+	for _, p := range policies {
+		res[p.MongoID] = coordinates(p)
+	}
+
+	return res, nil
+}
+
+func coordinates(p *storage.Policy) storage.Policy {
+	return storage.Policy{
+		MongoID:    p.MongoID,
+		Repository: p.Repository,
+		Group:      p.Group,
+		Name:       p.Name,
+		Version:    p.Version,
+	}
+}
+
 func (s *Storage) AddPolicySubscribers(subscribers ...storage.PolicySubscriber) {
 	s.subscribers = subscribers
 }
@@ -218,11 +383,116 @@ func (s *Storage) UpdateNextRefreshTime(ctx context.Context, p *storage.Policy,
 		nextDataRefreshTimeField: nextDataRefreshTime,
 		dataField:                p.Data,
 	}}
-	_, err := s.policy.UpdateOne(ctx, filter, update)
+	if _, err := s.policy.UpdateOne(ctx, filter, update); err != nil {
+		return err
+	}
+
+	source, commit := storage.RevisionSource(ctx, storage.RevisionSourceDataRefresh)
+	return SaveRevision(ctx, s.revision, p, source, commit)
+}
+
+// CreateRevisionIndex creates a unique index on the revision numbers of
+// policies, so that concurrent changes of a policy can't store two
+// revisions with the same number.
+func CreateRevisionIndex(ctx context.Context, revisions *mongo.Collection) error {
+	_, err := revisions.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "repository", Value: 1},
+			{Key: "group", Value: 1},
+			{Key: "name", Value: 1},
+			{Key: "version", Value: 1},
+			{Key: "revision", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating policy revision index: %v", err)
+	}
+
+	return nil
+}
+
+// SaveRevision stores a new revision of the policy in the given collection
+// if the policy content differs from the latest revision. If a concurrent
+// change inserts a revision with the same number, the latest revision is
+// read again and the revision is inserted with the next number.
+func SaveRevision(ctx context.Context, revisions Revisions, policy *storage.Policy, source, commit string) error {
+	filter := bson.M{
+		"repository": policy.Repository,
+		"group":      policy.Group,
+		"name":       policy.Name,
+		"version":    policy.Version,
+	}
+
+	var err error
+	for attempt := 0; attempt < maxRevisionAttempts; attempt++ {
+		var latest storage.PolicyRevision
+		err = revisions.FindOne(ctx, filter, options.FindOne().SetSort(bson.M{"revision": -1})).Decode(&latest)
+		if err != nil && !goerrors.Is(err, mongo.ErrNoDocuments) {
+			return err
+		}
+
+		rev := storage.NewPolicyRevision(policy, source, commit)
+		if rev.Hash == latest.Hash {
+			return nil
+		}
+		rev.Revision = latest.Revision + 1
+
+		_, err = revisions.InsertOne(ctx, rev)
+		if !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+	}
 
 	return err
 }
 
+func (s *Storage) PolicyRevisions(ctx context.Context, repository, group, name, version string) ([]*storage.PolicyRevision, error) {
+	filter := bson.M{
+		"repository": repository,
+		"group":      group,
+		"name":       name,
+		"version":    version,
+	}
+
+	cursor, err := s.revision.Find(ctx, filter, options.Find().SetSort(bson.M{"revision": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var revisions []*storage.PolicyRevision
+	if err := cursor.All(ctx, &revisions); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (s *Storage) PolicyRevision(ctx context.Context, repository, group, name, version string, revision int) (*storage.PolicyRevision, error) {
+	result := s.revision.FindOne(ctx, bson.M{
+		"repository": repository,
+		"group":      group,
+		"name":       name,
+		"version":    version,
+		"revision":   revision,
+	})
+
+	if result.Err() != nil {
+		if goerrors.Is(result.Err(), mongo.ErrNoDocuments) {
+			return nil, errors.New(errors.NotFound, "policy revision not found")
+		}
+		return nil, result.Err()
+	}
+
+	var rev storage.PolicyRevision
+	if err := result.Decode(&rev); err != nil {
+		return nil, err
+	}
+
+	return &rev, nil
+}
+
 func (s *Storage) Transaction(ctx context.Context, callback func(mCtx mongo.SessionContext) (interface{}, error)) (interface{}, error) {
 	session, err := s.db.StartSession()
 	if err != nil {
@@ -261,6 +531,124 @@ func (s *Storage) GetPolicies(ctx context.Context, locked *bool, policyName *str
 	return policies, nil
 }
 
+// ListPolicies returns a page of the policies matching the query and the
+// cursor of the next page. The next page starts after the last returned
+// policy in the sort order, so that pages are stable when policies are
+// added or removed. Large fields are only fetched when they are requested.
+func (s *Storage) ListPolicies(ctx context.Context, query *storage.PolicyQuery) ([]*storage.Policy, string, error) {
+	sortBy := query.SortBy()
+
+	filter := bson.M{}
+	if query.Locked != nil {
+		filter[lockedField] = *query.Locked
+	}
+	if query.Name != nil {
+		filter[policyNameField] = primitive.Regex{Pattern: regexp.QuoteMeta(strings.TrimSpace(*query.Name)), Options: "i"}
+	}
+	if query.Repository != nil {
+		filter["repository"] = *query.Repository
+	}
+	if query.Group != nil {
+		filter["group"] = *query.Group
+	}
+	if query.Version != nil {
+		filter["version"] = *query.Version
+	}
+	if query.UpdatedSince != nil {
+		filter[lastUpdateField] = bson.M{"$gte": *query.UpdatedSince}
+	}
+	for key, value := range query.Custom {
+		// array values match if they contain the value
+		filter[customMetadataField+key] = value
+	}
+
+	// policies with the same sort value are sorted by their unique key
+	order := 1
+	if query.Desc {
+		order = -1
+	}
+	sortFields := []string{policyNameField, "repository", "group", "version"}
+	if sortBy == storage.SortByLastUpdate {
+		sortFields = []string{lastUpdateField, "repository", "group", policyNameField, "version"}
+	}
+
+	if query.After != "" {
+		after, err := storage.ParsePolicyCursor(query.After, sortBy)
+		if err != nil {
+			return nil, "", err
+		}
+		filter = bson.M{"$and": bson.A{filter, afterCursor(sortFields, after, order)}}
+	}
+
+	sort := bson.D{}
+	for _, field := range sortFields {
+		sort = append(sort, bson.E{Key: field, Value: order})
+	}
+
+	projection := bson.M{outputSchemaField: 0, exportConfigField: 0}
+	if !query.Rego {
+		projection[regoField] = 0
+	}
+	if !query.Data {
+		projection[dataField] = 0
+	}
+	if !query.DataConfig {
+		projection[dataConfigField] = 0
+	}
+
+	opts := options.Find().SetSort(sort).SetProjection(projection)
+	if query.Limit > 0 {
+		// one more policy is fetched to know if there is a next page
+		opts.SetLimit(int64(query.Limit) + 1)
+	}
+
+	cursor, err := s.policy.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var policies []*storage.Policy
+	if err := cursor.All(ctx, &policies); err != nil {
+		return nil, "", err
+	}
+
+	if query.Limit > 0 && len(policies) > query.Limit {
+		policies = policies[:query.Limit]
+		return policies, storage.NewPolicyCursor(policies[len(policies)-1], sortBy).String(), nil
+	}
+
+	return policies, "", nil
+}
+
+// afterCursor returns a filter for the documents after the cursor
+// in the order of the given sort fields.
+func afterCursor(sortFields []string, after *storage.PolicyCursor, order int) bson.M {
+	values := map[string]interface{}{
+		lastUpdateField: after.LastUpdate,
+		"repository":    after.Repository,
+		"group":         after.Group,
+		policyNameField: after.Name,
+		"version":       after.Version,
+	}
+
+	op := "$gt"
+	if order < 0 {
+		op = "$lt"
+	}
+
+	// e.g. name > n OR (name == n AND repository > r) OR ...
+	or := bson.A{}
+	for i, field := range sortFields {
+		cond := bson.M{field: bson.M{op: values[field]}}
+		for _, prev := range sortFields[:i] {
+			cond[prev] = values[prev]
+		}
+		or = append(or, cond)
+	}
+
+	return bson.M{"$or": or}
+}
+
 func (s *Storage) Close(ctx context.Context) {
 	s.db.Disconnect(ctx) //nolint:errcheck
 }
@@ -322,6 +710,87 @@ func (s *Storage) Subscriber(ctx context.Context, policyRepository, policyGroup,
 	return &subscriber, nil
 }
 
+// SaveDelivery creates or updates a webhook delivery.
+func (s *Storage) SaveDelivery(ctx context.Context, delivery *storage.Delivery) error {
+	delivery.UpdatedAt = time.Now()
+	if delivery.CreatedAt.IsZero() {
+		delivery.CreatedAt = delivery.UpdatedAt
+	}
+	if delivery.MongoID.IsZero() {
+		delivery.MongoID = primitive.NewObjectID()
+	}
+
+	_, err := s.delivery.ReplaceOne(ctx, bson.M{"id": delivery.ID}, delivery, options.Replace().SetUpsert(true))
+	return err
+}
+
+// PendingDeliveries returns the pending webhook deliveries
+// which must be attempted before the given time.
+func (s *Storage) PendingDeliveries(ctx context.Context, before time.Time) ([]*storage.Delivery, error) {
+	cursor, err := s.delivery.Find(ctx, bson.M{
+		"state":       storage.DeliveryPending,
+		"nextattempt": bson.M{"$lte": before},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []*storage.Delivery
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// ClaimDelivery atomically postpones the next attempt of a pending
+// delivery from nextAttempt until the given time. It returns false if
+// the delivery isn't pending or its next attempt is changed.
+func (s *Storage) ClaimDelivery(ctx context.Context, id string, nextAttempt, until time.Time) (bool, error) {
+	filter := bson.M{
+		"id":          id,
+		"state":       storage.DeliveryPending,
+		"nextattempt": nextAttempt,
+	}
+	update := bson.M{"$set": bson.M{
+		"nextattempt": until,
+		"updatedat":   time.Now(),
+	}}
+
+	res, err := s.delivery.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return res.MatchedCount == 1, nil
+}
+
+// SubscriberDeliveries returns the webhook deliveries
+// of a policy change subscriber sorted by creation time.
+func (s *Storage) SubscriberDeliveries(ctx context.Context, policyRepository, policyGroup, policyName, policyVersion, subscriber string) ([]*storage.Delivery, error) {
+	filter := bson.M{
+		"policyrepository": policyRepository,
+		"policygroup":      policyGroup,
+		"policyname":       policyName,
+		"policyversion":    policyVersion,
+		"subscriber":       subscriber,
+	}
+
+	cursor, err := s.delivery.Find(ctx, filter, options.Find().SetSort(bson.M{"createdat": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []*storage.Delivery
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
 func (s *Storage) SetData(ctx context.Context, key string, data map[string]interface{}) error {
 	opts := options.Update().SetUpsert(true)
 	query := bson.M{"key": key}
@@ -0,0 +1,132 @@
+package mongodb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage/mongodb"
+)
+
+// fakeRevisions stores the revisions of a single policy like a collection
+// with a unique index on the revision number.
+type fakeRevisions struct {
+	revisions []*storage.PolicyRevision
+	// concurrent are inserted by concurrent changes right before
+	// the next insert of SaveRevision.
+	concurrent []*storage.PolicyRevision
+	inserts    int
+	findErr    error
+}
+
+func (f *fakeRevisions) FindOne(_ context.Context, _ interface{}, _ ...*options.FindOneOptions) *mongo.SingleResult {
+	if f.findErr != nil {
+		return mongo.NewSingleResultFromDocument(storage.PolicyRevision{}, f.findErr, nil)
+	}
+	if len(f.revisions) == 0 {
+		return mongo.NewSingleResultFromDocument(storage.PolicyRevision{}, mongo.ErrNoDocuments, nil)
+	}
+	return mongo.NewSingleResultFromDocument(f.revisions[len(f.revisions)-1], nil, nil)
+}
+
+func (f *fakeRevisions) InsertOne(_ context.Context, document interface{}, _ ...*options.InsertOneOptions) (*mongo.InsertOneResult, error) {
+	f.inserts++
+	if len(f.concurrent) > 0 {
+		f.revisions = append(f.revisions, f.concurrent[0])
+		f.concurrent = f.concurrent[1:]
+	}
+
+	rev := document.(*storage.PolicyRevision)
+	for _, r := range f.revisions {
+		if r.Revision == rev.Revision {
+			return nil, mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000, Message: "E11000 duplicate key error"}}}
+		}
+	}
+	f.revisions = append(f.revisions, rev)
+
+	return &mongo.InsertOneResult{}, nil
+}
+
+func TestSaveRevision(t *testing.T) {
+	policy := &storage.Policy{Repository: "policies", Group: "xfsc", Name: "example", Version: "1.0", Rego: "package xfsc.example"}
+	other := &storage.Policy{Repository: "policies", Group: "xfsc", Name: "example", Version: "1.0", Rego: "package xfsc.example\n\nallow := true"}
+
+	revision := func(p *storage.Policy, n int) *storage.PolicyRevision {
+		rev := storage.NewPolicyRevision(p, storage.RevisionSourceAPI, "")
+		rev.Revision = n
+		return rev
+	}
+	concurrent := func(n int) []*storage.PolicyRevision {
+		var revs []*storage.PolicyRevision
+		for i := 1; i <= n; i++ {
+			revs = append(revs, revision(other, i))
+		}
+		return revs
+	}
+
+	tests := []struct {
+		name      string
+		revisions *fakeRevisions
+
+		revision int
+		inserts  int
+		errtext  string
+	}{
+		{
+			name:      "first revision",
+			revisions: &fakeRevisions{},
+			revision:  1,
+			inserts:   1,
+		},
+		{
+			name:      "next revision",
+			revisions: &fakeRevisions{revisions: []*storage.PolicyRevision{revision(other, 1)}},
+			revision:  2,
+			inserts:   1,
+		},
+		{
+			name:      "content is not changed",
+			revisions: &fakeRevisions{revisions: []*storage.PolicyRevision{revision(policy, 1)}},
+			revision:  1,
+		},
+		{
+			name:      "revision number is inserted by a concurrent change",
+			revisions: &fakeRevisions{concurrent: concurrent(1)},
+			revision:  2,
+			inserts:   2,
+		},
+		{
+			name:      "revision numbers are inserted by concurrent changes too often",
+			revisions: &fakeRevisions{concurrent: concurrent(5)},
+			inserts:   5,
+			errtext:   "duplicate key error",
+		},
+		{
+			name:      "error getting latest revision",
+			revisions: &fakeRevisions{findErr: errors.New("connection refused")},
+			errtext:   "connection refused",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := mongodb.SaveRevision(context.Background(), test.revisions, policy, storage.RevisionSourceAPI, "")
+			assert.Equal(t, test.inserts, test.revisions.inserts)
+			if test.errtext != "" {
+				assert.ErrorContains(t, err, test.errtext)
+				return
+			}
+
+			require.NoError(t, err)
+			latest := test.revisions.revisions[len(test.revisions.revisions)-1]
+			assert.Equal(t, test.revision, latest.Revision)
+			assert.Equal(t, storage.ContentHash(policy), latest.Hash)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package mongodb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/storage"
+)
+
+func TestPolicyUpdate(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		policy *storage.Policy
+
+		nextDataRefreshTime time.Time
+	}{
+		{
+			name:   "policy without data configuration",
+			policy: &storage.Policy{Rego: "package xfsc.example", Data: `{"key":"value"}`},
+		},
+		{
+			name:                "policy with data configuration is refreshed right away",
+			policy:              &storage.Policy{Rego: "package xfsc.example", DataConfig: `{"url":"https://example.com/data"}`},
+			nextDataRefreshTime: now,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			update := policyUpdate(test.policy, now)["$set"].(bson.M)
+			assert.Equal(t, test.nextDataRefreshTime, update[nextDataRefreshTimeField])
+			assert.Equal(t, now, update["lastUpdate"])
+			assert.Equal(t, test.policy.DataConfig, update["dataConfig"])
+		})
+	}
+}
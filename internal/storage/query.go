@@ -0,0 +1,221 @@
+package storage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+)
+
+// Fields by which policies can be sorted.
+const (
+	SortByName       = "name"
+	SortByLastUpdate = "lastUpdate"
+)
+
+// PolicyQuery selects the policies returned by ListPolicies.
+type PolicyQuery struct {
+	// Filters, nil filters are not applied. Name matches
+	// policies whose name contains the value ignoring case.
+	Locked       *bool
+	Name         *string
+	Repository   *string
+	Group        *string
+	Version      *string
+	UpdatedSince *time.Time
+	// Custom matches policies whose custom METADATA annotations have
+	// the given values or are lists containing the values.
+	Custom map[string]string
+
+	// Sort is the field by which policies are sorted. Policies with
+	// the same value are sorted by repository, group, name and version.
+	Sort string
+	Desc bool
+
+	// After is the cursor returned with the previous page of policies.
+	After string
+	// Limit is the maximum number of returned policies,
+	// all policies are returned if it's zero.
+	Limit int
+
+	// Rego, Data and DataConfig tell whether the large policy fields are
+	// returned. Other content fields are never returned by ListPolicies.
+	Rego       bool
+	Data       bool
+	DataConfig bool
+}
+
+// PolicyCursor is the position of a policy in the sorted list of policies.
+// It's returned to clients as an opaque string to continue the listing
+// after the policy.
+type PolicyCursor struct {
+	Sort       string    `json:"s"`
+	LastUpdate time.Time `json:"u,omitempty"`
+	Repository string    `json:"r"`
+	Group      string    `json:"g"`
+	Name       string    `json:"n"`
+	Version    string    `json:"v"`
+}
+
+// NewPolicyCursor returns the cursor of a policy.
+func NewPolicyCursor(p *Policy, sortBy string) *PolicyCursor {
+	c := &PolicyCursor{
+		Sort:       sortBy,
+		Repository: p.Repository,
+		Group:      p.Group,
+		Name:       p.Name,
+		Version:    p.Version,
+	}
+	if sortBy == SortByLastUpdate {
+		c.LastUpdate = p.LastUpdate
+	}
+
+	return c
+}
+
+// String encodes the cursor.
+func (c *PolicyCursor) String() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// ParsePolicyCursor decodes a cursor returned for a query with the given sort field.
+func ParsePolicyCursor(s, sortBy string) (*PolicyCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New(errors.BadRequest, "invalid cursor", err)
+	}
+
+	var c PolicyCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, errors.New(errors.BadRequest, "invalid cursor", err)
+	}
+
+	if c.Sort != sortBy {
+		return nil, errors.New(errors.BadRequest, "cursor is created for another sort order")
+	}
+
+	return &c, nil
+}
+
+// SortBy returns the sort field of the query, which is the name by default.
+func (q *PolicyQuery) SortBy() string {
+	if q.Sort == "" {
+		return SortByName
+	}
+	return q.Sort
+}
+
+// Match reports whether a policy matches the filters of the query.
+func (q *PolicyQuery) Match(p *Policy) bool {
+	if q.Locked != nil && *q.Locked != p.Locked {
+		return false
+	}
+
+	if q.Name != nil && !strings.Contains(strings.ToLower(p.Name), strings.ToLower(strings.TrimSpace(*q.Name))) {
+		return false
+	}
+
+	if q.Repository != nil && *q.Repository != p.Repository {
+		return false
+	}
+
+	if q.Group != nil && *q.Group != p.Group {
+		return false
+	}
+
+	if q.Version != nil && *q.Version != p.Version {
+		return false
+	}
+
+	if q.UpdatedSince != nil && p.LastUpdate.Before(*q.UpdatedSince) {
+		return false
+	}
+
+	for key, value := range q.Custom {
+		if !p.Metadata.HasCustomValue(key, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Page sorts the policies matching the query and returns the requested
+// page of them and the cursor of the next page. The cursor is empty if
+// there are no more policies. It's used by storages which cannot query
+// the policies, so they are filtered in memory.
+func (q *PolicyQuery) Page(policies []*Policy) ([]*Policy, string, error) {
+	sortBy := q.SortBy()
+
+	var after *PolicyCursor
+	if q.After != "" {
+		var err error
+		if after, err = ParsePolicyCursor(q.After, sortBy); err != nil {
+			return nil, "", err
+		}
+	}
+
+	res := make([]*Policy, 0, len(policies))
+	for _, p := range policies {
+		if !q.Match(p) {
+			continue
+		}
+		if after != nil && q.compare(NewPolicyCursor(p, sortBy), after) <= 0 {
+			continue
+		}
+
+		cpy := *p
+		if !q.Rego {
+			cpy.Rego = ""
+		}
+		if !q.Data {
+			cpy.Data = ""
+		}
+		if !q.DataConfig {
+			cpy.DataConfig = ""
+		}
+		cpy.OutputSchema = ""
+		cpy.ExportConfig = ""
+		res = append(res, &cpy)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return q.compare(NewPolicyCursor(res[i], sortBy), NewPolicyCursor(res[j], sortBy)) < 0
+	})
+
+	if q.Limit > 0 && len(res) > q.Limit {
+		res = res[:q.Limit]
+		return res, NewPolicyCursor(res[len(res)-1], sortBy).String(), nil
+	}
+
+	return res, "", nil
+}
+
+// compare returns the order of two cursors in the sort order of the query.
+func (q *PolicyQuery) compare(a, b *PolicyCursor) int {
+	res := a.LastUpdate.Compare(b.LastUpdate)
+	if q.SortBy() == SortByName {
+		res = strings.Compare(a.Name, b.Name)
+	}
+
+	for _, cmp := range []int{
+		res,
+		strings.Compare(a.Repository, b.Repository),
+		strings.Compare(a.Group, b.Group),
+		strings.Compare(a.Name, b.Name),
+		strings.Compare(a.Version, b.Version),
+	} {
+		if cmp != 0 {
+			if q.Desc {
+				return -cmp
+			}
+			return cmp
+		}
+	}
+
+	return 0
+}
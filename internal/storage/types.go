@@ -2,6 +2,8 @@ package storage
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -9,10 +11,35 @@ import (
 
 const RefreshPostponePeriod = 5 * time.Minute
 
+// Sources of policy revisions.
+const (
+	RevisionSourceSync        = "sync"
+	RevisionSourceImport      = "import"
+	RevisionSourceAPI         = "api"
+	RevisionSourceDataRefresh = "data refresh"
+	RevisionSourceRollback    = "rollback"
+)
+
 type PolicySubscriber interface {
 	PolicyDataChange(ctx context.Context, repo, group, name, version string) error
 }
 
+// PolicyDeleteSubscriber is implemented by policy subscribers which must
+// distinguish deleted policies from changed ones. Other subscribers are
+// notified about deleted policies with PolicyDataChange.
+type PolicyDeleteSubscriber interface {
+	PolicyDeleted(ctx context.Context, repo, name, group, version string) error
+}
+
+// NotifyPolicyDeleted notifies the subscriber that a policy is deleted.
+func NotifyPolicyDeleted(ctx context.Context, subscriber PolicySubscriber, repo, name, group, version string) error {
+	if s, ok := subscriber.(PolicyDeleteSubscriber); ok {
+		return s.PolicyDeleted(ctx, repo, name, group, version)
+	}
+
+	return subscriber.PolicyDataChange(ctx, repo, name, group, version)
+}
+
 type Policy struct {
 	MongoID             primitive.ObjectID `bson:"_id"`
 	Filename            string
@@ -26,10 +53,37 @@ type Policy struct {
 	OutputSchema        string
 	ExportConfig        string
 	Locked              bool
+	Lock                *PolicyLock
+	Archived            bool
+	Commit              *Commit
+	Metadata            *PolicyMetadata
 	LastUpdate          time.Time
 	NextDataRefreshTime time.Time
 }
 
+// PolicyLock describes why, when and by whom a policy is locked.
+type PolicyLock struct {
+	Reason   string
+	Actor    string
+	LockedAt time.Time
+	// ExpiresAt is the time when the policy is unlocked
+	// automatically, the lock doesn't expire if it's nil.
+	ExpiresAt *time.Time
+}
+
+// Expired reports whether the lock has expired at the given time.
+func (l *PolicyLock) Expired(now time.Time) bool {
+	return l != nil && l.ExpiresAt != nil && !l.ExpiresAt.After(now)
+}
+
+// Commit is the Git commit from which a policy is synchronized.
+type Commit struct {
+	SHA    string
+	Time   time.Time
+	Author string
+	Branch string
+}
+
 type Subscriber struct {
 	MongoID          primitive.ObjectID `bson:"_id"`
 	Name             string
@@ -38,8 +92,40 @@ type Subscriber struct {
 	PolicyName       string
 	PolicyGroup      string
 	PolicyVersion    string
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
+	// Secret is the key for signing the webhook calls to the subscriber.
+	// Calls to subscribers created without a secret are not signed.
+	Secret    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// States of webhook deliveries.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// Delivery is a webhook call notifying a subscriber about a policy change.
+// Pending deliveries are retried until they are delivered or the maximum
+// number of attempts is reached and they become dead.
+type Delivery struct {
+	MongoID          primitive.ObjectID `bson:"_id"`
+	ID               string
+	Subscriber       string
+	WebhookURL       string
+	PolicyRepository string
+	PolicyName       string
+	PolicyGroup      string
+	PolicyVersion    string
+	// Event is the JSON encoded policy change event.
+	Event       []byte
+	State       string
+	Attempts    int
+	LastError   string
+	NextAttempt time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
 
 type CommonStorage struct {
@@ -53,3 +139,93 @@ type PolicyAutoImport struct {
 	Interval   time.Duration
 	NextImport time.Time
 }
+
+// PolicyRevision is an immutable snapshot of the policy source code,
+// data and configuration. A new revision is stored every time the
+// content of a policy changes.
+type PolicyRevision struct {
+	MongoID      primitive.ObjectID `bson:"_id,omitempty"`
+	Repository   string
+	Name         string
+	Group        string
+	Version      string
+	Revision     int
+	Hash         string
+	Rego         string
+	Data         string
+	DataConfig   string
+	OutputSchema string
+	ExportConfig string
+	Source       string
+	Commit       string
+	CreatedAt    time.Time
+}
+
+// NewPolicyRevision creates a revision out of the current policy content.
+// If the commit is not given, the commit of the policy is used.
+// The revision number is assigned by the storage when the revision is saved.
+func NewPolicyRevision(p *Policy, source, commit string) *PolicyRevision {
+	if commit == "" && p.Commit != nil {
+		commit = p.Commit.SHA
+	}
+
+	return &PolicyRevision{
+		Repository:   p.Repository,
+		Name:         p.Name,
+		Group:        p.Group,
+		Version:      p.Version,
+		Hash:         ContentHash(p),
+		Rego:         p.Rego,
+		Data:         p.Data,
+		DataConfig:   p.DataConfig,
+		OutputSchema: p.OutputSchema,
+		ExportConfig: p.ExportConfig,
+		Source:       source,
+		Commit:       commit,
+		CreatedAt:    time.Now(),
+	}
+}
+
+// ContentHash returns a SHA-256 hash of the policy source code, data
+// and configuration.
+func ContentHash(p *Policy) string {
+	h := sha256.New()
+	for _, field := range []string{p.Rego, p.Data, p.DataConfig, p.OutputSchema, p.ExportConfig} {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Apply replaces the policy source code, data and configuration
+// with the content of the revision.
+func (r *PolicyRevision) Apply(p *Policy) {
+	p.Rego = r.Rego
+	p.Data = r.Data
+	p.DataConfig = r.DataConfig
+	p.OutputSchema = r.OutputSchema
+	p.ExportConfig = r.ExportConfig
+}
+
+type revisionSourceKey struct{}
+
+type revisionSource struct {
+	source string
+	commit string
+}
+
+// WithRevisionSource returns a context which specifies the source and the
+// Git commit (if known) of policy revisions created with the context.
+func WithRevisionSource(ctx context.Context, source, commit string) context.Context {
+	return context.WithValue(ctx, revisionSourceKey{}, revisionSource{source: source, commit: commit})
+}
+
+// RevisionSource returns the source and Git commit of policy revisions
+// created with the given context. If the context doesn't specify a
+// source, the given default source is returned.
+func RevisionSource(ctx context.Context, defaultSource string) (source, commit string) {
+	if s, ok := ctx.Value(revisionSourceKey{}).(revisionSource); ok {
+		return s.source, s.commit
+	}
+	return defaultSource, ""
+}
@@ -0,0 +1,244 @@
+// Package tlsconfig creates TLS configurations for servers and clients
+// with certificates and CA bundles which are reloaded from files when
+// the files are changed, so that certificates can be rotated without
+// restarting the service.
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/golib/errors"
+)
+
+// Certificates holds a certificate and a CA bundle loaded from files.
+// The certificate is used by servers as server certificate and by clients
+// as client certificate. The CA bundle is used by servers to verify client
+// certificates and by clients to verify server certificates.
+type Certificates struct {
+	certFile string
+	keyFile  string
+	caFile   string
+	logger   *zap.Logger
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	pool     *x509.CertPool
+	modTimes map[string]time.Time
+}
+
+// Load loads the certificate and the CA bundle from the given files.
+// The certificate files or the CA file may be empty, but not all of them.
+func Load(certFile, keyFile, caFile string, logger *zap.Logger) (*Certificates, error) {
+	if (certFile == "") != (keyFile == "") {
+		return nil, errors.New(errors.BadRequest, "certificate and key files must be set together")
+	}
+	if certFile == "" && caFile == "" {
+		return nil, errors.New(errors.BadRequest, "certificate or CA file must be set")
+	}
+
+	c := &Certificates{
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   caFile,
+		logger:   logger,
+	}
+	if _, err := c.reload(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Start periodically checks the certificate and CA files for changes
+// and reloads them. If the changed files cannot be loaded, the previously
+// loaded certificates are kept.
+func (c *Certificates) Start(ctx context.Context, pollInterval time.Duration) {
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info("certificates reloader stopped", zap.Error(ctx.Err()))
+			return
+		case <-time.After(pollInterval):
+			reloaded, err := c.reload()
+			if err != nil {
+				c.logger.Error("error reloading certificates", zap.Error(err))
+				continue
+			}
+
+			if reloaded {
+				c.logger.Info("certificates are reloaded", zap.String("certFile", c.certFile), zap.String("caFile", c.caFile))
+			}
+		}
+	}
+}
+
+// ServerConfig returns a TLS server configuration. If a CA bundle is loaded,
+// clients must present a certificate signed by one of the CAs, unless the
+// client certificate is optional, in which case only given certificates
+// are verified.
+func (c *Certificates) ServerConfig(clientCertOptional bool) *tls.Config {
+	cfg := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) { return c.certificate() },
+	}
+
+	if c.caFile != "" {
+		// client certificates are verified with the current CA bundle,
+		// because ClientCAs cannot be changed after the server is started
+		cfg.ClientAuth = tls.RequireAnyClientCert
+		if clientCertOptional {
+			cfg.ClientAuth = tls.RequestClientCert
+		}
+		cfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return nil
+			}
+			return c.verify(rawCerts, "", x509.ExtKeyUsageClientAuth)
+		}
+	}
+
+	return cfg
+}
+
+// ClientConfig returns a TLS client configuration which presents the loaded
+// certificate, if any, to the server. If a CA bundle is loaded, servers are
+// verified only with the CAs from the bundle instead of the system CAs.
+func (c *Certificates) ClientConfig() *tls.Config {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			c.mu.RLock()
+			defer c.mu.RUnlock()
+			if c.cert == nil {
+				return &tls.Certificate{}, nil
+			}
+			return c.cert, nil
+		},
+	}
+
+	if c.caFile != "" {
+		// server certificates are verified with the current CA bundle,
+		// because RootCAs cannot be changed after the client is created
+		cfg.InsecureSkipVerify = true //nolint:gosec
+		cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+			rawCerts := make([][]byte, 0, len(cs.PeerCertificates))
+			for _, cert := range cs.PeerCertificates {
+				rawCerts = append(rawCerts, cert.Raw)
+			}
+			return c.verify(rawCerts, cs.ServerName, x509.ExtKeyUsageServerAuth)
+		}
+	}
+
+	return cfg
+}
+
+func (c *Certificates) certificate() (*tls.Certificate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.cert == nil {
+		return nil, errors.New("certificate is not loaded")
+	}
+
+	return c.cert, nil
+}
+
+// verify verifies a certificate chain with the loaded CA bundle.
+func (c *Certificates) verify(rawCerts [][]byte, dnsName string, usage x509.ExtKeyUsage) error {
+	if len(rawCerts) == 0 {
+		return errors.New("certificate is missing")
+	}
+
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return errors.New("error parsing certificate", err)
+		}
+		certs = append(certs, cert)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	c.mu.RLock()
+	pool := c.pool
+	c.mu.RUnlock()
+
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		DNSName:       dnsName,
+		Roots:         pool,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{usage},
+	})
+	return err
+}
+
+// reload loads the files if they are changed since the last load.
+func (c *Certificates) reload() (bool, error) {
+	modTimes := make(map[string]time.Time, 3)
+	for _, file := range []string{c.certFile, c.keyFile, c.caFile} {
+		if file == "" {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return false, errors.New("error reading certificate file", err)
+		}
+		modTimes[file] = info.ModTime()
+	}
+
+	if !changed(c.modTimes, modTimes) {
+		return false, nil
+	}
+
+	var cert *tls.Certificate
+	if c.certFile != "" {
+		keyPair, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+		if err != nil {
+			return false, errors.New("error loading certificate", err)
+		}
+		cert = &keyPair
+	}
+
+	var pool *x509.CertPool
+	if c.caFile != "" {
+		pem, err := os.ReadFile(c.caFile)
+		if err != nil {
+			return false, errors.New("error reading CA file", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return false, errors.New("CA file doesn't contain certificates")
+		}
+	}
+
+	c.mu.Lock()
+	c.cert = cert
+	c.pool = pool
+	c.modTimes = modTimes
+	c.mu.Unlock()
+
+	return true, nil
+}
+
+func changed(old, current map[string]time.Time) bool {
+	if len(old) != len(current) {
+		return true
+	}
+	for file, modTime := range current {
+		if !old[file].Equal(modTime) {
+			return true
+		}
+	}
+	return false
+}
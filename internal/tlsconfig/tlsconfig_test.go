@@ -0,0 +1,270 @@
+package tlsconfig_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"gitlab.eclipse.org/eclipse/xfsc/tsa/policy/internal/tlsconfig"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newCA(t *testing.T, name string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns PEM encoded certificate and key signed by the CA.
+func (ca *testCA) issue(t *testing.T, name string, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+// startTLS starts the server with the TLS configuration. The StartTLS
+// method of httptest.Server is not used, because it sets a test certificate.
+func startTLS(srv *httptest.Server, cfg *tls.Config) {
+	srv.Listener = tls.NewListener(srv.Listener, cfg)
+	srv.Start()
+	srv.URL = strings.Replace(srv.URL, "http://", "https://", 1)
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	ca := newCA(t, "ca")
+	cert, key := ca.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	writeFile(t, filepath.Join(dir, "cert.pem"), cert, time.Now())
+	writeFile(t, filepath.Join(dir, "key.pem"), key, time.Now())
+	writeFile(t, filepath.Join(dir, "invalid.pem"), []byte("invalid"), time.Now())
+
+	tests := []struct {
+		name     string
+		certFile string
+		keyFile  string
+		caFile   string
+		errtext  string
+	}{
+		{
+			name:    "no files",
+			errtext: "certificate or CA file must be set",
+		},
+		{
+			name:     "key file is missing",
+			certFile: filepath.Join(dir, "cert.pem"),
+			errtext:  "certificate and key files must be set together",
+		},
+		{
+			name:     "certificate file is not found",
+			certFile: filepath.Join(dir, "missing.pem"),
+			keyFile:  filepath.Join(dir, "key.pem"),
+			errtext:  "error reading certificate file",
+		},
+		{
+			name:    "invalid CA file",
+			caFile:  filepath.Join(dir, "invalid.pem"),
+			errtext: "CA file doesn't contain certificates",
+		},
+		{
+			name:     "certificate is loaded",
+			certFile: filepath.Join(dir, "cert.pem"),
+			keyFile:  filepath.Join(dir, "key.pem"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := tlsconfig.Load(test.certFile, test.keyFile, test.caFile, zap.NewNop())
+			if test.errtext != "" {
+				assert.ErrorContains(t, err, test.errtext)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCertificates_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	file := func(name string) string { return filepath.Join(dir, name) }
+
+	oldCA := newCA(t, "old ca")
+	newCA := newCA(t, "new ca")
+	modTime := time.Now().Add(-time.Minute)
+
+	// server certificate and client CA bundle
+	serverCert, serverKey := oldCA.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	writeFile(t, file("server.pem"), serverCert, modTime)
+	writeFile(t, file("server-key.pem"), serverKey, modTime)
+	writeFile(t, file("client-ca.pem"), oldCA.pem, modTime)
+
+	// client certificate and server CA bundle
+	clientCert, clientKey := oldCA.issue(t, "client", x509.ExtKeyUsageClientAuth)
+	writeFile(t, file("client.pem"), clientCert, modTime)
+	writeFile(t, file("client-key.pem"), clientKey, modTime)
+	writeFile(t, file("server-ca.pem"), oldCA.pem, modTime)
+
+	serverCerts, err := tlsconfig.Load(file("server.pem"), file("server-key.pem"), file("client-ca.pem"), zap.NewNop())
+	require.NoError(t, err)
+	clientCerts, err := tlsconfig.Load(file("client.pem"), file("client-key.pem"), file("server-ca.pem"), zap.NewNop())
+	require.NoError(t, err)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	startTLS(srv, serverCerts.ServerConfig(false))
+	defer srv.Close()
+
+	get := func(certs *tlsconfig.Certificates) (string, error) {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: certs.ClientConfig()}}
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+
+		var body [64]byte
+		n, _ := resp.Body.Read(body[:])
+		return string(body[:n]), nil
+	}
+
+	t.Run("client certificate is verified", func(t *testing.T) {
+		res, err := get(clientCerts)
+		require.NoError(t, err)
+		assert.Equal(t, "client", res)
+	})
+
+	t.Run("client without certificate is rejected", func(t *testing.T) {
+		caOnly, err := tlsconfig.Load("", "", file("server-ca.pem"), zap.NewNop())
+		require.NoError(t, err)
+
+		_, err = get(caOnly)
+		assert.Error(t, err)
+	})
+
+	t.Run("client with certificate of unknown CA is rejected", func(t *testing.T) {
+		cert, key := newCA.issue(t, "client", x509.ExtKeyUsageClientAuth)
+		writeFile(t, file("other.pem"), cert, modTime)
+		writeFile(t, file("other-key.pem"), key, modTime)
+		other, err := tlsconfig.Load(file("other.pem"), file("other-key.pem"), file("server-ca.pem"), zap.NewNop())
+		require.NoError(t, err)
+
+		_, err = get(other)
+		assert.Error(t, err)
+	})
+
+	t.Run("rotated certificates are reloaded", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go serverCerts.Start(ctx, 10*time.Millisecond)
+		go clientCerts.Start(ctx, 10*time.Millisecond)
+
+		// both sides rotate to certificates of the new CA
+		serverCert, serverKey := newCA.issue(t, "server", x509.ExtKeyUsageServerAuth)
+		writeFile(t, file("server.pem"), serverCert, time.Now())
+		writeFile(t, file("server-key.pem"), serverKey, time.Now())
+		writeFile(t, file("client-ca.pem"), newCA.pem, time.Now())
+
+		clientCert, clientKey := newCA.issue(t, "rotated client", x509.ExtKeyUsageClientAuth)
+		writeFile(t, file("client.pem"), clientCert, time.Now())
+		writeFile(t, file("client-key.pem"), clientKey, time.Now())
+		writeFile(t, file("server-ca.pem"), newCA.pem, time.Now())
+
+		assert.Eventually(t, func() bool {
+			res, err := get(clientCerts)
+			return err == nil && res == "rotated client"
+		}, 5*time.Second, 20*time.Millisecond)
+	})
+}
+
+func TestCertificates_OptionalClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	ca := newCA(t, "ca")
+	cert, key := ca.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	writeFile(t, filepath.Join(dir, "cert.pem"), cert, time.Now())
+	writeFile(t, filepath.Join(dir, "key.pem"), key, time.Now())
+	writeFile(t, filepath.Join(dir, "ca.pem"), ca.pem, time.Now())
+
+	serverCerts, err := tlsconfig.Load(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"), filepath.Join(dir, "ca.pem"), zap.NewNop())
+	require.NoError(t, err)
+	clientCerts, err := tlsconfig.Load("", "", filepath.Join(dir, "ca.pem"), zap.NewNop())
+	require.NoError(t, err)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.TLS.PeerCertificates)
+	}))
+	startTLS(srv, serverCerts.ServerConfig(true))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientCerts.ClientConfig()}}
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// the server name must match the server certificate
+	cfg := clientCerts.ClientConfig()
+	cfg.ServerName = "example.com"
+	client = &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+	_, err = client.Get(srv.URL)
+	assert.Error(t, err)
+}